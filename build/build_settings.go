@@ -26,8 +26,9 @@ func init() {
 
 type SSettings struct {
 	FProtoMask struct {
-		FNetwork uint32 `yaml:"network"`
-		FService uint32 `yaml:"service"`
+		FNetwork  uint32 `yaml:"network"`
+		FService  uint32 `yaml:"service"`
+		FFragment uint32 `yaml:"fragment"`
 	} `yaml:"proto_mask"`
 	FQueueProblem struct {
 		FMainPoolCap  uint64 `yaml:"main_pool_cap"`
@@ -47,6 +47,10 @@ func TestHiddenLakeSettings(t *testing.T) {
 		t.Error(`GGSettings.ProtoMask.Service != 0x5f686c5f`)
 		return
 	}
+	if GSettings.FProtoMask.FFragment != 0x5f66725f {
+		t.Error(`GSettings.ProtoMask.Fragment != 0x5f66725f`)
+		return
+	}
 	if GSettings.FQueueProblem.FMainPoolCap != 256 {
 		t.Error(`GSettings.QueueCapacity.FMainPoolCap != 256`)
 		return
@@ -0,0 +1,4 @@
+#!/bin/bash
+
+# root mode
+systemctl disable hidden_lake_adapter_udp.service
@@ -0,0 +1,24 @@
+#!/bin/bash
+
+# root mode
+echo "
+[Unit]
+Description=HiddenLakeAdapterUDP
+
+[Service]
+ExecStart=/root/hla_udp_amd64_linux --path /root
+Restart=always
+RestartSec=10
+
+[Install]
+WantedBy=multi-user.target
+" > /etc/systemd/system/hidden_lake_adapter_udp.service
+
+cd /root && \
+    rm -f hla_udp_amd64_linux && \
+    wget https://github.com/number571/hidden-lake/releases/latest/download/hla_udp_amd64_linux && \
+    chmod +x hla_udp_amd64_linux
+
+systemctl daemon-reload
+systemctl enable hidden_lake_adapter_udp.service
+systemctl restart hidden_lake_adapter_udp.service
@@ -0,0 +1,3 @@
+#!/bin/bash
+
+journalctl -eu hidden_lake_adapter_udp.service
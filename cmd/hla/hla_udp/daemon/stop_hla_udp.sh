@@ -0,0 +1,4 @@
+#!/bin/bash
+
+# root mode
+systemctl stop hidden_lake_adapter_udp.service
@@ -0,0 +1,4 @@
+#!/bin/bash
+
+# root mode
+systemctl restart hidden_lake_adapter_ws.service
@@ -0,0 +1,3 @@
+#!/bin/bash
+
+watch -c SYSTEMD_COLORS=1 systemctl status -o cat hidden_lake_adapter_ws.service
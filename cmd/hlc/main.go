@@ -13,6 +13,8 @@ import (
 	"github.com/number571/hidden-lake/internal/composite/pkg/settings"
 	"github.com/number571/hidden-lake/internal/utils/flag"
 	"github.com/number571/hidden-lake/internal/utils/help"
+
+	hls_app "github.com/number571/hidden-lake/internal/service/pkg/app"
 )
 
 var (
@@ -30,6 +32,13 @@ var (
 		flag.NewFlagBuilder("-t", "--threads").
 			WithDescription("set num of parallel functions to calculate PoW").
 			WithDefinedValue("1"),
+		flag.NewFlagBuilder("-f", "--passphrase-fd").
+			WithDescription("read passphrase of private key from file descriptor").
+			WithDefinedValue(""),
+		flag.NewFlagBuilder("-k", "--key-passwd").
+			WithDescription("set or change passphrase of private key and exit"),
+		flag.NewFlagBuilder("-r", "--key-required").
+			WithDescription("fail if private key is not found instead of generating new"),
 	).Build()
 )
 
@@ -49,6 +58,14 @@ func main() {
 		return
 	}
 
+	if gFlags.Get("-k").GetBoolValue(args) {
+		if err := hls_app.ChangeKeyPassphrase(args, gFlags); err != nil {
+			panic(err)
+		}
+		fmt.Println("passphrase of private key changed")
+		return
+	}
+
 	app, err := app.InitApp(args, gFlags)
 	if err != nil {
 		panic(err)
@@ -7,6 +7,7 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 
 	"github.com/number571/hidden-lake/build"
@@ -31,11 +32,68 @@ var (
 		flag.NewFlagBuilder("-t", "--threads").
 			WithDescription("set num of parallel functions to calculate PoW").
 			WithDefinedValue("1"),
+		flag.NewFlagBuilder("-f", "--passphrase-fd").
+			WithDescription("read passphrase of private key from file descriptor").
+			WithDefinedValue(""),
+		flag.NewFlagBuilder("-k", "--key-passwd").
+			WithDescription("set or change passphrase of private key and exit"),
+		flag.NewFlagBuilder("-r", "--key-required").
+			WithDescription("fail if private key is not found instead of generating new"),
+	).Build()
+	gCommands = flag.NewCommandsBuilder(
+		flag.NewCommandBuilder("key", "show").
+			WithDescription("print public key").
+			WithFlags(
+				newPathFlag(),
+				newPassphraseFDFlag(),
+			),
+		flag.NewCommandBuilder("key", "fingerprint").
+			WithDescription("print fingerprint (hash) of public key").
+			WithFlags(
+				newPathFlag(),
+				newPassphraseFDFlag(),
+			),
+		flag.NewCommandBuilder("key", "export").
+			WithDescription("write encrypted backup of private key").
+			WithFlags(
+				newPathFlag(),
+				newPassphraseFDFlag(),
+				flag.NewFlagBuilder("-o", "--output").
+					WithDescription("set path to backup file").
+					WithDefinedValue(""),
+				flag.NewFlagBuilder("-w", "--with-friends").
+					WithDescription("include list of friends from config"),
+				flag.NewFlagBuilder("--force").
+					WithDescription("overwrite existing backup file"),
+			),
+		flag.NewCommandBuilder("key", "import").
+			WithDescription("restore private key from backup").
+			WithFlags(
+				newPathFlag(),
+				newPassphraseFDFlag(),
+				flag.NewFlagBuilder("-i", "--input").
+					WithDescription("set path to backup file").
+					WithDefinedValue(""),
+				flag.NewFlagBuilder("-w", "--with-friends").
+					WithDescription("add list of friends to config"),
+				flag.NewFlagBuilder("--force").
+					WithDescription("overwrite existing private key"),
+			),
 	).Build()
 )
 
 func main() {
 	args := os.Args[1:]
+	if cmd, cmdArgs, ok := gCommands.Match(args); ok {
+		if ok := cmd.GetFlags().Validate(cmdArgs); !ok {
+			panic("args invalid")
+		}
+		if err := runCommand(cmd, cmdArgs); err != nil {
+			panic(err)
+		}
+		return
+	}
+
 	if ok := gFlags.Validate(args); !ok {
 		panic("args invalid")
 	}
@@ -47,6 +105,15 @@ func main() {
 
 	if gFlags.Get("-h").GetBoolValue(args) {
 		help.Println(settings.GServiceName, settings.CServiceDescription, gFlags)
+		help.PrintlnCommands(gCommands)
+		return
+	}
+
+	if gFlags.Get("-k").GetBoolValue(args) {
+		if err := app.ChangeKeyPassphrase(args, gFlags); err != nil {
+			panic(err)
+		}
+		fmt.Println("passphrase of private key changed")
 		return
 	}
 
@@ -74,3 +141,46 @@ func main() {
 
 	<-shutdown
 }
+
+func runCommand(pCmd flag.ICommand, pArgs []string) error {
+	flags := pCmd.GetFlags()
+	switch strings.Join(pCmd.GetNames(), " ") {
+	case "key show", "key fingerprint":
+		pubKey, err := app.GetKeyPubKey(pArgs, flags)
+		if err != nil {
+			return err
+		}
+		if pCmd.GetNames()[1] == "show" {
+			fmt.Println(pubKey.ToString())
+			return nil
+		}
+		fmt.Println(pubKey.GetHasher().ToString())
+		return nil
+	case "key export":
+		if err := app.ExportKey(pArgs, flags); err != nil {
+			return err
+		}
+		fmt.Println("private key exported")
+		return nil
+	case "key import":
+		if err := app.ImportKey(pArgs, flags); err != nil {
+			return err
+		}
+		fmt.Println("private key imported")
+		return nil
+	default:
+		panic("undefined command")
+	}
+}
+
+func newPathFlag() flag.IFlagBuilder {
+	return flag.NewFlagBuilder("-p", "--path").
+		WithDescription("set path to config, database files").
+		WithDefinedValue(".")
+}
+
+func newPassphraseFDFlag() flag.IFlagBuilder {
+	return flag.NewFlagBuilder("-f", "--passphrase-fd").
+		WithDescription("read passphrase of private key from file descriptor").
+		WithDefinedValue("")
+}
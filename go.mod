@@ -4,13 +4,13 @@ go 1.23
 
 require (
 	github.com/number571/go-peer v1.7.10
+	golang.org/x/crypto v0.31.0
 	golang.org/x/net v0.30.0
+	golang.org/x/sys v0.28.0
 )
 
 require (
 	github.com/cloudflare/circl v1.5.0 // indirect
 	go.etcd.io/bbolt v1.3.5 // indirect
-	golang.org/x/crypto v0.31.0 // indirect
-	golang.org/x/sys v0.28.0 // indirect
 	gopkg.in/yaml.v2 v2.4.0 // indirect
 )
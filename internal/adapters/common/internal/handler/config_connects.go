@@ -9,21 +9,22 @@ import (
 	"strings"
 
 	"github.com/number571/go-peer/pkg/logger"
-	"github.com/number571/go-peer/pkg/network"
-	"github.com/number571/hidden-lake/internal/adapters/tcp/pkg/app/config"
-	pkg_settings "github.com/number571/hidden-lake/internal/adapters/tcp/pkg/settings"
+	"github.com/number571/hidden-lake/internal/adapters/common/pkg/app/config"
+	hla_settings "github.com/number571/hidden-lake/internal/adapters/common/pkg/settings"
 	"github.com/number571/hidden-lake/internal/utils/api"
 	http_logger "github.com/number571/hidden-lake/internal/utils/logger/http"
 )
 
 func HandleConfigConnectsAPI(
 	pCtx context.Context,
+	pSettings hla_settings.ISettings,
 	pWrapper config.IWrapper,
 	pLogger logger.ILogger,
-	pNetworkNode network.INode,
+	pNetworkNode INetworkNode,
 ) http.HandlerFunc {
 	return func(pW http.ResponseWriter, pR *http.Request) {
-		logBuilder := http_logger.NewLogBuilder(pkg_settings.GServiceName.Short(), pR)
+		scheme := pSettings.GetAdapterScheme()
+		logBuilder := http_logger.NewLogBuilder(pSettings.GetServiceName().Short(), pR)
 
 		if pR.Method != http.MethodGet && pR.Method != http.MethodPost && pR.Method != http.MethodDelete {
 			pLogger.PushWarn(logBuilder.WithMessage(http_logger.CLogMethod))
@@ -35,7 +36,7 @@ func HandleConfigConnectsAPI(
 			connects := pWrapper.GetConfig().GetConnections()
 			result := make([]string, 0, len(connects))
 			for _, addr := range connects {
-				result = append(result, "tcp://"+addr)
+				result = append(result, scheme+"://"+addr)
 			}
 			pLogger.PushInfo(logBuilder.WithMessage(http_logger.CLogSuccess))
 			_ = api.Response(pW, http.StatusOK, result)
@@ -55,9 +56,9 @@ func HandleConfigConnectsAPI(
 			_ = api.Response(pW, http.StatusTeapot, "failed: connect is nil")
 			return
 		}
-		if u.Scheme != pkg_settings.CServiceAdapterScheme {
+		if u.Scheme != scheme {
 			pLogger.PushWarn(logBuilder.WithMessage("scheme_rejected"))
-			_ = api.Response(pW, http.StatusAccepted, "rejected: scheme != tcp")
+			_ = api.Response(pW, http.StatusAccepted, "rejected: scheme != "+scheme)
 			return
 		}
 
@@ -7,17 +7,22 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
-	"time"
 
 	"github.com/number571/go-peer/pkg/logger"
-	"github.com/number571/go-peer/pkg/message/layer1"
-	"github.com/number571/go-peer/pkg/network"
-	"github.com/number571/go-peer/pkg/network/conn"
-	"github.com/number571/go-peer/pkg/storage/cache"
-	"github.com/number571/hidden-lake/internal/adapters/tcp/pkg/app/config"
+	"github.com/number571/hidden-lake/internal/adapters/common/pkg/app/config"
+	hla_settings "github.com/number571/hidden-lake/internal/adapters/common/pkg/settings"
 	std_logger "github.com/number571/hidden-lake/internal/utils/logger/std"
 )
 
+var (
+	tgSettings = hla_settings.NewSettings(&hla_settings.SSettings{
+		FServiceFullName: "hidden-lake-adapter=tcp",
+		FAdapterScheme:   "tcp",
+		FPathYML:         "hla_tcp.yml",
+		FPathDB:          "hla_tcp.db",
+	})
+)
+
 func TestHandleConfigConnectsAPI(t *testing.T) {
 	t.Parallel()
 
@@ -27,7 +32,7 @@ func TestHandleConfigConnectsAPI(t *testing.T) {
 		func(_ logger.ILogArg) string { return "" },
 	)
 
-	handler := HandleConfigConnectsAPI(ctx, &tsConfigWrapper{}, log, &tsNetworkNode{})
+	handler := HandleConfigConnectsAPI(ctx, tgSettings, &tsConfigWrapper{}, log, &tsNetworkNode{})
 	if err := configConnectsRequestMethod(handler); err != nil {
 		t.Error(err)
 		return
@@ -53,7 +58,7 @@ func TestHandleConfigConnectsAPI(t *testing.T) {
 		return
 	}
 
-	handlerx := HandleConfigConnectsAPI(ctx, &tsConfigWrapper{fWithFail: true}, log, &tsNetworkNode{})
+	handlerx := HandleConfigConnectsAPI(ctx, tgSettings, &tsConfigWrapper{fWithFail: true}, log, &tsNetworkNode{})
 	if err := configConnectsRequestAddConnection(handlerx, http.StatusInternalServerError); err != nil {
 		t.Error(err)
 		return
@@ -155,7 +160,7 @@ func configConnectsRequestDelConnection(handler http.HandlerFunc, code int) erro
 }
 
 var (
-	_ network.INode          = &tsNetworkNode{}
+	_ INetworkNode           = &tsNetworkNode{}
 	_ config.IConfig         = &tsConfig{}
 	_ config.IEditor         = &tsEditor{}
 	_ config.IConfigSettings = &tsConfigSettings{}
@@ -177,6 +182,8 @@ func (p *tsConfig) GetSettings() config.IConfigSettings { return &tsConfigSettin
 func (p *tsConfig) GetAddress() config.IAddress { return &tsAddress{} }
 func (p *tsConfig) GetEndpoints() []string      { return []string{"bbb"} }
 func (p *tsConfig) GetConnections() []string    { return []string{"aaa"} }
+func (p *tsConfig) GetChaos() config.IChaos     { return nil }
+func (p *tsConfig) GetTLS() config.ITLS         { return nil }
 
 type tsAddress struct{}
 
@@ -205,32 +212,8 @@ type tsNetworkNode struct {
 	fWithFail bool
 }
 
-func (p *tsNetworkNode) Close() error                                       { return nil }
-func (p *tsNetworkNode) Run(context.Context) error                          { return nil }
-func (p *tsNetworkNode) HandleFunc(uint32, network.IHandlerF) network.INode { return nil }
-func (p *tsNetworkNode) GetSettings() network.ISettings {
-	return network.NewSettings(&network.SSettings{
-		FConnSettings: conn.NewSettings(&conn.SSettings{
-			FLimitMessageSizeBytes: 1,
-			FWaitReadTimeout:       time.Second,
-			FDialTimeout:           time.Second,
-			FReadTimeout:           time.Second,
-			FWriteTimeout:          time.Second,
-			FMessageSettings: layer1.NewSettings(&layer1.SSettings{
-				FWorkSizeBits: 1,
-				FNetworkKey:   "_",
-			}),
-		}),
-		FMaxConnects:  1,
-		FReadTimeout:  time.Second,
-		FWriteTimeout: time.Second,
-	})
-}
-func (p *tsNetworkNode) GetCacheSetter() cache.ICacheSetter { return nil }
-func (p *tsNetworkNode) GetConnections() map[string]conn.IConn {
-	return map[string]conn.IConn{
-		"127.0.0.1:9999": nil,
-	}
+func (p *tsNetworkNode) GetConnections() []string {
+	return []string{"127.0.0.1:9999"}
 }
 func (p *tsNetworkNode) AddConnection(context.Context, string) error {
 	if p.fWithFail {
@@ -244,4 +227,3 @@ func (p *tsNetworkNode) DelConnection(string) error {
 	}
 	return nil
 }
-func (p *tsNetworkNode) BroadcastMessage(context.Context, layer1.IMessage) error { return nil }
@@ -4,18 +4,19 @@ import (
 	"net/http"
 
 	"github.com/number571/go-peer/pkg/logger"
-	"github.com/number571/hidden-lake/internal/adapters/tcp/pkg/app/config"
-	pkg_settings "github.com/number571/hidden-lake/internal/adapters/tcp/pkg/settings"
+	"github.com/number571/hidden-lake/internal/adapters/common/pkg/app/config"
+	hla_settings "github.com/number571/hidden-lake/internal/adapters/common/pkg/settings"
 	"github.com/number571/hidden-lake/internal/utils/api"
 	http_logger "github.com/number571/hidden-lake/internal/utils/logger/http"
 )
 
 func HandleConfigSettingsAPI(
+	pSettings hla_settings.ISettings,
 	pConfig config.IConfig,
 	pLogger logger.ILogger,
 ) http.HandlerFunc {
 	return func(pW http.ResponseWriter, pR *http.Request) {
-		logBuilder := http_logger.NewLogBuilder(pkg_settings.GServiceName.Short(), pR)
+		logBuilder := http_logger.NewLogBuilder(pSettings.GetServiceName().Short(), pR)
 
 		if pR.Method != http.MethodGet {
 			pLogger.PushWarn(logBuilder.WithMessage(http_logger.CLogMethod))
@@ -18,7 +18,7 @@ func TestHandleConfigSettingsAPI(t *testing.T) {
 		func(_ logger.ILogArg) string { return "" },
 	)
 
-	handler := HandleConfigSettingsAPI(&tsConfig{}, log)
+	handler := HandleConfigSettingsAPI(tgSettings, &tsConfig{}, log)
 	if err := settingsAPIRequestOK(handler); err != nil {
 		t.Error(err)
 		return
@@ -67,7 +67,7 @@ func TestHandleIndexAPI2(t *testing.T) {
 		func(_ logger.ILogArg) string { return "" },
 	)
 
-	handler := HandleIndexAPI(log)
+	handler := HandleIndexAPI(tgSettings, log)
 	if err := indexAPIRequestOK(handler); err != nil {
 		t.Error(err)
 		return
@@ -8,18 +8,19 @@ import (
 	"strings"
 
 	"github.com/number571/go-peer/pkg/logger"
-	"github.com/number571/go-peer/pkg/network"
-	pkg_settings "github.com/number571/hidden-lake/internal/adapters/tcp/pkg/settings"
+	hla_settings "github.com/number571/hidden-lake/internal/adapters/common/pkg/settings"
 	"github.com/number571/hidden-lake/internal/utils/api"
 	http_logger "github.com/number571/hidden-lake/internal/utils/logger/http"
 )
 
 func HandleNetworkOnlineAPI(
+	pSettings hla_settings.ISettings,
 	pLogger logger.ILogger,
-	pNetworkNode network.INode,
+	pNetworkNode INetworkNode,
 ) http.HandlerFunc {
 	return func(pW http.ResponseWriter, pR *http.Request) {
-		logBuilder := http_logger.NewLogBuilder(pkg_settings.GServiceName.Short(), pR)
+		scheme := pSettings.GetAdapterScheme()
+		logBuilder := http_logger.NewLogBuilder(pSettings.GetServiceName().Short(), pR)
 
 		if pR.Method != http.MethodGet && pR.Method != http.MethodDelete {
 			pLogger.PushWarn(logBuilder.WithMessage(http_logger.CLogMethod))
@@ -31,8 +32,8 @@ func HandleNetworkOnlineAPI(
 		case http.MethodGet:
 			connects := pNetworkNode.GetConnections()
 			inOnline := make([]string, 0, len(connects))
-			for addr := range connects {
-				inOnline = append(inOnline, pkg_settings.CServiceAdapterScheme+"://"+addr)
+			for _, addr := range connects {
+				inOnline = append(inOnline, scheme+"://"+addr)
 			}
 			sort.SliceStable(inOnline, func(i, j int) bool {
 				return inOnline[i] < inOnline[j]
@@ -54,9 +55,9 @@ func HandleNetworkOnlineAPI(
 				_ = api.Response(pW, http.StatusTeapot, "failed: connect is nil")
 				return
 			}
-			if u.Scheme != pkg_settings.CServiceAdapterScheme {
+			if u.Scheme != scheme {
 				pLogger.PushWarn(logBuilder.WithMessage("scheme_rejected"))
-				_ = api.Response(pW, http.StatusAccepted, "rejected: scheme != tcp")
+				_ = api.Response(pW, http.StatusAccepted, "rejected: scheme != "+scheme)
 				return
 			}
 
@@ -18,7 +18,7 @@ func TestHandleNetworkOnlineAPI(t *testing.T) {
 		func(_ logger.ILogArg) string { return "" },
 	)
 
-	handler := HandleNetworkOnlineAPI(log, &tsNetworkNode{})
+	handler := HandleNetworkOnlineAPI(tgSettings, log, &tsNetworkNode{})
 	if err := networkOnlineRequestMethod(handler); err != nil {
 		t.Error(err)
 		return
@@ -40,7 +40,7 @@ func TestHandleNetworkOnlineAPI(t *testing.T) {
 		return
 	}
 
-	handlerx := HandleNetworkOnlineAPI(log, &tsNetworkNode{fWithFail: true})
+	handlerx := HandleNetworkOnlineAPI(tgSettings, log, &tsNetworkNode{fWithFail: true})
 	if err := networkOnlineRequestDelConnection(handlerx, http.StatusInternalServerError); err != nil {
 		t.Error(err)
 		return
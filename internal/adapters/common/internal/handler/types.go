@@ -0,0 +1,9 @@
+package handler
+
+import "context"
+
+type INetworkNode interface {
+	GetConnections() []string
+	AddConnection(context.Context, string) error
+	DelConnection(string) error
+}
@@ -0,0 +1,274 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/number571/go-peer/pkg/logger"
+	"github.com/number571/go-peer/pkg/message/layer1"
+	"github.com/number571/go-peer/pkg/state"
+	"github.com/number571/go-peer/pkg/storage/cache"
+	"github.com/number571/go-peer/pkg/storage/database"
+	"github.com/number571/go-peer/pkg/types"
+	"github.com/number571/hidden-lake/build"
+	"github.com/number571/hidden-lake/internal/adapters/common/pkg/app/config"
+	hla_settings "github.com/number571/hidden-lake/internal/adapters/common/pkg/settings"
+	"github.com/number571/hidden-lake/internal/utils/closer"
+	anon_logger "github.com/number571/hidden-lake/internal/utils/logger/anon"
+	http_logger "github.com/number571/hidden-lake/internal/utils/logger/http"
+	std_logger "github.com/number571/hidden-lake/internal/utils/logger/std"
+	internal_types "github.com/number571/hidden-lake/internal/utils/types"
+	"github.com/number571/hidden-lake/pkg/adapters"
+	hla_chaos "github.com/number571/hidden-lake/pkg/adapters/chaos"
+	hla_http "github.com/number571/hidden-lake/pkg/adapters/http"
+)
+
+var (
+	_ types.IRunner = &sApp{}
+)
+
+type sApp struct {
+	fState    state.IState
+	fSettings hla_settings.ISettings
+	fWrapper  config.IWrapper
+
+	fPathTo   string
+	fDatabase database.IKVDatabase
+
+	fAnonLogger logger.ILogger
+	fHTTPLogger logger.ILogger
+	fStdfLogger logger.ILogger
+
+	fNodeAdapter  INetworkAdapter
+	fChaosAdapter hla_chaos.IChaosAdapter
+	fNetAdapter   adapters.IRunnerAdapter
+	fHTTPAdapter  hla_http.IHTTPAdapter
+}
+
+func NewApp(
+	pSettings hla_settings.ISettings,
+	pCfg config.IConfig,
+	pPathTo string,
+	pAdapterF INetworkAdapterF,
+) types.IRunner {
+	logging := pCfg.GetLogging()
+	anonLogger := std_logger.NewStdLogger(logging, anon_logger.GetLogFunc())
+	stdfLogger := std_logger.NewStdLogger(logging, std_logger.GetLogFunc())
+	lruCache := cache.NewLRUCache(build.GSettings.FNetworkManager.FCacheHashesCap)
+	adaptersSettings := adapters.NewSettings(&adapters.SSettings{
+		FMessageSizeBytes: pCfg.GetSettings().GetMessageSizeBytes(),
+		FWorkSizeBits:     pCfg.GetSettings().GetWorkSizeBits(),
+		FNetworkKey:       pCfg.GetSettings().GetNetworkKey(),
+	})
+	nodeAdapter := pAdapterF(pCfg, pPathTo, adaptersSettings, lruCache, anonLogger, stdfLogger)
+	chaosAdapter := newChaosAdapter(pCfg.GetChaos(), nodeAdapter)
+	netAdapter := adapters.IRunnerAdapter(nodeAdapter)
+	if chaosAdapter != nil {
+		netAdapter = chaosAdapter
+	}
+	return &sApp{
+		fState:        state.NewBoolState(),
+		fSettings:     pSettings,
+		fPathTo:       pPathTo,
+		fWrapper:      config.NewWrapper(pCfg),
+		fAnonLogger:   anonLogger,
+		fStdfLogger:   stdfLogger,
+		fHTTPLogger:   std_logger.NewStdLogger(logging, http_logger.GetLogFunc()),
+		fNodeAdapter:  nodeAdapter,
+		fChaosAdapter: chaosAdapter,
+		fNetAdapter:   netAdapter,
+		fHTTPAdapter: hla_http.NewHTTPAdapter(
+			hla_http.NewSettings(&hla_http.SSettings{
+				FAddress:         pCfg.GetAddress().GetInternal(),
+				FAdapterSettings: adaptersSettings,
+			}),
+			lruCache,
+			func() []string { return pCfg.GetEndpoints() },
+		),
+	}
+}
+
+func newChaosAdapter(pChaos config.IChaos, pAdapter adapters.IRunnerAdapter) hla_chaos.IChaosAdapter {
+	if pChaos == nil {
+		return nil
+	}
+	return hla_chaos.NewChaosAdapter(
+		hla_chaos.NewSettings(&hla_chaos.SSettings{
+			FSeed:          pChaos.GetSeed(),
+			FDropProb:      pChaos.GetDropProb(),
+			FDelayProb:     pChaos.GetDelayProb(),
+			FDuplicateProb: pChaos.GetDuplicateProb(),
+			FReorderProb:   pChaos.GetReorderProb(),
+			FCorruptProb:   pChaos.GetCorruptProb(),
+			FDelayMax:      time.Duration(pChaos.GetDelayMaxMS()) * time.Millisecond, // nolint: gosec
+		}),
+		pAdapter,
+	)
+}
+
+func (p *sApp) Run(pCtx context.Context) error {
+	services := []internal_types.IServiceF{
+		p.runNodeAdapter,
+		p.runNodeRelayer,
+		p.runHTTPAdapter,
+		p.runHTTPRelayer,
+	}
+
+	ctx, cancel := context.WithCancel(pCtx)
+	defer cancel()
+
+	wg := &sync.WaitGroup{}
+	wg.Add(len(services))
+
+	if err := p.fState.Enable(p.enable(ctx)); err != nil {
+		return errors.Join(ErrRunning, err)
+	}
+	defer func() { _ = p.fState.Disable(p.disable(cancel, wg)) }()
+
+	chErr := make(chan error, len(services))
+	for _, f := range services {
+		go f(ctx, wg, chErr)
+	}
+
+	select {
+	case <-pCtx.Done():
+		return pCtx.Err()
+	case err := <-chErr:
+		return errors.Join(ErrService, err)
+	}
+}
+
+func (p *sApp) enable(pCtx context.Context) state.IStateF {
+	return func() error {
+		if err := p.initDatabase(); err != nil {
+			return errors.Join(ErrInitDB, err)
+		}
+
+		if initAdapter, ok := p.fNodeAdapter.(IInitAdapter); ok {
+			if err := initAdapter.Init(); err != nil {
+				return errors.Join(ErrInitAdapter, err)
+			}
+		}
+
+		p.initLoggers()
+		p.initHandlers(pCtx)
+
+		p.fStdfLogger.PushInfo(fmt.Sprintf( // nolint: perfsprint
+			"%s is started",
+			p.fSettings.GetServiceName().Short(),
+		))
+		if p.fChaosAdapter != nil {
+			p.fStdfLogger.PushWarn(fmt.Sprintf(
+				"%s chaos is enabled (seed=%d)",
+				p.fSettings.GetServiceName().Short(),
+				p.fChaosAdapter.GetSettings().GetSeed(),
+			))
+		}
+		return nil
+	}
+}
+
+func (p *sApp) disable(pCancel context.CancelFunc, pWg *sync.WaitGroup) state.IStateF {
+	return func() error {
+		pCancel()
+		pWg.Wait() // wait canceled context
+
+		p.fStdfLogger.PushInfo(fmt.Sprintf( // nolint: perfsprint
+			"%s is stopped",
+			p.fSettings.GetServiceName().Short(),
+		))
+		return p.stop()
+	}
+}
+
+func (p *sApp) runNodeAdapter(pCtx context.Context, wg *sync.WaitGroup, pChErr chan<- error) {
+	defer wg.Done()
+
+	if err := p.fNetAdapter.Run(pCtx); err != nil {
+		pChErr <- err
+		return
+	}
+}
+
+func (p *sApp) runHTTPAdapter(pCtx context.Context, wg *sync.WaitGroup, pChErr chan<- error) {
+	defer wg.Done()
+
+	if err := p.fHTTPAdapter.Run(pCtx); err != nil {
+		pChErr <- err
+		return
+	}
+}
+
+func (p *sApp) runNodeRelayer(pCtx context.Context, wg *sync.WaitGroup, pChErr chan<- error) {
+	defer wg.Done()
+
+	for {
+		select {
+		case <-pCtx.Done():
+			pChErr <- pCtx.Err()
+			return
+		default:
+			// Node (connections) -> HTTP (endpoints), Node (connections)
+			msg, err := p.fNetAdapter.Consume(pCtx)
+			if err != nil {
+				continue
+			}
+			if err := p.setIntoDB(msg); err != nil {
+				continue
+			}
+			if err := p.fHTTPAdapter.Produce(pCtx, msg); err != nil {
+				if !errors.Is(err, hla_http.ErrNoConnections) {
+					continue
+				}
+			}
+			_ = p.fNetAdapter.Produce(pCtx, msg)
+		}
+	}
+}
+
+func (p *sApp) runHTTPRelayer(pCtx context.Context, wg *sync.WaitGroup, pChErr chan<- error) {
+	defer wg.Done()
+
+	for {
+		select {
+		case <-pCtx.Done():
+			pChErr <- pCtx.Err()
+			return
+		default:
+			// HTTP (endpoints) -> Node (connections)
+			msg, err := p.fHTTPAdapter.Consume(pCtx)
+			if err != nil {
+				continue
+			}
+			if err := p.setIntoDB(msg); err != nil {
+				continue
+			}
+			_ = p.fNetAdapter.Produce(pCtx, msg)
+		}
+	}
+}
+
+func (p *sApp) setIntoDB(msg layer1.IMessage) error {
+	_, err := p.fDatabase.Get(msg.GetHash())
+	if err == nil {
+		return ErrExist
+	}
+	if !errors.Is(err, database.ErrNotFound) {
+		return err
+	}
+	return p.fDatabase.Set(msg.GetHash(), []byte{})
+}
+
+func (p *sApp) stop() error {
+	err := closer.CloseAll([]io.Closer{
+		p.fDatabase,
+	})
+	if err != nil {
+		return errors.Join(ErrClose, err)
+	}
+	return nil
+}
@@ -0,0 +1,274 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/number571/go-peer/pkg/crypto/random"
+	"github.com/number571/go-peer/pkg/logger"
+	"github.com/number571/go-peer/pkg/message/layer1"
+	"github.com/number571/go-peer/pkg/payload"
+	"github.com/number571/go-peer/pkg/storage/cache"
+	testutils_gopeer "github.com/number571/go-peer/test/utils"
+	"github.com/number571/hidden-lake/internal/adapters/common/pkg/app/config"
+	hla_settings "github.com/number571/hidden-lake/internal/adapters/common/pkg/settings"
+	"github.com/number571/hidden-lake/internal/utils/flag"
+	"github.com/number571/hidden-lake/pkg/adapters"
+	"github.com/number571/hidden-lake/pkg/adapters/http/client"
+	hla_memory "github.com/number571/hidden-lake/pkg/adapters/memory"
+	testutils "github.com/number571/hidden-lake/test/utils"
+)
+
+var (
+	tgFlags = flag.NewFlagsBuilder(
+		flag.NewFlagBuilder("-v", "--version").
+			WithDescription("print information about service"),
+		flag.NewFlagBuilder("-h", "--help").
+			WithDescription("print version of service"),
+		flag.NewFlagBuilder("-p", "--path").
+			WithDescription("set path to config, database files").
+			WithDefinedValue("."),
+		flag.NewFlagBuilder("-n", "--network").
+			WithDescription("set network key for connections").
+			WithDefinedValue(""),
+	).Build()
+	tgSettings = hla_settings.NewSettings(&hla_settings.SSettings{
+		FServiceFullName: "hidden-lake-adapter=memory",
+		FAdapterScheme:   "memory",
+		FPathYML:         "hla_memory.yml",
+		FPathDB:          "hla_memory.db",
+	})
+)
+
+var (
+	_ INetworkAdapter = &tsNetworkAdapter{}
+	_ IInitAdapter    = &tsNetworkAdapter{}
+)
+
+type tsNetworkAdapter struct {
+	hla_memory.IMemoryAdapter
+	fInitErr error
+}
+
+func (p *tsNetworkAdapter) Init() error                                 { return p.fInitErr }
+func (p *tsNetworkAdapter) GetConnections() []string                    { return nil }
+func (p *tsNetworkAdapter) AddConnection(context.Context, string) error { return nil }
+func (p *tsNetworkAdapter) DelConnection(string) error                  { return nil }
+
+func testNewNetworkAdapterF(pInitErr error) INetworkAdapterF {
+	return func(
+		_ config.IConfig,
+		_ string,
+		pSettings adapters.ISettings,
+		pCache cache.ICache,
+		pAnonLogger logger.ILogger,
+		_ logger.ILogger,
+	) INetworkAdapter {
+		memoryAdapter := hla_memory.NewMemoryAdapter(
+			hla_memory.NewSettings(&hla_memory.SSettings{FAdapterSettings: pSettings}),
+			hla_memory.NewBus(),
+			pCache,
+		)
+		return &tsNetworkAdapter{
+			IMemoryAdapter: memoryAdapter.WithLogger(tgSettings.GetServiceName(), pAnonLogger),
+			fInitErr:       pInitErr,
+		}
+	}
+}
+
+func TestError(t *testing.T) {
+	t.Parallel()
+
+	str := "value"
+	err := &SAppError{str}
+	if err.Error() != errPrefix+str {
+		t.Error("incorrect err.Error()")
+		return
+	}
+}
+
+const tcPathConfig = "./testdata/"
+const tcDataConfig = `settings:
+  message_size_bytes: 8192
+`
+
+func TestInitApp(t *testing.T) {
+	t.Parallel()
+
+	testDeleteFiles(tcPathConfig)
+	defer testDeleteFiles(tcPathConfig)
+
+	if err := os.WriteFile(tcPathConfig+tgSettings.GetPathYML(), []byte(tcDataConfig), 0600); err != nil {
+		t.Error(err)
+		return
+	}
+
+	app, err := InitApp([]string{"--path", tcPathConfig}, tgFlags, tgSettings, testNewNetworkAdapterF(nil))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		if err := app.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			t.Error(err)
+			return
+		}
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+}
+
+func TestInitAppWithTLS(t *testing.T) {
+	t.Parallel()
+
+	pathTo := t.TempDir()
+	dataConfig := tcDataConfig + `tls:
+  self_signed: true
+`
+	if err := os.WriteFile(filepath.Join(pathTo, tgSettings.GetPathYML()), []byte(dataConfig), 0600); err != nil {
+		t.Error(err)
+		return
+	}
+
+	if _, err := InitApp([]string{"--path", pathTo}, tgFlags, tgSettings, testNewNetworkAdapterF(nil)); !errors.Is(err, ErrTLSNotSupported) {
+		t.Error("success init app with tls which is not supported by adapter")
+		return
+	}
+}
+
+func TestAppInitAdapter(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.SConfig{
+		FSettings: &config.SConfigSettings{
+			FMessageSizeBytes: 8192,
+		},
+	}
+	if _, err := config.BuildConfig(filepath.Join(t.TempDir(), tgSettings.GetPathYML()), cfg); err != nil {
+		t.Error(err)
+		return
+	}
+
+	initErr := errors.New("some error") // nolint: err113
+	app := NewApp(tgSettings, cfg, t.TempDir(), testNewNetworkAdapterF(initErr))
+
+	if err := app.Run(context.Background()); !errors.Is(err, ErrInitAdapter) {
+		t.Error("success run app with failed init of adapter")
+		return
+	}
+}
+
+func testDeleteFiles(path string) {
+	os.RemoveAll(path + tgSettings.GetPathYML())
+	os.RemoveAll(path + tgSettings.GetPathDB())
+}
+
+func TestApp(t *testing.T) {
+	t.Parallel()
+
+	testDeleteFiles("./")
+	defer testDeleteFiles("./")
+
+	// Run application
+	cfg, err := config.BuildConfig(tgSettings.GetPathYML(), &config.SConfig{
+		FSettings: &config.SConfigSettings{
+			FMessageSizeBytes: 8192,
+			FWorkSizeBits:     10,
+			FNetworkKey:       "_",
+			FDatabaseEnabled:  true,
+		},
+		FAddress: &config.SAddress{
+			FInternal: testutils.TgAddrs[46],
+			FExternal: testutils.TgAddrs[47],
+		},
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	app := NewApp(tgSettings, cfg, ".", testNewNetworkAdapterF(nil))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		if err := app.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			t.Error(err)
+			return
+		}
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+
+	client := client.NewClient(
+		client.NewRequester(
+			testutils.TgAddrs[46],
+			&http.Client{Timeout: time.Minute},
+		),
+	)
+
+	err1 := testutils_gopeer.TryN(
+		50,
+		10*time.Millisecond,
+		func() error {
+			_, err := client.GetIndex(context.Background())
+			return err
+		},
+	)
+	if err1 != nil {
+		t.Error(err1)
+		return
+	}
+
+	msgBytes := []byte("hello, world!")
+	msgBytes = append(msgBytes, random.NewRandom().GetBytes(uint64(8192-len(msgBytes)))...)
+	netMsg := layer1.NewMessage(
+		layer1.NewConstructSettings(&layer1.SConstructSettings{
+			FSettings: layer1.NewSettings(&layer1.SSettings{
+				FWorkSizeBits: 10,
+				FNetworkKey:   "_",
+			}),
+		}),
+		payload.NewPayload32(0x01, msgBytes),
+	)
+
+	if err := client.ProduceMessage(ctx, netMsg); err != nil {
+		t.Error(err)
+		return
+	}
+
+	// try twice running
+	go func() {
+		if err := app.Run(ctx); err == nil {
+			t.Error("success double run")
+			return
+		}
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+	time.Sleep(100 * time.Millisecond)
+
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	defer cancel1()
+
+	// try twice running
+	go func() {
+		if err := app.Run(ctx1); err != nil && !errors.Is(err, context.Canceled) {
+			t.Error(err)
+			return
+		}
+	}()
+	time.Sleep(100 * time.Millisecond)
+}
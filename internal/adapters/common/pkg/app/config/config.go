@@ -7,11 +7,14 @@ import (
 
 	"github.com/number571/go-peer/pkg/encoding"
 	logger "github.com/number571/hidden-lake/internal/utils/logger/std"
+	"github.com/number571/hidden-lake/pkg/adapters/chaos"
 )
 
 var (
 	_ IConfig  = &SConfig{}
 	_ IAddress = &SAddress{}
+	_ IChaos   = &SChaos{}
+	_ ITLS     = &STLS{}
 )
 
 type SConfigSettings struct {
@@ -31,6 +34,8 @@ type SConfig struct {
 	FAddress     *SAddress        `yaml:"address,omitempty"`
 	FEndpoints   []string         `yaml:"endpoints,omitempty"`
 	FConnections []string         `yaml:"connections,omitempty"`
+	FChaos       *SChaos          `yaml:"chaos,omitempty"`
+	FTLS         *STLS            `yaml:"tls,omitempty"`
 }
 
 type SAddress struct {
@@ -38,6 +43,30 @@ type SAddress struct {
 	FInternal string `yaml:"internal,omitempty"`
 }
 
+// SChaos enables the injection of faults into the messages produced
+// to the connections. It is used only for testing of networks.
+type SChaos struct {
+	FSeed       uint64  `yaml:"seed,omitempty"`
+	FDrop       float64 `yaml:"drop,omitempty"`
+	FDelay      float64 `yaml:"delay,omitempty"`
+	FDuplicate  float64 `yaml:"duplicate,omitempty"`
+	FReorder    float64 `yaml:"reorder,omitempty"`
+	FCorrupt    float64 `yaml:"corrupt,omitempty"`
+	FDelayMaxMS uint64  `yaml:"delay_max_ms,omitempty"`
+}
+
+// STLS wraps the connections by TLS, so observers can not distinguish
+// the traffic from other TLS traffic. Pins are the SHA-256 hashes of
+// certificates of peers by their addresses.
+type STLS struct {
+	FCertFile   string            `yaml:"cert_file,omitempty"`
+	FKeyFile    string            `yaml:"key_file,omitempty"`
+	FSelfSigned bool              `yaml:"self_signed,omitempty"`
+	FCAFile     string            `yaml:"ca_file,omitempty"`
+	FClientAuth bool              `yaml:"client_auth,omitempty"`
+	FPins       map[string]string `yaml:"pins,omitempty"`
+}
+
 func BuildConfig(pFilepath string, pCfg *SConfig) (IConfig, error) {
 	if _, err := os.Stat(pFilepath); !os.IsNotExist(err) {
 		return nil, errors.Join(ErrConfigAlreadyExist, err)
@@ -80,7 +109,34 @@ func LoadConfig(pFilepath string) (IConfig, error) {
 
 func (p *SConfig) isValid() bool {
 	return true &&
-		p.FSettings.FMessageSizeBytes != 0
+		p.FSettings.FMessageSizeBytes != 0 &&
+		p.isValidChaos() &&
+		p.isValidTLS()
+}
+
+func (p *SConfig) isValidChaos() bool {
+	if p.FChaos == nil {
+		return true
+	}
+	return true &&
+		chaos.IsValidProb(p.FChaos.FDrop) &&
+		chaos.IsValidProb(p.FChaos.FDelay) &&
+		chaos.IsValidProb(p.FChaos.FDuplicate) &&
+		chaos.IsValidProb(p.FChaos.FReorder) &&
+		chaos.IsValidProb(p.FChaos.FCorrupt)
+}
+
+func (p *SConfig) isValidTLS() bool {
+	if p.FTLS == nil {
+		return true
+	}
+	if !p.FTLS.FSelfSigned && (p.FTLS.FCertFile == "" || p.FTLS.FKeyFile == "") {
+		return false
+	}
+	if p.FTLS.FClientAuth && p.FTLS.FCAFile == "" && len(p.FTLS.FPins) == 0 {
+		return false
+	}
+	return true
 }
 
 func (p *SConfig) initConfig() error {
@@ -131,6 +187,20 @@ func (p *SConfig) GetConnections() []string {
 	return p.FConnections
 }
 
+func (p *SConfig) GetChaos() IChaos {
+	if p.FChaos == nil {
+		return nil
+	}
+	return p.FChaos
+}
+
+func (p *SConfig) GetTLS() ITLS {
+	if p.FTLS == nil {
+		return nil
+	}
+	return p.FTLS
+}
+
 func (p *SConfigSettings) GetMessageSizeBytes() uint64 {
 	return p.FMessageSizeBytes
 }
@@ -158,3 +228,55 @@ func (p *SAddress) GetExternal() string {
 func (p *SAddress) GetInternal() string {
 	return p.FInternal
 }
+
+func (p *SChaos) GetSeed() uint64 {
+	return p.FSeed
+}
+
+func (p *SChaos) GetDropProb() float64 {
+	return p.FDrop
+}
+
+func (p *SChaos) GetDelayProb() float64 {
+	return p.FDelay
+}
+
+func (p *SChaos) GetDuplicateProb() float64 {
+	return p.FDuplicate
+}
+
+func (p *SChaos) GetReorderProb() float64 {
+	return p.FReorder
+}
+
+func (p *SChaos) GetCorruptProb() float64 {
+	return p.FCorrupt
+}
+
+func (p *SChaos) GetDelayMaxMS() uint64 {
+	return p.FDelayMaxMS
+}
+
+func (p *STLS) GetCertFile() string {
+	return p.FCertFile
+}
+
+func (p *STLS) GetKeyFile() string {
+	return p.FKeyFile
+}
+
+func (p *STLS) GetSelfSigned() bool {
+	return p.FSelfSigned
+}
+
+func (p *STLS) GetCAFile() string {
+	return p.FCAFile
+}
+
+func (p *STLS) GetClientAuth() bool {
+	return p.FClientAuth
+}
+
+func (p *STLS) GetPins() map[string]string {
+	return p.FPins
+}
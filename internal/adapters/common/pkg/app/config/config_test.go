@@ -275,3 +275,143 @@ func TestWrapper(t *testing.T) {
 		return
 	}
 }
+
+func TestChaos(t *testing.T) {
+	t.Parallel()
+
+	configFile := fmt.Sprintf(tcConfigFileTemplate, 7)
+	defer os.Remove(configFile)
+
+	testConfigDefaultInit(configFile)
+	cfg, err := LoadConfig(configFile)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if cfg.GetChaos() != nil {
+		t.Error("chaos is enabled by default")
+		return
+	}
+
+	chaosConfig := testNewConfigString() + `chaos:
+  seed: 571
+  drop: 0.1
+  delay: 0.2
+  duplicate: 0.3
+  reorder: 0.4
+  corrupt: 0.5
+  delay_max_ms: 1000
+`
+	if err := os.WriteFile(configFile, []byte(chaosConfig), 0o600); err != nil {
+		t.Error(err)
+		return
+	}
+
+	cfg, err = LoadConfig(configFile)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	chaos := cfg.GetChaos()
+	if chaos == nil {
+		t.Error("chaos is not loaded")
+		return
+	}
+	if chaos.GetSeed() != 571 || chaos.GetDelayMaxMS() != 1000 {
+		t.Error("got invalid chaos seed or delay")
+		return
+	}
+	if chaos.GetDropProb() != 0.1 || chaos.GetDelayProb() != 0.2 || chaos.GetDuplicateProb() != 0.3 {
+		t.Error("got invalid chaos probabilities")
+		return
+	}
+	if chaos.GetReorderProb() != 0.4 || chaos.GetCorruptProb() != 0.5 {
+		t.Error("got invalid chaos probabilities")
+		return
+	}
+
+	invalidConfig := strings.ReplaceAll(chaosConfig, "drop: 0.1", "drop: 1.1")
+	if err := os.WriteFile(configFile, []byte(invalidConfig), 0o600); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err := LoadConfig(configFile); err == nil {
+		t.Error("success load config with invalid chaos probability")
+		return
+	}
+}
+
+func TestTLS(t *testing.T) {
+	t.Parallel()
+
+	configFile := fmt.Sprintf(tcConfigFileTemplate, 8)
+	defer os.Remove(configFile)
+
+	testConfigDefaultInit(configFile)
+	cfg, err := LoadConfig(configFile)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if cfg.GetTLS() != nil {
+		t.Error("tls is enabled by default")
+		return
+	}
+
+	tlsConfig := testNewConfigString() + `tls:
+  cert_file: node.crt
+  key_file: node.key
+  ca_file: ca.crt
+  client_auth: true
+  pins:
+    127.0.0.1:9999: abcd
+`
+	if err := os.WriteFile(configFile, []byte(tlsConfig), 0o600); err != nil {
+		t.Error(err)
+		return
+	}
+
+	cfg, err = LoadConfig(configFile)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	tls := cfg.GetTLS()
+	if tls == nil {
+		t.Error("tls is not loaded")
+		return
+	}
+	if tls.GetCertFile() != "node.crt" || tls.GetKeyFile() != "node.key" || tls.GetCAFile() != "ca.crt" {
+		t.Error("got invalid tls files")
+		return
+	}
+	if tls.GetSelfSigned() || !tls.GetClientAuth() || tls.GetPins()["127.0.0.1:9999"] != "abcd" {
+		t.Error("got invalid tls options")
+		return
+	}
+
+	withoutKey := strings.ReplaceAll(tlsConfig, "  key_file: node.key\n", "")
+	if err := os.WriteFile(configFile, []byte(withoutKey), 0o600); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err := LoadConfig(configFile); err == nil {
+		t.Error("success load config without key file")
+		return
+	}
+
+	selfSigned := testNewConfigString() + `tls:
+  self_signed: true
+  client_auth: true
+`
+	if err := os.WriteFile(configFile, []byte(selfSigned), 0o600); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err := LoadConfig(configFile); err == nil {
+		t.Error("success load config with client auth without ca and pins")
+		return
+	}
+}
@@ -25,6 +25,8 @@ func (p *tsConfig) GetShare() bool               { return false }
 func (p *tsConfig) GetAddress() IAddress         { return nil }
 func (p *tsConfig) GetEndpoints() []string       { return nil }
 func (p *tsConfig) GetConnections() []string     { return nil }
+func (p *tsConfig) GetChaos() IChaos             { return nil }
+func (p *tsConfig) GetTLS() ITLS                 { return nil }
 
 func TestPanicEditor(t *testing.T) {
 	t.Parallel()
@@ -1,7 +1,7 @@
 package config
 
 const (
-	errPrefix = "internal/adapters/tcp/pkg/app/config = "
+	errPrefix = "internal/adapters/common/pkg/app/config = "
 )
 
 type SConfigError struct {
@@ -7,30 +7,30 @@ import (
 
 	"github.com/number571/go-peer/pkg/encoding"
 	"github.com/number571/hidden-lake/build"
-	hla_settings "github.com/number571/hidden-lake/internal/adapters/tcp/pkg/settings"
+	hla_settings "github.com/number571/hidden-lake/internal/adapters/common/pkg/settings"
 	hls_settings "github.com/number571/hidden-lake/internal/service/pkg/settings"
 	logger "github.com/number571/hidden-lake/internal/utils/logger/std"
 )
 
-func InitConfig(cfgPath string, initCfg *SConfig, useNetwork string) (IConfig, error) {
+func InitConfig(sett hla_settings.ISettings, cfgPath string, initCfg *SConfig, useNetwork string) (IConfig, error) {
 	if _, err := os.Stat(cfgPath); !os.IsNotExist(err) {
 		cfg, err := LoadConfig(cfgPath)
 		if err != nil {
 			return nil, errors.Join(ErrLoadConfig, err)
 		}
-		return rebuildConfig(cfg, useNetwork)
+		return rebuildConfig(sett, cfg, useNetwork)
 	}
 	if initCfg == nil {
-		initCfg = initConfig()
+		initCfg = initConfig(sett)
 	}
 	cfg, err := BuildConfig(cfgPath, initCfg)
 	if err != nil {
 		return nil, errors.Join(ErrBuildConfig, err)
 	}
-	return rebuildConfig(cfg, useNetwork)
+	return rebuildConfig(sett, cfg, useNetwork)
 }
 
-func initConfig() *SConfig {
+func initConfig(pSettings hla_settings.ISettings) *SConfig {
 	defaultNetwork := build.GNetworks[build.CDefaultNetwork]
 	return &SConfig{
 		FSettings: &SConfigSettings{
@@ -39,8 +39,8 @@ func initConfig() *SConfig {
 		},
 		FLogging: []string{logger.CLogInfo, logger.CLogWarn, logger.CLogErro},
 		FAddress: &SAddress{
-			FExternal: hla_settings.CDefaultExternalAddress,
-			FInternal: hla_settings.CDefaultInternalAddress,
+			FExternal: pSettings.GetDefaultExternalAddress(),
+			FInternal: pSettings.GetDefaultInternalAddress(),
 		},
 		FEndpoints: []string{
 			hls_settings.CDefaultExternalAddress,
@@ -48,7 +48,7 @@ func initConfig() *SConfig {
 	}
 }
 
-func rebuildConfig(pCfg IConfig, pUseNetwork string) (IConfig, error) {
+func rebuildConfig(pSettings hla_settings.ISettings, pCfg IConfig, pUseNetwork string) (IConfig, error) {
 	if pUseNetwork == "" {
 		return pCfg, nil
 	}
@@ -69,7 +69,7 @@ func rebuildConfig(pCfg IConfig, pUseNetwork string) (IConfig, error) {
 		if err != nil {
 			return nil, errors.Join(ErrParseURL, err)
 		}
-		if u.Scheme != hla_settings.CServiceAdapterScheme {
+		if u.Scheme != pSettings.GetAdapterScheme() {
 			continue
 		}
 		cfg.FConnections = append(cfg.FConnections, u.Host)
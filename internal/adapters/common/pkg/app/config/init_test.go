@@ -6,7 +6,18 @@ import (
 	"testing"
 
 	"github.com/number571/hidden-lake/build"
-	hls_settings "github.com/number571/hidden-lake/internal/adapters/tcp/pkg/settings"
+	hla_settings "github.com/number571/hidden-lake/internal/adapters/common/pkg/settings"
+)
+
+var (
+	tgSettings = hla_settings.NewSettings(&hla_settings.SSettings{
+		FServiceFullName:        "hidden-lake-adapter=test",
+		FAdapterScheme:          "test",
+		FPathYML:                "hla_test.yml",
+		FPathDB:                 "hla_test.db",
+		FDefaultExternalAddress: "127.0.0.1:9527",
+		FDefaultInternalAddress: "127.0.0.1:9528",
+	})
 )
 
 func TestRebuild(t *testing.T) {
@@ -17,7 +28,7 @@ func TestRebuild(t *testing.T) {
 
 	testConfigDefaultInit(configFile)
 
-	if _, err := InitConfig(configFile, nil, "test_rebuild_config_network"); err == nil {
+	if _, err := InitConfig(tgSettings, configFile, nil, "test_rebuild_config_network"); err == nil {
 		t.Error("success init config with rebuild for unknown network")
 		return
 	}
@@ -28,7 +39,7 @@ func TestRebuild(t *testing.T) {
 		break
 	}
 
-	if _, err := InitConfig(configFile, nil, network); err != nil {
+	if _, err := InitConfig(tgSettings, configFile, nil, network); err != nil {
 		t.Error(err)
 		return
 	}
@@ -42,7 +53,7 @@ func TestInit(t *testing.T) {
 
 	testConfigDefaultInit(configFile)
 
-	config1, err := InitConfig(configFile, nil, "")
+	config1, err := InitConfig(tgSettings, configFile, nil, "")
 	if err != nil {
 		t.Error(err)
 		return
@@ -59,21 +70,21 @@ func TestInit(t *testing.T) {
 		return
 	}
 
-	if _, err := InitConfig(configFile, nil, ""); err == nil {
+	if _, err := InitConfig(tgSettings, configFile, nil, ""); err == nil {
 		t.Error("success init config with invalid config structure (1)")
 		return
 	}
 
 	os.Remove(configFile)
 
-	if _, err := InitConfig(configFile, &SConfig{}, ""); err == nil {
+	if _, err := InitConfig(tgSettings, configFile, &SConfig{}, ""); err == nil {
 		t.Error("success init config with invalid config structure (2)")
 		return
 	}
 
 	os.Remove(configFile)
 
-	config2, err := InitConfig(configFile, config1.(*SConfig), "")
+	config2, err := InitConfig(tgSettings, configFile, config1.(*SConfig), "")
 	if err != nil {
 		t.Error(err)
 		return
@@ -86,13 +97,13 @@ func TestInit(t *testing.T) {
 
 	os.Remove(configFile)
 
-	config3, err := InitConfig(configFile, nil, "")
+	config3, err := InitConfig(tgSettings, configFile, nil, "")
 	if err != nil {
 		t.Error(err)
 		return
 	}
 
-	if config3.GetAddress().GetExternal() != hls_settings.CDefaultExternalAddress {
+	if config3.GetAddress().GetExternal() != tgSettings.GetDefaultExternalAddress() {
 		t.Error("got invalid field with exist config (3)")
 		return
 	}
@@ -21,6 +21,8 @@ type IConfig interface {
 	GetAddress() IAddress
 	GetEndpoints() []string
 	GetConnections() []string
+	GetChaos() IChaos
+	GetTLS() ITLS
 }
 
 type IConfigSettings interface {
@@ -34,3 +36,22 @@ type IAddress interface {
 	GetExternal() string
 	GetInternal() string
 }
+
+type IChaos interface {
+	GetSeed() uint64
+	GetDropProb() float64
+	GetDelayProb() float64
+	GetDuplicateProb() float64
+	GetReorderProb() float64
+	GetCorruptProb() float64
+	GetDelayMaxMS() uint64
+}
+
+type ITLS interface {
+	GetCertFile() string
+	GetKeyFile() string
+	GetSelfSigned() bool
+	GetCAFile() string
+	GetClientAuth() bool
+	GetPins() map[string]string
+}
@@ -0,0 +1,23 @@
+package app
+
+const (
+	errPrefix = "internal/adapters/common/pkg/app = "
+)
+
+type SAppError struct {
+	str string
+}
+
+func (err *SAppError) Error() string {
+	return errPrefix + err.str
+}
+
+var (
+	ErrRunning         = &SAppError{"app running"}
+	ErrService         = &SAppError{"service"}
+	ErrClose           = &SAppError{"close"}
+	ErrInitDB          = &SAppError{"init database"}
+	ErrInitAdapter     = &SAppError{"init adapter"}
+	ErrTLSNotSupported = &SAppError{"tls is not supported by adapter"}
+	ErrExist           = &SAppError{"exist"}
+)
@@ -0,0 +1,33 @@
+package app
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/number571/go-peer/pkg/types"
+	"github.com/number571/hidden-lake/internal/adapters/common/pkg/app/config"
+	hla_settings "github.com/number571/hidden-lake/internal/adapters/common/pkg/settings"
+	"github.com/number571/hidden-lake/internal/utils/flag"
+)
+
+func InitApp(
+	pArgs []string,
+	pFlags flag.IFlags,
+	pSettings hla_settings.ISettings,
+	pAdapterF INetworkAdapterF,
+) (types.IRunner, error) {
+	inputPath := strings.TrimSuffix(pFlags.Get("-p").GetStringValue(pArgs), "/")
+
+	cfgPath := filepath.Join(inputPath, pSettings.GetPathYML())
+	cfg, err := config.InitConfig(pSettings, cfgPath, nil, pFlags.Get("-n").GetStringValue(pArgs))
+	if err != nil {
+		return nil, fmt.Errorf("init config: %w", err)
+	}
+
+	if cfg.GetTLS() != nil && !pSettings.GetTLSSupported() {
+		return nil, ErrTLSNotSupported
+	}
+
+	return NewApp(pSettings, cfg, inputPath, pAdapterF), nil
+}
@@ -4,16 +4,15 @@ import (
 	"context"
 	"net/http"
 
-	"github.com/number571/hidden-lake/internal/adapters/tcp/internal/handler"
+	"github.com/number571/hidden-lake/internal/adapters/common/internal/handler"
 	hla_settings "github.com/number571/hidden-lake/pkg/adapters/http/settings"
 )
 
 func (p *sApp) initHandlers(pCtx context.Context) {
-	networkNode := p.fTCPAdapter.GetConnKeeper().GetNetworkNode()
 	p.fHTTPAdapter.WithHandlers(map[string]http.HandlerFunc{
-		hla_settings.CHandleIndexPath:          handler.HandleIndexAPI(p.fHTTPLogger),
-		hla_settings.CHandleConfigSettingsPath: handler.HandleConfigSettingsAPI(p.fWrapper.GetConfig(), p.fHTTPLogger),
-		hla_settings.CHandleConfigConnectsPath: handler.HandleConfigConnectsAPI(pCtx, p.fWrapper, p.fHTTPLogger, networkNode),
-		hla_settings.CHandleNetworkOnlinePath:  handler.HandleNetworkOnlineAPI(p.fHTTPLogger, networkNode),
+		hla_settings.CHandleIndexPath:          handler.HandleIndexAPI(p.fSettings, p.fHTTPLogger),
+		hla_settings.CHandleConfigSettingsPath: handler.HandleConfigSettingsAPI(p.fSettings, p.fWrapper.GetConfig(), p.fHTTPLogger),
+		hla_settings.CHandleConfigConnectsPath: handler.HandleConfigConnectsAPI(pCtx, p.fSettings, p.fWrapper, p.fHTTPLogger, p.fNodeAdapter),
+		hla_settings.CHandleNetworkOnlinePath:  handler.HandleNetworkOnlineAPI(p.fSettings, p.fHTTPLogger, p.fNodeAdapter),
 	})
 }
@@ -0,0 +1,8 @@
+package app
+
+func (p *sApp) initLoggers() {
+	p.fHTTPAdapter.WithLogger(p.fSettings.GetServiceName(), p.fAnonLogger)
+	if p.fChaosAdapter != nil {
+		p.fChaosAdapter.WithLogger(p.fSettings.GetServiceName(), p.fAnonLogger)
+	}
+}
@@ -0,0 +1,35 @@
+package app
+
+import (
+	"context"
+
+	"github.com/number571/go-peer/pkg/logger"
+	"github.com/number571/go-peer/pkg/storage/cache"
+	"github.com/number571/hidden-lake/internal/adapters/common/pkg/app/config"
+	"github.com/number571/hidden-lake/pkg/adapters"
+)
+
+type INetworkAdapter interface {
+	adapters.IRunnerAdapter
+
+	GetConnections() []string
+	AddConnection(context.Context, string) error
+	DelConnection(string) error
+}
+
+// IInitAdapter is implemented by the network adapters which prepare
+// resources (as example certificates) or print their state at startup.
+type IInitAdapter interface {
+	Init() error
+}
+
+// INetworkAdapterF builds the network adapter of HLA by the config.
+// The anon logger is used for messages, the std logger for states.
+type INetworkAdapterF func(
+	pCfg config.IConfig,
+	pPathTo string,
+	pSettings adapters.ISettings,
+	pCache cache.ICache,
+	pAnonLogger logger.ILogger,
+	pStdfLogger logger.ILogger,
+) INetworkAdapter
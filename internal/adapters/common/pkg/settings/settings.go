@@ -0,0 +1,86 @@
+package settings
+
+import (
+	"github.com/number571/hidden-lake/internal/utils/name"
+)
+
+var (
+	_ ISettings = &sSettings{}
+)
+
+type SSettings sSettings
+type sSettings struct {
+	FServiceFullName        string
+	FAdapterScheme          string
+	FPathYML                string
+	FPathDB                 string
+	FDefaultExternalAddress string
+	FDefaultInternalAddress string
+	FTLSSupported           bool
+
+	fServiceName name.IServiceName
+}
+
+func NewSettings(pSett *SSettings) ISettings {
+	if pSett == nil {
+		pSett = &SSettings{}
+	}
+	return (&sSettings{
+		FServiceFullName:        pSett.FServiceFullName,
+		FAdapterScheme:          pSett.FAdapterScheme,
+		FPathYML:                pSett.FPathYML,
+		FPathDB:                 pSett.FPathDB,
+		FDefaultExternalAddress: pSett.FDefaultExternalAddress,
+		FDefaultInternalAddress: pSett.FDefaultInternalAddress,
+		FTLSSupported:           pSett.FTLSSupported,
+	}).useDefault()
+}
+
+func (p *sSettings) useDefault() *sSettings {
+	if p.FServiceFullName == "" {
+		panic(`p.FServiceFullName == ""`)
+	}
+	if p.FAdapterScheme == "" {
+		panic(`p.FAdapterScheme == ""`)
+	}
+	if p.FPathYML == "" {
+		panic(`p.FPathYML == ""`)
+	}
+	if p.FPathDB == "" {
+		panic(`p.FPathDB == ""`)
+	}
+	p.fServiceName = name.LoadServiceName(p.FServiceFullName)
+	return p
+}
+
+func (p *sSettings) GetServiceFullName() string {
+	return p.FServiceFullName
+}
+
+func (p *sSettings) GetServiceName() name.IServiceName {
+	return p.fServiceName
+}
+
+func (p *sSettings) GetAdapterScheme() string {
+	return p.FAdapterScheme
+}
+
+func (p *sSettings) GetPathYML() string {
+	return p.FPathYML
+}
+
+func (p *sSettings) GetPathDB() string {
+	return p.FPathDB
+}
+
+func (p *sSettings) GetDefaultExternalAddress() string {
+	return p.FDefaultExternalAddress
+}
+
+func (p *sSettings) GetDefaultInternalAddress() string {
+	return p.FDefaultInternalAddress
+}
+
+func (p *sSettings) GetTLSSupported() bool {
+	return p.FTLSSupported
+}
@@ -0,0 +1,26 @@
+package settings
+
+import "testing"
+
+func TestSettings(t *testing.T) {
+	t.Parallel()
+
+	sett := NewSettings(&SSettings{
+		FServiceFullName: "hidden-lake-adapter=test",
+		FAdapterScheme:   "test",
+		FPathYML:         "hla_test.yml",
+		FPathDB:          "hla_test.db",
+	})
+	if sett.GetServiceName().Short() != "HLA=test" {
+		t.Error("invalid service name")
+		return
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("nothing panics")
+			return
+		}
+	}()
+	_ = NewSettings(nil)
+}
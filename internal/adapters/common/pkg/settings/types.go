@@ -0,0 +1,19 @@
+package settings
+
+import (
+	"github.com/number571/hidden-lake/internal/utils/name"
+)
+
+type ISettings interface {
+	GetServiceFullName() string
+	GetServiceName() name.IServiceName
+	GetAdapterScheme() string
+
+	GetPathYML() string
+	GetPathDB() string
+
+	GetDefaultExternalAddress() string
+	GetDefaultInternalAddress() string
+
+	GetTLSSupported() bool
+}
@@ -0,0 +1,151 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/number571/go-peer/pkg/logger"
+	"github.com/number571/go-peer/pkg/storage/cache"
+	hla_app "github.com/number571/hidden-lake/internal/adapters/common/pkg/app"
+	"github.com/number571/hidden-lake/internal/adapters/common/pkg/app/config"
+	hla_tcp_settings "github.com/number571/hidden-lake/internal/adapters/tcp/pkg/settings"
+	"github.com/number571/hidden-lake/pkg/adapters"
+	hla_tcp "github.com/number571/hidden-lake/pkg/adapters/tcp"
+)
+
+var (
+	_ hla_app.INetworkAdapter = &sTCPAdapter{}
+	_ hla_app.IInitAdapter    = &sTCPAdapter{}
+)
+
+type sTCPAdapter struct {
+	hla_tcp.ITCPAdapter
+
+	fLogger      logger.ILogger
+	fTLSSettings hla_tcp.ITLSSettings
+	fTLSSigned   bool
+}
+
+func newTCPAdapter(
+	pCfg config.IConfig,
+	pPathTo string,
+	pSettings adapters.ISettings,
+	pCache cache.ICache,
+	pAnonLogger logger.ILogger,
+	pStdfLogger logger.ILogger,
+) hla_app.INetworkAdapter {
+	tlsSettings := newTLSSettings(pCfg.GetTLS(), pPathTo)
+	tcpAdapter := hla_tcp.NewTCPAdapter(
+		hla_tcp.NewSettings(&hla_tcp.SSettings{
+			FAddress:         pCfg.GetAddress().GetExternal(),
+			FAdapterSettings: pSettings,
+			FTLSSettings:     tlsSettings,
+		}),
+		pCache,
+		func() []string { return pCfg.GetConnections() },
+	)
+	return &sTCPAdapter{
+		ITCPAdapter:  tcpAdapter.WithLogger(gSettings.GetServiceName(), pAnonLogger),
+		fLogger:      pStdfLogger,
+		fTLSSettings: tlsSettings,
+		fTLSSigned:   pCfg.GetTLS() != nil && pCfg.GetTLS().GetSelfSigned(),
+	}
+}
+
+func newTLSSettings(pTLS config.ITLS, pPathTo string) hla_tcp.ITLSSettings {
+	if pTLS == nil {
+		return nil
+	}
+	certFile, keyFile := pTLS.GetCertFile(), pTLS.GetKeyFile()
+	if certFile == "" {
+		certFile = hla_tcp_settings.CPathCert
+	}
+	if keyFile == "" {
+		keyFile = hla_tcp_settings.CPathKey
+	}
+	return hla_tcp.NewTLSSettings(&hla_tcp.STLSSettings{
+		FCertFile:   getFilePath(pPathTo, certFile),
+		FKeyFile:    getFilePath(pPathTo, keyFile),
+		FCAFile:     getFilePath(pPathTo, pTLS.GetCAFile()),
+		FClientAuth: pTLS.GetClientAuth(),
+		FPins:       pTLS.GetPins(),
+	})
+}
+
+func getFilePath(pPathTo, pFile string) string {
+	if pFile == "" || filepath.IsAbs(pFile) {
+		return pFile
+	}
+	return filepath.Join(pPathTo, pFile)
+}
+
+func (p *sTCPAdapter) GetConnections() []string {
+	connects := p.GetConnKeeper().GetNetworkNode().GetConnections()
+	result := make([]string, 0, len(connects))
+	for addr := range connects {
+		result = append(result, addr)
+	}
+	return result
+}
+
+func (p *sTCPAdapter) AddConnection(pCtx context.Context, pAddress string) error {
+	return p.GetConnKeeper().GetNetworkNode().AddConnection(pCtx, pAddress)
+}
+
+func (p *sTCPAdapter) DelConnection(pAddress string) error {
+	return p.GetConnKeeper().GetNetworkNode().DelConnection(pAddress)
+}
+
+func (p *sTCPAdapter) Init() error {
+	if p.fTLSSettings == nil {
+		return nil
+	}
+
+	fingerprint, err := p.initTLS()
+	if err != nil {
+		return errors.Join(ErrInitTLS, err)
+	}
+
+	p.fLogger.PushInfo(fmt.Sprintf(
+		"%s tls is enabled (fingerprint=%s, client_auth=%t)",
+		gSettings.GetServiceName().Short(),
+		fingerprint,
+		p.fTLSSettings.GetClientAuth(),
+	))
+	if len(p.fTLSSettings.GetPins()) == 0 && p.fTLSSettings.GetCAFile() == "" {
+		// certificates of peers can not be checked, so the connections are
+		// encrypted, but the peers are not authenticated (MITM is possible)
+		p.fLogger.PushWarn(fmt.Sprintf(
+			"%s tls is unauthenticated (pins and ca_file are not set, any certificate of peer is accepted)",
+			gSettings.GetServiceName().Short(),
+		))
+	}
+	return nil
+}
+
+// initTLS generates the certificate in the self-signed mode if it does
+// not exist and returns the fingerprint which is used as the pin by peers.
+func (p *sTCPAdapter) initTLS() (string, error) {
+	certFile := p.fTLSSettings.GetCertFile()
+	if _, err := os.Stat(certFile); os.IsNotExist(err) && p.fTLSSigned {
+		certPEM, keyPEM, err := hla_tcp.NewSelfSignedCertificate()
+		if err != nil {
+			return "", err
+		}
+		if err := os.WriteFile(p.fTLSSettings.GetKeyFile(), keyPEM, 0o600); err != nil {
+			return "", err
+		}
+		if err := os.WriteFile(certFile, certPEM, 0o600); err != nil {
+			return "", err
+		}
+	}
+
+	certPEM, err := os.ReadFile(certFile)
+	if err != nil {
+		return "", err
+	}
+	return hla_tcp.GetCertFingerprint(certPEM)
+}
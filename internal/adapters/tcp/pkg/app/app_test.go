@@ -5,6 +5,7 @@ import (
 	"errors"
 	"net/http"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -12,10 +13,11 @@ import (
 	"github.com/number571/go-peer/pkg/message/layer1"
 	"github.com/number571/go-peer/pkg/payload"
 	testutils_gopeer "github.com/number571/go-peer/test/utils"
-	"github.com/number571/hidden-lake/internal/adapters/tcp/pkg/app/config"
+	"github.com/number571/hidden-lake/internal/adapters/common/pkg/app/config"
 	"github.com/number571/hidden-lake/internal/adapters/tcp/pkg/settings"
 	"github.com/number571/hidden-lake/internal/utils/flag"
 	"github.com/number571/hidden-lake/pkg/adapters/http/client"
+	hla_tcp "github.com/number571/hidden-lake/pkg/adapters/tcp"
 	testutils "github.com/number571/hidden-lake/test/utils"
 )
 
@@ -81,6 +83,52 @@ func TestInitApp(t *testing.T) {
 	cancel()
 }
 
+func TestInitAppWithTLS(t *testing.T) {
+	t.Parallel()
+
+	pathTo := t.TempDir()
+	dataConfig := tcDataConfig + `tls:
+  self_signed: true
+`
+	if err := os.WriteFile(filepath.Join(pathTo, settings.CPathYML), []byte(dataConfig), 0600); err != nil {
+		t.Error(err)
+		return
+	}
+
+	app, err := InitApp([]string{"--path", pathTo}, tgFlags)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		if err := app.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			t.Error(err)
+			return
+		}
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+
+	certPEM, err := os.ReadFile(filepath.Join(pathTo, settings.CPathCert))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err := hla_tcp.GetCertFingerprint(certPEM); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err := os.Stat(filepath.Join(pathTo, settings.CPathKey)); err != nil {
+		t.Error(err)
+		return
+	}
+}
+
 func testDeleteFiles(path string) {
 	os.RemoveAll(path + settings.CPathYML)
 	os.RemoveAll(path + settings.CPathDB)
@@ -13,9 +13,5 @@ func (err *SAppError) Error() string {
 }
 
 var (
-	ErrRunning = &SAppError{"app running"}
-	ErrService = &SAppError{"service"}
-	ErrClose   = &SAppError{"close"}
-	ErrInitDB  = &SAppError{"init database"}
-	ErrExist   = &SAppError{"exist"}
+	ErrInitTLS = &SAppError{"init tls"}
 )
@@ -1,24 +1,11 @@
 package app
 
 import (
-	"fmt"
-	"path/filepath"
-	"strings"
-
 	"github.com/number571/go-peer/pkg/types"
-	"github.com/number571/hidden-lake/internal/adapters/tcp/pkg/app/config"
-	"github.com/number571/hidden-lake/internal/adapters/tcp/pkg/settings"
+	hla_app "github.com/number571/hidden-lake/internal/adapters/common/pkg/app"
 	"github.com/number571/hidden-lake/internal/utils/flag"
 )
 
 func InitApp(pArgs []string, pFlags flag.IFlags) (types.IRunner, error) {
-	inputPath := strings.TrimSuffix(pFlags.Get("-p").GetStringValue(pArgs), "/")
-
-	cfgPath := filepath.Join(inputPath, settings.CPathYML)
-	cfg, err := config.InitConfig(cfgPath, nil, pFlags.Get("-n").GetStringValue(pArgs))
-	if err != nil {
-		return nil, fmt.Errorf("init config: %w", err)
-	}
-
-	return NewApp(cfg, inputPath), nil
+	return hla_app.InitApp(pArgs, pFlags, gSettings, newTCPAdapter)
 }
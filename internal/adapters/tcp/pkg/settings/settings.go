@@ -22,6 +22,12 @@ const (
 	CPathDB  = "hla_" + CServiceAdapterScheme + ".db"
 )
 
+const (
+	// used by the self-signed mode of TLS if paths are not set
+	CPathCert = "hla_" + CServiceAdapterScheme + ".crt"
+	CPathKey  = "hla_" + CServiceAdapterScheme + ".key"
+)
+
 const (
 	CDefaultExternalAddress = "127.0.0.1:9521"
 	CDefaultInternalAddress = "127.0.0.1:9522"
@@ -0,0 +1,102 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/number571/go-peer/pkg/logger"
+	"github.com/number571/go-peer/pkg/storage/cache"
+	hla_app "github.com/number571/hidden-lake/internal/adapters/common/pkg/app"
+	"github.com/number571/hidden-lake/internal/adapters/common/pkg/app/config"
+	"github.com/number571/hidden-lake/pkg/adapters"
+	hla_udp "github.com/number571/hidden-lake/pkg/adapters/udp"
+)
+
+const (
+	statsPeriod = time.Minute
+)
+
+var (
+	_ hla_app.INetworkAdapter = &sUDPAdapter{}
+	_ hla_app.IInitAdapter    = &sUDPAdapter{}
+)
+
+type sUDPAdapter struct {
+	hla_udp.IUDPAdapter
+
+	fLogger logger.ILogger
+}
+
+func newUDPAdapter(
+	pCfg config.IConfig,
+	_ string,
+	pSettings adapters.ISettings,
+	pCache cache.ICache,
+	pAnonLogger logger.ILogger,
+	pStdfLogger logger.ILogger,
+) hla_app.INetworkAdapter {
+	udpAdapter := hla_udp.NewUDPAdapter(
+		hla_udp.NewSettings(&hla_udp.SSettings{
+			FAddress:         pCfg.GetAddress().GetExternal(),
+			FAdapterSettings: pSettings,
+		}),
+		pCache,
+		func() []string { return pCfg.GetConnections() },
+	)
+	return &sUDPAdapter{
+		IUDPAdapter: udpAdapter.WithLogger(gSettings.GetServiceName(), pAnonLogger),
+		fLogger:     pStdfLogger,
+	}
+}
+
+func (p *sUDPAdapter) Init() error {
+	// datagrams are sent without waiting for each other (no head-of-line
+	// blocking), but the loss of one datagram is the loss of the message
+	udpSettings := p.GetSettings()
+	p.fLogger.PushWarn(fmt.Sprintf(
+		"%s delivery is unreliable (datagram=%dB, datagrams per message=%d, reassembly timeout=%s)",
+		gSettings.GetServiceName().Short(),
+		udpSettings.GetDatagramSizeBytes(),
+		hla_udp.GetDatagramsPerMessage(udpSettings),
+		udpSettings.GetReassemblyTimeout(),
+	))
+	return nil
+}
+
+func (p *sUDPAdapter) Run(pCtx context.Context) error {
+	ctx, cancel := context.WithCancel(pCtx)
+	defer cancel()
+
+	go p.runStats(ctx)
+	return p.IUDPAdapter.Run(ctx)
+}
+
+func (p *sUDPAdapter) runStats(pCtx context.Context) {
+	ticker := time.NewTicker(statsPeriod)
+	defer ticker.Stop()
+
+	prevDatagrams := uint64(0)
+	for {
+		select {
+		case <-pCtx.Done():
+			return
+		case <-ticker.C:
+			stats := p.GetStats()
+			datagrams := stats.GetSentDatagrams() + stats.GetRecvDatagrams()
+			if datagrams == prevDatagrams {
+				continue
+			}
+			prevDatagrams = datagrams
+			p.fLogger.PushInfo(fmt.Sprintf(
+				"%s stats (datagrams: sent=%d recv=%d; messages: sent=%d recv=%d lost=%d)",
+				gSettings.GetServiceName().Short(),
+				stats.GetSentDatagrams(),
+				stats.GetRecvDatagrams(),
+				stats.GetSentMessages(),
+				stats.GetRecvMessages(),
+				stats.GetLostMessages(),
+			))
+		}
+	}
+}
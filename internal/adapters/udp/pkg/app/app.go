@@ -0,0 +1,24 @@
+package app
+
+import (
+	"github.com/number571/go-peer/pkg/types"
+	hla_app "github.com/number571/hidden-lake/internal/adapters/common/pkg/app"
+	"github.com/number571/hidden-lake/internal/adapters/common/pkg/app/config"
+	hla_settings "github.com/number571/hidden-lake/internal/adapters/common/pkg/settings"
+	hla_udp_settings "github.com/number571/hidden-lake/internal/adapters/udp/pkg/settings"
+)
+
+var (
+	gSettings = hla_settings.NewSettings(&hla_settings.SSettings{
+		FServiceFullName:        hla_udp_settings.CServiceFullName,
+		FAdapterScheme:          hla_udp_settings.CServiceAdapterScheme,
+		FPathYML:                hla_udp_settings.CPathYML,
+		FPathDB:                 hla_udp_settings.CPathDB,
+		FDefaultExternalAddress: hla_udp_settings.CDefaultExternalAddress,
+		FDefaultInternalAddress: hla_udp_settings.CDefaultInternalAddress,
+	})
+)
+
+func NewApp(pCfg config.IConfig, pPathTo string) types.IRunner {
+	return hla_app.NewApp(gSettings, pCfg, pPathTo, newUDPAdapter)
+}
@@ -0,0 +1,47 @@
+package app
+
+import (
+	"github.com/number571/go-peer/pkg/logger"
+	"github.com/number571/go-peer/pkg/storage/cache"
+	"github.com/number571/go-peer/pkg/types"
+	hla_app "github.com/number571/hidden-lake/internal/adapters/common/pkg/app"
+	"github.com/number571/hidden-lake/internal/adapters/common/pkg/app/config"
+	hla_settings "github.com/number571/hidden-lake/internal/adapters/common/pkg/settings"
+	hla_ws_settings "github.com/number571/hidden-lake/internal/adapters/ws/pkg/settings"
+	"github.com/number571/hidden-lake/pkg/adapters"
+	hla_ws "github.com/number571/hidden-lake/pkg/adapters/ws"
+)
+
+var (
+	gSettings = hla_settings.NewSettings(&hla_settings.SSettings{
+		FServiceFullName:        hla_ws_settings.CServiceFullName,
+		FAdapterScheme:          hla_ws_settings.CServiceAdapterScheme,
+		FPathYML:                hla_ws_settings.CPathYML,
+		FPathDB:                 hla_ws_settings.CPathDB,
+		FDefaultExternalAddress: hla_ws_settings.CDefaultExternalAddress,
+		FDefaultInternalAddress: hla_ws_settings.CDefaultInternalAddress,
+	})
+)
+
+func NewApp(pCfg config.IConfig, pPathTo string) types.IRunner {
+	return hla_app.NewApp(gSettings, pCfg, pPathTo, newWSAdapter)
+}
+
+func newWSAdapter(
+	pCfg config.IConfig,
+	_ string,
+	pSettings adapters.ISettings,
+	pCache cache.ICache,
+	pAnonLogger logger.ILogger,
+	_ logger.ILogger,
+) hla_app.INetworkAdapter {
+	wsAdapter := hla_ws.NewWSAdapter(
+		hla_ws.NewSettings(&hla_ws.SSettings{
+			FAddress:         pCfg.GetAddress().GetExternal(),
+			FAdapterSettings: pSettings,
+		}),
+		pCache,
+		func() []string { return pCfg.GetConnections() },
+	)
+	return wsAdapter.WithLogger(gSettings.GetServiceName(), pAnonLogger)
+}
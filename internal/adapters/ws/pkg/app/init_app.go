@@ -0,0 +1,11 @@
+package app
+
+import (
+	"github.com/number571/go-peer/pkg/types"
+	hla_app "github.com/number571/hidden-lake/internal/adapters/common/pkg/app"
+	"github.com/number571/hidden-lake/internal/utils/flag"
+)
+
+func InitApp(pArgs []string, pFlags flag.IFlags) (types.IRunner, error) {
+	return hla_app.InitApp(pArgs, pFlags, gSettings, newWSAdapter)
+}
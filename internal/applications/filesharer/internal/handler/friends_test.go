@@ -13,6 +13,7 @@ import (
 	"github.com/number571/go-peer/pkg/crypto/asymmetric"
 	"github.com/number571/go-peer/pkg/logger"
 	"github.com/number571/hidden-lake/internal/applications/filesharer/pkg/app/config"
+	"github.com/number571/hidden-lake/internal/utils/contact"
 	std_logger "github.com/number571/hidden-lake/internal/utils/logger/std"
 )
 
@@ -57,6 +58,65 @@ func TestFriendsPage(t *testing.T) {
 		t.Error("request success with invalid pubkey (void)")
 		return
 	}
+
+	newContact, err := contact.NewContact(asymmetric.NewPrivKey(), "alias_name", "", nil)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if err := friendsRequestPostContact(handler, contact.ToURI(newContact)); err != nil {
+		t.Error(err)
+		return
+	}
+
+	modifiedContact := *newContact
+	modifiedContact.FAliasName = "modified"
+	if err := friendsRequestPostContact(handler, contact.ToURI(&modifiedContact)); err == nil {
+		t.Error("request success with invalid signature of contact")
+		return
+	}
+
+	otherContact, err := contact.NewContact(asymmetric.NewPrivKey(), "", "other_network", nil)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if err := friendsRequestPostContact(handler, contact.ToURI(otherContact)); err == nil {
+		t.Error("request success with other network key of contact")
+		return
+	}
+	if err := friendsRequestIntroduction(handler, "PUT", "id"); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := friendsRequestIntroduction(handler, "DELETE", "id"); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := friendsRequestUpdate(handler, "alias_name"); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := friendsRequestTTL(handler, "POST", "3600"); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := friendsRequestTTL(handler, "PUT", "3600"); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := friendsRequestTTL(handler, "POST", "abc"); err == nil {
+		t.Error("request success with invalid ttl (post)")
+		return
+	}
+	if err := friendsRequestTTL(handler, "PUT", "0"); err == nil {
+		t.Error("request success with invalid ttl (put)")
+		return
+	}
+	if err := friendsRequestIntroduction(handler, "PUT", ""); err == nil {
+		t.Error("request success with invalid introduction_id")
+		return
+	}
 	if err := friendsRequestDeleteAliasName(handler); err == nil {
 		t.Error("request success with invalid alias_name")
 	}
@@ -88,6 +148,94 @@ func friendsRequestPostOK(handler http.HandlerFunc) error {
 	return nil
 }
 
+func friendsRequestPostContact(handler http.HandlerFunc, contactURI string) error {
+	formData := url.Values{
+		"method":  {"POST"},
+		"contact": {contactURI},
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/friends", strings.NewReader(formData.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	handler(w, req)
+	res := w.Result()
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return errors.New("bad status code")
+	}
+
+	return nil
+}
+
+func friendsRequestIntroduction(handler http.HandlerFunc, method, introductionID string) error {
+	formData := url.Values{
+		"method":          {method},
+		"introduction_id": {introductionID},
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/friends", strings.NewReader(formData.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	handler(w, req)
+	res := w.Result()
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return errors.New("bad status code")
+	}
+
+	return nil
+}
+
+func friendsRequestTTL(handler http.HandlerFunc, method, ttl string) error {
+	formData := url.Values{
+		"method":     {method},
+		"public_key": {asymmetric.NewPrivKey().GetPubKey().ToString()},
+		"alias_name": {"alias_name"},
+		"ttl":        {ttl},
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/friends", strings.NewReader(formData.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	handler(w, req)
+	res := w.Result()
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return errors.New("bad status code")
+	}
+
+	return nil
+}
+
+func friendsRequestUpdate(handler http.HandlerFunc, aliasName string) error {
+	formData := url.Values{
+		"method":     {"PUT"},
+		"alias_name": {aliasName},
+		"notes":      {"notes"},
+		"tags":       {"tag1, tag2"},
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/friends", strings.NewReader(formData.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	handler(w, req)
+	res := w.Result()
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return errors.New("bad status code")
+	}
+
+	return nil
+}
+
 func friendsRequestPostPubKey(handler http.HandlerFunc) error {
 	formData := url.Values{
 		"method":     {"POST"},
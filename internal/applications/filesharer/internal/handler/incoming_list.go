@@ -16,7 +16,7 @@ import (
 	http_logger "github.com/number571/hidden-lake/internal/utils/logger/http"
 
 	hlf_settings "github.com/number571/hidden-lake/internal/applications/filesharer/pkg/settings"
-	hls_settings "github.com/number571/hidden-lake/internal/service/pkg/settings"
+	hls_settings "github.com/number571/hidden-lake/pkg/service/settings"
 )
 
 func HandleIncomingListHTTP(pLogger logger.ILogger, pCfg config.IConfig, pStgPath string) http.HandlerFunc {
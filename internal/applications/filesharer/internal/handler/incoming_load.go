@@ -14,8 +14,8 @@ import (
 	http_logger "github.com/number571/hidden-lake/internal/utils/logger/http"
 
 	hlf_settings "github.com/number571/hidden-lake/internal/applications/filesharer/pkg/settings"
-	hls_client "github.com/number571/hidden-lake/internal/service/pkg/client"
-	hls_settings "github.com/number571/hidden-lake/internal/service/pkg/settings"
+	hls_client "github.com/number571/hidden-lake/pkg/service/client"
+	hls_settings "github.com/number571/hidden-lake/pkg/service/settings"
 )
 
 func HandleIncomingLoadHTTP(
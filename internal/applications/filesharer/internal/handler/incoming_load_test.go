@@ -7,14 +7,16 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/number571/go-peer/pkg/crypto/asymmetric"
 	"github.com/number571/go-peer/pkg/logger"
-	hls_client "github.com/number571/hidden-lake/internal/service/pkg/client"
-	hls_config "github.com/number571/hidden-lake/internal/service/pkg/config"
 	std_logger "github.com/number571/hidden-lake/internal/utils/logger/std"
 	"github.com/number571/hidden-lake/pkg/request"
 	"github.com/number571/hidden-lake/pkg/response"
+	hls_client "github.com/number571/hidden-lake/pkg/service/client"
+	hls_settings "github.com/number571/hidden-lake/pkg/service/settings"
+	"github.com/number571/hidden-lake/test/utils/hlsclient"
 )
 
 func TestHandleIncomingLoadHTTP(t *testing.T) {
@@ -214,6 +216,8 @@ var (
 )
 
 type tsHLSClient struct {
+	hlsclient.SNopClient
+
 	fFetchOK bool
 	fWithOK  bool
 	fPrivKey asymmetric.IPrivKey
@@ -227,12 +231,11 @@ func newTsHLSClient(pFetchOK, pWithOK bool) *tsHLSClient {
 	}
 }
 
-func (p *tsHLSClient) GetIndex(context.Context) (string, error) { return "", nil }
-func (p *tsHLSClient) GetSettings(context.Context) (hls_config.IConfigSettings, error) {
+func (p *tsHLSClient) GetSettings(context.Context) (hls_settings.IConfigSettings, error) {
 	if !p.fWithOK {
 		return nil, errors.New("some error") // nolint: err113
 	}
-	return &hls_config.SConfigSettings{
+	return &hls_settings.SConfigSettings{
 		FPayloadSizeBytes: 1024,
 	}, nil
 }
@@ -244,24 +247,32 @@ func (p *tsHLSClient) GetPubKey(context.Context) (asymmetric.IPubKey, error) {
 func (p *tsHLSClient) GetOnlines(context.Context) ([]string, error) {
 	return []string{"tcp://aaa"}, nil
 }
-func (p *tsHLSClient) DelOnline(context.Context, string) error { return nil }
 
-func (p *tsHLSClient) GetFriends(context.Context) (map[string]asymmetric.IPubKey, error) {
-	return nil, nil
+func (p *tsHLSClient) GetFriendsInfo(context.Context) ([]*hls_settings.SFriend, error) {
+	return []*hls_settings.SFriend{{
+		FAliasName:    "abc",
+		FPublicKey:    asymmetric.NewPrivKey().GetPubKey().ToString(),
+		FSafetyNumber: "12345 67890",
+		FNotes:        "notes",
+		FTags:         []string{"tag"},
+		FAdded:        time.Now().Format(time.RFC3339),
+		FExpires:      time.Now().Add(time.Hour).Format(time.RFC3339),
+		FTTLMS:        3_600_000,
+	}}, nil
 }
 
-func (p *tsHLSClient) AddFriend(context.Context, string, asymmetric.IPubKey) error { return nil }
-func (p *tsHLSClient) DelFriend(context.Context, string) error                     { return nil }
+func (p *tsHLSClient) GetIntroductions(context.Context) ([]*hls_settings.SIntroduction, error) {
+	return []*hls_settings.SIntroduction{{
+		FID:           "id",
+		FIntroducer:   "introducer",
+		FAliasName:    "alias_name",
+		FSafetyNumber: "00000 00000",
+	}}, nil
+}
 
 func (p *tsHLSClient) GetConnections(context.Context) ([]string, error) {
 	return []string{"tcp://aaa"}, nil
 }
-func (p *tsHLSClient) AddConnection(context.Context, string) error { return nil }
-func (p *tsHLSClient) DelConnection(context.Context, string) error { return nil }
-
-func (p *tsHLSClient) SendRequest(context.Context, string, request.IRequest) error {
-	return nil
-}
 
 func (p *tsHLSClient) FetchRequest(context.Context, string, request.IRequest) (response.IResponse, error) {
 	if !p.fFetchOK {
@@ -14,7 +14,7 @@ import (
 	http_logger "github.com/number571/hidden-lake/internal/utils/logger/http"
 	"github.com/number571/hidden-lake/internal/webui"
 
-	hls_client "github.com/number571/hidden-lake/internal/service/pkg/client"
+	hls_client "github.com/number571/hidden-lake/pkg/service/client"
 )
 
 type sConnection struct {
@@ -12,9 +12,9 @@ import (
 	"github.com/number571/hidden-lake/internal/applications/filesharer/pkg/app/config"
 	hlf_client "github.com/number571/hidden-lake/internal/applications/filesharer/pkg/client"
 	hlf_settings "github.com/number571/hidden-lake/internal/applications/filesharer/pkg/settings"
-	hls_client "github.com/number571/hidden-lake/internal/service/pkg/client"
 	http_logger "github.com/number571/hidden-lake/internal/utils/logger/http"
 	"github.com/number571/hidden-lake/internal/webui"
+	hls_client "github.com/number571/hidden-lake/pkg/service/client"
 )
 
 type sStorage struct {
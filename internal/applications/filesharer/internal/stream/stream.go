@@ -12,7 +12,7 @@ import (
 	"github.com/number571/go-peer/pkg/encoding"
 	internal_utils "github.com/number571/hidden-lake/internal/applications/filesharer/internal/utils"
 	hlf_client "github.com/number571/hidden-lake/internal/applications/filesharer/pkg/client"
-	hls_client "github.com/number571/hidden-lake/internal/service/pkg/client"
+	hls_client "github.com/number571/hidden-lake/pkg/service/client"
 )
 
 func init() {
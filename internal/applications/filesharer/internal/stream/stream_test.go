@@ -9,10 +9,11 @@ import (
 
 	"github.com/number571/go-peer/pkg/crypto/asymmetric"
 	"github.com/number571/go-peer/pkg/crypto/hashing"
-	hls_client "github.com/number571/hidden-lake/internal/service/pkg/client"
-	hls_config "github.com/number571/hidden-lake/internal/service/pkg/config"
 	"github.com/number571/hidden-lake/pkg/request"
 	"github.com/number571/hidden-lake/pkg/response"
+	hls_client "github.com/number571/hidden-lake/pkg/service/client"
+	hls_settings "github.com/number571/hidden-lake/pkg/service/settings"
+	"github.com/number571/hidden-lake/test/utils/hlsclient"
 )
 
 func TestError(t *testing.T) {
@@ -81,6 +82,8 @@ var (
 )
 
 type tsHLSClient struct {
+	hlsclient.SNopClient
+
 	fCounter   int
 	fFileBytes []byte
 	fPrivKey   asymmetric.IPrivKey
@@ -93,9 +96,8 @@ func newTsHLSClient(pFileBytes []byte) *tsHLSClient {
 	}
 }
 
-func (p *tsHLSClient) GetIndex(context.Context) (string, error) { return "", nil }
-func (p *tsHLSClient) GetSettings(context.Context) (hls_config.IConfigSettings, error) {
-	return &hls_config.SConfigSettings{
+func (p *tsHLSClient) GetSettings(context.Context) (hls_settings.IConfigSettings, error) {
+	return &hls_settings.SConfigSettings{
 		FPayloadSizeBytes: 104, // gRespSize + 1
 	}, nil
 }
@@ -104,24 +106,6 @@ func (p *tsHLSClient) GetPubKey(context.Context) (asymmetric.IPubKey, error) {
 	return p.fPrivKey.GetPubKey(), nil
 }
 
-func (p *tsHLSClient) GetOnlines(context.Context) ([]string, error) { return nil, nil }
-func (p *tsHLSClient) DelOnline(context.Context, string) error      { return nil }
-
-func (p *tsHLSClient) GetFriends(context.Context) (map[string]asymmetric.IPubKey, error) {
-	return nil, nil
-}
-
-func (p *tsHLSClient) AddFriend(context.Context, string, asymmetric.IPubKey) error { return nil }
-func (p *tsHLSClient) DelFriend(context.Context, string) error                     { return nil }
-
-func (p *tsHLSClient) GetConnections(context.Context) ([]string, error) { return nil, nil }
-func (p *tsHLSClient) AddConnection(context.Context, string) error      { return nil }
-func (p *tsHLSClient) DelConnection(context.Context, string) error      { return nil }
-
-func (p *tsHLSClient) SendRequest(context.Context, string, request.IRequest) error {
-	return nil
-}
-
 func (p *tsHLSClient) FetchRequest(context.Context, string, request.IRequest) (response.IResponse, error) {
 	resp := response.NewResponseBuilder().WithCode(200).WithBody([]byte{p.fFileBytes[p.fCounter]})
 	p.fCounter++
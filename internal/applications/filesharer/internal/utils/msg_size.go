@@ -4,10 +4,10 @@ import (
 	"context"
 	"errors"
 
-	hls_client "github.com/number571/hidden-lake/internal/service/pkg/client"
-	hls_settings "github.com/number571/hidden-lake/internal/service/pkg/settings"
 	"github.com/number571/hidden-lake/internal/utils/api"
 	hls_response "github.com/number571/hidden-lake/pkg/response"
+	hls_client "github.com/number571/hidden-lake/pkg/service/client"
+	hls_settings "github.com/number571/hidden-lake/pkg/service/settings"
 )
 
 var (
@@ -5,10 +5,9 @@ import (
 	"testing"
 
 	"github.com/number571/go-peer/pkg/crypto/asymmetric"
-	hls_client "github.com/number571/hidden-lake/internal/service/pkg/client"
-	hls_config "github.com/number571/hidden-lake/internal/service/pkg/config"
-	"github.com/number571/hidden-lake/pkg/request"
-	"github.com/number571/hidden-lake/pkg/response"
+	hls_client "github.com/number571/hidden-lake/pkg/service/client"
+	hls_settings "github.com/number571/hidden-lake/pkg/service/settings"
+	"github.com/number571/hidden-lake/test/utils/hlsclient"
 )
 
 func TestError(t *testing.T) {
@@ -46,6 +45,8 @@ var (
 )
 
 type tsHLSClient struct {
+	hlsclient.SNopClient
+
 	fMsgSize uint64
 	fPrivKey asymmetric.IPrivKey
 }
@@ -57,9 +58,8 @@ func newTsHLSClient(pMsgSize uint64) *tsHLSClient {
 	}
 }
 
-func (p *tsHLSClient) GetIndex(context.Context) (string, error) { return "", nil }
-func (p *tsHLSClient) GetSettings(context.Context) (hls_config.IConfigSettings, error) {
-	return &hls_config.SConfigSettings{
+func (p *tsHLSClient) GetSettings(context.Context) (hls_settings.IConfigSettings, error) {
+	return &hls_settings.SConfigSettings{
 		FPayloadSizeBytes: p.fMsgSize,
 	}, nil
 }
@@ -67,25 +67,3 @@ func (p *tsHLSClient) GetSettings(context.Context) (hls_config.IConfigSettings,
 func (p *tsHLSClient) GetPubKey(context.Context) (asymmetric.IPubKey, error) {
 	return p.fPrivKey.GetPubKey(), nil
 }
-
-func (p *tsHLSClient) GetOnlines(context.Context) ([]string, error) { return nil, nil }
-func (p *tsHLSClient) DelOnline(context.Context, string) error      { return nil }
-
-func (p *tsHLSClient) GetFriends(context.Context) (map[string]asymmetric.IPubKey, error) {
-	return nil, nil
-}
-
-func (p *tsHLSClient) AddFriend(context.Context, string, asymmetric.IPubKey) error { return nil }
-func (p *tsHLSClient) DelFriend(context.Context, string) error                     { return nil }
-
-func (p *tsHLSClient) GetConnections(context.Context) ([]string, error) { return nil, nil }
-func (p *tsHLSClient) AddConnection(context.Context, string) error      { return nil }
-func (p *tsHLSClient) DelConnection(context.Context, string) error      { return nil }
-
-func (p *tsHLSClient) SendRequest(context.Context, string, request.IRequest) error {
-	return nil
-}
-
-func (p *tsHLSClient) FetchRequest(context.Context, string, request.IRequest) (response.IResponse, error) {
-	return nil, nil
-}
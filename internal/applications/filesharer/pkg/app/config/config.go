@@ -29,10 +29,12 @@ type SConfig struct {
 	fFilepath string
 	fLogging  logger.ILogging
 
-	FSettings   *SConfigSettings `yaml:"settings"`
-	FLogging    []string         `yaml:"logging,omitempty"`
-	FAddress    *SAddress        `yaml:"address"`
-	FConnection string           `yaml:"connection"`
+	FSettings           *SConfigSettings `yaml:"settings"`
+	FLogging            []string         `yaml:"logging,omitempty"`
+	FAddress            *SAddress        `yaml:"address"`
+	FConnection         string           `yaml:"connection"`
+	FConnectionToken    string           `yaml:"connection_token,omitempty"`    // bearer token of the HLS API
+	FConnectionIdentity string           `yaml:"connection_identity,omitempty"` // identity of the HLS
 }
 
 type SAddress struct {
@@ -156,6 +158,14 @@ func (p *SConfig) GetConnection() string {
 	return p.FConnection
 }
 
+func (p *SConfig) GetConnectionToken() string {
+	return p.FConnectionToken
+}
+
+func (p *SConfig) GetConnectionIdentity() string {
+	return p.FConnectionIdentity
+}
+
 func (p *SAddress) GetInternal() string {
 	return p.FInternal
 }
@@ -24,16 +24,20 @@ logging:
 address:
   internal: '%s'
   external: '%s'
-connection: '%s'`
+connection: '%s'
+connection_token: '%s'
+connection_identity: '%s'`
 )
 
 const (
-	tcAddressInterface  = "address_interface"
-	tcAddressIncoming   = "address_incoming"
-	tcConnectionService = "connection_service"
-	tcMessageSize       = (1 << 20)
-	tcPageOffset        = 10
-	tcRetryNum          = 2
+	tcAddressInterface   = "address_interface"
+	tcAddressIncoming    = "address_incoming"
+	tcConnectionService  = "connection_service"
+	tcConnectionToken    = "connection_token"
+	tcConnectionIdentity = "connection_identity"
+	tcMessageSize        = (1 << 20)
+	tcPageOffset         = 10
+	tcRetryNum           = 2
 )
 
 func TestError(t *testing.T) {
@@ -55,6 +59,8 @@ func testNewConfigString() string {
 		tcAddressInterface,
 		tcAddressIncoming,
 		tcConnectionService,
+		tcConnectionToken,
+		tcConnectionIdentity,
 	)
 }
 
@@ -104,6 +110,16 @@ func TestConfig(t *testing.T) {
 		return
 	}
 
+	if cfg.GetConnectionToken() != tcConnectionToken {
+		t.Error("connection_token is invalid")
+		return
+	}
+
+	if cfg.GetConnectionIdentity() != tcConnectionIdentity {
+		t.Error("connection_identity is invalid")
+		return
+	}
+
 	if cfg.GetSettings().GetPageOffset() != tcPageOffset {
 		t.Error("settings.page_offset is invalid")
 		return
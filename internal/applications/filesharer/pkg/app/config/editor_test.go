@@ -27,6 +27,8 @@ func (p *tsConfig) GetShare() bool                   { return false }
 func (p *tsConfig) GetAddress() IAddress             { return nil }
 func (p *tsConfig) GetNetworkKey() string            { return "" }
 func (p *tsConfig) GetConnection() string            { return "" }
+func (p *tsConfig) GetConnectionToken() string       { return "" }
+func (p *tsConfig) GetConnectionIdentity() string    { return "" }
 func (p *tsConfig) GetSecretKeys() map[string]string { return nil }
 func (p *tsConfig) GetStoragePath() string           { return "" }
 
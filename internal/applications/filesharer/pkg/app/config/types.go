@@ -19,6 +19,8 @@ type IConfig interface {
 	GetAddress() IAddress
 	GetLogging() logger.ILogging
 	GetConnection() string
+	GetConnectionToken() string
+	GetConnectionIdentity() string
 }
 
 type IConfigSettings interface {
@@ -10,8 +10,8 @@ import (
 	"github.com/number571/hidden-lake/internal/applications/filesharer/internal/handler"
 	"github.com/number571/hidden-lake/internal/applications/filesharer/pkg/app/config"
 	hlf_settings "github.com/number571/hidden-lake/internal/applications/filesharer/pkg/settings"
-	hls_client "github.com/number571/hidden-lake/internal/service/pkg/client"
 	"github.com/number571/hidden-lake/internal/webui"
+	hls_client "github.com/number571/hidden-lake/pkg/service/client"
 )
 
 func (p *sApp) initExternalServiceHTTP(pCtx context.Context, pHlsClient hls_client.IClient) {
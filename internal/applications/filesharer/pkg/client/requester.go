@@ -8,8 +8,8 @@ import (
 
 	"github.com/number571/go-peer/pkg/encoding"
 	hlf_settings "github.com/number571/hidden-lake/internal/applications/filesharer/pkg/settings"
-	hls_client "github.com/number571/hidden-lake/internal/service/pkg/client"
 	hls_request "github.com/number571/hidden-lake/pkg/request"
+	hls_client "github.com/number571/hidden-lake/pkg/service/client"
 )
 
 var (
@@ -4,23 +4,39 @@ import (
 	"context"
 	"net/http"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/number571/go-peer/pkg/crypto/asymmetric"
 	"github.com/number571/go-peer/pkg/logger"
 	"github.com/number571/hidden-lake/internal/applications/messenger/pkg/app/config"
 	hlm_settings "github.com/number571/hidden-lake/internal/applications/messenger/pkg/settings"
-	hls_client "github.com/number571/hidden-lake/internal/service/pkg/client"
+	"github.com/number571/hidden-lake/internal/utils/contact"
 	http_logger "github.com/number571/hidden-lake/internal/utils/logger/http"
 	"github.com/number571/hidden-lake/internal/webui"
+	hls_client "github.com/number571/hidden-lake/pkg/service/client"
+	hls_settings "github.com/number571/hidden-lake/pkg/service/settings"
 )
 
 type sFriends struct {
 	*sTemplate
-	FFriends       []string
+	FFriends       []sFriend
+	FIntroductions []*hls_settings.SIntroduction
 	FFriendBaseURL string
 }
 
+type sFriend struct {
+	FAliasName    string
+	FSafetyNumber string
+	FNotes        string
+	FTags         []string
+	FTagsString   string
+	FAdded        string
+	FLastSeen     string
+	FExpiresIn    string
+}
+
 func FriendsPage(
 	pCtx context.Context,
 	pLogger logger.ILogger,
@@ -45,6 +61,44 @@ func FriendsPage(
 			pubStrKey := strings.TrimSpace(pR.FormValue("public_key"))
 			aliasName := strings.TrimSpace(pR.FormValue("alias_name")) // may be nil
 
+			ttl, err := getFriendTTL(pR.FormValue("ttl")) // zero for permanent friend
+			if err != nil {
+				ErrorPage(pLogger, pCfg, "get_ttl", "invalid ttl")(pW, pR)
+				return
+			}
+			addFriend := func(pAliasName string, pPubKey asymmetric.IPubKey) error {
+				if ttl == 0 {
+					return pHlsClient.AddFriend(pCtx, pAliasName, pPubKey)
+				}
+				return pHlsClient.AddTempFriend(pCtx, pAliasName, pPubKey, ttl)
+			}
+
+			if contactStr := strings.TrimSpace(pR.FormValue("contact")); contactStr != "" {
+				friendContact, pubKey, err := contact.LoadContact(contactStr)
+				if err != nil {
+					ErrorPage(pLogger, pCfg, "load_contact", "failed load contact")(pW, pR)
+					return
+				}
+
+				settings, err := pHlsClient.GetSettings(pCtx)
+				if err != nil {
+					ErrorPage(pLogger, pCfg, "get_settings", "read settings")(pW, pR)
+					return
+				}
+
+				networkKey := friendContact.FNetworkKey
+				if networkKey != "" && networkKey != settings.GetNetworkKey() {
+					ErrorPage(pLogger, pCfg, "network_key", "network key mismatch")(pW, pR)
+					return
+				}
+
+				if err := addFriend(contact.GetAliasName(friendContact, aliasName), pubKey); err != nil {
+					ErrorPage(pLogger, pCfg, "add_friend", "add friend")(pW, pR)
+					return
+				}
+				break
+			}
+
 			if pubStrKey == "" {
 				ErrorPage(pLogger, pCfg, "public_key_nil", "public key is nil")(pW, pR)
 				return
@@ -61,11 +115,55 @@ func FriendsPage(
 				aliasName = pubKey.GetHasher().ToString()
 			}
 
-			if err := pHlsClient.AddFriend(pCtx, aliasName, pubKey); err != nil {
+			if err := addFriend(aliasName, pubKey); err != nil {
 				ErrorPage(pLogger, pCfg, "add_friend", "add friend")(pW, pR)
 				return
 			}
+		case http.MethodPut:
+			introductionID := strings.TrimSpace(pR.FormValue("introduction_id"))
+			aliasName := strings.TrimSpace(pR.FormValue("alias_name")) // may be nil
+
+			if introductionID == "" {
+				if aliasName == "" {
+					ErrorPage(pLogger, pCfg, "get_introduction_id", "introduction_id is nil")(pW, pR)
+					return
+				}
+				// extension of temporary friend
+				if ttlStr := strings.TrimSpace(pR.FormValue("ttl")); ttlStr != "" {
+					ttl, err := getFriendTTL(ttlStr)
+					if err != nil || ttl == 0 {
+						ErrorPage(pLogger, pCfg, "get_ttl", "invalid ttl")(pW, pR)
+						return
+					}
+					if err := pHlsClient.ExtendFriend(pCtx, aliasName, ttl); err != nil {
+						ErrorPage(pLogger, pCfg, "extend_friend", "extend friend")(pW, pR)
+						return
+					}
+					break
+				}
+				// notes and tags of friend
+				notes := strings.TrimSpace(pR.FormValue("notes"))
+				tags := strings.Split(pR.FormValue("tags"), ",")
+				if err := pHlsClient.UpdateFriend(pCtx, aliasName, notes, tags); err != nil {
+					ErrorPage(pLogger, pCfg, "update_friend", "update friend")(pW, pR)
+					return
+				}
+				break
+			}
+
+			if _, err := pHlsClient.AcceptIntroduction(pCtx, introductionID, aliasName); err != nil {
+				ErrorPage(pLogger, pCfg, "accept_introduction", "accept introduction")(pW, pR)
+				return
+			}
 		case http.MethodDelete:
+			if introductionID := strings.TrimSpace(pR.FormValue("introduction_id")); introductionID != "" {
+				if err := pHlsClient.RejectIntroduction(pCtx, introductionID); err != nil {
+					ErrorPage(pLogger, pCfg, "reject_introduction", "reject introduction")(pW, pR)
+					return
+				}
+				break
+			}
+
 			aliasName := strings.TrimSpace(pR.FormValue("alias_name"))
 			if aliasName == "" {
 				ErrorPage(pLogger, pCfg, "get_alias_name", "alias_name is nil")(pW, pR)
@@ -78,26 +176,69 @@ func FriendsPage(
 			}
 		}
 
-		friends, err := pHlsClient.GetFriends(pCtx)
+		friends, err := pHlsClient.GetFriendsInfo(pCtx)
 		if err != nil {
 			ErrorPage(pLogger, pCfg, "get_friends", "read friends")(pW, pR)
 			return
 		}
 
+		introductions, err := pHlsClient.GetIntroductions(pCtx)
+		if err != nil {
+			ErrorPage(pLogger, pCfg, "get_introductions", "read introductions")(pW, pR)
+			return
+		}
+
 		result := new(sFriends)
 		result.sTemplate = getTemplate(pCfg)
-		result.FFriends = make([]string, 0, len(friends))
+		result.FFriends = make([]sFriend, 0, len(friends))
+		result.FIntroductions = introductions
 		result.FFriendBaseURL = "/friends/chat"
 
-		friendsList := make([]string, 0, len(friends))
-		for aliasName := range friends {
-			friendsList = append(friendsList, aliasName)
+		sort.Slice(friends, func(i, j int) bool {
+			return friends[i].FAliasName < friends[j].FAliasName
+		})
+
+		for _, friend := range friends {
+			result.FFriends = append(result.FFriends, sFriend{
+				FAliasName:    friend.FAliasName,
+				FSafetyNumber: friend.FSafetyNumber,
+				FNotes:        friend.FNotes,
+				FTags:         friend.FTags,
+				FTagsString:   strings.Join(friend.FTags, ", "),
+				FAdded:        formatFriendTime(friend.FAdded),
+				FLastSeen:     formatFriendTime(friend.FLastSeen),
+				FExpiresIn:    formatExpiresIn(friend.FTTLMS),
+			})
 		}
-		sort.Strings(friendsList)
-
-		result.FFriends = append(result.FFriends, friendsList...)
 
 		pLogger.PushInfo(logBuilder.WithMessage(http_logger.CLogSuccess))
 		_ = webui.MustParseTemplate("index.html", "friends.html").Execute(pW, result)
 	}
 }
+
+func formatFriendTime(pTime string) string {
+	t, err := time.Parse(time.RFC3339, pTime)
+	if err != nil {
+		return "-"
+	}
+	return t.Local().Format(time.DateTime)
+}
+
+func getFriendTTL(pTTL string) (time.Duration, error) {
+	pTTL = strings.TrimSpace(pTTL)
+	if pTTL == "" {
+		return 0, nil
+	}
+	seconds, err := strconv.ParseUint(pTTL, 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
+func formatExpiresIn(pTTLMS uint64) string {
+	if pTTLMS == 0 {
+		return ""
+	}
+	return (time.Duration(pTTLMS) * time.Millisecond).Truncate(time.Second).String() // nolint: gosec
+}
@@ -14,10 +14,10 @@ import (
 	"github.com/number571/hidden-lake/internal/applications/messenger/pkg/app/config"
 	hlm_client "github.com/number571/hidden-lake/internal/applications/messenger/pkg/client"
 	hlp_client "github.com/number571/hidden-lake/internal/applications/pinger/pkg/client"
-	hls_client "github.com/number571/hidden-lake/internal/service/pkg/client"
 	"github.com/number571/hidden-lake/internal/utils/chars"
 	http_logger "github.com/number571/hidden-lake/internal/utils/logger/http"
 	"github.com/number571/hidden-lake/internal/webui"
+	hls_client "github.com/number571/hidden-lake/pkg/service/client"
 
 	hlm_settings "github.com/number571/hidden-lake/internal/applications/messenger/pkg/settings"
 )
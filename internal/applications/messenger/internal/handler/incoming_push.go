@@ -14,8 +14,8 @@ import (
 	http_logger "github.com/number571/hidden-lake/internal/utils/logger/http"
 
 	hlm_settings "github.com/number571/hidden-lake/internal/applications/messenger/pkg/settings"
-	hls_client "github.com/number571/hidden-lake/internal/service/pkg/client"
-	hls_settings "github.com/number571/hidden-lake/internal/service/pkg/settings"
+	hls_client "github.com/number571/hidden-lake/pkg/service/client"
+	hls_settings "github.com/number571/hidden-lake/pkg/service/settings"
 )
 
 func HandleIncomingPushHTTP(
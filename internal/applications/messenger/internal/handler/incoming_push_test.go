@@ -8,17 +8,18 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/number571/go-peer/pkg/crypto/asymmetric"
 	"github.com/number571/go-peer/pkg/logger"
 	"github.com/number571/hidden-lake/internal/applications/messenger/internal/database"
 	"github.com/number571/hidden-lake/internal/applications/messenger/internal/msgbroker"
-	hls_client "github.com/number571/hidden-lake/internal/service/pkg/client"
-	hls_config "github.com/number571/hidden-lake/internal/service/pkg/config"
-	hls_settings "github.com/number571/hidden-lake/internal/service/pkg/settings"
 	std_logger "github.com/number571/hidden-lake/internal/utils/logger/std"
 	"github.com/number571/hidden-lake/pkg/request"
 	"github.com/number571/hidden-lake/pkg/response"
+	hls_client "github.com/number571/hidden-lake/pkg/service/client"
+	hls_settings "github.com/number571/hidden-lake/pkg/service/settings"
+	"github.com/number571/hidden-lake/test/utils/hlsclient"
 )
 
 func TestHandleIncomingPushHTTP(t *testing.T) {
@@ -150,6 +151,8 @@ var (
 )
 
 type tsHLSClient struct {
+	hlsclient.SNopClient
+
 	fWithOK       bool
 	fGetPubKey    bool
 	fPrivKey      asymmetric.IPrivKey
@@ -166,12 +169,11 @@ func newTsHLSClient(pGetPubKey, pWithOK bool) *tsHLSClient {
 	}
 }
 
-func (p *tsHLSClient) GetIndex(context.Context) (string, error) { return "", nil }
-func (p *tsHLSClient) GetSettings(context.Context) (hls_config.IConfigSettings, error) {
+func (p *tsHLSClient) GetSettings(context.Context) (hls_settings.IConfigSettings, error) {
 	if !p.fWithOK {
 		return nil, errors.New("some error") // nolint: err113
 	}
-	return &hls_config.SConfigSettings{
+	return &hls_settings.SConfigSettings{
 		FPayloadSizeBytes: 256,
 	}, nil
 }
@@ -186,7 +188,6 @@ func (p *tsHLSClient) GetPubKey(context.Context) (asymmetric.IPubKey, error) {
 func (p *tsHLSClient) GetOnlines(context.Context) ([]string, error) {
 	return []string{"tcp://aaa"}, nil
 }
-func (p *tsHLSClient) DelOnline(context.Context, string) error { return nil }
 
 func (p *tsHLSClient) GetFriends(context.Context) (map[string]asymmetric.IPubKey, error) {
 	return map[string]asymmetric.IPubKey{
@@ -194,18 +195,39 @@ func (p *tsHLSClient) GetFriends(context.Context) (map[string]asymmetric.IPubKey
 	}, nil
 }
 
-func (p *tsHLSClient) AddFriend(context.Context, string, asymmetric.IPubKey) error { return nil }
-func (p *tsHLSClient) DelFriend(context.Context, string) error                     { return nil }
+func (p *tsHLSClient) GetFriendsInfo(context.Context) ([]*hls_settings.SFriend, error) {
+	return []*hls_settings.SFriend{{
+		FAliasName:    "abc",
+		FPublicKey:    p.fFriendPubKey.ToString(),
+		FSafetyNumber: "12345 67890",
+		FNotes:        "notes",
+		FTags:         []string{"tag"},
+		FAdded:        time.Now().Format(time.RFC3339),
+		FExpires:      time.Now().Add(time.Hour).Format(time.RFC3339),
+		FTTLMS:        3_600_000,
+	}}, nil
+}
+
+func (p *tsHLSClient) GetIntroductions(context.Context) ([]*hls_settings.SIntroduction, error) {
+	return []*hls_settings.SIntroduction{{
+		FID:           "id",
+		FIntroducer:   "introducer",
+		FAliasName:    "alias_name",
+		FSafetyNumber: "00000 00000",
+	}}, nil
+}
 
 func (p *tsHLSClient) GetConnections(context.Context) ([]string, error) {
 	return []string{"tcp://aaa"}, nil
 }
+
 func (p *tsHLSClient) AddConnection(context.Context, string) error {
 	if !p.fWithOK {
 		return errors.New("some error") // nolint: err113
 	}
 	return nil
 }
+
 func (p *tsHLSClient) DelConnection(context.Context, string) error {
 	if !p.fWithOK {
 		return errors.New("some error") // nolint: err113
@@ -213,10 +235,6 @@ func (p *tsHLSClient) DelConnection(context.Context, string) error {
 	return nil
 }
 
-func (p *tsHLSClient) SendRequest(context.Context, string, request.IRequest) error {
-	return nil
-}
-
 func (p *tsHLSClient) FetchRequest(context.Context, string, request.IRequest) (response.IResponse, error) {
 	return response.NewResponseBuilder().WithCode(200).Build(), nil
 }
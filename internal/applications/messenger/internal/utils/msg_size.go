@@ -5,7 +5,7 @@ import (
 	"errors"
 
 	hlm_client "github.com/number571/hidden-lake/internal/applications/messenger/pkg/client"
-	"github.com/number571/hidden-lake/internal/service/pkg/client"
+	"github.com/number571/hidden-lake/pkg/service/client"
 )
 
 var (
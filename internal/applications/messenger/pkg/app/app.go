@@ -19,11 +19,12 @@ import (
 
 	pkg_config "github.com/number571/hidden-lake/internal/applications/messenger/pkg/config"
 	hlm_settings "github.com/number571/hidden-lake/internal/applications/messenger/pkg/settings"
-	hls_client "github.com/number571/hidden-lake/internal/service/pkg/client"
 	"github.com/number571/hidden-lake/internal/utils/closer"
 	http_logger "github.com/number571/hidden-lake/internal/utils/logger/http"
 	std_logger "github.com/number571/hidden-lake/internal/utils/logger/std"
+	"github.com/number571/hidden-lake/internal/utils/socket"
 	internal_types "github.com/number571/hidden-lake/internal/utils/types"
+	hls_client "github.com/number571/hidden-lake/pkg/service/client"
 )
 
 var (
@@ -102,6 +103,10 @@ func (p *sApp) enable(pCtx context.Context) state.IStateF {
 			hls_client.NewRequester(
 				p.fConfig.GetConnection(),
 				&http.Client{Timeout: time.Hour},
+				hls_client.NewSettings(&hls_client.SSettings{
+					FToken:    p.fConfig.GetConnectionToken(),
+					FIdentity: p.fConfig.GetConnectionIdentity(),
+				}),
 			),
 		)
 
@@ -135,7 +140,7 @@ func (p *sApp) runInternalListenerHTTP(pCtx context.Context, wg *sync.WaitGroup,
 	defer func() { <-pCtx.Done() }()
 
 	go func() {
-		err := p.fIntServiceHTTP.ListenAndServe()
+		err := socket.ListenAndServe(p.fIntServiceHTTP)
 		if err != nil && !errors.Is(err, http.ErrServerClosed) {
 			pChErr <- err
 			return
@@ -152,7 +157,7 @@ func (p *sApp) runExternalListenerHTTP(pCtx context.Context, wg *sync.WaitGroup,
 	}
 
 	go func() {
-		err := p.fExtServiceHTTP.ListenAndServe()
+		err := socket.ListenAndServe(p.fExtServiceHTTP)
 		if err != nil && !errors.Is(err, http.ErrServerClosed) {
 			pChErr <- err
 			return
@@ -23,15 +23,19 @@ logging:
 address:
   internal: '%s'
   external: '%s'
-connection: '%s'`
+connection: '%s'
+connection_token: '%s'
+connection_identity: '%s'`
 )
 
 const (
-	tcAddressInterface  = "address_interface"
-	tcAddressIncoming   = "address_incoming"
-	tcConnectionService = "connection_service"
-	tcMessageSize       = (1 << 20)
-	tcMessagesCapacity  = 1000
+	tcAddressInterface   = "address_interface"
+	tcAddressIncoming    = "address_incoming"
+	tcConnectionService  = "connection_service"
+	tcConnectionToken    = "connection_token"
+	tcConnectionIdentity = "connection_identity"
+	tcMessageSize        = (1 << 20)
+	tcMessagesCapacity   = 1000
 )
 
 func TestError(t *testing.T) {
@@ -52,6 +56,8 @@ func testNewConfigString() string {
 		tcAddressInterface,
 		tcAddressIncoming,
 		tcConnectionService,
+		tcConnectionToken,
+		tcConnectionIdentity,
 	)
 }
 
@@ -111,4 +117,14 @@ func TestConfig(t *testing.T) {
 		return
 	}
 
+	if cfg.GetConnectionToken() != tcConnectionToken {
+		t.Error("connection_token is invalid")
+		return
+	}
+
+	if cfg.GetConnectionIdentity() != tcConnectionIdentity {
+		t.Error("connection_identity is invalid")
+		return
+	}
+
 }
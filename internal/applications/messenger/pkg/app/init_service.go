@@ -11,8 +11,8 @@ import (
 	"github.com/number571/hidden-lake/internal/applications/messenger/internal/msgbroker"
 	"github.com/number571/hidden-lake/internal/applications/messenger/pkg/app/config"
 	hlm_settings "github.com/number571/hidden-lake/internal/applications/messenger/pkg/settings"
-	hls_client "github.com/number571/hidden-lake/internal/service/pkg/client"
 	"github.com/number571/hidden-lake/internal/webui"
+	hls_client "github.com/number571/hidden-lake/pkg/service/client"
 	"golang.org/x/net/websocket"
 )
 
@@ -4,8 +4,8 @@ import (
 	"context"
 	"errors"
 
-	hls_client "github.com/number571/hidden-lake/internal/service/pkg/client"
 	hls_request "github.com/number571/hidden-lake/pkg/request"
+	hls_client "github.com/number571/hidden-lake/pkg/service/client"
 )
 
 var (
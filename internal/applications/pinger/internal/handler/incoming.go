@@ -6,9 +6,9 @@ import (
 	"github.com/number571/go-peer/pkg/logger"
 	"github.com/number571/hidden-lake/internal/applications/pinger/pkg/app/config"
 	hlr_settings "github.com/number571/hidden-lake/internal/applications/pinger/pkg/settings"
-	hls_settings "github.com/number571/hidden-lake/internal/service/pkg/settings"
 	"github.com/number571/hidden-lake/internal/utils/api"
 	http_logger "github.com/number571/hidden-lake/internal/utils/logger/http"
+	hls_settings "github.com/number571/hidden-lake/pkg/service/settings"
 )
 
 func HandleIncomingPingHTTP(pConfig config.IConfig, pLogger logger.ILogger) http.HandlerFunc {
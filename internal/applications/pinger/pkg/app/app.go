@@ -18,6 +18,7 @@ import (
 	"github.com/number571/hidden-lake/internal/utils/closer"
 	http_logger "github.com/number571/hidden-lake/internal/utils/logger/http"
 	std_logger "github.com/number571/hidden-lake/internal/utils/logger/std"
+	"github.com/number571/hidden-lake/internal/utils/socket"
 	internal_types "github.com/number571/hidden-lake/internal/utils/types"
 )
 
@@ -114,7 +115,7 @@ func (p *sApp) runExternalListenerHTTP(pCtx context.Context, wg *sync.WaitGroup,
 	defer func() { <-pCtx.Done() }()
 
 	go func() {
-		err := p.fExtServiceHTTP.ListenAndServe()
+		err := socket.ListenAndServe(p.fExtServiceHTTP)
 		if err != nil && !errors.Is(err, http.ErrServerClosed) {
 			pChErr <- err
 			return
@@ -10,10 +10,10 @@ import (
 	"github.com/number571/go-peer/pkg/logger"
 	"github.com/number571/hidden-lake/internal/applications/remoter/pkg/app/config"
 	hlr_settings "github.com/number571/hidden-lake/internal/applications/remoter/pkg/settings"
-	hls_settings "github.com/number571/hidden-lake/internal/service/pkg/settings"
 	"github.com/number571/hidden-lake/internal/utils/api"
 	"github.com/number571/hidden-lake/internal/utils/chars"
 	http_logger "github.com/number571/hidden-lake/internal/utils/logger/http"
+	hls_settings "github.com/number571/hidden-lake/pkg/service/settings"
 )
 
 func HandleIncomingExecHTTP(pCtx context.Context, pConfig config.IConfig, pLogger logger.ILogger) http.HandlerFunc {
@@ -5,8 +5,8 @@ import (
 	"errors"
 	"net/http"
 
-	hls_client "github.com/number571/hidden-lake/internal/service/pkg/client"
 	hls_request "github.com/number571/hidden-lake/pkg/request"
+	hls_client "github.com/number571/hidden-lake/pkg/service/client"
 )
 
 var (
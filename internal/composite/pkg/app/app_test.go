@@ -32,6 +32,13 @@ var (
 		flag.NewFlagBuilder("-t", "--threads").
 			WithDescription("set num of parallel functions to calculate PoW").
 			WithDefinedValue("1"),
+		flag.NewFlagBuilder("-f", "--passphrase-fd").
+			WithDescription("read passphrase of private key from file descriptor").
+			WithDefinedValue(""),
+		flag.NewFlagBuilder("-k", "--key-passwd").
+			WithDescription("set or change passphrase of private key and exit"),
+		flag.NewFlagBuilder("-r", "--key-required").
+			WithDescription("fail if private key is not found instead of generating new"),
 	).Build()
 )
 
@@ -13,6 +13,8 @@ import (
 	logger "github.com/number571/hidden-lake/internal/utils/logger/std"
 
 	hla_tcp_settings "github.com/number571/hidden-lake/internal/adapters/tcp/pkg/settings"
+	hla_udp_settings "github.com/number571/hidden-lake/internal/adapters/udp/pkg/settings"
+	hla_ws_settings "github.com/number571/hidden-lake/internal/adapters/ws/pkg/settings"
 )
 
 func InitConfig(cfgPath string, initCfg *SConfig, useNetwork string) (IConfig, error) {
@@ -68,9 +70,13 @@ func rebuildConfig(pCfg IConfig, pUseNetwork string) (IConfig, error) {
 			continue
 		}
 		mapAdapters[scheme] = struct{}{}
-		switch scheme { // nolint: gocritic
+		switch scheme {
 		case hla_tcp_settings.CServiceAdapterScheme:
 			cfg.FServices = append(cfg.FServices, hla_tcp_settings.CServiceFullName)
+		case hla_ws_settings.CServiceAdapterScheme:
+			cfg.FServices = append(cfg.FServices, hla_ws_settings.CServiceFullName)
+		case hla_udp_settings.CServiceAdapterScheme:
+			cfg.FServices = append(cfg.FServices, hla_udp_settings.CServiceFullName)
 		}
 	}
 
@@ -12,6 +12,10 @@ import (
 
 	hla_tcp_app "github.com/number571/hidden-lake/internal/adapters/tcp/pkg/app"
 	hla_tcp_settings "github.com/number571/hidden-lake/internal/adapters/tcp/pkg/settings"
+	hla_udp_app "github.com/number571/hidden-lake/internal/adapters/udp/pkg/app"
+	hla_udp_settings "github.com/number571/hidden-lake/internal/adapters/udp/pkg/settings"
+	hla_ws_app "github.com/number571/hidden-lake/internal/adapters/ws/pkg/app"
+	hla_ws_settings "github.com/number571/hidden-lake/internal/adapters/ws/pkg/settings"
 
 	hlm_app "github.com/number571/hidden-lake/internal/applications/messenger/pkg/app"
 	hlm_settings "github.com/number571/hidden-lake/internal/applications/messenger/pkg/settings"
@@ -77,6 +81,10 @@ func getRunners(pCfg config.IConfig, pArgs []string, pFlags flag.IFlags) ([]type
 			runner, err = hlp_app.InitApp(pArgs, pFlags)
 		case hla_tcp_settings.CServiceFullName:
 			runner, err = hla_tcp_app.InitApp(pArgs, pFlags)
+		case hla_ws_settings.CServiceFullName:
+			runner, err = hla_ws_app.InitApp(pArgs, pFlags)
+		case hla_udp_settings.CServiceFullName:
+			runner, err = hla_udp_app.InitApp(pArgs, pFlags)
 		default:
 			return nil, ErrUnknownService
 		}
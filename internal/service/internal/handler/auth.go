@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/number571/go-peer/pkg/logger"
+	"github.com/number571/hidden-lake/internal/service/pkg/app/config"
+	pkg_settings "github.com/number571/hidden-lake/internal/service/pkg/settings"
+	"github.com/number571/hidden-lake/internal/utils/api"
+	http_logger "github.com/number571/hidden-lake/internal/utils/logger/http"
+)
+
+const (
+	cBearerPrefix = "Bearer "
+)
+
+// HandleAuth checks the bearer token of each request to the API.
+// If the tokens are not set in the config, then the API is not restricted.
+func HandleAuth(
+	pWrapper config.IWrapper,
+	pLogger logger.ILogger,
+	pHandler http.Handler,
+) http.HandlerFunc {
+	return func(pW http.ResponseWriter, pR *http.Request) {
+		tokens := pWrapper.GetConfig().GetTokens()
+		if len(tokens) == 0 {
+			pHandler.ServeHTTP(pW, pR)
+			return
+		}
+
+		logBuilder := http_logger.NewLogBuilder(pkg_settings.GServiceName.Short(), pR)
+
+		token := getToken(tokens, pR.Header.Get("Authorization"))
+		if token == nil {
+			pLogger.PushWarn(logBuilder.WithMessage("auth_token"))
+			pW.Header().Set("WWW-Authenticate", "Bearer")
+			_ = api.Response(pW, http.StatusUnauthorized, "failed: unauthorized")
+			return
+		}
+
+		if !token.IsAllowed(pR.Method, pR.URL.Path) {
+			pLogger.PushWarn(logBuilder.WithMessage("auth_scope"))
+			_ = api.Response(pW, http.StatusForbidden, "failed: forbidden")
+			return
+		}
+
+		pHandler.ServeHTTP(pW, pR)
+	}
+}
+
+func getToken(pTokens map[string]config.IToken, pAuthorization string) config.IToken {
+	if !strings.HasPrefix(pAuthorization, cBearerPrefix) {
+		return nil
+	}
+
+	// hashes have the same size, so the comparison does not leak the length
+	hash := sha256.Sum256([]byte(strings.TrimPrefix(pAuthorization, cBearerPrefix)))
+
+	var result config.IToken
+	for _, token := range pTokens {
+		tokenHash := sha256.Sum256([]byte(token.GetToken()))
+		if subtle.ConstantTimeCompare(hash[:], tokenHash[:]) == 1 {
+			result = token
+		}
+	}
+	return result
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/number571/go-peer/pkg/logger"
+	"github.com/number571/hidden-lake/internal/service/pkg/app/config"
+	pkg_settings "github.com/number571/hidden-lake/internal/service/pkg/settings"
+)
+
+func TestHandleAuth(t *testing.T) {
+	t.Parallel()
+
+	logger := logger.NewLogger(
+		logger.NewSettings(&logger.SSettings{}),
+		func(_ logger.ILogArg) string { return "" },
+	)
+
+	okHandler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	handlerNoTokens := HandleAuth(&tsWrapper{fConfig: &tsConfig{}}, logger, okHandler)
+	if err := authRequest(handlerNoTokens, http.MethodPost, pkg_settings.CHandleConfigFriendsPath, ""); err != nil {
+		t.Error(err)
+		return
+	}
+
+	wcfg := &tsWrapper{fConfig: &tsConfig{fTokens: map[string]config.IToken{
+		"admin":   &config.SToken{FToken: "admin-token"},
+		"reader":  &config.SToken{FToken: "reader-token", FScopes: []string{pkg_settings.CTokenScopeRead}},
+		"network": &config.SToken{FToken: "network-token", FScopes: []string{pkg_settings.CTokenScopeNetwork}},
+	}}}
+	handler := HandleAuth(wcfg, logger, okHandler)
+
+	testCases := []struct {
+		fMethod string
+		fPath   string
+		fToken  string
+		fOK     bool
+	}{
+		{http.MethodGet, pkg_settings.CHandleIndexPath, "", false},
+		{http.MethodGet, pkg_settings.CHandleIndexPath, "unknown-token", false},
+		{http.MethodPost, pkg_settings.CHandleConfigFriendsPath, "admin-token", true},
+		{http.MethodGet, pkg_settings.CHandleConfigFriendsPath, "reader-token", true},
+		{http.MethodPost, pkg_settings.CHandleConfigFriendsPath, "reader-token", false},
+		{http.MethodGet, pkg_settings.CHandleServiceStreamPath, "reader-token", false},
+		{http.MethodPost, pkg_settings.CHandleNetworkRequestPath, "network-token", true},
+		{http.MethodGet, pkg_settings.CHandleNetworkTicketPath, "network-token", true},
+		{http.MethodGet, pkg_settings.CHandleIndexPath, "network-token", true},
+		{http.MethodGet, pkg_settings.CHandleConfigFriendsPath, "network-token", false},
+	}
+
+	for i, tc := range testCases {
+		err := authRequest(handler, tc.fMethod, tc.fPath, tc.fToken)
+		if (err == nil) != tc.fOK {
+			t.Errorf("got invalid result (%d)", i)
+			return
+		}
+	}
+}
+
+func authRequest(handler http.HandlerFunc, method, path, token string) error {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, path, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	handler(w, req)
+	res := w.Result()
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return errors.New("bad status code") // nolint: err113
+	}
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/number571/go-peer/pkg/logger"
+	"github.com/number571/hidden-lake/internal/service/pkg/app/config"
+	pkg_settings "github.com/number571/hidden-lake/internal/service/pkg/settings"
+	"github.com/number571/hidden-lake/internal/utils/api"
+	http_logger "github.com/number571/hidden-lake/internal/utils/logger/http"
+)
+
+func HandleConfigAccessAPI(
+	pWrapper config.IWrapper,
+	pLogger logger.ILogger,
+) http.HandlerFunc {
+	return func(pW http.ResponseWriter, pR *http.Request) {
+		logBuilder := http_logger.NewLogBuilder(pkg_settings.GServiceName.Short(), pR)
+
+		var vAccess pkg_settings.SAccess
+
+		if pR.Method != http.MethodGet && pR.Method != http.MethodPost && pR.Method != http.MethodDelete {
+			pLogger.PushWarn(logBuilder.WithMessage(http_logger.CLogMethod))
+			_ = api.Response(pW, http.StatusMethodNotAllowed, "failed: incorrect method")
+			return
+		}
+
+		if pR.Method == http.MethodGet {
+			access := pWrapper.GetConfig().GetAccess()
+
+			listAccess := make([]pkg_settings.SAccess, 0, len(access))
+			for hostName, v := range access {
+				listAccess = append(listAccess, pkg_settings.SAccess{
+					FHostName: hostName,
+					FAllow:    v.GetAllow(),
+					FDeny:     v.GetDeny(),
+				})
+			}
+
+			pLogger.PushInfo(logBuilder.WithMessage(http_logger.CLogSuccess))
+			_ = api.Response(pW, http.StatusOK, listAccess)
+			return
+		}
+
+		if err := json.NewDecoder(pR.Body).Decode(&vAccess); err != nil {
+			pLogger.PushWarn(logBuilder.WithMessage(http_logger.CLogDecodeBody))
+			_ = api.Response(pW, http.StatusConflict, "failed: decode request")
+			return
+		}
+
+		hostName := strings.TrimSpace(vAccess.FHostName)
+		if hostName == "" {
+			pLogger.PushWarn(logBuilder.WithMessage("get_host_name"))
+			_ = api.Response(pW, http.StatusTeapot, "failed: load host name")
+			return
+		}
+
+		cfg := pWrapper.GetConfig()
+		access := cfg.GetAccess()
+
+		switch pR.Method {
+		case http.MethodPost:
+			// built-in services are not defined in the config
+			_, ok := cfg.GetService(hostName)
+			if !ok && hostName != pkg_settings.CIntroductionHostName {
+				pLogger.PushWarn(logBuilder.WithMessage("get_services"))
+				_ = api.Response(pW, http.StatusNotFound, "failed: service does not exist")
+				return
+			}
+
+			access[hostName] = &config.SAccess{
+				FAllow: vAccess.FAllow,
+				FDeny:  vAccess.FDeny,
+			}
+			if err := pWrapper.GetEditor().UpdateAccess(access); err != nil {
+				pLogger.PushWarn(logBuilder.WithMessage("update_access"))
+				_ = api.Response(pW, http.StatusInternalServerError, "failed: update access")
+				return
+			}
+
+			pLogger.PushInfo(logBuilder.WithMessage(http_logger.CLogSuccess))
+			_ = api.Response(pW, http.StatusOK, "success: update access")
+			return
+
+		case http.MethodDelete:
+			if _, ok := access[hostName]; !ok {
+				pLogger.PushWarn(logBuilder.WithMessage("get_access"))
+				_ = api.Response(pW, http.StatusNotFound, "failed: access does not exist")
+				return
+			}
+
+			delete(access, hostName)
+
+			if err := pWrapper.GetEditor().UpdateAccess(access); err != nil {
+				pLogger.PushWarn(logBuilder.WithMessage("update_access"))
+				_ = api.Response(pW, http.StatusInternalServerError, "failed: delete access")
+				return
+			}
+
+			pLogger.PushInfo(logBuilder.WithMessage(http_logger.CLogSuccess))
+			_ = api.Response(pW, http.StatusOK, "success: delete access")
+			return
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/number571/go-peer/pkg/logger"
+	"github.com/number571/hidden-lake/internal/service/pkg/settings"
+	std_logger "github.com/number571/hidden-lake/internal/utils/logger/std"
+	hls_client "github.com/number571/hidden-lake/pkg/service/client"
+	testutils "github.com/number571/hidden-lake/test/utils"
+)
+
+func TestHandleAccessAPI2(t *testing.T) {
+	t.Parallel()
+
+	httpLogger := std_logger.NewStdLogger(
+		func() std_logger.ILogging {
+			logging, err := std_logger.LoadLogging([]string{})
+			if err != nil {
+				panic(err)
+			}
+			return logging
+		}(),
+		func(_ logger.ILogArg) string {
+			return ""
+		},
+	)
+
+	handler := HandleConfigAccessAPI(newTsWrapper(true), httpLogger)
+	if err := configAPIRequest(handler, http.MethodGet, nil); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := configAPIRequest(handler, http.MethodPost, &settings.SAccess{
+		FHostName: "hidden-some-host-ok",
+		FDeny:     []string{"abc"},
+	}); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := configAPIRequest(handler, http.MethodDelete, &settings.SAccess{
+		FHostName: "hidden-some-host-denied",
+	}); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := configAPIRequest(handler, http.MethodPost, &settings.SAccess{
+		FHostName: settings.CIntroductionHostName,
+		FAllow:    []string{"abc"},
+	}); err != nil {
+		t.Error(err)
+		return
+	}
+
+	if err := configAPIRequest(handler, http.MethodPost, &settings.SAccess{
+		FHostName: "notfound",
+		FAllow:    []string{"abc"},
+	}); err == nil {
+		t.Error("request success with not found service")
+		return
+	}
+	if err := configAPIRequest(handler, http.MethodDelete, &settings.SAccess{
+		FHostName: "hidden-some-host-failed",
+	}); err == nil {
+		t.Error("request success with not found access")
+		return
+	}
+	if err := configAPIRequest(handler, http.MethodPost, &settings.SAccess{
+		FAllow: []string{"abc"},
+	}); err == nil {
+		t.Error("request success with invalid host_name")
+		return
+	}
+	if err := configAPIRequest(handler, http.MethodPost, []byte{1}); err == nil {
+		t.Error("request success with invalid decode")
+		return
+	}
+	if err := configAPIRequest(handler, http.MethodPut, nil); err == nil {
+		t.Error("request success with invalid method")
+		return
+	}
+
+	handlerx := HandleConfigAccessAPI(newTsWrapper(false), httpLogger)
+	if err := configAPIRequest(handlerx, http.MethodPost, &settings.SAccess{
+		FHostName: "hidden-some-host-ok",
+	}); err == nil {
+		t.Error("request success with invalid update editor (post)")
+		return
+	}
+	if err := configAPIRequest(handlerx, http.MethodDelete, &settings.SAccess{
+		FHostName: "hidden-some-host-ok",
+	}); err == nil {
+		t.Error("request success with invalid update editor (delete)")
+		return
+	}
+}
+
+func TestHandleAccessAPI(t *testing.T) {
+	t.Parallel()
+
+	pathCfg := fmt.Sprintf(tcPathConfigTemplate, 11)
+	pathDB := fmt.Sprintf(tcPathDBTemplate, 11)
+
+	_, node, _, cancel, srv := testAllCreate(pathCfg, pathDB, testutils.TgAddrs[22])
+	defer testAllFree(node, cancel, srv, pathCfg, pathDB)
+
+	client := hls_client.NewClient(
+		hls_client.NewBuilder(),
+		hls_client.NewRequester(
+			testutils.TgAddrs[22],
+			&http.Client{Timeout: time.Minute},
+			hls_client.NewSettings(nil),
+		),
+	)
+
+	ctx := context.Background()
+	if err := client.SetAccess(ctx, "test_service1", []string{"test_name1"}, nil); err != nil {
+		t.Error(err)
+		return
+	}
+
+	access, err := client.GetAccess(ctx)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	v, ok := access["test_service1"]
+	if !ok || len(v.FAllow) != 1 || v.FAllow[0] != "test_name1" {
+		t.Error("undefined new access for 'test_service1'")
+		return
+	}
+
+	if err := client.SetAccess(ctx, "undefined_service", nil, nil); err == nil {
+		t.Error("success set access for undefined service")
+		return
+	}
+
+	if err := client.DelAccess(ctx, "test_service1"); err != nil {
+		t.Error(err)
+		return
+	}
+
+	access, err = client.GetAccess(ctx)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if _, ok := access["test_service1"]; ok {
+		t.Error("deleted access exists for 'test_service1'")
+		return
+	}
+}
@@ -16,7 +16,7 @@ import (
 func HandleConfigConnectsAPI(
 	pCtx context.Context,
 	pLogger logger.ILogger,
-	pEPClients []client.IClient,
+	pEPClients func() []client.IClient,
 ) http.HandlerFunc {
 	return func(pW http.ResponseWriter, pR *http.Request) {
 		logBuilder := http_logger.NewLogBuilder(pkg_settings.GServiceName.Short(), pR)
@@ -29,7 +29,7 @@ func HandleConfigConnectsAPI(
 
 		if pR.Method == http.MethodGet {
 			connects := make([]string, 0, 256)
-			for _, client := range pEPClients {
+			for _, client := range pEPClients() {
 				gotConns, err := client.GetConnections(pCtx)
 				if err != nil {
 					pLogger.PushWarn(logBuilder.WithMessage("get_connections"))
@@ -59,7 +59,7 @@ func HandleConfigConnectsAPI(
 
 		switch pR.Method {
 		case http.MethodPost:
-			for _, client := range pEPClients {
+			for _, client := range pEPClients() {
 				if err := client.AddConnection(pCtx, connect); err != nil {
 					pLogger.PushWarn(logBuilder.WithMessage("add_connections"))
 					_ = api.Response(pW, http.StatusInternalServerError, "failed: add connections")
@@ -72,7 +72,7 @@ func HandleConfigConnectsAPI(
 			return
 
 		case http.MethodDelete:
-			for _, client := range pEPClients {
+			for _, client := range pEPClients() {
 				if err := client.DelConnection(pCtx, connect); err != nil {
 					pLogger.PushWarn(logBuilder.WithMessage("del_connection"))
 					_ = api.Response(pW, http.StatusInternalServerError, "failed: del connection")
@@ -13,10 +13,10 @@ import (
 
 	"github.com/number571/go-peer/pkg/logger"
 	"github.com/number571/go-peer/pkg/message/layer1"
-	hls_client "github.com/number571/hidden-lake/internal/service/pkg/client"
-	"github.com/number571/hidden-lake/internal/service/pkg/config"
 	std_logger "github.com/number571/hidden-lake/internal/utils/logger/std"
 	"github.com/number571/hidden-lake/pkg/adapters/http/client"
+	hls_client "github.com/number571/hidden-lake/pkg/service/client"
+	hls_settings "github.com/number571/hidden-lake/pkg/service/settings"
 	testutils "github.com/number571/hidden-lake/test/utils"
 )
 
@@ -46,7 +46,7 @@ func TestHandleConnectsAPI2(t *testing.T) {
 		client.NewClient(&tsRequester{}),
 	}
 
-	handler := HandleConfigConnectsAPI(ctx, httpLogger, epClients)
+	handler := HandleConfigConnectsAPI(ctx, httpLogger, func() []client.IClient { return epClients })
 	if err := connectsAPIRequestOK(handler); err != nil {
 		t.Error(err)
 		return
@@ -73,7 +73,7 @@ func TestHandleConnectsAPI2(t *testing.T) {
 		client.NewClient(&tsRequester{fWithFail: true}),
 	}
 
-	handlerx := HandleConfigConnectsAPI(ctx, httpLogger, epClientsx)
+	handlerx := HandleConfigConnectsAPI(ctx, httpLogger, func() []client.IClient { return epClientsx })
 	if err := connectsAPIRequestOK(handlerx); err == nil {
 		t.Error("request success with invalid get connections")
 		return
@@ -197,6 +197,7 @@ func TestHandleConnectsAPI(t *testing.T) {
 		hls_client.NewRequester(
 			testutils.TgAddrs[6],
 			&http.Client{Timeout: time.Minute},
+			hls_client.NewSettings(nil),
 		),
 	)
 
@@ -240,8 +241,10 @@ type tsRequester struct {
 	fWithFail bool
 }
 
-func (p *tsRequester) GetIndex(context.Context) (string, error)                    { return "", nil }
-func (p *tsRequester) GetSettings(context.Context) (config.IConfigSettings, error) { return nil, nil }
+func (p *tsRequester) GetIndex(context.Context) (string, error) { return "", nil }
+func (p *tsRequester) GetSettings(context.Context) (hls_settings.IConfigSettings, error) {
+	return nil, nil
+}
 func (p *tsRequester) GetOnlines(context.Context) ([]string, error) {
 	if p.fWithFail {
 		return nil, errors.New("some error") // nolint: err113
@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/number571/go-peer/pkg/anonymity"
+	"github.com/number571/go-peer/pkg/logger"
+	"github.com/number571/hidden-lake/internal/service/internal/metadata"
+	"github.com/number571/hidden-lake/internal/service/pkg/app/config"
+	pkg_settings "github.com/number571/hidden-lake/internal/service/pkg/settings"
+	"github.com/number571/hidden-lake/internal/utils/api"
+	"github.com/number571/hidden-lake/internal/utils/contact"
+	http_logger "github.com/number571/hidden-lake/internal/utils/logger/http"
+	"github.com/number571/hidden-lake/internal/utils/safety"
+)
+
+func HandleConfigContactAPI(
+	pWrapper config.IWrapper,
+	pLogger logger.ILogger,
+	pNode anonymity.INode,
+	pMetadata metadata.IMetadata,
+) http.HandlerFunc {
+	privKey := pNode.GetQBProcessor().GetClient().GetPrivKey()
+	networkKey := pWrapper.GetConfig().GetSettings().GetNetworkKey()
+
+	// the contact is built once without alias and with the connections
+	// of the network, so the read scope of token can only export it
+	defaultContact, errContact := contact.NewContact(privKey, "", networkKey, nil)
+
+	return func(pW http.ResponseWriter, pR *http.Request) {
+		logBuilder := http_logger.NewLogBuilder(pkg_settings.GServiceName.Short(), pR)
+
+		var vContact pkg_settings.SContactImport
+
+		if pR.Method != http.MethodGet && pR.Method != http.MethodPost {
+			pLogger.PushWarn(logBuilder.WithMessage(http_logger.CLogMethod))
+			_ = api.Response(pW, http.StatusMethodNotAllowed, "failed: incorrect method")
+			return
+		}
+
+		if pR.Method == http.MethodGet {
+			if len(pR.URL.Query()) != 0 {
+				pLogger.PushWarn(logBuilder.WithMessage("get_params"))
+				_ = api.Response(pW, http.StatusBadRequest, "failed: use POST "+pkg_settings.CHandleConfigContactNewPath)
+				return
+			}
+			if errContact != nil {
+				pLogger.PushWarn(logBuilder.WithMessage("new_contact"))
+				_ = api.Response(pW, http.StatusInternalServerError, "failed: create contact")
+				return
+			}
+
+			pLogger.PushInfo(logBuilder.WithMessage(http_logger.CLogSuccess))
+			_ = api.Response(pW, http.StatusOK, pkg_settings.SContactExport{
+				FURI:     contact.ToURI(defaultContact),
+				FContact: defaultContact,
+			})
+			return
+		}
+
+		if err := json.NewDecoder(pR.Body).Decode(&vContact); err != nil {
+			pLogger.PushWarn(logBuilder.WithMessage(http_logger.CLogDecodeBody))
+			_ = api.Response(pW, http.StatusConflict, "failed: decode request")
+			return
+		}
+
+		loadedContact, pubKey, err := contact.LoadContact(vContact.FContact)
+		if err != nil {
+			pLogger.PushWarn(logBuilder.WithMessage("load_contact"))
+			_ = api.Response(pW, http.StatusBadRequest, "failed: load contact")
+			return
+		}
+
+		if loadedContact.FNetworkKey != "" && loadedContact.FNetworkKey != networkKey {
+			pLogger.PushWarn(logBuilder.WithMessage("network_key"))
+			_ = api.Response(pW, http.StatusPreconditionFailed, "failed: network key mismatch")
+			return
+		}
+
+		aliasName := contact.GetAliasName(loadedContact, vContact.FAliasName)
+		friends := pWrapper.GetConfig().GetFriends()
+
+		if _, ok := friends[aliasName]; ok {
+			pLogger.PushWarn(logBuilder.WithMessage("get_friends"))
+			_ = api.Response(pW, http.StatusNotAcceptable, "failed: friend already exist")
+			return
+		}
+		for _, friendPubKey := range friends {
+			if friendPubKey.ToString() == pubKey.ToString() {
+				pLogger.PushWarn(logBuilder.WithMessage("get_friends"))
+				_ = api.Response(pW, http.StatusNotAcceptable, "failed: public key already exist")
+				return
+			}
+		}
+
+		friends[aliasName] = pubKey
+		if err := pWrapper.GetEditor().UpdateFriends(friends); err != nil {
+			pLogger.PushWarn(logBuilder.WithMessage("update_friends"))
+			_ = api.Response(pW, http.StatusInternalServerError, "failed: update friends")
+			return
+		}
+
+		pNode.GetMapPubKeys().SetPubKey(pubKey)
+		if err := pMetadata.SetAdded(pubKey); err != nil {
+			pLogger.PushWarn(logBuilder.WithMessage("set_metadata"))
+		}
+
+		pLogger.PushInfo(logBuilder.WithMessage(http_logger.CLogSuccess))
+		_ = api.Response(pW, http.StatusOK, pkg_settings.SFriend{
+			FAliasName:    aliasName,
+			FPublicKey:    pubKey.ToString(),
+			FSafetyNumber: safety.GetSafetyNumber(privKey.GetPubKey(), pubKey),
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/number571/go-peer/pkg/anonymity"
+	"github.com/number571/go-peer/pkg/logger"
+	"github.com/number571/hidden-lake/internal/service/pkg/app/config"
+	pkg_settings "github.com/number571/hidden-lake/internal/service/pkg/settings"
+	"github.com/number571/hidden-lake/internal/utils/api"
+	"github.com/number571/hidden-lake/internal/utils/contact"
+	http_logger "github.com/number571/hidden-lake/internal/utils/logger/http"
+)
+
+func HandleConfigContactNewAPI(
+	pWrapper config.IWrapper,
+	pLogger logger.ILogger,
+	pNode anonymity.INode,
+) http.HandlerFunc {
+	return func(pW http.ResponseWriter, pR *http.Request) {
+		logBuilder := http_logger.NewLogBuilder(pkg_settings.GServiceName.Short(), pR)
+
+		var vNew pkg_settings.SContactNew
+
+		if pR.Method != http.MethodPost {
+			pLogger.PushWarn(logBuilder.WithMessage(http_logger.CLogMethod))
+			_ = api.Response(pW, http.StatusMethodNotAllowed, "failed: incorrect method")
+			return
+		}
+
+		if err := json.NewDecoder(pR.Body).Decode(&vNew); err != nil {
+			pLogger.PushWarn(logBuilder.WithMessage(http_logger.CLogDecodeBody))
+			_ = api.Response(pW, http.StatusConflict, "failed: decode request")
+			return
+		}
+
+		// connections of the network are used if they are not defined
+		newContact, err := contact.NewContact(
+			pNode.GetQBProcessor().GetClient().GetPrivKey(),
+			vNew.FAliasName,
+			pWrapper.GetConfig().GetSettings().GetNetworkKey(),
+			vNew.FConnections,
+		)
+		if err != nil {
+			pLogger.PushWarn(logBuilder.WithMessage("new_contact"))
+			_ = api.Response(pW, http.StatusBadRequest, "failed: create contact")
+			return
+		}
+
+		pLogger.PushInfo(logBuilder.WithMessage(http_logger.CLogSuccess))
+		_ = api.Response(pW, http.StatusOK, pkg_settings.SContactExport{
+			FURI:     contact.ToURI(newContact),
+			FContact: newContact,
+		})
+	}
+}
@@ -0,0 +1,334 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/number571/go-peer/pkg/crypto/asymmetric"
+	"github.com/number571/go-peer/pkg/encoding"
+	"github.com/number571/go-peer/pkg/logger"
+	"github.com/number571/hidden-lake/internal/service/pkg/settings"
+	"github.com/number571/hidden-lake/internal/utils/contact"
+	std_logger "github.com/number571/hidden-lake/internal/utils/logger/std"
+	hls_client "github.com/number571/hidden-lake/pkg/service/client"
+	testutils "github.com/number571/hidden-lake/test/utils"
+)
+
+func TestHandleContactAPI2(t *testing.T) {
+	t.Parallel()
+
+	httpLogger := std_logger.NewStdLogger(
+		func() std_logger.ILogging {
+			logging, err := std_logger.LoadLogging([]string{})
+			if err != nil {
+				panic(err)
+			}
+			return logging
+		}(),
+		func(_ logger.ILogArg) string {
+			return ""
+		},
+	)
+
+	handler := HandleConfigContactAPI(newTsWrapper(true), httpLogger, newTsNode(true, true, true), newTsMetadata(true))
+	if err := contactAPIRequestExportOK(handler); err != nil {
+		t.Error(err)
+		return
+	}
+
+	newContact, err := contact.NewContact(asymmetric.NewPrivKey(), "new_friend", "", nil)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if err := contactAPIRequestImportOK(handler, contact.ToURI(newContact)); err != nil {
+		t.Error(err)
+		return
+	}
+
+	if code := contactAPIRequest(handler, http.MethodGet, "/?alias_name=Alice", nil); code != http.StatusBadRequest {
+		t.Error("request success with params of new contact")
+		return
+	}
+	if code := contactAPIRequest(handler, http.MethodDelete, "/", nil); code != http.StatusMethodNotAllowed {
+		t.Error("request success with invalid method")
+		return
+	}
+	if code := contactAPIRequest(handler, http.MethodPost, "/", []byte("abc")); code != http.StatusConflict {
+		t.Error("request success with invalid decode")
+		return
+	}
+
+	modified := *newContact
+	modified.FAliasName = "modified"
+	if code := contactAPIImport(handler, contact.ToURI(&modified), ""); code != http.StatusBadRequest {
+		t.Error("request success with invalid signature")
+		return
+	}
+
+	otherContact, err := contact.NewContact(asymmetric.NewPrivKey(), "", "other_network", nil)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if code := contactAPIImport(handler, contact.ToURI(otherContact), ""); code != http.StatusPreconditionFailed {
+		t.Error("request success with other network key")
+		return
+	}
+
+	if code := contactAPIImport(handler, contact.ToURI(newContact), "abc"); code != http.StatusNotAcceptable {
+		t.Error("request success with exist alias_name")
+		return
+	}
+
+	existContact, err := contact.NewContact(tgPrivKey2, "", "", nil)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if code := contactAPIImport(handler, contact.ToURI(existContact), "xyz"); code != http.StatusNotAcceptable {
+		t.Error("request success with exist public key")
+		return
+	}
+
+	handlerx := HandleConfigContactAPI(newTsWrapper(false), httpLogger, newTsNode(true, true, true), newTsMetadata(true))
+	if err := contactAPIRequestImportOK(handlerx, contact.ToURI(newContact)); err == nil {
+		t.Error("request success with invalid update editor")
+		return
+	}
+}
+
+func TestHandleContactNewAPI2(t *testing.T) {
+	t.Parallel()
+
+	httpLogger := std_logger.NewStdLogger(
+		func() std_logger.ILogging {
+			logging, err := std_logger.LoadLogging([]string{})
+			if err != nil {
+				panic(err)
+			}
+			return logging
+		}(),
+		func(_ logger.ILogArg) string {
+			return ""
+		},
+	)
+
+	handler := HandleConfigContactNewAPI(newTsWrapper(true), httpLogger, newTsNode(true, true, true))
+	if err := contactNewAPIRequestOK(handler); err != nil {
+		t.Error(err)
+		return
+	}
+
+	if code := contactAPIRequest(handler, http.MethodGet, "/", nil); code != http.StatusMethodNotAllowed {
+		t.Error("request success with invalid method")
+		return
+	}
+	if code := contactAPIRequest(handler, http.MethodPost, "/", []byte("abc")); code != http.StatusConflict {
+		t.Error("request success with invalid decode")
+		return
+	}
+
+	invalidNew := encoding.SerializeJSON(settings.SContactNew{
+		FConnections: []string{"udp://127.0.0.1:9581"},
+	})
+	if code := contactAPIRequest(handler, http.MethodPost, "/", invalidNew); code != http.StatusBadRequest {
+		t.Error("request success with invalid connection")
+		return
+	}
+}
+
+func contactNewAPIRequestOK(handler http.HandlerFunc) error {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBuffer(encoding.SerializeJSON(settings.SContactNew{
+		FAliasName:   "Alice",
+		FConnections: []string{"tcp://127.0.0.1:9581"},
+	})))
+
+	handler(w, req)
+	res := w.Result()
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return errors.New("bad status code") // nolint: err113
+	}
+
+	var export settings.SContactExport
+	if err := json.NewDecoder(res.Body).Decode(&export); err != nil {
+		return err
+	}
+
+	loaded, _, err := contact.LoadContact(export.FURI)
+	if err != nil {
+		return err
+	}
+	if loaded.FAliasName != "Alice" || len(loaded.FConnections) != 1 {
+		return errors.New("invalid contact") // nolint: err113
+	}
+
+	return nil
+}
+
+func contactAPIRequestExportOK(handler http.HandlerFunc) error {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	handler(w, req)
+	res := w.Result()
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return errors.New("bad status code") // nolint: err113
+	}
+
+	var export settings.SContactExport
+	if err := json.NewDecoder(res.Body).Decode(&export); err != nil {
+		return err
+	}
+
+	loaded, pubKey, err := contact.LoadContact(export.FURI)
+	if err != nil {
+		return err
+	}
+	if pubKey.ToString() != export.FContact.FPublicKey {
+		return errors.New("invalid public key") // nolint: err113
+	}
+	if loaded.FAliasName != "" {
+		return errors.New("invalid contact") // nolint: err113
+	}
+
+	return nil
+}
+
+func contactAPIRequestImportOK(handler http.HandlerFunc, uri string) error {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(
+		http.MethodPost,
+		"/",
+		bytes.NewBuffer(encoding.SerializeJSON(settings.SContactImport{FContact: uri})),
+	)
+
+	handler(w, req)
+	res := w.Result()
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return errors.New("bad status code") // nolint: err113
+	}
+
+	var friend settings.SFriend
+	if err := json.NewDecoder(res.Body).Decode(&friend); err != nil {
+		return err
+	}
+	if friend.FAliasName != "new_friend" || friend.FSafetyNumber == "" {
+		return errors.New("invalid friend") // nolint: err113
+	}
+
+	return nil
+}
+
+func contactAPIImport(handler http.HandlerFunc, uri, aliasName string) int {
+	return contactAPIRequest(handler, http.MethodPost, "/", encoding.SerializeJSON(settings.SContactImport{
+		FContact:   uri,
+		FAliasName: aliasName,
+	}))
+}
+
+func contactAPIRequest(handler http.HandlerFunc, method, target string, body []byte) int {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, target, bytes.NewBuffer(body))
+
+	handler(w, req)
+	res := w.Result()
+	defer res.Body.Close()
+
+	_, _ = io.ReadAll(res.Body)
+	return res.StatusCode
+}
+
+func TestHandleContactAPI(t *testing.T) {
+	t.Parallel()
+
+	pathCfg := fmt.Sprintf(tcPathConfigTemplate, 12)
+	pathDB := fmt.Sprintf(tcPathDBTemplate, 12)
+
+	_, node, _, cancel, srv := testAllCreate(pathCfg, pathDB, testutils.TgAddrs[24])
+	defer testAllFree(node, cancel, srv, pathCfg, pathDB)
+
+	client := hls_client.NewClient(
+		hls_client.NewBuilder(),
+		hls_client.NewRequester(
+			testutils.TgAddrs[24],
+			&http.Client{Timeout: time.Minute},
+			hls_client.NewSettings(nil),
+		),
+	)
+
+	ctx := context.Background()
+
+	export, err := client.ExportContact(ctx, "", []string{"tcp://127.0.0.1:9581"})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	pubKey, err := client.GetPubKey(ctx)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if export.FContact.FPublicKey != pubKey.ToString() {
+		t.Error("got invalid public key of contact")
+		return
+	}
+
+	exportDefault, err := client.ExportContact(ctx, "", nil)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if exportDefault.FContact.FPublicKey != pubKey.ToString() {
+		t.Error("got invalid public key of default contact")
+		return
+	}
+
+	privKey := asymmetric.NewPrivKey()
+	newContact, err := contact.NewContact(privKey, "suggested_name", "", nil)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	aliasName := "test_name5"
+	friend, err := client.ImportContact(ctx, contact.ToURI(newContact), aliasName)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if friend.FAliasName != aliasName {
+		t.Error("got invalid alias name of friend")
+		return
+	}
+
+	friends, err := client.GetFriends(ctx)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if v, ok := friends[aliasName]; !ok || v.ToString() != privKey.GetPubKey().ToString() {
+		t.Error("imported friend is not found")
+		return
+	}
+
+	if _, err := client.ImportContact(ctx, contact.ToURI(newContact), ""); err == nil {
+		t.Error("success import exist contact")
+		return
+	}
+}
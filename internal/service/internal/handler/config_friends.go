@@ -2,29 +2,35 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/number571/go-peer/pkg/anonymity"
 	"github.com/number571/go-peer/pkg/crypto/asymmetric"
 	"github.com/number571/go-peer/pkg/logger"
+	"github.com/number571/hidden-lake/internal/service/internal/metadata"
 	"github.com/number571/hidden-lake/internal/service/pkg/app/config"
 	pkg_settings "github.com/number571/hidden-lake/internal/service/pkg/settings"
 	"github.com/number571/hidden-lake/internal/utils/api"
 	http_logger "github.com/number571/hidden-lake/internal/utils/logger/http"
+	"github.com/number571/hidden-lake/internal/utils/safety"
 )
 
 func HandleConfigFriendsAPI(
 	pWrapper config.IWrapper,
 	pLogger logger.ILogger,
 	pNode anonymity.INode,
+	pMetadata metadata.IMetadata,
 ) http.HandlerFunc {
 	return func(pW http.ResponseWriter, pR *http.Request) {
 		logBuilder := http_logger.NewLogBuilder(pkg_settings.GServiceName.Short(), pR)
 
 		var vFriend pkg_settings.SFriend
 
-		if pR.Method != http.MethodGet && pR.Method != http.MethodPost && pR.Method != http.MethodDelete {
+		if pR.Method != http.MethodGet && pR.Method != http.MethodPost &&
+			pR.Method != http.MethodPut && pR.Method != http.MethodDelete {
 			pLogger.PushWarn(logBuilder.WithMessage(http_logger.CLogMethod))
 			_ = api.Response(pW, http.StatusMethodNotAllowed, "failed: incorrect method")
 			return
@@ -32,12 +38,27 @@ func HandleConfigFriendsAPI(
 
 		if pR.Method == http.MethodGet {
 			friends := pWrapper.GetConfig().GetFriends()
+			expires := pWrapper.GetConfig().GetExpires()
+			myPubKey := pNode.GetQBProcessor().GetClient().GetPrivKey().GetPubKey()
 
 			listFriends := make([]pkg_settings.SFriend, 0, len(friends))
 			for name, pubKey := range friends {
+				meta, err := pMetadata.Get(pubKey)
+				if err != nil {
+					pLogger.PushWarn(logBuilder.WithMessage("get_metadata"))
+					_ = api.Response(pW, http.StatusInternalServerError, "failed: get metadata")
+					return
+				}
 				listFriends = append(listFriends, pkg_settings.SFriend{
-					FAliasName: name,
-					FPublicKey: pubKey.ToString(),
+					FAliasName:    name,
+					FPublicKey:    pubKey.ToString(),
+					FSafetyNumber: safety.GetSafetyNumber(myPubKey, pubKey),
+					FNotes:        meta.GetNotes(),
+					FTags:         meta.GetTags(),
+					FAdded:        timeToString(meta.GetAdded()),
+					FLastSeen:     timeToString(meta.GetLastSeen()),
+					FExpires:      timeToString(expires[name]),
+					FTTLMS:        getRemainingMS(expires[name]),
 				})
 			}
 
@@ -63,6 +84,12 @@ func HandleConfigFriendsAPI(
 
 		switch pR.Method {
 		case http.MethodPost:
+			if err := metadata.ValidateInfo(vFriend.FNotes, vFriend.FTags); err != nil {
+				pLogger.PushWarn(logBuilder.WithMessage("validate_metadata"))
+				_ = api.Response(pW, http.StatusBadRequest, "failed: invalid notes or tags")
+				return
+			}
+
 			if _, ok := friends[aliasName]; ok {
 				pLogger.PushWarn(logBuilder.WithMessage("get_friends"))
 				_ = api.Response(pW, http.StatusNotAcceptable, "failed: friend already exist")
@@ -76,8 +103,30 @@ func HandleConfigFriendsAPI(
 				return
 			}
 
+			expiresAt, err := getFriendExpires(&vFriend)
+			if err != nil {
+				pLogger.PushWarn(logBuilder.WithMessage("get_expires"))
+				_ = api.Response(pW, http.StatusBadRequest, "failed: invalid expiry")
+				return
+			}
+
+			// the expiry is saved before the friend to not add a permanent friend
+			expires := pWrapper.GetConfig().GetExpires()
+			if !expiresAt.IsZero() {
+				expires[aliasName] = expiresAt
+				if err := pWrapper.GetEditor().UpdateExpires(expires); err != nil {
+					pLogger.PushWarn(logBuilder.WithMessage("update_expires"))
+					_ = api.Response(pW, http.StatusInternalServerError, "failed: update expiry")
+					return
+				}
+			}
+
 			friends[aliasName] = pubKey
 			if err := pWrapper.GetEditor().UpdateFriends(friends); err != nil {
+				if !expiresAt.IsZero() {
+					delete(expires, aliasName)
+					_ = pWrapper.GetEditor().UpdateExpires(expires)
+				}
 				pLogger.PushWarn(logBuilder.WithMessage("update_friends"))
 				_ = api.Response(pW, http.StatusInternalServerError, "failed: update friends")
 				return
@@ -85,10 +134,60 @@ func HandleConfigFriendsAPI(
 
 			pNode.GetMapPubKeys().SetPubKey(pubKey)
 
+			if err := setFriendMetadata(pMetadata, pubKey, &vFriend); err != nil {
+				pLogger.PushWarn(logBuilder.WithMessage("set_metadata"))
+			}
+
 			pLogger.PushInfo(logBuilder.WithMessage(http_logger.CLogSuccess))
 			_ = api.Response(pW, http.StatusOK, "success: update friends")
 			return
 
+		case http.MethodPut:
+			pubKey, ok := friends[aliasName]
+			if !ok {
+				pLogger.PushWarn(logBuilder.WithMessage("get_friends"))
+				_ = api.Response(pW, http.StatusNotFound, "failed: friend does not exist")
+				return
+			}
+
+			// only the expiry is changed if it is set
+			if vFriend.FExpires != "" || vFriend.FTTLMS != 0 {
+				expiresAt, err := getFriendExpires(&vFriend)
+				if err != nil {
+					pLogger.PushWarn(logBuilder.WithMessage("get_expires"))
+					_ = api.Response(pW, http.StatusBadRequest, "failed: invalid expiry")
+					return
+				}
+
+				expires := pWrapper.GetConfig().GetExpires()
+				expires[aliasName] = expiresAt
+				if err := pWrapper.GetEditor().UpdateExpires(expires); err != nil {
+					pLogger.PushWarn(logBuilder.WithMessage("update_expires"))
+					_ = api.Response(pW, http.StatusInternalServerError, "failed: update expiry")
+					return
+				}
+
+				pLogger.PushInfo(logBuilder.WithMessage(http_logger.CLogSuccess))
+				_ = api.Response(pW, http.StatusOK, "success: update expiry")
+				return
+			}
+
+			if err := metadata.ValidateInfo(vFriend.FNotes, vFriend.FTags); err != nil {
+				pLogger.PushWarn(logBuilder.WithMessage("validate_metadata"))
+				_ = api.Response(pW, http.StatusBadRequest, "failed: invalid notes or tags")
+				return
+			}
+
+			if err := pMetadata.SetInfo(pubKey, vFriend.FNotes, vFriend.FTags); err != nil {
+				pLogger.PushWarn(logBuilder.WithMessage("set_metadata"))
+				_ = api.Response(pW, http.StatusInternalServerError, "failed: update metadata")
+				return
+			}
+
+			pLogger.PushInfo(logBuilder.WithMessage(http_logger.CLogSuccess))
+			_ = api.Response(pW, http.StatusOK, "success: update friend")
+			return
+
 		case http.MethodDelete:
 			pubKey, ok := friends[aliasName]
 			if !ok {
@@ -107,9 +206,62 @@ func HandleConfigFriendsAPI(
 
 			pNode.GetMapPubKeys().DelPubKey(pubKey)
 
+			if err := pMetadata.Delete(pubKey); err != nil {
+				pLogger.PushWarn(logBuilder.WithMessage("delete_metadata"))
+			}
+
 			pLogger.PushInfo(logBuilder.WithMessage(http_logger.CLogSuccess))
 			_ = api.Response(pW, http.StatusOK, "success: delete friend")
 			return
 		}
 	}
 }
+
+func setFriendMetadata(pMetadata metadata.IMetadata, pPubKey asymmetric.IPubKey, pFriend *pkg_settings.SFriend) error {
+	if err := pMetadata.SetAdded(pPubKey); err != nil {
+		return err
+	}
+	if pFriend.FNotes == "" && len(pFriend.FTags) == 0 {
+		return nil
+	}
+	return pMetadata.SetInfo(pPubKey, pFriend.FNotes, pFriend.FTags)
+}
+
+// getFriendExpires returns the zero time for the permanent friend.
+func getFriendExpires(pFriend *pkg_settings.SFriend) (time.Time, error) {
+	if pFriend.FExpires != "" && pFriend.FTTLMS != 0 {
+		return time.Time{}, ErrInvalidExpires
+	}
+	if pFriend.FTTLMS != 0 {
+		return time.Now().Add(time.Duration(pFriend.FTTLMS) * time.Millisecond), nil // nolint: gosec
+	}
+	if pFriend.FExpires == "" {
+		return time.Time{}, nil
+	}
+	expiresAt, err := time.Parse(time.RFC3339, pFriend.FExpires)
+	if err != nil {
+		return time.Time{}, errors.Join(ErrInvalidExpires, err)
+	}
+	if !expiresAt.After(time.Now()) {
+		return time.Time{}, ErrInvalidExpires
+	}
+	return expiresAt, nil
+}
+
+func getRemainingMS(pExpires time.Time) uint64 {
+	if pExpires.IsZero() {
+		return 0
+	}
+	remaining := time.Until(pExpires).Milliseconds()
+	if remaining <= 0 {
+		return 0
+	}
+	return uint64(remaining)
+}
+
+func timeToString(pTime time.Time) string {
+	if pTime.IsZero() {
+		return ""
+	}
+	return pTime.Format(time.RFC3339)
+}
@@ -3,20 +3,23 @@ package handler
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/number571/go-peer/pkg/encoding"
 	"github.com/number571/go-peer/pkg/logger"
 	"github.com/number571/hidden-lake/internal/service/pkg/app/config"
-	hls_client "github.com/number571/hidden-lake/internal/service/pkg/client"
 	"github.com/number571/hidden-lake/internal/service/pkg/settings"
 	std_logger "github.com/number571/hidden-lake/internal/utils/logger/std"
+	hls_client "github.com/number571/hidden-lake/pkg/service/client"
 	testutils "github.com/number571/hidden-lake/test/utils"
 )
 
@@ -36,7 +39,7 @@ func TestHandleFriendsAPI2(t *testing.T) {
 		},
 	)
 
-	handler := HandleConfigFriendsAPI(newTsWrapper(true), httpLogger, newTsNode(true, true, true))
+	handler := HandleConfigFriendsAPI(newTsWrapper(true), httpLogger, newTsNode(true, true, true), newTsMetadata(true))
 	if err := friendsAPIRequestOK(handler); err != nil {
 		t.Error(err)
 		return
@@ -45,11 +48,54 @@ func TestHandleFriendsAPI2(t *testing.T) {
 		t.Error(err)
 		return
 	}
+	if err := friendsAPIRequestPutOK(handler, "abc", "notes", []string{"tag"}); err != nil {
+		t.Error(err)
+		return
+	}
 	if err := friendsAPIRequestDeleteOK(handler); err != nil {
 		t.Error(err)
 		return
 	}
 
+	if err := friendsAPIRequestExpires(handler, http.MethodPost, "temp_friend", "", 60_000); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := friendsAPIRequestExpires(handler, http.MethodPut, "abc", "", 60_000); err != nil {
+		t.Error(err)
+		return
+	}
+	expiresAt := time.Now().Add(time.Hour).Format(time.RFC3339)
+	if err := friendsAPIRequestExpires(handler, http.MethodPut, "abc", expiresAt, 0); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := friendsAPIRequestExpires(handler, http.MethodPut, "notfound", "", 60_000); err == nil {
+		t.Error("request success with not found alias_name (expires)")
+		return
+	}
+	if err := friendsAPIRequestExpires(handler, http.MethodPost, "temp_friend", expiresAt, 60_000); err == nil {
+		t.Error("request success with expires and ttl")
+		return
+	}
+	expiredAt := time.Now().Add(-time.Hour).Format(time.RFC3339)
+	if err := friendsAPIRequestExpires(handler, http.MethodPut, "abc", expiredAt, 0); err == nil {
+		t.Error("request success with expires in the past")
+		return
+	}
+	if err := friendsAPIRequestExpires(handler, http.MethodPut, "abc", "abc", 0); err == nil {
+		t.Error("request success with invalid expires")
+		return
+	}
+
+	if err := friendsAPIRequestPutOK(handler, "notfound", "notes", nil); err == nil {
+		t.Error("request success with not found alias_name (put)")
+		return
+	}
+	if err := friendsAPIRequestPutOK(handler, "abc", strings.Repeat("a", 2048), nil); err == nil {
+		t.Error("request success with invalid notes")
+		return
+	}
 	if err := friendsAPIRequestNotFound(handler); err == nil {
 		t.Error("request success with not found alias_name")
 		return
@@ -75,7 +121,17 @@ func TestHandleFriendsAPI2(t *testing.T) {
 		return
 	}
 
-	handlerx := HandleConfigFriendsAPI(newTsWrapper(false), httpLogger, newTsNode(true, true, true))
+	handlerm := HandleConfigFriendsAPI(newTsWrapper(true), httpLogger, newTsNode(true, true, true), newTsMetadata(false))
+	if err := friendsAPIRequestOK(handlerm); err == nil {
+		t.Error("request success with invalid metadata (get)")
+		return
+	}
+	if err := friendsAPIRequestPutOK(handlerm, "abc", "notes", nil); err == nil {
+		t.Error("request success with invalid metadata (put)")
+		return
+	}
+
+	handlerx := HandleConfigFriendsAPI(newTsWrapper(false), httpLogger, newTsNode(true, true, true), newTsMetadata(true))
 	if err := friendsAPIRequestPostOK(handlerx); err == nil {
 		t.Error("request success with invalid update editor (post)")
 		return
@@ -84,6 +140,42 @@ func TestHandleFriendsAPI2(t *testing.T) {
 		t.Error("request success with invalid update editor (post)")
 		return
 	}
+	if err := friendsAPIRequestExpires(handlerx, http.MethodPost, "temp_friend", "", 60_000); err == nil {
+		t.Error("request success with invalid update editor (post expires)")
+		return
+	}
+	if err := friendsAPIRequestExpires(handlerx, http.MethodPut, "abc", "", 60_000); err == nil {
+		t.Error("request success with invalid update editor (put expires)")
+		return
+	}
+}
+
+func friendsAPIRequestExpires(handler http.HandlerFunc, method, aliasName, expires string, ttlMS uint64) error {
+	newFriend := settings.SFriend{
+		FAliasName: aliasName,
+		FExpires:   expires,
+		FTTLMS:     ttlMS,
+	}
+	if method == http.MethodPost {
+		newFriend.FPublicKey = tgPrivKey3.GetPubKey().ToString()
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, "/", bytes.NewBuffer(encoding.SerializeJSON(newFriend)))
+
+	handler(w, req)
+	res := w.Result()
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return errors.New("bad status code") // nolint: err113
+	}
+
+	if _, err := io.ReadAll(res.Body); err != nil {
+		return err
+	}
+
+	return nil
 }
 
 func friendsAPIRequestDeleteOK(handler http.HandlerFunc) error {
@@ -109,6 +201,31 @@ func friendsAPIRequestDeleteOK(handler http.HandlerFunc) error {
 	return nil
 }
 
+func friendsAPIRequestPutOK(handler http.HandlerFunc, aliasName, notes string, tags []string) error {
+	newFriend := settings.SFriend{
+		FAliasName: aliasName,
+		FNotes:     notes,
+		FTags:      tags,
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPut, "/", bytes.NewBuffer(encoding.SerializeJSON(newFriend)))
+
+	handler(w, req)
+	res := w.Result()
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return errors.New("bad status code") // nolint: err113
+	}
+
+	if _, err := io.ReadAll(res.Body); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func friendsAPIRequestPostOK(handler http.HandlerFunc) error {
 	newFriend := settings.SFriend{
 		FAliasName: "new_friend",
@@ -168,10 +285,23 @@ func friendsAPIRequestOK(handler http.HandlerFunc) error {
 		return errors.New("bad status code") // nolint: err113
 	}
 
-	if _, err := io.ReadAll(res.Body); err != nil {
+	var friends []settings.SFriend
+	if err := json.NewDecoder(res.Body).Decode(&friends); err != nil {
 		return err
 	}
 
+	for _, friend := range friends {
+		if friend.FSafetyNumber == "" {
+			return errors.New("safety number is not found") // nolint: err113
+		}
+		if friend.FAdded == "" || friend.FLastSeen != "" {
+			return errors.New("invalid metadata of friend") // nolint: err113
+		}
+		if friend.FAliasName == "abc" && (friend.FExpires == "" || friend.FTTLMS == 0) {
+			return errors.New("invalid expiry of friend") // nolint: err113
+		}
+	}
+
 	return nil
 }
 
@@ -279,7 +409,7 @@ func friendsAPIRequestDecode(handler http.HandlerFunc) error {
 
 func friendsAPIRequestMethod(handler http.HandlerFunc) error {
 	w := httptest.NewRecorder()
-	req := httptest.NewRequest(http.MethodPut, "/", nil)
+	req := httptest.NewRequest(http.MethodPatch, "/", nil)
 
 	handler(w, req)
 	res := w.Result()
@@ -310,12 +440,14 @@ func TestHandleFriendsAPI(t *testing.T) {
 		hls_client.NewRequester(
 			testutils.TgAddrs[7],
 			&http.Client{Timeout: time.Minute},
+			hls_client.NewSettings(nil),
 		),
 	)
 
 	aliasName := "test_name4"
 	testGetFriends(t, client, wcfg.GetConfig())
 	testAddFriend(t, client, aliasName)
+	testUpdateFriend(t, client, aliasName)
 	testDelFriend(t, client, aliasName)
 }
 
@@ -367,6 +499,42 @@ func testAddFriend(t *testing.T, client hls_client.IClient, aliasName string) {
 	}
 }
 
+func testUpdateFriend(t *testing.T, client hls_client.IClient, aliasName string) {
+	err := client.UpdateFriend(
+		context.Background(),
+		aliasName,
+		"notes",
+		[]string{" tag1 ", "tag2", "tag1", ""},
+	)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	friends, err := client.GetFriendsInfo(context.Background())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	for _, friend := range friends {
+		if friend.FAliasName != aliasName {
+			continue
+		}
+		if friend.FNotes != "notes" || !slices.Equal(friend.FTags, []string{"tag1", "tag2"}) {
+			t.Errorf("invalid notes or tags of '%s'", aliasName)
+			return
+		}
+		if _, err := time.Parse(time.RFC3339, friend.FAdded); err != nil {
+			t.Errorf("invalid time of adding '%s'", aliasName)
+			return
+		}
+		return
+	}
+
+	t.Errorf("undefined friend '%s'", aliasName)
+}
+
 func testDelFriend(t *testing.T, client hls_client.IClient, aliasName string) {
 	err := client.DelFriend(context.Background(), aliasName)
 	if err != nil {
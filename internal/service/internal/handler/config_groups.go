@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/number571/go-peer/pkg/logger"
+	"github.com/number571/hidden-lake/internal/service/pkg/app/config"
+	pkg_settings "github.com/number571/hidden-lake/internal/service/pkg/settings"
+	"github.com/number571/hidden-lake/internal/utils/api"
+	http_logger "github.com/number571/hidden-lake/internal/utils/logger/http"
+)
+
+func HandleConfigGroupsAPI(
+	pWrapper config.IWrapper,
+	pLogger logger.ILogger,
+) http.HandlerFunc {
+	return func(pW http.ResponseWriter, pR *http.Request) {
+		logBuilder := http_logger.NewLogBuilder(pkg_settings.GServiceName.Short(), pR)
+
+		var vGroup pkg_settings.SGroup
+
+		if pR.Method != http.MethodGet && pR.Method != http.MethodPost && pR.Method != http.MethodDelete {
+			pLogger.PushWarn(logBuilder.WithMessage(http_logger.CLogMethod))
+			_ = api.Response(pW, http.StatusMethodNotAllowed, "failed: incorrect method")
+			return
+		}
+
+		if pR.Method == http.MethodGet {
+			groups := pWrapper.GetConfig().GetGroups()
+
+			listGroups := make([]pkg_settings.SGroup, 0, len(groups))
+			for name, members := range groups {
+				listGroups = append(listGroups, pkg_settings.SGroup{
+					FName:    name,
+					FMembers: members,
+				})
+			}
+			sort.Slice(listGroups, func(i, j int) bool {
+				return listGroups[i].FName < listGroups[j].FName
+			})
+
+			pLogger.PushInfo(logBuilder.WithMessage(http_logger.CLogSuccess))
+			_ = api.Response(pW, http.StatusOK, listGroups)
+			return
+		}
+
+		if err := json.NewDecoder(pR.Body).Decode(&vGroup); err != nil {
+			pLogger.PushWarn(logBuilder.WithMessage(http_logger.CLogDecodeBody))
+			_ = api.Response(pW, http.StatusConflict, "failed: decode request")
+			return
+		}
+
+		name := strings.TrimSpace(vGroup.FName)
+		if name == "" {
+			pLogger.PushWarn(logBuilder.WithMessage("get_group_name"))
+			_ = api.Response(pW, http.StatusTeapot, "failed: load group name")
+			return
+		}
+
+		groups := pWrapper.GetConfig().GetGroups()
+
+		switch pR.Method {
+		case http.MethodPost:
+			members := trimAliasNames(vGroup.FMembers)
+			if len(members) == 0 {
+				pLogger.PushWarn(logBuilder.WithMessage("get_members"))
+				_ = api.Response(pW, http.StatusBadRequest, "failed: load members")
+				return
+			}
+
+			groups[name] = members
+			if err := pWrapper.GetEditor().UpdateGroups(groups); err != nil {
+				pLogger.PushWarn(logBuilder.WithMessage("update_groups"))
+				_ = api.Response(pW, http.StatusInternalServerError, "failed: update groups")
+				return
+			}
+
+			pLogger.PushInfo(logBuilder.WithMessage(http_logger.CLogSuccess))
+			_ = api.Response(pW, http.StatusOK, "success: update groups")
+			return
+
+		case http.MethodDelete:
+			if _, ok := groups[name]; !ok {
+				pLogger.PushWarn(logBuilder.WithMessage("get_groups"))
+				_ = api.Response(pW, http.StatusNotFound, "failed: group does not exist")
+				return
+			}
+
+			delete(groups, name)
+
+			if err := pWrapper.GetEditor().UpdateGroups(groups); err != nil {
+				pLogger.PushWarn(logBuilder.WithMessage("update_groups"))
+				_ = api.Response(pW, http.StatusInternalServerError, "failed: delete group")
+				return
+			}
+
+			pLogger.PushInfo(logBuilder.WithMessage(http_logger.CLogSuccess))
+			_ = api.Response(pW, http.StatusOK, "success: delete group")
+			return
+		}
+	}
+}
+
+// trimAliasNames removes spaces, empty and repeated aliases.
+func trimAliasNames(pAliasNames []string) []string {
+	result := make([]string, 0, len(pAliasNames))
+	mapping := make(map[string]struct{}, len(pAliasNames))
+	for _, aliasName := range pAliasNames {
+		aliasName = strings.TrimSpace(aliasName)
+		if aliasName == "" {
+			continue
+		}
+		if _, ok := mapping[aliasName]; ok {
+			continue
+		}
+		mapping[aliasName] = struct{}{}
+		result = append(result, aliasName)
+	}
+	return result
+}
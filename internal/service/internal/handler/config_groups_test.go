@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/number571/go-peer/pkg/encoding"
+	"github.com/number571/go-peer/pkg/logger"
+	"github.com/number571/hidden-lake/internal/service/pkg/settings"
+	std_logger "github.com/number571/hidden-lake/internal/utils/logger/std"
+	"github.com/number571/hidden-lake/pkg/request"
+	hls_client "github.com/number571/hidden-lake/pkg/service/client"
+	testutils "github.com/number571/hidden-lake/test/utils"
+)
+
+func TestHandleGroupsAPI(t *testing.T) {
+	t.Parallel()
+
+	httpLogger := std_logger.NewStdLogger(
+		func() std_logger.ILogging {
+			logging, err := std_logger.LoadLogging([]string{})
+			if err != nil {
+				panic(err)
+			}
+			return logging
+		}(),
+		func(_ logger.ILogArg) string {
+			return ""
+		},
+	)
+
+	handler := HandleConfigGroupsAPI(newTsWrapper(true), httpLogger)
+	if err := groupsAPIRequestOK(handler); err != nil {
+		t.Error(err)
+		return
+	}
+
+	newGroup := &settings.SGroup{FName: "new_group", FMembers: []string{"abc", " abc ", ""}}
+	if err := groupsAPIRequest(handler, http.MethodPost, newGroup); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := groupsAPIRequest(handler, http.MethodDelete, &settings.SGroup{FName: "group"}); err != nil {
+		t.Error(err)
+		return
+	}
+
+	if err := groupsAPIRequest(handler, http.MethodDelete, &settings.SGroup{FName: "notfound"}); err == nil {
+		t.Error("request success with not found group")
+		return
+	}
+	if err := groupsAPIRequest(handler, http.MethodPost, &settings.SGroup{FName: "group", FMembers: []string{" "}}); err == nil {
+		t.Error("request success with invalid members")
+		return
+	}
+	if err := groupsAPIRequest(handler, http.MethodPost, &settings.SGroup{FName: " ", FMembers: []string{"abc"}}); err == nil {
+		t.Error("request success with invalid group name")
+		return
+	}
+	if err := groupsAPIRequest(handler, http.MethodPost, nil); err == nil {
+		t.Error("request success with invalid decode")
+		return
+	}
+	if err := groupsAPIRequest(handler, http.MethodPut, newGroup); err == nil {
+		t.Error("request success with invalid method")
+		return
+	}
+
+	handlerx := HandleConfigGroupsAPI(newTsWrapper(false), httpLogger)
+	if err := groupsAPIRequest(handlerx, http.MethodPost, newGroup); err == nil {
+		t.Error("request success with invalid update editor (post)")
+		return
+	}
+	if err := groupsAPIRequest(handlerx, http.MethodDelete, &settings.SGroup{FName: "group"}); err == nil {
+		t.Error("request success with invalid update editor (delete)")
+		return
+	}
+}
+
+func groupsAPIRequestOK(handler http.HandlerFunc) error {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	handler(w, req)
+	res := w.Result()
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return errors.New("bad status code") // nolint: err113
+	}
+
+	var groups []settings.SGroup
+	if err := json.NewDecoder(res.Body).Decode(&groups); err != nil {
+		return err
+	}
+
+	if len(groups) != 1 || groups[0].FName != "group" || len(groups[0].FMembers) != 2 {
+		return errors.New("invalid groups") // nolint: err113
+	}
+
+	return nil
+}
+
+func groupsAPIRequest(handler http.HandlerFunc, method string, group *settings.SGroup) error {
+	body := []byte{1}
+	if group != nil {
+		body = encoding.SerializeJSON(group)
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, "/", bytes.NewBuffer(body))
+
+	handler(w, req)
+	res := w.Result()
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return errors.New("bad status code") // nolint: err113
+	}
+
+	if _, err := io.ReadAll(res.Body); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func TestHandleGroupsAPI2(t *testing.T) {
+	t.Parallel()
+
+	pathCfg := fmt.Sprintf(tcPathConfigTemplate, 15)
+	pathDB := fmt.Sprintf(tcPathDBTemplate, 15)
+
+	_, node, _, cancel, srv := testAllCreate(pathCfg, pathDB, testutils.TgAddrs[28])
+	defer testAllFree(node, cancel, srv, pathCfg, pathDB)
+
+	client := hls_client.NewClient(
+		hls_client.NewBuilder(),
+		hls_client.NewRequester(
+			testutils.TgAddrs[28],
+			&http.Client{Timeout: time.Minute},
+			hls_client.NewSettings(nil),
+		),
+	)
+
+	ctx := context.Background()
+	if err := client.SetGroup(ctx, "group", []string{"test_recvr", "test_name1"}); err != nil {
+		t.Error(err)
+		return
+	}
+
+	groups, err := client.GetGroups(ctx)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if members, ok := groups["group"]; !ok || len(members) != 2 {
+		t.Error("undefined group")
+		return
+	}
+
+	req := request.NewRequestBuilder().
+		WithMethod(http.MethodGet).
+		WithHost(tcServiceAddressInHLS).
+		WithPath("/echo").
+		Build()
+
+	results, err := client.SendMulticast(ctx, "group", []string{"undefined"}, req)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(results) != 3 || results[0].FError != "" || results[1].FError != "" || results[2].FError == "" {
+		t.Error("invalid results of multicast")
+		return
+	}
+
+	if err := client.DelGroup(ctx, "group"); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err := client.SendMulticast(ctx, "group", nil, req); err == nil {
+		t.Error("success multicast to deleted group")
+		return
+	}
+}
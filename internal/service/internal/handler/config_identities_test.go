@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/number571/go-peer/pkg/encoding"
+	"github.com/number571/go-peer/pkg/logger"
+	pkg_settings "github.com/number571/hidden-lake/internal/service/pkg/settings"
+)
+
+func TestHandleConfigIdentitiesAPI2(t *testing.T) {
+	t.Parallel()
+
+	logger := logger.NewLogger(
+		logger.NewSettings(&logger.SSettings{}),
+		func(_ logger.ILogArg) string { return "" },
+	)
+
+	handler := HandleConfigIdentitiesAPI(logger, []*pkg_settings.SIdentity{
+		{FName: pkg_settings.CDefaultIdentity, FPublicKey: tgPrivKey1.GetPubKey().ToString()},
+		{FName: "work", FPublicKey: tgPrivKey2.GetPubKey().ToString()},
+	})
+
+	identities, err := identitiesRequest(handler, http.MethodGet)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(identities) != 2 || identities[1].FName != "work" {
+		t.Error("got invalid identities")
+		return
+	}
+
+	if _, err := identitiesRequest(handler, http.MethodPost); err == nil {
+		t.Error("request success with invalid method")
+		return
+	}
+}
+
+func identitiesRequest(handler http.HandlerFunc, method string) ([]*pkg_settings.SIdentity, error) {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, pkg_settings.CHandleConfigIdentitiesPath, nil)
+
+	handler(w, req)
+	res := w.Result()
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.New("bad status code") // nolint: err113
+	}
+
+	var identities []*pkg_settings.SIdentity
+	if err := encoding.DeserializeJSON(w.Body.Bytes(), &identities); err != nil {
+		return nil, err
+	}
+	return identities, nil
+}
@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/number571/go-peer/pkg/encoding"
+	"github.com/number571/go-peer/pkg/logger"
+	"github.com/number571/hidden-lake/internal/service/internal/introductions"
+	"github.com/number571/hidden-lake/internal/service/internal/metadata"
+	"github.com/number571/hidden-lake/internal/service/pkg/app/config"
+	pkg_settings "github.com/number571/hidden-lake/internal/service/pkg/settings"
+	"github.com/number571/hidden-lake/internal/utils/api"
+	http_logger "github.com/number571/hidden-lake/internal/utils/logger/http"
+	"github.com/number571/hidden-lake/internal/utils/safety"
+	"github.com/number571/hidden-lake/pkg/network"
+	"github.com/number571/hidden-lake/pkg/request"
+)
+
+func HandleConfigIntroductionsAPI(
+	pCtx context.Context,
+	pWrapper config.IWrapper,
+	pLogger logger.ILogger,
+	pNode network.IHiddenLakeNode,
+	pIntroductions introductions.IIntroductions,
+	pMetadata metadata.IMetadata,
+) http.HandlerFunc {
+	return func(pW http.ResponseWriter, pR *http.Request) {
+		logBuilder := http_logger.NewLogBuilder(pkg_settings.GServiceName.Short(), pR)
+
+		switch pR.Method {
+		case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete:
+			// pass
+		default:
+			pLogger.PushWarn(logBuilder.WithMessage(http_logger.CLogMethod))
+			_ = api.Response(pW, http.StatusMethodNotAllowed, "failed: incorrect method")
+			return
+		}
+
+		origNode := pNode.GetAnonymityNode()
+		myPubKey := origNode.GetQBProcessor().GetClient().GetPrivKey().GetPubKey()
+		friends := pWrapper.GetConfig().GetFriends()
+
+		if pR.Method == http.MethodGet {
+			list := pIntroductions.List()
+			result := make([]pkg_settings.SIntroduction, 0, len(list))
+			for _, v := range list {
+				result = append(result, pkg_settings.SIntroduction{
+					FID:           v.GetID(),
+					FIntroducer:   getAliasName(friends, v.GetIntroducer()),
+					FAliasName:    v.GetAliasName(),
+					FPublicKey:    v.GetPubKey().ToString(),
+					FSafetyNumber: safety.GetSafetyNumber(myPubKey, v.GetPubKey()),
+				})
+			}
+
+			pLogger.PushInfo(logBuilder.WithMessage(http_logger.CLogSuccess))
+			_ = api.Response(pW, http.StatusOK, result)
+			return
+		}
+
+		if pR.Method == http.MethodPost {
+			var vIntroduce pkg_settings.SIntroduce
+
+			if err := json.NewDecoder(pR.Body).Decode(&vIntroduce); err != nil {
+				pLogger.PushWarn(logBuilder.WithMessage(http_logger.CLogDecodeBody))
+				_ = api.Response(pW, http.StatusConflict, "failed: decode request")
+				return
+			}
+
+			if len(vIntroduce.FAliasNames) != 2 || vIntroduce.FAliasNames[0] == vIntroduce.FAliasNames[1] {
+				pLogger.PushWarn(logBuilder.WithMessage("get_alias_names"))
+				_ = api.Response(pW, http.StatusTeapot, "failed: load alias names")
+				return
+			}
+
+			aliasName1, aliasName2 := vIntroduce.FAliasNames[0], vIntroduce.FAliasNames[1]
+			pubKey1, ok1 := friends[aliasName1]
+			pubKey2, ok2 := friends[aliasName2]
+			if !ok1 || !ok2 {
+				pLogger.PushWarn(logBuilder.WithMessage("get_friends"))
+				_ = api.Response(pW, http.StatusNotFound, "failed: friend does not exist")
+				return
+			}
+
+			// each friend receives the key of other friend and
+			// confirms the introduction independently
+			req1 := newIntroductionRequest(aliasName2, pubKey2.ToString())
+			req2 := newIntroductionRequest(aliasName1, pubKey1.ToString())
+			if err := pNode.SendRequest(pCtx, pubKey1, req1); err != nil {
+				pLogger.PushWarn(logBuilder.WithMessage("send_introduction"))
+				_ = api.Response(pW, http.StatusInternalServerError, "failed: send introduction")
+				return
+			}
+			if err := pNode.SendRequest(pCtx, pubKey2, req2); err != nil {
+				pLogger.PushWarn(logBuilder.WithMessage("send_introduction"))
+				_ = api.Response(pW, http.StatusInternalServerError, "failed: send introduction")
+				return
+			}
+
+			pLogger.PushInfo(logBuilder.WithMessage(http_logger.CLogSuccess))
+			_ = api.Response(pW, http.StatusOK, "success: send introduction")
+			return
+		}
+
+		var vIntroduction pkg_settings.SIntroduction
+
+		if err := json.NewDecoder(pR.Body).Decode(&vIntroduction); err != nil {
+			pLogger.PushWarn(logBuilder.WithMessage(http_logger.CLogDecodeBody))
+			_ = api.Response(pW, http.StatusConflict, "failed: decode request")
+			return
+		}
+
+		introduction, err := pIntroductions.Load(vIntroduction.FID)
+		if err != nil {
+			pLogger.PushWarn(logBuilder.WithMessage("load_introduction"))
+			_ = api.Response(pW, http.StatusNotFound, "failed: introduction does not exist")
+			return
+		}
+
+		switch pR.Method {
+		case http.MethodPut:
+			aliasName := strings.TrimSpace(vIntroduction.FAliasName)
+			if aliasName == "" {
+				aliasName = introduction.GetAliasName()
+			}
+
+			pubKey := introduction.GetPubKey()
+			if aliasName == "" {
+				// get hash of public key as alias_name
+				aliasName = pubKey.GetHasher().ToString()
+			}
+
+			if _, ok := friends[aliasName]; ok {
+				pLogger.PushWarn(logBuilder.WithMessage("get_friends"))
+				_ = api.Response(pW, http.StatusNotAcceptable, "failed: friend already exist")
+				return
+			}
+			if getAliasName(friends, pubKey) != "" {
+				pLogger.PushWarn(logBuilder.WithMessage("get_friends"))
+				_ = api.Response(pW, http.StatusNotAcceptable, "failed: public key already exist")
+				return
+			}
+
+			friends[aliasName] = pubKey
+			if err := pWrapper.GetEditor().UpdateFriends(friends); err != nil {
+				pLogger.PushWarn(logBuilder.WithMessage("update_friends"))
+				_ = api.Response(pW, http.StatusInternalServerError, "failed: update friends")
+				return
+			}
+
+			origNode.GetMapPubKeys().SetPubKey(pubKey)
+			if err := pMetadata.SetAdded(pubKey); err != nil {
+				pLogger.PushWarn(logBuilder.WithMessage("set_metadata"))
+			}
+			_ = pIntroductions.Delete(introduction.GetID())
+
+			pLogger.PushInfo(logBuilder.WithMessage(http_logger.CLogSuccess))
+			_ = api.Response(pW, http.StatusOK, pkg_settings.SFriend{
+				FAliasName:    aliasName,
+				FPublicKey:    pubKey.ToString(),
+				FSafetyNumber: safety.GetSafetyNumber(myPubKey, pubKey),
+			})
+			return
+
+		case http.MethodDelete:
+			_ = pIntroductions.Delete(introduction.GetID())
+
+			pLogger.PushInfo(logBuilder.WithMessage(http_logger.CLogSuccess))
+			_ = api.Response(pW, http.StatusOK, "success: reject introduction")
+			return
+		}
+	}
+}
+
+func newIntroductionRequest(pAliasName, pPubKey string) request.IRequest {
+	return request.NewRequestBuilder().
+		WithMethod(http.MethodPost).
+		WithHost(pkg_settings.CIntroductionHostName).
+		WithPath(pkg_settings.CIntroductionPath).
+		WithBody(encoding.SerializeJSON(pkg_settings.SIntroduction{
+			FAliasName: pAliasName,
+			FPublicKey: pPubKey,
+		})).
+		Build()
+}
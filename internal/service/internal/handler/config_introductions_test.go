@@ -0,0 +1,231 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/number571/go-peer/pkg/crypto/asymmetric"
+	"github.com/number571/go-peer/pkg/encoding"
+	"github.com/number571/go-peer/pkg/logger"
+	"github.com/number571/hidden-lake/internal/service/internal/introductions"
+	"github.com/number571/hidden-lake/internal/service/pkg/settings"
+	std_logger "github.com/number571/hidden-lake/internal/utils/logger/std"
+	hls_client "github.com/number571/hidden-lake/pkg/service/client"
+	testutils "github.com/number571/hidden-lake/test/utils"
+)
+
+func TestHandleIntroductionsAPI2(t *testing.T) {
+	t.Parallel()
+
+	httpLogger := std_logger.NewStdLogger(
+		func() std_logger.ILogging {
+			logging, err := std_logger.LoadLogging([]string{})
+			if err != nil {
+				panic(err)
+			}
+			return logging
+		}(),
+		func(_ logger.ILogArg) string {
+			return ""
+		},
+	)
+
+	ctx := context.Background()
+	hlNode := newTsHiddenLakeNode(newTsNode(true, true, true))
+	storage := introductions.NewIntroductions(time.Minute, 16, 4)
+
+	handler := HandleConfigIntroductionsAPI(ctx, newTsWrapper(true), httpLogger, hlNode, storage, newTsMetadata(true))
+
+	id1, _ := storage.Push(tgPrivKey2.GetPubKey(), "Bob", asymmetric.NewPrivKey().GetPubKey())
+	id2, _ := storage.Push(tgPrivKey2.GetPubKey(), "", asymmetric.NewPrivKey().GetPubKey())
+	id3, _ := storage.Push(tgPrivKey2.GetPubKey(), "Carol", asymmetric.NewPrivKey().GetPubKey())
+	id4, _ := storage.Push(tgPrivKey2.GetPubKey(), "Dave", tgPrivKey2.GetPubKey())
+
+	if err := introductionsAPIRequestOK(handler); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := introductionsAPIRequestAcceptOK(handler, id1, "", "Bob"); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := introductionsAPIRequestAcceptOK(handler, id2, "new_name", "new_name"); err != nil {
+		t.Error(err)
+		return
+	}
+
+	introduce := func(aliasNames ...string) []byte {
+		return encoding.SerializeJSON(settings.SIntroduce{FAliasNames: aliasNames})
+	}
+	introduction := func(id, aliasName string) []byte {
+		return encoding.SerializeJSON(settings.SIntroduction{FID: id, FAliasName: aliasName})
+	}
+
+	testCases := []struct {
+		fMethod string
+		fBody   []byte
+		fCode   int
+		fDesc   string
+	}{
+		{http.MethodPatch, nil, http.StatusMethodNotAllowed, "invalid method"},
+		{http.MethodPost, []byte("abc"), http.StatusConflict, "invalid decode (post)"},
+		{http.MethodPut, []byte("abc"), http.StatusConflict, "invalid decode (put)"},
+		{http.MethodPost, introduce("abc"), http.StatusTeapot, "one alias name"},
+		{http.MethodPost, introduce("abc", "abc"), http.StatusTeapot, "same alias names"},
+		{http.MethodPost, introduce("abc", "undefined"), http.StatusNotFound, "undefined friend"},
+		{http.MethodPut, introduction(id1, ""), http.StatusNotFound, "accepted introduction"},
+		{http.MethodPut, introduction(id3, "abc"), http.StatusNotAcceptable, "exist alias name"},
+		{http.MethodPut, introduction(id4, ""), http.StatusNotAcceptable, "exist public key"},
+		{http.MethodDelete, introduction("undefined", ""), http.StatusNotFound, "undefined introduction"},
+		{http.MethodDelete, introduction(id4, ""), http.StatusOK, "reject introduction"},
+		{http.MethodDelete, introduction(id4, ""), http.StatusNotFound, "rejected introduction"},
+	}
+	for _, tc := range testCases {
+		if code := introductionsAPIRequest(handler, tc.fMethod, tc.fBody); code != tc.fCode {
+			t.Errorf("got status code %d with %s", code, tc.fDesc)
+			return
+		}
+	}
+
+	pathCfg := fmt.Sprintf(tcPathConfigTemplate, 14)
+	defer os.Remove(pathCfg)
+
+	handlerc := HandleConfigIntroductionsAPI(ctx, testNewWrapper(pathCfg), httpLogger, hlNode, storage, newTsMetadata(true))
+	if code := introductionsAPIRequest(handlerc, http.MethodPost, introduce("test_recvr", "test_name1")); code != http.StatusOK {
+		t.Error("failed introduce friends")
+		return
+	}
+
+	handlers := HandleConfigIntroductionsAPI(ctx, testNewWrapper(pathCfg), httpLogger, newTsHiddenLakeNode(newTsNode(true, false, true)), storage, newTsMetadata(true))
+	if code := introductionsAPIRequest(handlers, http.MethodPost, introduce("test_recvr", "test_name1")); code != http.StatusInternalServerError {
+		t.Error("request success with invalid send")
+		return
+	}
+
+	handlerx := HandleConfigIntroductionsAPI(ctx, newTsWrapper(false), httpLogger, hlNode, storage, newTsMetadata(true))
+	if err := introductionsAPIRequestAcceptOK(handlerx, id3, "", "Carol"); err == nil {
+		t.Error("request success with invalid update editor")
+		return
+	}
+}
+
+func TestHandleIntroductionsAPI(t *testing.T) {
+	t.Parallel()
+
+	pathCfg := fmt.Sprintf(tcPathConfigTemplate, 13)
+	pathDB := fmt.Sprintf(tcPathDBTemplate, 13)
+
+	_, node, _, cancel, srv := testAllCreate(pathCfg, pathDB, testutils.TgAddrs[25])
+	defer testAllFree(node, cancel, srv, pathCfg, pathDB)
+
+	client := hls_client.NewClient(
+		hls_client.NewBuilder(),
+		hls_client.NewRequester(
+			testutils.TgAddrs[25],
+			&http.Client{Timeout: time.Minute},
+			hls_client.NewSettings(nil),
+		),
+	)
+
+	ctx := context.Background()
+
+	if err := client.Introduce(ctx, "test_recvr", "undefined"); err == nil {
+		t.Error("success introduce undefined friend")
+		return
+	}
+
+	list, err := client.GetIntroductions(ctx)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(list) != 0 {
+		t.Error("got invalid list of introductions")
+		return
+	}
+
+	if _, err := client.AcceptIntroduction(ctx, "undefined", ""); err == nil {
+		t.Error("success accept undefined introduction")
+		return
+	}
+	if err := client.RejectIntroduction(ctx, "undefined"); err == nil {
+		t.Error("success reject undefined introduction")
+		return
+	}
+}
+
+func introductionsAPIRequestOK(handler http.HandlerFunc) error {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	handler(w, req)
+	res := w.Result()
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return errors.New("bad status code") // nolint: err113
+	}
+
+	var list []settings.SIntroduction
+	if err := json.NewDecoder(res.Body).Decode(&list); err != nil {
+		return err
+	}
+	if len(list) != 4 {
+		return errors.New("invalid length of introductions") // nolint: err113
+	}
+	for _, v := range list {
+		if v.FID == "" || v.FIntroducer != "abc" || v.FSafetyNumber == "" {
+			return errors.New("invalid introduction") // nolint: err113
+		}
+	}
+
+	return nil
+}
+
+func introductionsAPIRequestAcceptOK(handler http.HandlerFunc, id, aliasName, expected string) error {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(
+		http.MethodPut,
+		"/",
+		bytes.NewBuffer(encoding.SerializeJSON(settings.SIntroduction{FID: id, FAliasName: aliasName})),
+	)
+
+	handler(w, req)
+	res := w.Result()
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return errors.New("bad status code") // nolint: err113
+	}
+
+	var friend settings.SFriend
+	if err := json.NewDecoder(res.Body).Decode(&friend); err != nil {
+		return err
+	}
+	if friend.FAliasName != expected {
+		return errors.New("invalid alias name") // nolint: err113
+	}
+
+	return nil
+}
+
+func introductionsAPIRequest(handler http.HandlerFunc, method string, body []byte) int {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, "/", bytes.NewBuffer(body))
+
+	handler(w, req)
+	res := w.Result()
+	defer res.Body.Close()
+
+	_, _ = io.ReadAll(res.Body)
+	return res.StatusCode
+}
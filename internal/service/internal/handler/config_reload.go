@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/number571/go-peer/pkg/logger"
+	pkg_settings "github.com/number571/hidden-lake/internal/service/pkg/settings"
+	"github.com/number571/hidden-lake/internal/utils/api"
+	http_logger "github.com/number571/hidden-lake/internal/utils/logger/http"
+)
+
+func HandleConfigReloadAPI(
+	pLogger logger.ILogger,
+	pReloadF func() ([]string, error),
+) http.HandlerFunc {
+	return func(pW http.ResponseWriter, pR *http.Request) {
+		logBuilder := http_logger.NewLogBuilder(pkg_settings.GServiceName.Short(), pR)
+
+		if pR.Method != http.MethodPost {
+			pLogger.PushWarn(logBuilder.WithMessage(http_logger.CLogMethod))
+			_ = api.Response(pW, http.StatusMethodNotAllowed, "failed: incorrect method")
+			return
+		}
+
+		restartRequired, err := pReloadF()
+		if err != nil {
+			pLogger.PushWarn(logBuilder.WithMessage("reload_config"))
+			_ = api.Response(pW, http.StatusInternalServerError, "failed: reload config")
+			return
+		}
+
+		pLogger.PushInfo(logBuilder.WithMessage(http_logger.CLogSuccess))
+		_ = api.Response(pW, http.StatusOK, pkg_settings.SReload{
+			FRestartRequired: restartRequired,
+		})
+	}
+}
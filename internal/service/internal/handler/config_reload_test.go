@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/number571/go-peer/pkg/logger"
+	std_logger "github.com/number571/hidden-lake/internal/utils/logger/std"
+)
+
+func TestHandleConfigReloadAPI(t *testing.T) {
+	t.Parallel()
+
+	httpLogger := std_logger.NewStdLogger(
+		func() std_logger.ILogging {
+			logging, err := std_logger.LoadLogging([]string{})
+			if err != nil {
+				panic(err)
+			}
+			return logging
+		}(),
+		func(_ logger.ILogArg) string {
+			return ""
+		},
+	)
+
+	handler := HandleConfigReloadAPI(httpLogger, newTsWrapper(true).ReloadConfig)
+	if err := reloadAPIRequest(handler, http.MethodPost); err != nil {
+		t.Error(err)
+		return
+	}
+
+	if err := reloadAPIRequest(handler, http.MethodGet); err == nil {
+		t.Error("request success with invalid method")
+		return
+	}
+
+	handlerx := HandleConfigReloadAPI(httpLogger, newTsWrapper(false).ReloadConfig)
+	if err := reloadAPIRequest(handlerx, http.MethodPost); err == nil {
+		t.Error("request success with failed reload")
+		return
+	}
+}
+
+func reloadAPIRequest(handler http.HandlerFunc, pMethod string) error {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(pMethod, "/", nil)
+
+	handler(w, req)
+	res := w.Result()
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return errors.New("bad status code") // nolint: err113
+	}
+
+	return nil
+}
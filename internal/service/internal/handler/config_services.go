@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/number571/go-peer/pkg/logger"
+	"github.com/number571/hidden-lake/internal/service/pkg/app/config"
+	pkg_settings "github.com/number571/hidden-lake/internal/service/pkg/settings"
+	"github.com/number571/hidden-lake/internal/utils/api"
+	http_logger "github.com/number571/hidden-lake/internal/utils/logger/http"
+)
+
+func HandleConfigServicesAPI(
+	pWrapper config.IWrapper,
+	pLogger logger.ILogger,
+) http.HandlerFunc {
+	return func(pW http.ResponseWriter, pR *http.Request) {
+		logBuilder := http_logger.NewLogBuilder(pkg_settings.GServiceName.Short(), pR)
+
+		var vService pkg_settings.SService
+
+		if pR.Method != http.MethodGet && pR.Method != http.MethodPost && pR.Method != http.MethodDelete {
+			pLogger.PushWarn(logBuilder.WithMessage(http_logger.CLogMethod))
+			_ = api.Response(pW, http.StatusMethodNotAllowed, "failed: incorrect method")
+			return
+		}
+
+		if pR.Method == http.MethodGet {
+			services := pWrapper.GetConfig().GetServices()
+
+			listServices := make([]pkg_settings.SService, 0, len(services))
+			for hostName, address := range services {
+				listServices = append(listServices, pkg_settings.SService{
+					FHostName: hostName,
+					FAddress:  address,
+				})
+			}
+
+			pLogger.PushInfo(logBuilder.WithMessage(http_logger.CLogSuccess))
+			_ = api.Response(pW, http.StatusOK, listServices)
+			return
+		}
+
+		if err := json.NewDecoder(pR.Body).Decode(&vService); err != nil {
+			pLogger.PushWarn(logBuilder.WithMessage(http_logger.CLogDecodeBody))
+			_ = api.Response(pW, http.StatusConflict, "failed: decode request")
+			return
+		}
+
+		hostName := strings.TrimSpace(vService.FHostName)
+		if hostName == "" {
+			pLogger.PushWarn(logBuilder.WithMessage("get_host_name"))
+			_ = api.Response(pW, http.StatusTeapot, "failed: load host name")
+			return
+		}
+
+		services := pWrapper.GetConfig().GetServices()
+
+		switch pR.Method {
+		case http.MethodPost:
+			if _, ok := services[hostName]; ok {
+				pLogger.PushWarn(logBuilder.WithMessage("get_services"))
+				_ = api.Response(pW, http.StatusNotAcceptable, "failed: service already exist")
+				return
+			}
+
+			address := strings.TrimSpace(vService.FAddress)
+			if address == "" {
+				pLogger.PushWarn(logBuilder.WithMessage("get_address"))
+				_ = api.Response(pW, http.StatusBadRequest, "failed: load address")
+				return
+			}
+
+			services[hostName] = address
+			if err := pWrapper.GetEditor().UpdateServices(services); err != nil {
+				pLogger.PushWarn(logBuilder.WithMessage("update_services"))
+				_ = api.Response(pW, http.StatusInternalServerError, "failed: update services")
+				return
+			}
+
+			pLogger.PushInfo(logBuilder.WithMessage(http_logger.CLogSuccess))
+			_ = api.Response(pW, http.StatusOK, "success: update services")
+			return
+
+		case http.MethodDelete:
+			if _, ok := services[hostName]; !ok {
+				pLogger.PushWarn(logBuilder.WithMessage("get_services"))
+				_ = api.Response(pW, http.StatusNotFound, "failed: service does not exist")
+				return
+			}
+
+			delete(services, hostName)
+
+			if err := pWrapper.GetEditor().UpdateServices(services); err != nil {
+				pLogger.PushWarn(logBuilder.WithMessage("update_services"))
+				_ = api.Response(pW, http.StatusInternalServerError, "failed: delete service")
+				return
+			}
+
+			pLogger.PushInfo(logBuilder.WithMessage(http_logger.CLogSuccess))
+			_ = api.Response(pW, http.StatusOK, "success: delete service")
+			return
+		}
+	}
+}
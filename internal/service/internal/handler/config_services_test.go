@@ -0,0 +1,219 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/number571/go-peer/pkg/encoding"
+	"github.com/number571/go-peer/pkg/logger"
+	"github.com/number571/hidden-lake/internal/service/pkg/app/config"
+	"github.com/number571/hidden-lake/internal/service/pkg/settings"
+	std_logger "github.com/number571/hidden-lake/internal/utils/logger/std"
+	hls_client "github.com/number571/hidden-lake/pkg/service/client"
+	testutils "github.com/number571/hidden-lake/test/utils"
+)
+
+func TestHandleServicesAPI2(t *testing.T) {
+	t.Parallel()
+
+	httpLogger := std_logger.NewStdLogger(
+		func() std_logger.ILogging {
+			logging, err := std_logger.LoadLogging([]string{})
+			if err != nil {
+				panic(err)
+			}
+			return logging
+		}(),
+		func(_ logger.ILogArg) string {
+			return ""
+		},
+	)
+
+	handler := HandleConfigServicesAPI(newTsWrapper(true), httpLogger)
+	if err := configAPIRequest(handler, http.MethodGet, nil); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := configAPIRequest(handler, http.MethodPost, &settings.SService{
+		FHostName: "new_service",
+		FAddress:  "localhost:8080",
+	}); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := configAPIRequest(handler, http.MethodDelete, &settings.SService{
+		FHostName: "hidden-some-host-ok",
+	}); err != nil {
+		t.Error(err)
+		return
+	}
+
+	if err := configAPIRequest(handler, http.MethodDelete, &settings.SService{
+		FHostName: "notfound",
+	}); err == nil {
+		t.Error("request success with not found host_name")
+		return
+	}
+	if err := configAPIRequest(handler, http.MethodPost, &settings.SService{
+		FHostName: "new_service",
+	}); err == nil {
+		t.Error("request success with invalid address")
+		return
+	}
+	if err := configAPIRequest(handler, http.MethodPost, &settings.SService{
+		FHostName: "hidden-some-host-ok",
+		FAddress:  "localhost:8080",
+	}); err == nil {
+		t.Error("request success with exist host_name")
+		return
+	}
+	if err := configAPIRequest(handler, http.MethodPost, &settings.SService{
+		FAddress: "localhost:8080",
+	}); err == nil {
+		t.Error("request success with invalid host_name")
+		return
+	}
+	if err := configAPIRequest(handler, http.MethodPost, []byte{1}); err == nil {
+		t.Error("request success with invalid decode")
+		return
+	}
+	if err := configAPIRequest(handler, http.MethodPut, nil); err == nil {
+		t.Error("request success with invalid method")
+		return
+	}
+
+	handlerx := HandleConfigServicesAPI(newTsWrapper(false), httpLogger)
+	if err := configAPIRequest(handlerx, http.MethodPost, &settings.SService{
+		FHostName: "new_service",
+		FAddress:  "localhost:8080",
+	}); err == nil {
+		t.Error("request success with invalid update editor (post)")
+		return
+	}
+	if err := configAPIRequest(handlerx, http.MethodDelete, &settings.SService{
+		FHostName: "hidden-some-host-ok",
+	}); err == nil {
+		t.Error("request success with invalid update editor (delete)")
+		return
+	}
+}
+
+func configAPIRequest(handler http.HandlerFunc, pMethod string, pBody interface{}) error {
+	var body io.Reader
+	switch x := pBody.(type) {
+	case nil:
+	case []byte:
+		body = bytes.NewBuffer(x)
+	default:
+		body = bytes.NewBuffer(encoding.SerializeJSON(x))
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(pMethod, "/", body)
+
+	handler(w, req)
+	res := w.Result()
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return errors.New("bad status code") // nolint: err113
+	}
+
+	if _, err := io.ReadAll(res.Body); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func TestHandleServicesAPI(t *testing.T) {
+	t.Parallel()
+
+	pathCfg := fmt.Sprintf(tcPathConfigTemplate, 10)
+	pathDB := fmt.Sprintf(tcPathDBTemplate, 10)
+
+	wcfg, node, _, cancel, srv := testAllCreate(pathCfg, pathDB, testutils.TgAddrs[21])
+	defer testAllFree(node, cancel, srv, pathCfg, pathDB)
+
+	client := hls_client.NewClient(
+		hls_client.NewBuilder(),
+		hls_client.NewRequester(
+			testutils.TgAddrs[21],
+			&http.Client{Timeout: time.Minute},
+			hls_client.NewSettings(nil),
+		),
+	)
+
+	hostName := "test_service4"
+	testGetServices(t, client, wcfg.GetConfig())
+	testAddService(t, client, hostName)
+	testDelService(t, client, hostName)
+}
+
+func testGetServices(t *testing.T, client hls_client.IClient, cfg config.IConfig) {
+	services, err := client.GetServices(context.Background())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(services) != 3 {
+		t.Error("length of services != 3")
+		return
+	}
+
+	for k, v := range services {
+		v1, ok := cfg.GetService(k)
+		if !ok {
+			t.Errorf("undefined service '%s'", k)
+			return
+		}
+		if v != v1 {
+			t.Errorf("addresses not equals for '%s'", k)
+			return
+		}
+	}
+}
+
+func testAddService(t *testing.T, client hls_client.IClient, hostName string) {
+	if err := client.AddService(context.Background(), hostName, "test_address4"); err != nil {
+		t.Error(err)
+		return
+	}
+
+	services, err := client.GetServices(context.Background())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if _, ok := services[hostName]; !ok {
+		t.Errorf("undefined new service by '%s'", hostName)
+		return
+	}
+}
+
+func testDelService(t *testing.T, client hls_client.IClient, hostName string) {
+	if err := client.DelService(context.Background(), hostName); err != nil {
+		t.Error(err)
+		return
+	}
+
+	services, err := client.GetServices(context.Background())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if _, ok := services[hostName]; ok {
+		t.Errorf("deleted service exists for '%s'", hostName)
+		return
+	}
+}
@@ -11,8 +11,8 @@ import (
 	"time"
 
 	"github.com/number571/go-peer/pkg/logger"
-	hls_client "github.com/number571/hidden-lake/internal/service/pkg/client"
 	std_logger "github.com/number571/hidden-lake/internal/utils/logger/std"
+	hls_client "github.com/number571/hidden-lake/pkg/service/client"
 	testutils "github.com/number571/hidden-lake/test/utils"
 )
 
@@ -97,6 +97,7 @@ func TestHandleConfigSettingsAPI(t *testing.T) {
 		hls_client.NewRequester(
 			addr,
 			&http.Client{Timeout: time.Minute},
+			hls_client.NewSettings(nil),
 		),
 	)
 
@@ -120,4 +121,15 @@ func TestHandleConfigSettingsAPI(t *testing.T) {
 		t.Error("invalid work size")
 		return
 	}
+
+	restartRequired, err := client.ReloadConfig(context.Background())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(restartRequired) != 0 {
+		t.Error("restart required for unchanged config")
+		return
+	}
 }
@@ -16,6 +16,10 @@ var (
 	ErrBadRequest          = &SHandlerError{"bad request"}
 	ErrBuildRequest        = &SHandlerError{"build request"}
 	ErrUndefinedService    = &SHandlerError{"undefined service"}
+	ErrAccessDenied        = &SHandlerError{"access denied"}
+	ErrLimitExceeded       = &SHandlerError{"limit exceeded"}
 	ErrLoadRequest         = &SHandlerError{"load request"}
 	ErrInvalidResponseMode = &SHandlerError{"invalid response mode"}
+	ErrDecodeIntroduction  = &SHandlerError{"decode introduction"}
+	ErrInvalidExpires      = &SHandlerError{"invalid expires"}
 )
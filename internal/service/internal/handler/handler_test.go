@@ -25,6 +25,9 @@ import (
 	"github.com/number571/go-peer/pkg/storage/cache"
 	"github.com/number571/go-peer/pkg/storage/database"
 	"github.com/number571/hidden-lake/build"
+	"github.com/number571/hidden-lake/internal/service/internal/introductions"
+	"github.com/number571/hidden-lake/internal/service/internal/metadata"
+	"github.com/number571/hidden-lake/internal/service/internal/tickets"
 	"github.com/number571/hidden-lake/internal/service/pkg/app/config"
 	pkg_settings "github.com/number571/hidden-lake/internal/service/pkg/settings"
 	"github.com/number571/hidden-lake/internal/utils/closer"
@@ -164,15 +167,26 @@ func testRunService(ctx context.Context, wcfg config.IWrapper, node anonymity.IN
 		hla_http_client.NewClient(&tsRequester{}),
 	}
 
-	cfg := wcfg.GetConfig()
 	hlNode := &tsHiddenLakeNode{node}
+	friendsMeta := metadata.NewMetadata(node.GetKVDatabase(), time.Minute)
 
 	mux.HandleFunc(pkg_settings.CHandleIndexPath, HandleIndexAPI(logger))
 	mux.HandleFunc(pkg_settings.CHandleConfigSettingsPath, HandleConfigSettingsAPI(wcfg, logger, node))
-	mux.HandleFunc(pkg_settings.CHandleConfigConnectsPath, HandleConfigConnectsAPI(ctx, logger, epClients))
-	mux.HandleFunc(pkg_settings.CHandleConfigFriendsPath, HandleConfigFriendsAPI(wcfg, logger, node))
-	mux.HandleFunc(pkg_settings.CHandleNetworkOnlinePath, HandleNetworkOnlineAPI(ctx, logger, epClients))
-	mux.HandleFunc(pkg_settings.CHandleNetworkRequestPath, HandleNetworkRequestAPI(ctx, cfg, logger, hlNode))
+	mux.HandleFunc(pkg_settings.CHandleConfigConnectsPath, HandleConfigConnectsAPI(ctx, logger, func() []hla_http_client.IClient { return epClients }))
+	mux.HandleFunc(pkg_settings.CHandleConfigFriendsPath, HandleConfigFriendsAPI(wcfg, logger, node, friendsMeta))
+	mux.HandleFunc(pkg_settings.CHandleConfigAccessPath, HandleConfigAccessAPI(wcfg, logger))
+	mux.HandleFunc(pkg_settings.CHandleConfigServicesPath, HandleConfigServicesAPI(wcfg, logger))
+	mux.HandleFunc(pkg_settings.CHandleConfigContactPath, HandleConfigContactAPI(wcfg, logger, node, friendsMeta))
+	mux.HandleFunc(pkg_settings.CHandleConfigContactNewPath, HandleConfigContactNewAPI(wcfg, logger, node))
+	mux.HandleFunc(pkg_settings.CHandleConfigIntroductionsPath, HandleConfigIntroductionsAPI(ctx, wcfg, logger, hlNode, introductions.NewIntroductions(time.Minute, 16, 4), friendsMeta))
+	mux.HandleFunc(pkg_settings.CHandleConfigGroupsPath, HandleConfigGroupsAPI(wcfg, logger))
+	mux.HandleFunc(pkg_settings.CHandleConfigReloadPath, HandleConfigReloadAPI(logger, wcfg.ReloadConfig))
+	mux.HandleFunc(pkg_settings.CHandleNetworkOnlinePath, HandleNetworkOnlineAPI(ctx, logger, func() []hla_http_client.IClient { return epClients }))
+	fetchTickets := tickets.NewTickets(time.Minute, 16)
+
+	mux.HandleFunc(pkg_settings.CHandleNetworkRequestPath, HandleNetworkRequestAPI(ctx, wcfg, logger, hlNode, fetchTickets))
+	mux.HandleFunc(pkg_settings.CHandleNetworkMulticastPath, HandleNetworkMulticastAPI(ctx, wcfg, logger, hlNode))
+	mux.HandleFunc(pkg_settings.CHandleNetworkTicketPath, HandleNetworkTicketAPI(logger, fetchTickets))
 	mux.HandleFunc(pkg_settings.CHandleServicePubKeyPath, HandleServicePubKeyAPI(logger, node))
 
 	srv := &http.Server{
@@ -253,6 +267,7 @@ func testNewNode(dbPath, addr string) anonymity.INode {
 
 type tsWrapper struct {
 	fEditorOK bool
+	fConfig   config.IConfig
 }
 
 func newTsWrapper(pEditorOK bool) *tsWrapper {
@@ -261,8 +276,19 @@ func newTsWrapper(pEditorOK bool) *tsWrapper {
 	}
 }
 
-func (p *tsWrapper) GetConfig() config.IConfig { return &tsConfig{} }
+func (p *tsWrapper) GetConfig() config.IConfig {
+	if p.fConfig != nil {
+		return p.fConfig
+	}
+	return &tsConfig{}
+}
 func (p *tsWrapper) GetEditor() config.IEditor { return &tsEditor{p.fEditorOK} }
+func (p *tsWrapper) ReloadConfig() ([]string, error) {
+	if !p.fEditorOK {
+		return nil, errors.New("some error") // nolint: err113
+	}
+	return []string{}, nil
+}
 
 type tsEditor struct {
 	fEditorOK bool
@@ -280,9 +306,74 @@ func (p *tsEditor) UpdateFriends(map[string]asymmetric.IPubKey) error {
 	}
 	return nil
 }
+func (p *tsEditor) UpdateAccess(map[string]config.IAccess) error {
+	if !p.fEditorOK {
+		return errors.New("some error") // nolint: err113
+	}
+	return nil
+}
+func (p *tsEditor) UpdateExpires(map[string]time.Time) error {
+	if !p.fEditorOK {
+		return errors.New("some error") // nolint: err113
+	}
+	return nil
+}
+func (p *tsEditor) DeleteExpiredFriends(time.Time) (map[string]asymmetric.IPubKey, error) {
+	if !p.fEditorOK {
+		return nil, errors.New("some error") // nolint: err113
+	}
+	return nil, nil
+}
+func (p *tsEditor) UpdateGroups(map[string][]string) error {
+	if !p.fEditorOK {
+		return errors.New("some error") // nolint: err113
+	}
+	return nil
+}
+func (p *tsEditor) UpdateServices(map[string]string) error {
+	if !p.fEditorOK {
+		return errors.New("some error") // nolint: err113
+	}
+	return nil
+}
+
+type tsMetadata struct {
+	fMetadataOK bool
+}
+
+type tsFriendMeta struct{}
+
+func newTsMetadata(pMetadataOK bool) *tsMetadata {
+	return &tsMetadata{fMetadataOK: pMetadataOK}
+}
+
+func (p *tsMetadata) Get(asymmetric.IPubKey) (metadata.IFriendMeta, error) {
+	if !p.fMetadataOK {
+		return nil, errors.New("some error") // nolint: err113
+	}
+	return &tsFriendMeta{}, nil
+}
+func (p *tsMetadata) Delete(asymmetric.IPubKey) error { return p.getError() }
+func (p *tsMetadata) SetInfo(asymmetric.IPubKey, string, []string) error {
+	return p.getError()
+}
+func (p *tsMetadata) SetAdded(asymmetric.IPubKey) error    { return p.getError() }
+func (p *tsMetadata) SetLastSeen(asymmetric.IPubKey) error { return p.getError() }
+func (p *tsMetadata) getError() error {
+	if !p.fMetadataOK {
+		return errors.New("some error") // nolint: err113
+	}
+	return nil
+}
+
+func (p *tsFriendMeta) GetNotes() string       { return "notes" }
+func (p *tsFriendMeta) GetTags() []string      { return []string{"tag"} }
+func (p *tsFriendMeta) GetAdded() time.Time    { return time.Now() }
+func (p *tsFriendMeta) GetLastSeen() time.Time { return time.Time{} }
 
 type tsConfig struct {
 	fServiceAddr string
+	fTokens      map[string]config.IToken
 }
 
 func (p *tsConfig) GetSettings() config.IConfigSettings {
@@ -296,9 +387,19 @@ func (p *tsConfig) GetFriends() map[string]asymmetric.IPubKey {
 		"abc": tgPrivKey2.GetPubKey(),
 	}
 }
+func (p *tsConfig) GetExpires() map[string]time.Time {
+	return map[string]time.Time{
+		"abc": time.Now().Add(time.Hour),
+	}
+}
+func (p *tsConfig) GetGroups() map[string][]string {
+	return map[string][]string{
+		"group": {"abc", "notfound"},
+	}
+}
 func (p *tsConfig) GetEndpoints() []string { return nil }
 func (p *tsConfig) GetService(s string) (string, bool) {
-	if s == "hidden-some-host-ok" {
+	if s == "hidden-some-host-ok" || s == "hidden-some-host-denied" {
 		return p.fServiceAddr, true
 	}
 	if s == "hidden-some-host-failed" {
@@ -306,6 +407,32 @@ func (p *tsConfig) GetService(s string) (string, bool) {
 	}
 	return "", false
 }
+func (p *tsConfig) GetAccess() map[string]config.IAccess {
+	return map[string]config.IAccess{
+		"hidden-some-host-ok":     &config.SAccess{FAllow: []string{"abc"}},
+		"hidden-some-host-denied": &config.SAccess{FDeny: []string{"abc"}},
+	}
+}
+func (p *tsConfig) GetLimits() map[string]config.ILimit {
+	return map[string]config.ILimit{
+		"hidden-some-host-limited": &config.SLimit{FRequestsPerMinute: 1},
+	}
+}
+func (p *tsConfig) GetTokens() map[string]config.IToken {
+	return p.fTokens
+}
+func (p *tsConfig) GetIdentities() []string {
+	return nil
+}
+func (p *tsConfig) GetIntroductionsEnabled() bool {
+	return true
+}
+func (p *tsConfig) GetServices() map[string]string {
+	return map[string]string{
+		"hidden-some-host-ok":     p.fServiceAddr,
+		"hidden-some-host-failed": "localhost:99999",
+	}
+}
 
 var (
 	_ hiddenlake_network.IHiddenLakeNode = &tsHLNode{}
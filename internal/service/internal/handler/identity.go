@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/number571/go-peer/pkg/logger"
+	pkg_settings "github.com/number571/hidden-lake/internal/service/pkg/settings"
+	"github.com/number571/hidden-lake/internal/utils/api"
+	http_logger "github.com/number571/hidden-lake/internal/utils/logger/http"
+)
+
+// HandleIdentity passes the request to the API of identity selected by the
+// header. If the header is not set, then the default identity is used.
+func HandleIdentity(
+	pLogger logger.ILogger,
+	pHandlers map[string]http.Handler,
+) http.HandlerFunc {
+	return func(pW http.ResponseWriter, pR *http.Request) {
+		identity := pR.Header.Get(pkg_settings.CHeaderIdentity)
+		if identity == "" {
+			identity = pkg_settings.CDefaultIdentity
+		}
+
+		handler, ok := pHandlers[identity]
+		if !ok {
+			logBuilder := http_logger.NewLogBuilder(pkg_settings.GServiceName.Short(), pR)
+			pLogger.PushWarn(logBuilder.WithMessage("get_identity"))
+			_ = api.Response(pW, http.StatusNotFound, "failed: identity not found")
+			return
+		}
+
+		handler.ServeHTTP(pW, pR)
+	}
+}
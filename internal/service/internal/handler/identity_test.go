@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/number571/go-peer/pkg/logger"
+	pkg_settings "github.com/number571/hidden-lake/internal/service/pkg/settings"
+)
+
+func TestHandleIdentity(t *testing.T) {
+	t.Parallel()
+
+	logger := logger.NewLogger(
+		logger.NewSettings(&logger.SSettings{}),
+		func(_ logger.ILogArg) string { return "" },
+	)
+
+	newHandler := func(pName string) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(pName))
+		})
+	}
+
+	handler := HandleIdentity(logger, map[string]http.Handler{
+		pkg_settings.CDefaultIdentity: newHandler(pkg_settings.CDefaultIdentity),
+		"work":                        newHandler("work"),
+	})
+
+	testCases := []struct {
+		fIdentity string
+		fCode     int
+		fResult   string
+	}{
+		{"", http.StatusOK, pkg_settings.CDefaultIdentity},
+		{pkg_settings.CDefaultIdentity, http.StatusOK, pkg_settings.CDefaultIdentity},
+		{"work", http.StatusOK, "work"},
+		{"undefined", http.StatusNotFound, ""},
+	}
+
+	for i, tc := range testCases {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, pkg_settings.CHandleConfigFriendsPath, nil)
+		if tc.fIdentity != "" {
+			req.Header.Set(pkg_settings.CHeaderIdentity, tc.fIdentity)
+		}
+
+		handler(w, req)
+		res := w.Result()
+		body, _ := io.ReadAll(res.Body)
+		res.Body.Close()
+
+		if res.StatusCode != tc.fCode {
+			t.Errorf("got invalid status code (%d)", i)
+			return
+		}
+		if tc.fCode == http.StatusOK && string(body) != tc.fResult {
+			t.Errorf("got invalid identity (%d)", i)
+			return
+		}
+	}
+}
@@ -11,10 +11,10 @@ import (
 	"time"
 
 	"github.com/number571/go-peer/pkg/logger"
-	hls_client "github.com/number571/hidden-lake/internal/service/pkg/client"
 	pkg_settings "github.com/number571/hidden-lake/internal/service/pkg/settings"
 	std_logger "github.com/number571/hidden-lake/internal/utils/logger/std"
 	"github.com/number571/hidden-lake/pkg/request"
+	hls_client "github.com/number571/hidden-lake/pkg/service/client"
 	testutils "github.com/number571/hidden-lake/test/utils"
 )
 
@@ -26,6 +26,7 @@ func TestErrorsAPI(t *testing.T) {
 		hls_client.NewRequester(
 			testutils.TcUnknownHost,
 			&http.Client{Timeout: time.Second},
+			hls_client.NewSettings(nil),
 		),
 	)
 
@@ -89,6 +90,41 @@ func TestErrorsAPI(t *testing.T) {
 		return
 	}
 
+	if _, err := client.GetServices(context.Background()); err == nil {
+		t.Error("success get services with unknown host")
+		return
+	}
+
+	if err := client.AddService(context.Background(), "", ""); err == nil {
+		t.Error("success add service with unknown host")
+		return
+	}
+
+	if err := client.DelService(context.Background(), ""); err == nil {
+		t.Error("success del service with unknown host")
+		return
+	}
+
+	if _, err := client.GetAccess(context.Background()); err == nil {
+		t.Error("success get access with unknown host")
+		return
+	}
+
+	if err := client.SetAccess(context.Background(), "", nil, nil); err == nil {
+		t.Error("success set access with unknown host")
+		return
+	}
+
+	if err := client.DelAccess(context.Background(), ""); err == nil {
+		t.Error("success del access with unknown host")
+		return
+	}
+
+	if _, err := client.ReloadConfig(context.Background()); err == nil {
+		t.Error("success reload config with unknown host")
+		return
+	}
+
 	if err := client.DelOnline(context.Background(), "test"); err == nil {
 		t.Error("success del online key with unknown host")
 		return
@@ -152,6 +188,7 @@ func TestHandleIndexAPI(t *testing.T) {
 		hls_client.NewRequester(
 			addr,
 			&http.Client{Timeout: time.Minute},
+			hls_client.NewSettings(nil),
 		),
 	)
 
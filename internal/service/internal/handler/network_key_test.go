@@ -7,7 +7,7 @@ import (
 	"testing"
 	"time"
 
-	hls_client "github.com/number571/hidden-lake/internal/service/pkg/client"
+	hls_client "github.com/number571/hidden-lake/pkg/service/client"
 	testutils "github.com/number571/hidden-lake/test/utils"
 )
 
@@ -25,6 +25,7 @@ func TestHandleNetworkKeyAPI(t *testing.T) {
 		hls_client.NewRequester(
 			testutils.TgAddrs[14],
 			&http.Client{Timeout: time.Minute},
+			hls_client.NewSettings(nil),
 		),
 	)
 
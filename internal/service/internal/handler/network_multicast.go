@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/number571/go-peer/pkg/crypto/asymmetric"
+	"github.com/number571/go-peer/pkg/logger"
+	"github.com/number571/hidden-lake/internal/service/pkg/app/config"
+	pkg_settings "github.com/number571/hidden-lake/internal/service/pkg/settings"
+	"github.com/number571/hidden-lake/internal/utils/api"
+	http_logger "github.com/number571/hidden-lake/internal/utils/logger/http"
+	"github.com/number571/hidden-lake/pkg/network"
+	"github.com/number571/hidden-lake/pkg/request"
+)
+
+// HandleNetworkMulticastAPI sends the request to each member of group and
+// to each receiver by the SendRequest of node. So every copy is a separate
+// encrypted message in the queue and is indistinguishable from the others.
+func HandleNetworkMulticastAPI(
+	pCtx context.Context,
+	pWrapper config.IWrapper,
+	pLogger logger.ILogger,
+	pNode network.IHiddenLakeNode,
+) http.HandlerFunc {
+	return func(pW http.ResponseWriter, pR *http.Request) {
+		logBuilder := http_logger.NewLogBuilder(pkg_settings.GServiceName.Short(), pR)
+
+		var vMulticast pkg_settings.SMulticast
+
+		if pR.Method != http.MethodPost {
+			pLogger.PushWarn(logBuilder.WithMessage(http_logger.CLogMethod))
+			_ = api.Response(pW, http.StatusMethodNotAllowed, "failed: incorrect method")
+			return
+		}
+
+		if err := json.NewDecoder(pR.Body).Decode(&vMulticast); err != nil {
+			pLogger.PushWarn(logBuilder.WithMessage(http_logger.CLogDecodeBody))
+			_ = api.Response(pW, http.StatusConflict, "failed: decode request")
+			return
+		}
+
+		req := vMulticast.FReqData
+		if req == nil {
+			pLogger.PushWarn(logBuilder.WithMessage("decode_data"))
+			_ = api.Response(pW, http.StatusTeapot, "failed: decode hex format data")
+			return
+		}
+
+		cfg := pWrapper.GetConfig()
+
+		receivers := vMulticast.FReceivers
+		if vMulticast.FGroup != "" {
+			members, ok := cfg.GetGroups()[vMulticast.FGroup]
+			if !ok {
+				pLogger.PushWarn(logBuilder.WithMessage("get_groups"))
+				_ = api.Response(pW, http.StatusNotFound, "failed: group does not exist")
+				return
+			}
+			receivers = append(members, receivers...)
+		}
+
+		receivers = trimAliasNames(receivers)
+		if len(receivers) == 0 {
+			pLogger.PushWarn(logBuilder.WithMessage("get_receivers"))
+			_ = api.Response(pW, http.StatusBadRequest, "failed: load receivers")
+			return
+		}
+
+		friends := cfg.GetFriends()
+		results := make([]pkg_settings.SMulticastResult, 0, len(receivers))
+		for _, aliasName := range receivers {
+			results = append(results, pkg_settings.SMulticastResult{
+				FReceiver: aliasName,
+				FError:    sendToFriend(pCtx, pNode, friends[aliasName], req),
+			})
+		}
+
+		pLogger.PushInfo(logBuilder.WithMessage(http_logger.CLogSuccess))
+		_ = api.Response(pW, http.StatusOK, results)
+	}
+}
+
+// sendToFriend returns the error message for result of multicast.
+func sendToFriend(
+	pCtx context.Context,
+	pNode network.IHiddenLakeNode,
+	pPubKey asymmetric.IPubKey,
+	pRequest request.IRequest,
+) string {
+	if pPubKey == nil {
+		return "friend not found"
+	}
+	if err := pNode.SendRequest(pCtx, pPubKey, pRequest); err != nil {
+		return "send payload"
+	}
+	return ""
+}
@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/number571/go-peer/pkg/encoding"
+	"github.com/number571/go-peer/pkg/logger"
+	pkg_settings "github.com/number571/hidden-lake/internal/service/pkg/settings"
+	std_logger "github.com/number571/hidden-lake/internal/utils/logger/std"
+	"github.com/number571/hidden-lake/pkg/request"
+)
+
+func TestHandleMulticastAPI(t *testing.T) {
+	t.Parallel()
+
+	httpLogger := std_logger.NewStdLogger(
+		func() std_logger.ILogging {
+			logging, err := std_logger.LoadLogging([]string{})
+			if err != nil {
+				panic(err)
+			}
+			return logging
+		}(),
+		func(_ logger.ILogArg) string {
+			return ""
+		},
+	)
+
+	ctx := context.Background()
+
+	handler := HandleNetworkMulticastAPI(ctx, newTsWrapper(true), httpLogger, newTsHiddenLakeNode(newTsNode(true, true, true)))
+	results, err := multicastAPIRequest(handler, http.MethodPost, &pkg_settings.SMulticast{
+		FGroup:     "group",
+		FReceivers: []string{"abc", " other "},
+		FReqData:   &request.SRequest{},
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(results) != 3 {
+		t.Error("invalid count of results")
+		return
+	}
+	expected := []pkg_settings.SMulticastResult{
+		{FReceiver: "abc"},
+		{FReceiver: "notfound", FError: "friend not found"},
+		{FReceiver: "other", FError: "friend not found"},
+	}
+	for i, r := range results {
+		if r != expected[i] {
+			t.Errorf("invalid result (%d)", i)
+			return
+		}
+	}
+
+	if _, err := multicastAPIRequest(handler, http.MethodPost, &pkg_settings.SMulticast{
+		FGroup:   "undefined",
+		FReqData: &request.SRequest{},
+	}); err == nil {
+		t.Error("request success with undefined group")
+		return
+	}
+	if _, err := multicastAPIRequest(handler, http.MethodPost, &pkg_settings.SMulticast{
+		FReceivers: []string{" "},
+		FReqData:   &request.SRequest{},
+	}); err == nil {
+		t.Error("request success without receivers")
+		return
+	}
+	if _, err := multicastAPIRequest(handler, http.MethodPost, &pkg_settings.SMulticast{
+		FReceivers: []string{"abc"},
+	}); err == nil {
+		t.Error("request success with invalid reqData")
+		return
+	}
+	if _, err := multicastAPIRequest(handler, http.MethodPost, nil); err == nil {
+		t.Error("request success with invalid decode")
+		return
+	}
+	if _, err := multicastAPIRequest(handler, http.MethodGet, nil); err == nil {
+		t.Error("request success with invalid method")
+		return
+	}
+
+	handlerx := HandleNetworkMulticastAPI(ctx, newTsWrapper(true), httpLogger, newTsHiddenLakeNode(newTsNode(false, false, true)))
+	results, err = multicastAPIRequest(handlerx, http.MethodPost, &pkg_settings.SMulticast{
+		FReceivers: []string{"abc"},
+		FReqData:   &request.SRequest{},
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(results) != 1 || results[0].FError != "send payload" {
+		t.Error("success send with error of node")
+		return
+	}
+}
+
+func multicastAPIRequest(
+	handler http.HandlerFunc,
+	method string,
+	multicast *pkg_settings.SMulticast,
+) ([]pkg_settings.SMulticastResult, error) {
+	body := []byte{1}
+	if multicast != nil {
+		body = encoding.SerializeJSON(multicast)
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, "/", bytes.NewBuffer(body))
+
+	handler(w, req)
+	res := w.Result()
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.New("bad status code") // nolint: err113
+	}
+
+	var results []pkg_settings.SMulticastResult
+	if err := json.NewDecoder(res.Body).Decode(&results); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
@@ -16,7 +16,7 @@ import (
 func HandleNetworkOnlineAPI(
 	pCtx context.Context,
 	pLogger logger.ILogger,
-	pEPClients []client.IClient,
+	pEPClients func() []client.IClient,
 ) http.HandlerFunc {
 	return func(pW http.ResponseWriter, pR *http.Request) {
 		logBuilder := http_logger.NewLogBuilder(pkg_settings.GServiceName.Short(), pR)
@@ -30,7 +30,7 @@ func HandleNetworkOnlineAPI(
 		switch pR.Method {
 		case http.MethodGet:
 			inOnline := make([]string, 0, 128)
-			for _, client := range pEPClients {
+			for _, client := range pEPClients() {
 				gotConns, err := client.GetOnlines(pCtx)
 				if err != nil {
 					pLogger.PushWarn(logBuilder.WithMessage("get_connections"))
@@ -54,7 +54,7 @@ func HandleNetworkOnlineAPI(
 				return
 			}
 
-			for _, client := range pEPClients {
+			for _, client := range pEPClients() {
 				if err := client.DelOnline(pCtx, string(connectBytes)); err != nil {
 					pLogger.PushWarn(logBuilder.WithMessage("del_connection"))
 					_ = api.Response(pW, http.StatusMethodNotAllowed, "failed: delete online connections")
@@ -12,9 +12,9 @@ import (
 	"time"
 
 	"github.com/number571/go-peer/pkg/logger"
-	hls_client "github.com/number571/hidden-lake/internal/service/pkg/client"
 	std_logger "github.com/number571/hidden-lake/internal/utils/logger/std"
 	"github.com/number571/hidden-lake/pkg/adapters/http/client"
+	hls_client "github.com/number571/hidden-lake/pkg/service/client"
 	testutils "github.com/number571/hidden-lake/test/utils"
 )
 
@@ -40,7 +40,7 @@ func TestHandleOnlineAPI2(t *testing.T) {
 		client.NewClient(&tsRequester{}),
 	}
 
-	handler := HandleNetworkOnlineAPI(ctx, httpLogger, epClients)
+	handler := HandleNetworkOnlineAPI(ctx, httpLogger, func() []client.IClient { return epClients })
 	if err := onlineAPIRequestOK(handler); err != nil {
 		t.Error(err)
 		return
@@ -59,7 +59,7 @@ func TestHandleOnlineAPI2(t *testing.T) {
 		client.NewClient(&tsRequester{fWithFail: true}),
 	}
 
-	handlerx := HandleNetworkOnlineAPI(ctx, httpLogger, epClientsx)
+	handlerx := HandleNetworkOnlineAPI(ctx, httpLogger, func() []client.IClient { return epClientsx })
 	if err := onlineAPIRequestOK(handlerx); err == nil {
 		t.Error("request success with get error")
 		return
@@ -141,6 +141,7 @@ func TestHandleOnlineAPI(t *testing.T) {
 		hls_client.NewRequester(
 			testutils.TgAddrs[12],
 			&http.Client{Timeout: time.Minute},
+			hls_client.NewSettings(nil),
 		),
 	)
 
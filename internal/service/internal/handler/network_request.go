@@ -7,12 +7,14 @@ import (
 
 	"github.com/number571/go-peer/pkg/crypto/asymmetric"
 	"github.com/number571/go-peer/pkg/logger"
+	"github.com/number571/hidden-lake/internal/service/internal/tickets"
 	"github.com/number571/hidden-lake/internal/service/pkg/app/config"
 	pkg_settings "github.com/number571/hidden-lake/internal/service/pkg/settings"
 	"github.com/number571/hidden-lake/internal/utils/api"
 	http_logger "github.com/number571/hidden-lake/internal/utils/logger/http"
 	"github.com/number571/hidden-lake/pkg/network"
 	"github.com/number571/hidden-lake/pkg/request"
+	"github.com/number571/hidden-lake/pkg/response"
 )
 
 const (
@@ -23,9 +25,10 @@ const (
 
 func HandleNetworkRequestAPI(
 	pCtx context.Context,
-	pConfig config.IConfig,
+	pWrapper config.IWrapper,
 	pLogger logger.ILogger,
 	pNode network.IHiddenLakeNode,
+	pTickets tickets.ITickets,
 ) http.HandlerFunc {
 	return func(pW http.ResponseWriter, pR *http.Request) {
 		logBuilder := http_logger.NewLogBuilder(pkg_settings.GServiceName.Short(), pR)
@@ -44,7 +47,7 @@ func HandleNetworkRequestAPI(
 			return
 		}
 
-		pubKey, req, errCode := unwrapRequest(pConfig, vRequest)
+		pubKey, req, errCode := unwrapRequest(pWrapper.GetConfig(), vRequest)
 		switch errCode {
 		case cErrorNone:
 			// pass
@@ -73,6 +76,21 @@ func HandleNetworkRequestAPI(
 			return
 
 		case http.MethodPost:
+			if vRequest.FAsync {
+				ticketID, err := pTickets.Push(pCtx, func(pCtx context.Context) (response.IResponse, error) {
+					return pNode.FetchRequest(pCtx, pubKey, req)
+				})
+				if err != nil {
+					pLogger.PushWarn(logBuilder.WithMessage("push_ticket"))
+					_ = api.Response(pW, http.StatusTooManyRequests, "failed: push ticket")
+					return
+				}
+
+				pLogger.PushInfo(logBuilder.WithMessage(http_logger.CLogSuccess))
+				_ = api.Response(pW, http.StatusOK, ticketID)
+				return
+			}
+
 			resp, err := pNode.FetchRequest(pCtx, pubKey, req)
 			if err != nil {
 				pLogger.PushWarn(logBuilder.WithMessage("fetch_payload"))
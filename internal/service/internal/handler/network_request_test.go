@@ -16,14 +16,18 @@ import (
 	"github.com/number571/go-peer/pkg/encoding"
 	"github.com/number571/go-peer/pkg/logger"
 	"github.com/number571/hidden-lake/build"
+	"github.com/number571/hidden-lake/internal/service/internal/limiter"
+	"github.com/number571/hidden-lake/internal/service/internal/streams"
+	"github.com/number571/hidden-lake/internal/service/internal/tickets"
 	"github.com/number571/hidden-lake/internal/service/pkg/app/config"
-	hls_client "github.com/number571/hidden-lake/internal/service/pkg/client"
 	pkg_settings "github.com/number571/hidden-lake/internal/service/pkg/settings"
 	"github.com/number571/hidden-lake/internal/utils/closer"
 	std_logger "github.com/number571/hidden-lake/internal/utils/logger/std"
 	"github.com/number571/hidden-lake/pkg/adapters/tcp"
 	"github.com/number571/hidden-lake/pkg/handler"
 	"github.com/number571/hidden-lake/pkg/request"
+	"github.com/number571/hidden-lake/pkg/response"
+	hls_client "github.com/number571/hidden-lake/pkg/service/client"
 	testutils "github.com/number571/hidden-lake/test/utils"
 )
 
@@ -47,9 +51,10 @@ func TestHandleRequestAPI2(t *testing.T) {
 
 	handler := HandleNetworkRequestAPI(
 		ctx,
-		&tsConfig{},
+		newTsWrapper(true),
 		httpLogger,
 		newTsHiddenLakeNode(newTsNode(true, true, true)),
+		tickets.NewTickets(time.Minute, 1),
 	)
 	if err := requestAPIRequestPutOK(handler); err != nil {
 		t.Error(err)
@@ -59,6 +64,14 @@ func TestHandleRequestAPI2(t *testing.T) {
 		t.Error(err)
 		return
 	}
+	if err := requestAPIRequestPostAsyncOK(handler); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := requestAPIRequestPostAsyncOK(handler); err == nil {
+		t.Error("request success with tickets limit")
+		return
+	}
 
 	if err := requestAPIRequestReqData(handler); err == nil {
 		t.Error("request success with invalid reqData")
@@ -79,9 +92,10 @@ func TestHandleRequestAPI2(t *testing.T) {
 
 	handlerx := HandleNetworkRequestAPI(
 		ctx,
-		&tsConfig{},
+		newTsWrapper(true),
 		httpLogger,
 		newTsHiddenLakeNode(newTsNode(false, false, true)),
+		tickets.NewTickets(time.Minute, 1),
 	)
 	if err := requestAPIRequestPutOK(handlerx); err == nil {
 		t.Error("request success with put error")
@@ -94,9 +108,10 @@ func TestHandleRequestAPI2(t *testing.T) {
 
 	handlery := HandleNetworkRequestAPI(
 		ctx,
-		&tsConfig{},
+		newTsWrapper(true),
 		httpLogger,
 		newTsHiddenLakeNode(newTsNode(true, true, false)),
+		tickets.NewTickets(time.Minute, 1),
 	)
 	if err := requestAPIRequestPostOK(handlery); err == nil {
 		t.Error("request success with post error (load response)")
@@ -152,6 +167,31 @@ func requestAPIRequestPostOK(handler http.HandlerFunc) error {
 	return nil
 }
 
+func requestAPIRequestPostAsyncOK(handler http.HandlerFunc) error {
+	request := pkg_settings.SRequest{
+		FReceiver: "abc",
+		FReqData:  &request.SRequest{},
+		FAsync:    true,
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBuffer(encoding.SerializeJSON(request)))
+
+	handler(w, req)
+	res := w.Result()
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return errors.New("bad status code") // nolint: err113
+	}
+
+	if _, err := io.ReadAll(res.Body); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func requestAPIRequestReqData(handler http.HandlerFunc) error {
 	request := pkg_settings.SRequest{
 		FReceiver: "abc",
@@ -265,6 +305,7 @@ func TestHandleRequestAPI(t *testing.T) {
 		hls_client.NewRequester(
 			testutils.TgAddrs[9],
 			&http.Client{Timeout: time.Minute},
+			hls_client.NewSettings(nil),
 		),
 	)
 
@@ -274,6 +315,7 @@ func TestHandleRequestAPI(t *testing.T) {
 
 	testSend(t, client)
 	testFetch(t, client)
+	testFetchAsync(t, client)
 }
 
 func testSend(t *testing.T, client hls_client.IClient) {
@@ -322,6 +364,53 @@ func testFetch(t *testing.T, client hls_client.IClient) {
 	}
 }
 
+func testFetchAsync(t *testing.T, client hls_client.IClient) {
+	ticketID, err := client.FetchRequestAsync(
+		context.Background(),
+		"test_recvr",
+		request.NewRequestBuilder().
+			WithMethod(http.MethodGet).
+			WithHost(tcServiceAddressInHLS).
+			WithPath("/echo").
+			WithHead(map[string]string{
+				"Content-Type": "application/json",
+			}).
+			WithBody([]byte(`{"message": "hello, world!"}`)).
+			Build(),
+	)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	var res response.IResponse
+	for i := 0; i < 10; i++ {
+		res, err = client.LoadTicket(context.Background(), ticketID, 5*time.Second)
+		if !errors.Is(err, hls_client.ErrTicketPending) {
+			break
+		}
+	}
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	body := res.GetBody()
+	if string(body) != "{\"echo\":\"hello, world!\",\"error\":0}\n" {
+		t.Errorf("result does not match; got '%s'", string(body))
+		return
+	}
+
+	if err := client.DelTicket(context.Background(), ticketID); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err := client.LoadTicket(context.Background(), ticketID, 0); err == nil {
+		t.Error("success load deleted ticket")
+		return
+	}
+}
+
 func testAllPushCreate(pathCfg, pathDB string) (anonymity.INode, context.CancelFunc, *http.Server) {
 	os.RemoveAll(pathCfg + "_push1")
 	os.RemoveAll(pathDB + "_push1")
@@ -374,7 +463,7 @@ func testNewPushNode(cfgPath, dbPath string) (anonymity.INode, context.CancelFun
 
 	node.HandleFunc(
 		build.GSettings.FProtoMask.FService,
-		handler.RequestHandler(HandleServiceFunc(cfg, logger)),
+		handler.RequestHandler(HandleServiceFunc(config.NewWrapper(cfg), logger, handler.NewServiceMux(), streams.NewStreams(time.Minute), newTsMetadata(true), limiter.NewLimiter())),
 	)
 	node.GetMapPubKeys().SetPubKey(tgPrivKey1.GetPubKey())
 
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/number571/go-peer/pkg/logger"
+	"github.com/number571/hidden-lake/internal/service/internal/tickets"
+	pkg_settings "github.com/number571/hidden-lake/internal/service/pkg/settings"
+	"github.com/number571/hidden-lake/internal/utils/api"
+	http_logger "github.com/number571/hidden-lake/internal/utils/logger/http"
+)
+
+func HandleNetworkTicketAPI(
+	pLogger logger.ILogger,
+	pTickets tickets.ITickets,
+) http.HandlerFunc {
+	return func(pW http.ResponseWriter, pR *http.Request) {
+		logBuilder := http_logger.NewLogBuilder(pkg_settings.GServiceName.Short(), pR)
+
+		if pR.Method != http.MethodGet && pR.Method != http.MethodDelete {
+			pLogger.PushWarn(logBuilder.WithMessage(http_logger.CLogMethod))
+			_ = api.Response(pW, http.StatusMethodNotAllowed, "failed: incorrect method")
+			return
+		}
+
+		switch pR.Method {
+		case http.MethodGet:
+			query := pR.URL.Query()
+
+			waitTime := time.Duration(0)
+			if waitMS := query.Get("wait_ms"); waitMS != "" {
+				v, err := strconv.ParseUint(waitMS, 10, 64)
+				if err != nil {
+					pLogger.PushWarn(logBuilder.WithMessage("decode_wait"))
+					_ = api.Response(pW, http.StatusBadRequest, "failed: decode wait_ms")
+					return
+				}
+				waitTime = min(time.Duration(v)*time.Millisecond, pkg_settings.CTicketsMaxWait) // nolint: gosec
+			}
+
+			ticketID := query.Get("id")
+			ticket, err := pTickets.Load(pR.Context(), ticketID, waitTime)
+			if err != nil {
+				pLogger.PushWarn(logBuilder.WithMessage("load_ticket"))
+				_ = api.Response(pW, http.StatusNotFound, "failed: load ticket")
+				return
+			}
+
+			result := pkg_settings.STicket{
+				FTicketID: ticketID,
+				FDone:     ticket.IsDone(),
+			}
+			if result.FDone {
+				if err := ticket.GetError(); err != nil {
+					result.FError = "fetch payload"
+				} else {
+					result.FResponse = ticket.GetResponse().ToString()
+				}
+			}
+
+			pLogger.PushInfo(logBuilder.WithMessage(http_logger.CLogSuccess))
+			_ = api.Response(pW, http.StatusOK, result)
+			return
+
+		case http.MethodDelete:
+			ticketID, err := io.ReadAll(pR.Body)
+			if err != nil {
+				pLogger.PushWarn(logBuilder.WithMessage(http_logger.CLogDecodeBody))
+				_ = api.Response(pW, http.StatusConflict, "failed: read ticket id")
+				return
+			}
+
+			if err := pTickets.Delete(string(ticketID)); err != nil {
+				pLogger.PushWarn(logBuilder.WithMessage("delete_ticket"))
+				_ = api.Response(pW, http.StatusNotFound, "failed: delete ticket")
+				return
+			}
+
+			pLogger.PushInfo(logBuilder.WithMessage(http_logger.CLogSuccess))
+			_ = api.Response(pW, http.StatusOK, "success: delete ticket")
+			return
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/number571/go-peer/pkg/encoding"
+	"github.com/number571/go-peer/pkg/logger"
+	"github.com/number571/hidden-lake/internal/service/internal/tickets"
+	pkg_settings "github.com/number571/hidden-lake/internal/service/pkg/settings"
+	std_logger "github.com/number571/hidden-lake/internal/utils/logger/std"
+	"github.com/number571/hidden-lake/pkg/response"
+)
+
+func TestHandleNetworkTicketAPI(t *testing.T) {
+	t.Parallel()
+
+	httpLogger := std_logger.NewStdLogger(
+		func() std_logger.ILogging {
+			logging, err := std_logger.LoadLogging([]string{})
+			if err != nil {
+				panic(err)
+			}
+			return logging
+		}(),
+		func(_ logger.ILogArg) string {
+			return ""
+		},
+	)
+
+	fetchTickets := tickets.NewTickets(time.Minute, 16)
+	handler := HandleNetworkTicketAPI(httpLogger, fetchTickets)
+
+	ticketID, err := fetchTickets.Push(context.Background(), func(context.Context) (response.IResponse, error) {
+		time.Sleep(100 * time.Millisecond)
+		return response.NewResponseBuilder().WithCode(http.StatusOK).Build(), nil
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	errTicketID, err := fetchTickets.Push(context.Background(), func(context.Context) (response.IResponse, error) {
+		return nil, errors.New("some error") // nolint: err113
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	ticket, err := ticketAPIRequestGet(handler, "/?id="+ticketID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if ticket.FDone {
+		t.Error("got done ticket before response")
+		return
+	}
+
+	ticket, err = ticketAPIRequestGet(handler, "/?wait_ms=60000&id="+ticketID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !ticket.FDone || ticket.FResponse == "" || ticket.FError != "" {
+		t.Error("got invalid ticket after wait")
+		return
+	}
+
+	ticket, err = ticketAPIRequestGet(handler, "/?wait_ms=60000&id="+errTicketID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !ticket.FDone || ticket.FError == "" {
+		t.Error("got success ticket with error")
+		return
+	}
+
+	if _, err := ticketAPIRequestGet(handler, "/?wait_ms=abc&id="+ticketID); err == nil {
+		t.Error("request success with invalid wait_ms")
+		return
+	}
+	if _, err := ticketAPIRequestGet(handler, "/?id=unknown"); err == nil {
+		t.Error("request success with unknown ticket")
+		return
+	}
+
+	if err := ticketAPIRequest(handler, http.MethodDelete, ticketID); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := ticketAPIRequest(handler, http.MethodDelete, ticketID); err == nil {
+		t.Error("request success with deleted ticket")
+		return
+	}
+	if err := ticketAPIRequest(handler, http.MethodPost, ticketID); err == nil {
+		t.Error("request success with invalid method")
+		return
+	}
+}
+
+func ticketAPIRequestGet(handler http.HandlerFunc, pTarget string) (*pkg_settings.STicket, error) {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, pTarget, nil)
+
+	handler(w, req)
+	res := w.Result()
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.New("bad status code") // nolint: err113
+	}
+
+	ticket := new(pkg_settings.STicket)
+	if err := encoding.DeserializeJSON(w.Body.Bytes(), ticket); err != nil {
+		return nil, err
+	}
+	return ticket, nil
+}
+
+func ticketAPIRequest(handler http.HandlerFunc, pMethod, pTicketID string) error {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(pMethod, "/", bytes.NewBufferString(pTicketID))
+
+	handler(w, req)
+	res := w.Result()
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return errors.New("bad status code") // nolint: err113
+	}
+
+	return nil
+}
@@ -9,8 +9,12 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/number571/hidden-lake/internal/service/internal/limiter"
+	"github.com/number571/hidden-lake/internal/service/internal/metadata"
+	"github.com/number571/hidden-lake/internal/service/internal/streams"
 	"github.com/number571/hidden-lake/internal/service/pkg/app/config"
 	hls_settings "github.com/number571/hidden-lake/internal/service/pkg/settings"
+	"github.com/number571/hidden-lake/internal/utils/socket"
 	"github.com/number571/hidden-lake/pkg/handler"
 	"github.com/number571/hidden-lake/pkg/request"
 	"github.com/number571/hidden-lake/pkg/response"
@@ -21,7 +25,17 @@ import (
 	internal_anon_logger "github.com/number571/hidden-lake/internal/utils/logger/anon"
 )
 
-func HandleServiceFunc(pCfg config.IConfig, pLogger logger.ILogger) handler.IHandlerF {
+func HandleServiceFunc(
+	pWrapper config.IWrapper,
+	pLogger logger.ILogger,
+	pServiceMux handler.IServiceMux,
+	pStreams streams.IStreams,
+	pMetadata metadata.IMetadata,
+	pLimiter limiter.ILimiter,
+) handler.IHandlerF {
+	// the services can be available by the unix sockets
+	httpClients := socket.NewHTTPClients(&http.Client{Timeout: time.Minute})
+
 	return func(
 		pCtx context.Context,
 		pSender asymmetric.IPubKey,
@@ -29,18 +43,89 @@ func HandleServiceFunc(pCfg config.IConfig, pLogger logger.ILogger) handler.IHan
 	) (response.IResponse, error) {
 		logBuilder := anon_logger.NewLogBuilder(hls_settings.GServiceName.Short())
 
+		// the request is decrypted, so the sender is the friend
+		if err := pMetadata.SetLastSeen(pSender); err != nil {
+			pLogger.PushErro(logBuilder.WithPubKey(pSender).WithType(anon_logger.CLogErroDatabaseSet))
+		}
+
+		cfg := pWrapper.GetConfig()
+
 		// get service's address by hostname
-		service, ok := pCfg.GetService(pRequest.GetHost())
-		if !ok {
+		service, ok := cfg.GetService(pRequest.GetHost())
+		inProcess := pServiceMux.HasService(pRequest.GetHost())
+		pullMode := pStreams.HasStream(pRequest.GetHost())
+		if !ok && !inProcess && !pullMode {
 			pLogger.PushWarn(logBuilder.WithType(internal_anon_logger.CLogWarnUndefinedService))
 			return nil, ErrUndefinedService
 		}
 
+		aliasName := getAliasName(cfg.GetFriends(), pSender)
+
+		// check friend's access to the service
+		if access, ok := cfg.GetAccess()[pRequest.GetHost()]; ok {
+			if !access.IsAllowed(aliasName) {
+				pLogger.PushWarn(logBuilder.WithType(internal_anon_logger.CLogWarnAccessDenied))
+				return nil, ErrAccessDenied
+			}
+		}
+
+		// check friend's rate of requests to the service
+		if limit, ok := cfg.GetLimits()[pRequest.GetHost()]; ok {
+			size := len(pRequest.ToBytes())
+			if err := pLimiter.Allow(aliasName, pRequest.GetHost(), limit.GetRate(aliasName), uint64(size)); err != nil {
+				pLogger.PushWarn(logBuilder.WithPubKey(pSender).WithSize(size).WithType(internal_anon_logger.CLogWarnLimitExceeded))
+				return nil, errors.Join(ErrLimitExceeded, err)
+			}
+		}
+
+		// in-process services take precedence over the config
+		if inProcess {
+			rsp, err := pServiceMux.ServeRequest(pCtx, pSender, pRequest)
+			switch {
+			case err != nil:
+				pLogger.PushWarn(logBuilder.WithType(internal_anon_logger.CLogWarnRequestToService))
+				return nil, errors.Join(ErrBadRequest, err)
+			case rsp == nil:
+				pLogger.PushInfo(logBuilder.WithType(internal_anon_logger.CLogBaseResponseModeFromService))
+				return nil, nil
+			default:
+				pLogger.PushInfo(logBuilder.WithType(internal_anon_logger.CLogInfoResponseFromService))
+				return rsp, nil
+			}
+		}
+
+		// pull-mode services receive requests from the stream
+		if pullMode {
+			rsp, err := pStreams.Request(pCtx, pSender, pRequest)
+			if err != nil {
+				pLogger.PushWarn(logBuilder.WithType(internal_anon_logger.CLogWarnRequestToService))
+				return nil, errors.Join(ErrBadRequest, err)
+			}
+			if rsp == nil {
+				pLogger.PushInfo(logBuilder.WithType(internal_anon_logger.CLogBaseResponseModeFromService))
+				return nil, nil
+			}
+			return getResponseByMode(
+				pLogger,
+				logBuilder,
+				rsp.GetHead()[hls_settings.CHeaderResponseMode],
+				func() response.IResponse {
+					return response.NewResponseBuilder().
+						WithCode(rsp.GetCode()).
+						WithHead(filterResponseHead(rsp.GetHead())).
+						WithBody(rsp.GetBody()).
+						Build()
+				},
+			)
+		}
+
+		host, httpClient := httpClients.Get(service)
+
 		// generate new request to serivce
 		pushReq, err := http.NewRequestWithContext(
 			pCtx,
 			pRequest.GetMethod(),
-			fmt.Sprintf("http://%s%s", service, pRequest.GetPath()),
+			fmt.Sprintf("http://%s%s", host, pRequest.GetPath()),
 			bytes.NewReader(pRequest.GetBody()),
 		)
 		if err != nil {
@@ -55,7 +140,6 @@ func HandleServiceFunc(pCfg config.IConfig, pLogger logger.ILogger) handler.IHan
 		pushReq.Header.Set(hls_settings.CHeaderPublicKey, pSender.ToString())
 
 		// send request and receive response from service
-		httpClient := &http.Client{Timeout: time.Minute}
 		resp, err := httpClient.Do(pushReq)
 		if err != nil {
 			pLogger.PushWarn(logBuilder.WithType(internal_anon_logger.CLogWarnRequestToService))
@@ -63,28 +147,52 @@ func HandleServiceFunc(pCfg config.IConfig, pLogger logger.ILogger) handler.IHan
 		}
 		defer resp.Body.Close()
 
-		// get response mode: on/off
-		respMode := resp.Header.Get(hls_settings.CHeaderResponseMode)
-		switch respMode {
-		case "", hls_settings.CHeaderResponseModeON:
-			// send response to the client
-			pLogger.PushInfo(logBuilder.WithType(internal_anon_logger.CLogInfoResponseFromService))
-			return response.NewResponseBuilder().
+		return getResponseByMode(
+			pLogger,
+			logBuilder,
+			resp.Header.Get(hls_settings.CHeaderResponseMode),
+			func() response.IResponse {
+				return response.NewResponseBuilder().
 					WithCode(resp.StatusCode).
 					WithHead(getResponseHead(resp)).
 					WithBody(getResponseBody(resp)).
-					Build(),
-				nil
-		case hls_settings.CHeaderResponseModeOFF:
-			// response is not required by the client side
-			pLogger.PushInfo(logBuilder.WithType(internal_anon_logger.CLogBaseResponseModeFromService))
-			return nil, nil
-		default:
-			// unknown response mode
-			pLogger.PushErro(logBuilder.WithType(internal_anon_logger.CLogBaseResponseModeFromService))
-			return nil, ErrInvalidResponseMode
+					Build()
+			},
+		)
+	}
+}
+
+func getResponseByMode(
+	pLogger logger.ILogger,
+	pLogBuilder anon_logger.ILogBuilder,
+	pRespMode string,
+	pBuildF func() response.IResponse,
+) (response.IResponse, error) {
+	// get response mode: on/off
+	switch pRespMode {
+	case "", hls_settings.CHeaderResponseModeON:
+		// send response to the client
+		pLogger.PushInfo(pLogBuilder.WithType(internal_anon_logger.CLogInfoResponseFromService))
+		return pBuildF(), nil
+	case hls_settings.CHeaderResponseModeOFF:
+		// response is not required by the client side
+		pLogger.PushInfo(pLogBuilder.WithType(internal_anon_logger.CLogBaseResponseModeFromService))
+		return nil, nil
+	default:
+		// unknown response mode
+		pLogger.PushErro(pLogBuilder.WithType(internal_anon_logger.CLogBaseResponseModeFromService))
+		return nil, ErrInvalidResponseMode
+	}
+}
+
+func getAliasName(pFriends map[string]asymmetric.IPubKey, pPubKey asymmetric.IPubKey) string {
+	pubKey := pPubKey.ToBytes()
+	for aliasName, friend := range pFriends {
+		if bytes.Equal(friend.ToBytes(), pubKey) {
+			return aliasName
 		}
 	}
+	return ""
 }
 
 func getResponseHead(pResp *http.Response) map[string]string {
@@ -98,6 +206,17 @@ func getResponseHead(pResp *http.Response) map[string]string {
 	return headers
 }
 
+func filterResponseHead(pHead map[string]string) map[string]string {
+	headers := make(map[string]string, len(pHead))
+	for k, v := range pHead {
+		if _, ok := gIgnoreHeaders[k]; ok {
+			continue
+		}
+		headers[k] = v
+	}
+	return headers
+}
+
 func getResponseBody(pResp *http.Response) []byte {
 	data, err := io.ReadAll(pResp.Body)
 	if err != nil {
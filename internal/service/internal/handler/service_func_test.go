@@ -12,6 +12,8 @@ import (
 	"time"
 
 	"github.com/number571/hidden-lake/build"
+	"github.com/number571/hidden-lake/internal/service/internal/limiter"
+	"github.com/number571/hidden-lake/internal/service/internal/streams"
 	"github.com/number571/hidden-lake/internal/service/pkg/app/config"
 	hls_settings "github.com/number571/hidden-lake/internal/service/pkg/settings"
 	"github.com/number571/hidden-lake/internal/utils/closer"
@@ -22,6 +24,7 @@ import (
 	testutils "github.com/number571/hidden-lake/test/utils"
 
 	"github.com/number571/go-peer/pkg/anonymity"
+	"github.com/number571/go-peer/pkg/crypto/asymmetric"
 	"github.com/number571/go-peer/pkg/logger"
 	"github.com/number571/go-peer/pkg/payload"
 )
@@ -62,9 +65,23 @@ func TestHandleServiceFunc(t *testing.T) {
 	)
 
 	ctx := context.Background()
-	cfg := &tsConfig{fServiceAddr: addr}
+	wcfg := &tsWrapper{fConfig: &tsConfig{fServiceAddr: addr}}
 	pubKey := tgPrivKey2.GetPubKey()
-	handler := HandleServiceFunc(cfg, logger)
+	serviceMux := handler.NewServiceMux().
+		HandleFunc("hidden-some-host-inproc", "GET /rsp-mode-on", func(context.Context, asymmetric.IPubKey, request.IRequest) (response.IResponse, error) {
+			return response.NewResponseBuilder().WithBody([]byte(rspMsg)).Build(), nil
+		}).
+		HandleFunc("hidden-some-host-inproc", "/rsp-mode-off", func(context.Context, asymmetric.IPubKey, request.IRequest) (response.IResponse, error) {
+			return nil, nil
+		}).
+		HandleFunc("hidden-some-host-limited", "/rsp-mode-on", func(context.Context, asymmetric.IPubKey, request.IRequest) (response.IResponse, error) {
+			return response.NewResponseBuilder().WithBody([]byte(rspMsg)).Build(), nil
+		}).
+		HandleFunc("hidden-some-host-inproc", "/failed", func(context.Context, asymmetric.IPubKey, request.IRequest) (response.IResponse, error) {
+			return nil, errors.New("some error")
+		})
+	serviceStreams := streams.NewStreams(time.Second)
+	handler := HandleServiceFunc(wcfg, logger, serviceMux, serviceStreams, newTsMetadata(true), limiter.NewLimiter())
 
 	reqx := request.NewRequestBuilder().
 		WithMethod(http.MethodGet).
@@ -88,12 +105,43 @@ func TestHandleServiceFunc(t *testing.T) {
 		return
 	}
 
+	reqz := request.NewRequestBuilder().
+		WithMethod(http.MethodGet).
+		WithHost("hidden-some-host-denied").
+		WithPath("/rsp-mode-on").
+		Build()
+
+	if _, err := handler(ctx, pubKey, reqz); !errors.Is(err, ErrAccessDenied) {
+		t.Error("success handle request with denied friend")
+		return
+	}
+
+	reql := request.NewRequestBuilder().
+		WithMethod(http.MethodGet).
+		WithHost("hidden-some-host-limited").
+		WithPath("/rsp-mode-on").
+		Build()
+
+	if _, err := handler(ctx, pubKey, reql); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err := handler(ctx, pubKey, reql); !errors.Is(err, ErrLimitExceeded) {
+		t.Error("success handle request over the limit")
+		return
+	}
+
 	req := request.NewRequestBuilder().
 		WithMethod(http.MethodGet).
 		WithHost("hidden-some-host-ok").
 		WithPath("/rsp-mode-on").
 		Build()
 
+	if _, err := handler(ctx, tgPrivKey3.GetPubKey(), req); !errors.Is(err, ErrAccessDenied) {
+		t.Error("success handle request with not allowed friend")
+		return
+	}
+
 	rsp, err := handler(ctx, pubKey, req)
 	if err != nil {
 		t.Error(err)
@@ -131,6 +179,120 @@ func TestHandleServiceFunc(t *testing.T) {
 		t.Error("success response with unknown response mode")
 		return
 	}
+
+	req4 := request.NewRequestBuilder().
+		WithMethod(http.MethodGet).
+		WithHost("hidden-some-host-inproc").
+		WithPath("/rsp-mode-on").
+		Build()
+
+	rsp4, err := handler(ctx, pubKey, req4)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if string(rsp4.GetBody()) != rspMsg {
+		t.Error("string(rsp4.GetBody()) != rspMsg")
+		return
+	}
+
+	req5 := request.NewRequestBuilder().
+		WithMethod(http.MethodGet).
+		WithHost("hidden-some-host-inproc").
+		WithPath("/rsp-mode-off").
+		Build()
+
+	rsp5, err := handler(ctx, pubKey, req5)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if rsp5 != nil {
+		t.Error("rsp5 != nil")
+		return
+	}
+
+	req6 := request.NewRequestBuilder().
+		WithMethod(http.MethodGet).
+		WithHost("hidden-some-host-inproc").
+		WithPath("/failed").
+		Build()
+
+	if _, err := handler(ctx, pubKey, req6); err == nil {
+		t.Error("success handle request with failed in-process service")
+		return
+	}
+
+	if err := testPullModeService(ctx, handler, serviceStreams); err != nil {
+		t.Error(err)
+		return
+	}
+}
+
+func testPullModeService(ctx context.Context, pHandler handler.IHandlerF, pStreams streams.IStreams) error {
+	stream, err := pStreams.Subscribe("hidden-some-host-pull")
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	go func() {
+		for incoming := range stream.GetIncoming() {
+			var rsp response.IResponse
+			switch incoming.GetRequest().GetPath() {
+			case "/rsp-mode-on":
+				rsp = response.NewResponseBuilder().
+					WithHead(map[string]string{"Date": "_"}).
+					WithBody([]byte(incoming.GetSender().ToString())).
+					Build()
+			case "/rsp-mode-unknown":
+				rsp = response.NewResponseBuilder().
+					WithHead(map[string]string{hls_settings.CHeaderResponseMode: "unknown"}).
+					Build()
+			case "/timeout":
+				continue
+			}
+			_ = pStreams.Reply(incoming.GetRequestID(), rsp)
+		}
+	}()
+
+	pubKey := tgPrivKey2.GetPubKey()
+	newRequest := func(pPath string) request.IRequest {
+		return request.NewRequestBuilder().
+			WithMethod(http.MethodGet).
+			WithHost("hidden-some-host-pull").
+			WithPath(pPath).
+			Build()
+	}
+
+	rsp, err := pHandler(ctx, pubKey, newRequest("/rsp-mode-on"))
+	if err != nil {
+		return err
+	}
+	if string(rsp.GetBody()) != pubKey.ToString() {
+		return errors.New("got invalid response from pull-mode service")
+	}
+	if _, ok := rsp.GetHead()["Date"]; ok {
+		return errors.New("got ignored header from pull-mode service")
+	}
+
+	rsp, err = pHandler(ctx, pubKey, newRequest("/rsp-mode-off"))
+	if err != nil {
+		return err
+	}
+	if rsp != nil {
+		return errors.New("got response with mode off from pull-mode service")
+	}
+
+	if _, err := pHandler(ctx, pubKey, newRequest("/rsp-mode-unknown")); err == nil {
+		return errors.New("success response with unknown response mode")
+	}
+
+	if _, err := pHandler(ctx, pubKey, newRequest("/timeout")); err == nil {
+		return errors.New("success response without reply")
+	}
+
+	return nil
 }
 
 func testCleanHLS() {
@@ -211,7 +373,7 @@ func testStartNodeHLS() (anonymity.INode, context.CancelFunc, error) {
 
 	node.HandleFunc(
 		build.GSettings.FProtoMask.FService,
-		handler.RequestHandler(HandleServiceFunc(cfg, logger)),
+		handler.RequestHandler(HandleServiceFunc(config.NewWrapper(cfg), logger, handler.NewServiceMux(), streams.NewStreams(time.Minute), newTsMetadata(true), limiter.NewLimiter())),
 	)
 	node.GetMapPubKeys().SetPubKey(tgPrivKey1.GetPubKey())
 
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	anon_logger "github.com/number571/go-peer/pkg/anonymity/logger"
+	"github.com/number571/go-peer/pkg/crypto/asymmetric"
+	"github.com/number571/go-peer/pkg/encoding"
+	"github.com/number571/go-peer/pkg/logger"
+	"github.com/number571/hidden-lake/internal/service/internal/introductions"
+	"github.com/number571/hidden-lake/internal/service/pkg/app/config"
+	hls_settings "github.com/number571/hidden-lake/internal/service/pkg/settings"
+	internal_anon_logger "github.com/number571/hidden-lake/internal/utils/logger/anon"
+	"github.com/number571/hidden-lake/pkg/handler"
+	"github.com/number571/hidden-lake/pkg/request"
+	"github.com/number571/hidden-lake/pkg/response"
+)
+
+const (
+	cMaxIntroductionAliasSize = 256
+)
+
+// HandleIntroductionService saves the introductions received from friends
+// as pending. The introduced friend is never added without the acceptance
+// of the user through the API.
+func HandleIntroductionService(
+	pWrapper config.IWrapper,
+	pLogger logger.ILogger,
+	pPubKey asymmetric.IPubKey,
+	pIntroductions introductions.IIntroductions,
+) handler.IHandlerF {
+	return func(
+		_ context.Context,
+		pSender asymmetric.IPubKey,
+		pRequest request.IRequest,
+	) (response.IResponse, error) {
+		logBuilder := anon_logger.NewLogBuilder(hls_settings.GServiceName.Short())
+
+		var vIntroduction hls_settings.SIntroduction
+		if err := encoding.DeserializeJSON(pRequest.GetBody(), &vIntroduction); err != nil {
+			return nil, errors.Join(ErrDecodeIntroduction, err)
+		}
+
+		aliasName := strings.TrimSpace(vIntroduction.FAliasName)
+		if len(aliasName) > cMaxIntroductionAliasSize {
+			return nil, ErrDecodeIntroduction
+		}
+
+		pubKey := asymmetric.LoadPubKey(vIntroduction.FPublicKey)
+		if pubKey == nil {
+			return nil, ErrDecodeIntroduction
+		}
+
+		// introductions of self and existing friends are ignored
+		if pubKey.ToString() == pPubKey.ToString() || getAliasName(pWrapper.GetConfig().GetFriends(), pubKey) != "" {
+			return nil, nil
+		}
+
+		if _, err := pIntroductions.Push(pSender, aliasName, pubKey); err != nil {
+			return nil, err
+		}
+
+		pLogger.PushInfo(logBuilder.WithType(internal_anon_logger.CLogInfoIntroduction).WithPubKey(pSender))
+		return nil, nil
+	}
+}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/number571/go-peer/pkg/crypto/asymmetric"
+	"github.com/number571/go-peer/pkg/encoding"
+	"github.com/number571/go-peer/pkg/logger"
+	"github.com/number571/hidden-lake/internal/service/internal/introductions"
+	"github.com/number571/hidden-lake/internal/service/pkg/settings"
+	"github.com/number571/hidden-lake/pkg/request"
+)
+
+func TestHandleIntroductionService(t *testing.T) {
+	t.Parallel()
+
+	log := logger.NewLogger(
+		logger.NewSettings(&logger.SSettings{}),
+		func(_ logger.ILogArg) string { return "" },
+	)
+
+	ctx := context.Background()
+	myPrivKey := asymmetric.NewPrivKey()
+	storage := introductions.NewIntroductions(time.Minute, 16, 4)
+	handlerF := HandleIntroductionService(newTsWrapper(true), log, myPrivKey.GetPubKey(), storage)
+
+	sender := tgPrivKey2.GetPubKey()
+	if _, err := handlerF(ctx, sender, newIntroductionRequest("Bob", tgPrivKey3.GetPubKey().ToString())); err != nil {
+		t.Error(err)
+		return
+	}
+
+	list := storage.List()
+	if len(list) != 1 {
+		t.Error("introduction is not saved")
+		return
+	}
+	if list[0].GetAliasName() != "Bob" || list[0].GetIntroducer().ToString() != sender.ToString() {
+		t.Error("got invalid introduction")
+		return
+	}
+
+	// introductions of existing friends and self are ignored
+	if _, err := handlerF(ctx, sender, newIntroductionRequest("abc", tgPrivKey2.GetPubKey().ToString())); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err := handlerF(ctx, sender, newIntroductionRequest("me", myPrivKey.GetPubKey().ToString())); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(storage.List()) != 1 {
+		t.Error("introduction of exist friend is saved")
+		return
+	}
+
+	if _, err := handlerF(ctx, sender, newIntroductionRequest("Bob", "abc")); err == nil {
+		t.Error("success introduction with invalid public key")
+		return
+	}
+
+	invalidReq := request.NewRequestBuilder().
+		WithMethod(http.MethodPost).
+		WithHost(settings.CIntroductionHostName).
+		WithPath(settings.CIntroductionPath).
+		WithBody([]byte("abc")).
+		Build()
+	if _, err := handlerF(ctx, sender, invalidReq); err == nil {
+		t.Error("success introduction with invalid body")
+		return
+	}
+
+	longReq := request.NewRequestBuilder().
+		WithMethod(http.MethodPost).
+		WithBody(encoding.SerializeJSON(settings.SIntroduction{
+			FAliasName: string(make([]byte, cMaxIntroductionAliasSize+1)),
+			FPublicKey: asymmetric.NewPrivKey().GetPubKey().ToString(),
+		})).
+		Build()
+	if _, err := handlerF(ctx, sender, longReq); err == nil {
+		t.Error("success introduction with long alias name")
+		return
+	}
+}
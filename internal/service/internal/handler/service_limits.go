@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/number571/go-peer/pkg/logger"
+	"github.com/number571/hidden-lake/internal/service/internal/limiter"
+	pkg_settings "github.com/number571/hidden-lake/internal/service/pkg/settings"
+	"github.com/number571/hidden-lake/internal/utils/api"
+	http_logger "github.com/number571/hidden-lake/internal/utils/logger/http"
+)
+
+func HandleServiceLimitsAPI(pLogger logger.ILogger, pLimiter limiter.ILimiter) http.HandlerFunc {
+	return func(pW http.ResponseWriter, pR *http.Request) {
+		logBuilder := http_logger.NewLogBuilder(pkg_settings.GServiceName.Short(), pR)
+
+		if pR.Method != http.MethodGet {
+			pLogger.PushWarn(logBuilder.WithMessage(http_logger.CLogMethod))
+			_ = api.Response(pW, http.StatusMethodNotAllowed, "failed: incorrect method")
+			return
+		}
+
+		counters := pLimiter.GetCounters()
+
+		listCounters := make([]pkg_settings.SLimitCounter, 0, len(counters))
+		for _, c := range counters {
+			listCounters = append(listCounters, pkg_settings.SLimitCounter{
+				FAliasName:         c.GetAliasName(),
+				FHostName:          c.GetHostName(),
+				FRequests:          c.GetRequests(),
+				FBytes:             c.GetBytes(),
+				FRejected:          c.GetRejected(),
+				FAvailableRequests: c.GetAvailableRequests(),
+				FAvailableBytes:    c.GetAvailableBytes(),
+			})
+		}
+
+		pLogger.PushInfo(logBuilder.WithMessage(http_logger.CLogSuccess))
+		_ = api.Response(pW, http.StatusOK, listCounters)
+	}
+}
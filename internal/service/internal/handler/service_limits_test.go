@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/number571/go-peer/pkg/logger"
+	"github.com/number571/hidden-lake/internal/service/internal/limiter"
+	"github.com/number571/hidden-lake/internal/service/pkg/app/config"
+	"github.com/number571/hidden-lake/internal/service/pkg/settings"
+	std_logger "github.com/number571/hidden-lake/internal/utils/logger/std"
+)
+
+func TestHandleLimitsAPI2(t *testing.T) {
+	t.Parallel()
+
+	httpLogger := std_logger.NewStdLogger(
+		func() std_logger.ILogging {
+			logging, err := std_logger.LoadLogging([]string{})
+			if err != nil {
+				panic(err)
+			}
+			return logging
+		}(),
+		func(_ logger.ILogArg) string {
+			return ""
+		},
+	)
+
+	serviceLimiter := limiter.NewLimiter()
+	rate := &config.SRate{FRequestsPerMinute: 1}
+	_ = serviceLimiter.Allow("abc", "hidden-some-host-limited", rate, 10)
+	_ = serviceLimiter.Allow("abc", "hidden-some-host-limited", rate, 10)
+
+	handler := HandleServiceLimitsAPI(httpLogger, serviceLimiter)
+	if err := limitsAPIRequestOK(handler); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := limitsAPIRequestMethod(handler); err == nil {
+		t.Error("request success with invalid method")
+		return
+	}
+}
+
+func limitsAPIRequestOK(handler http.HandlerFunc) error {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	handler(w, req)
+	res := w.Result()
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return errors.New("bad status code") // nolint: err113
+	}
+
+	var counters []settings.SLimitCounter
+	if err := json.NewDecoder(res.Body).Decode(&counters); err != nil {
+		return err
+	}
+	if len(counters) != 1 || counters[0].FRequests != 1 || counters[0].FRejected != 1 || counters[0].FBytes != 10 {
+		return errors.New("got invalid counters") // nolint: err113
+	}
+
+	return nil
+}
+
+func limitsAPIRequestMethod(handler http.HandlerFunc) error {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+
+	handler(w, req)
+	res := w.Result()
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return errors.New("bad status code") // nolint: err113
+	}
+
+	return nil
+}
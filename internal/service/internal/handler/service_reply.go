@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/number571/go-peer/pkg/logger"
+	"github.com/number571/hidden-lake/internal/service/internal/streams"
+	pkg_settings "github.com/number571/hidden-lake/internal/service/pkg/settings"
+	"github.com/number571/hidden-lake/internal/utils/api"
+	http_logger "github.com/number571/hidden-lake/internal/utils/logger/http"
+	"github.com/number571/hidden-lake/pkg/response"
+)
+
+func HandleServiceReplyAPI(
+	pLogger logger.ILogger,
+	pStreams streams.IStreams,
+) http.HandlerFunc {
+	return func(pW http.ResponseWriter, pR *http.Request) {
+		logBuilder := http_logger.NewLogBuilder(pkg_settings.GServiceName.Short(), pR)
+
+		var vReply pkg_settings.SReply
+
+		if pR.Method != http.MethodPost {
+			pLogger.PushWarn(logBuilder.WithMessage(http_logger.CLogMethod))
+			_ = api.Response(pW, http.StatusMethodNotAllowed, "failed: incorrect method")
+			return
+		}
+
+		if err := json.NewDecoder(pR.Body).Decode(&vReply); err != nil {
+			pLogger.PushWarn(logBuilder.WithMessage(http_logger.CLogDecodeBody))
+			_ = api.Response(pW, http.StatusConflict, "failed: decode request")
+			return
+		}
+
+		// nil response = response mode 'off'
+		var rsp response.IResponse
+		if vReply.FResponse != nil {
+			rsp = vReply.FResponse
+		}
+
+		if err := pStreams.Reply(vReply.FRequestID, rsp); err != nil {
+			pLogger.PushWarn(logBuilder.WithMessage("reply_request"))
+			_ = api.Response(pW, http.StatusNotFound, "failed: request not found")
+			return
+		}
+
+		pLogger.PushInfo(logBuilder.WithMessage(http_logger.CLogSuccess))
+		_ = api.Response(pW, http.StatusOK, "success: reply request")
+	}
+}
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/number571/go-peer/pkg/encoding"
+	"github.com/number571/go-peer/pkg/logger"
+	"github.com/number571/hidden-lake/internal/service/internal/streams"
+	pkg_settings "github.com/number571/hidden-lake/internal/service/pkg/settings"
+)
+
+func TestHandleServiceReplyAPI(t *testing.T) {
+	t.Parallel()
+
+	logger := logger.NewLogger(
+		logger.NewSettings(&logger.SSettings{}),
+		func(_ logger.ILogArg) string { return "" },
+	)
+
+	handler := HandleServiceReplyAPI(logger, streams.NewStreams(time.Minute))
+
+	reply := encoding.SerializeJSON(pkg_settings.SReply{FRequestID: "undefined"})
+	if err := serviceReplyRequest(handler, http.MethodPost, reply); err == nil {
+		t.Error("request success with undefined request id")
+		return
+	}
+	if err := serviceReplyRequest(handler, http.MethodGet, reply); err == nil {
+		t.Error("request success with invalid method")
+		return
+	}
+	if err := serviceReplyRequest(handler, http.MethodPost, []byte("abc")); err == nil {
+		t.Error("request success with invalid body")
+		return
+	}
+}
+
+func serviceReplyRequest(handler http.HandlerFunc, method string, body []byte) error {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, "/", bytes.NewBuffer(body))
+
+	handler(w, req)
+	res := w.Result()
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return errors.New("bad status code") // nolint: err113
+	}
+
+	return nil
+}
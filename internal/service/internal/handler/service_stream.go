@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/number571/go-peer/pkg/encoding"
+	"github.com/number571/go-peer/pkg/logger"
+	"github.com/number571/hidden-lake/internal/service/internal/streams"
+	pkg_settings "github.com/number571/hidden-lake/internal/service/pkg/settings"
+	"github.com/number571/hidden-lake/internal/utils/api"
+	http_logger "github.com/number571/hidden-lake/internal/utils/logger/http"
+	"github.com/number571/hidden-lake/pkg/request"
+)
+
+func HandleServiceStreamAPI(
+	pLogger logger.ILogger,
+	pStreams streams.IStreams,
+	pPingPeriod time.Duration,
+) http.HandlerFunc {
+	return func(pW http.ResponseWriter, pR *http.Request) {
+		logBuilder := http_logger.NewLogBuilder(pkg_settings.GServiceName.Short(), pR)
+
+		if pR.Method != http.MethodGet {
+			pLogger.PushWarn(logBuilder.WithMessage(http_logger.CLogMethod))
+			_ = api.Response(pW, http.StatusMethodNotAllowed, "failed: incorrect method")
+			return
+		}
+
+		hostName := strings.TrimSpace(pR.URL.Query().Get("host"))
+		if hostName == "" {
+			pLogger.PushWarn(logBuilder.WithMessage("get_host_name"))
+			_ = api.Response(pW, http.StatusTeapot, "failed: load host name")
+			return
+		}
+
+		flusher, ok := pW.(http.Flusher)
+		if !ok {
+			pLogger.PushErro(logBuilder.WithMessage("get_flusher"))
+			_ = api.Response(pW, http.StatusInternalServerError, "failed: streaming unsupported")
+			return
+		}
+
+		stream, err := pStreams.Subscribe(hostName)
+		if err != nil {
+			pLogger.PushWarn(logBuilder.WithMessage("subscribe_stream"))
+			_ = api.Response(pW, http.StatusNotAcceptable, "failed: stream already exist")
+			return
+		}
+		defer stream.Close()
+
+		pW.Header().Set("Content-Type", "text/event-stream")
+		pW.Header().Set("Cache-Control", "no-cache")
+		pW.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		pLogger.PushInfo(logBuilder.WithMessage(http_logger.CLogSuccess))
+
+		ticker := time.NewTicker(pPingPeriod)
+		defer ticker.Stop()
+
+		for {
+			var event string
+
+			select {
+			case <-pR.Context().Done():
+				return
+			case <-ticker.C:
+				// comment line keeps the connection alive
+				event = ": ping\n\n"
+			case incoming := <-stream.GetIncoming():
+				event = fmt.Sprintf(
+					"id: %s\nevent: request\ndata: %s\n\n",
+					incoming.GetRequestID(),
+					encoding.SerializeJSON(getIncoming(incoming)),
+				)
+			}
+
+			if _, err := pW.Write([]byte(event)); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+func getIncoming(pIncoming streams.IIncoming) pkg_settings.SIncoming {
+	req := pIncoming.GetRequest()
+	return pkg_settings.SIncoming{
+		FRequestID: pIncoming.GetRequestID(),
+		FSender:    pIncoming.GetSender().ToString(),
+		FReqData: &request.SRequest{
+			SRequestBlock: request.SRequestBlock{
+				FMethod: req.GetMethod(),
+				FHost:   req.GetHost(),
+				FPath:   req.GetPath(),
+				FHead:   req.GetHead(),
+			},
+			FBody: req.GetBody(),
+		},
+	}
+}
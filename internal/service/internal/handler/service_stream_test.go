@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/number571/go-peer/pkg/crypto/asymmetric"
+	"github.com/number571/go-peer/pkg/logger"
+	"github.com/number571/hidden-lake/internal/service/internal/streams"
+	pkg_settings "github.com/number571/hidden-lake/internal/service/pkg/settings"
+	"github.com/number571/hidden-lake/pkg/request"
+	"github.com/number571/hidden-lake/pkg/response"
+	hls_client "github.com/number571/hidden-lake/pkg/service/client"
+	testutils "github.com/number571/hidden-lake/test/utils"
+)
+
+func TestHandleServiceStreamAPI(t *testing.T) {
+	t.Parallel()
+
+	logger := logger.NewLogger(
+		logger.NewSettings(&logger.SSettings{}),
+		func(_ logger.ILogArg) string { return "" },
+	)
+
+	addr := testutils.TgAddrs[23]
+	serviceStreams := streams.NewStreams(time.Minute)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(pkg_settings.CHandleServiceStreamPath, HandleServiceStreamAPI(logger, serviceStreams, 100*time.Millisecond))
+	mux.HandleFunc(pkg_settings.CHandleServiceReplyPath, HandleServiceReplyAPI(logger, serviceStreams))
+
+	srv := &http.Server{
+		Addr:        addr,
+		Handler:     mux,
+		ReadTimeout: time.Second,
+	}
+	defer srv.Close()
+	go func() { _ = srv.ListenAndServe() }()
+
+	time.Sleep(200 * time.Millisecond)
+
+	client := hls_client.NewClient(
+		hls_client.NewBuilder(),
+		hls_client.NewRequester(
+			addr,
+			&http.Client{Timeout: time.Second},
+			hls_client.NewSettings(nil),
+		),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		_ = client.ServeStream(ctx, "hidden-some-host", func(_ context.Context, pSender asymmetric.IPubKey, pReq request.IRequest) (response.IResponse, error) {
+			switch pReq.GetPath() {
+			case "/echo":
+				return response.NewResponseBuilder().
+					WithCode(http.StatusOK).
+					WithBody(append([]byte(pSender.ToString()), pReq.GetBody()...)).
+					Build(), nil
+			case "/failed":
+				return nil, errors.New("some error") // nolint: err113
+			default:
+				return nil, nil
+			}
+		})
+	}()
+
+	time.Sleep(200 * time.Millisecond)
+
+	if err := client.ServeStream(ctx, "hidden-some-host", nil); err == nil {
+		t.Error("success subscribe to the existing stream")
+		return
+	}
+
+	// wait ping event
+	time.Sleep(200 * time.Millisecond)
+
+	pubKey := tgPrivKey1.GetPubKey()
+	req := request.NewRequestBuilder().
+		WithMethod(http.MethodPost).
+		WithHost("hidden-some-host").
+		WithPath("/echo").
+		WithBody([]byte("hello")).
+		Build()
+
+	rsp, err := serviceStreams.Request(ctx, pubKey, req)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if string(rsp.GetBody()) != pubKey.ToString()+"hello" {
+		t.Error("got invalid response")
+		return
+	}
+
+	for _, path := range []string{"/off", "/failed"} {
+		reqOff := request.NewRequestBuilder().
+			WithHost("hidden-some-host").
+			WithPath(path).
+			Build()
+
+		rsp, err := serviceStreams.Request(ctx, pubKey, reqOff)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if rsp != nil {
+			t.Error("got response with mode off")
+			return
+		}
+	}
+
+	cancel()
+	time.Sleep(200 * time.Millisecond)
+
+	if serviceStreams.HasStream("hidden-some-host") {
+		t.Error("stream is not closed after disconnect")
+		return
+	}
+}
+
+func TestHandleServiceStreamAPIFailed(t *testing.T) {
+	t.Parallel()
+
+	logger := logger.NewLogger(
+		logger.NewSettings(&logger.SSettings{}),
+		func(_ logger.ILogArg) string { return "" },
+	)
+
+	handler := HandleServiceStreamAPI(logger, streams.NewStreams(time.Minute), time.Second)
+
+	if err := serviceStreamRequest(handler, http.MethodPost, "/?host=hidden-some-host"); err == nil {
+		t.Error("request success with invalid method")
+		return
+	}
+	if err := serviceStreamRequest(handler, http.MethodGet, "/"); err == nil {
+		t.Error("request success without host")
+		return
+	}
+}
+
+func serviceStreamRequest(handler http.HandlerFunc, method, target string) error {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, target, nil)
+
+	handler(w, req)
+	res := w.Result()
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return errors.New("bad status code") // nolint: err113
+	}
+
+	return nil
+}
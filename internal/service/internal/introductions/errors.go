@@ -0,0 +1,19 @@
+package introductions
+
+const (
+	errPrefix = "internal/service/internal/introductions = "
+)
+
+type SIntroductionsError struct {
+	str string
+}
+
+func (err *SIntroductionsError) Error() string {
+	return errPrefix + err.str
+}
+
+var (
+	ErrIntroductionsLimit   = &SIntroductionsError{"introductions limit"}
+	ErrIntroducerLimit      = &SIntroductionsError{"introducer limit"}
+	ErrIntroductionNotFound = &SIntroductionsError{"introduction not found"}
+)
@@ -0,0 +1,167 @@
+package introductions
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/number571/go-peer/pkg/crypto/asymmetric"
+	"github.com/number571/go-peer/pkg/crypto/random"
+	"github.com/number571/go-peer/pkg/encoding"
+)
+
+const (
+	cIntroductionIDSize = 16
+)
+
+var (
+	_ IIntroductions = &sIntroductions{}
+	_ IIntroduction  = &sIntroduction{}
+)
+
+type sIntroductions struct {
+	fMutex         sync.Mutex
+	fTTL           time.Duration
+	fLimit         uint64
+	fIntroducerLim uint64
+	fIntroductions map[string]*sIntroduction
+}
+
+type sIntroduction struct {
+	fID         string
+	fIntroducer asymmetric.IPubKey
+	fAliasName  string
+	fPubKey     asymmetric.IPubKey
+	fReceived   time.Time
+}
+
+// NewIntroductions creates the storage of pending introductions. The
+// introductions are only stored until they are accepted or rejected by
+// the user, expired introductions are removed after TTL. The limit of
+// introducer restricts the count of pending introductions from one friend,
+// so one friend cannot fill all the storage.
+func NewIntroductions(pTTL time.Duration, pLimit, pIntroducerLimit uint64) IIntroductions {
+	return &sIntroductions{
+		fTTL:           pTTL,
+		fLimit:         pLimit,
+		fIntroducerLim: pIntroducerLimit,
+		fIntroductions: make(map[string]*sIntroduction, 16),
+	}
+}
+
+// Push saves the introduction of the friend by the introducer. The repeated
+// introduction of the same key by the same introducer updates the old one.
+func (p *sIntroductions) Push(
+	pIntroducer asymmetric.IPubKey,
+	pAliasName string,
+	pPubKey asymmetric.IPubKey,
+) (string, error) {
+	p.fMutex.Lock()
+	defer p.fMutex.Unlock()
+
+	p.clearExpired()
+
+	introducerCount := uint64(0)
+	for _, v := range p.fIntroductions {
+		if v.fIntroducer.ToString() != pIntroducer.ToString() {
+			continue
+		}
+		if v.fPubKey.ToString() != pPubKey.ToString() {
+			introducerCount++
+			continue
+		}
+		v.fAliasName = pAliasName
+		v.fReceived = time.Now()
+		return v.fID, nil
+	}
+
+	if introducerCount >= p.fIntroducerLim {
+		return "", ErrIntroducerLimit
+	}
+	if uint64(len(p.fIntroductions)) >= p.fLimit {
+		return "", ErrIntroductionsLimit
+	}
+
+	introductionID := encoding.HexEncode(random.NewRandom().GetBytes(cIntroductionIDSize))
+	p.fIntroductions[introductionID] = &sIntroduction{
+		fID:         introductionID,
+		fIntroducer: pIntroducer,
+		fAliasName:  pAliasName,
+		fPubKey:     pPubKey,
+		fReceived:   time.Now(),
+	}
+	return introductionID, nil
+}
+
+func (p *sIntroductions) Load(pID string) (IIntroduction, error) {
+	p.fMutex.Lock()
+	defer p.fMutex.Unlock()
+
+	p.clearExpired()
+
+	introduction, ok := p.fIntroductions[pID]
+	if !ok {
+		return nil, ErrIntroductionNotFound
+	}
+
+	// copy is returned because introduction can be updated by push
+	result := *introduction
+	return &result, nil
+}
+
+func (p *sIntroductions) Delete(pID string) error {
+	p.fMutex.Lock()
+	defer p.fMutex.Unlock()
+
+	if _, ok := p.fIntroductions[pID]; !ok {
+		return ErrIntroductionNotFound
+	}
+	delete(p.fIntroductions, pID)
+	return nil
+}
+
+// List returns the pending introductions sorted by the time of receipt.
+func (p *sIntroductions) List() []IIntroduction {
+	p.fMutex.Lock()
+	defer p.fMutex.Unlock()
+
+	p.clearExpired()
+
+	result := make([]IIntroduction, 0, len(p.fIntroductions))
+	for _, v := range p.fIntroductions {
+		introduction := *v
+		result = append(result, &introduction)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].GetReceived().Before(result[j].GetReceived())
+	})
+	return result
+}
+
+func (p *sIntroductions) clearExpired() {
+	for k, v := range p.fIntroductions {
+		if time.Since(v.fReceived) > p.fTTL {
+			delete(p.fIntroductions, k)
+		}
+	}
+}
+
+func (p *sIntroduction) GetID() string {
+	return p.fID
+}
+
+func (p *sIntroduction) GetIntroducer() asymmetric.IPubKey {
+	return p.fIntroducer
+}
+
+func (p *sIntroduction) GetAliasName() string {
+	return p.fAliasName
+}
+
+func (p *sIntroduction) GetPubKey() asymmetric.IPubKey {
+	return p.fPubKey
+}
+
+func (p *sIntroduction) GetReceived() time.Time {
+	return p.fReceived
+}
@@ -0,0 +1,148 @@
+package introductions
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/number571/go-peer/pkg/crypto/asymmetric"
+)
+
+func TestError(t *testing.T) {
+	t.Parallel()
+
+	str := "value"
+	err := &SIntroductionsError{str}
+	if err.Error() != errPrefix+str {
+		t.Error("incorrect err.Error()")
+		return
+	}
+}
+
+func TestIntroductions(t *testing.T) {
+	t.Parallel()
+
+	var (
+		introducer = asymmetric.NewPrivKey().GetPubKey()
+		pubKey1    = asymmetric.NewPrivKey().GetPubKey()
+		pubKey2    = asymmetric.NewPrivKey().GetPubKey()
+		pubKey3    = asymmetric.NewPrivKey().GetPubKey()
+	)
+
+	introductions := NewIntroductions(time.Minute, 2, 3)
+
+	id1, err := introductions.Push(introducer, "Bob", pubKey1)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	id2, err := introductions.Push(introducer, "Carol", pubKey2)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// repeated introduction updates the old one
+	id3, err := introductions.Push(introducer, "Bob2", pubKey1)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if id3 != id1 {
+		t.Error("repeated introduction is saved as new")
+		return
+	}
+
+	if _, err := introductions.Push(introducer, "Dave", pubKey3); !errors.Is(err, ErrIntroductionsLimit) {
+		t.Error("success push with limit")
+		return
+	}
+
+	list := introductions.List()
+	if len(list) != 2 || list[0].GetID() != id2 || list[1].GetID() != id1 {
+		t.Error("got invalid list of introductions")
+		return
+	}
+
+	introduction, err := introductions.Load(id1)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if introduction.GetAliasName() != "Bob2" ||
+		introduction.GetPubKey().ToString() != pubKey1.ToString() ||
+		introduction.GetIntroducer().ToString() != introducer.ToString() {
+		t.Error("got invalid introduction")
+		return
+	}
+
+	if err := introductions.Delete(id1); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := introductions.Delete(id1); !errors.Is(err, ErrIntroductionNotFound) {
+		t.Error("success delete not found introduction")
+		return
+	}
+	if _, err := introductions.Load(id1); !errors.Is(err, ErrIntroductionNotFound) {
+		t.Error("success load deleted introduction")
+		return
+	}
+}
+
+func TestIntroducerLimit(t *testing.T) {
+	t.Parallel()
+
+	var (
+		introducer1 = asymmetric.NewPrivKey().GetPubKey()
+		introducer2 = asymmetric.NewPrivKey().GetPubKey()
+		pubKey1     = asymmetric.NewPrivKey().GetPubKey()
+		pubKey2     = asymmetric.NewPrivKey().GetPubKey()
+	)
+
+	introductions := NewIntroductions(time.Minute, 16, 1)
+
+	if _, err := introductions.Push(introducer1, "Bob", pubKey1); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err := introductions.Push(introducer1, "Bob", pubKey1); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err := introductions.Push(introducer1, "Carol", pubKey2); !errors.Is(err, ErrIntroducerLimit) {
+		t.Error("success push with limit of introducer")
+		return
+	}
+	if _, err := introductions.Push(introducer2, "Carol", pubKey2); err != nil {
+		t.Error(err)
+		return
+	}
+}
+
+func TestIntroductionsTTL(t *testing.T) {
+	t.Parallel()
+
+	introductions := NewIntroductions(100*time.Millisecond, 2, 2)
+
+	id, err := introductions.Push(
+		asymmetric.NewPrivKey().GetPubKey(),
+		"Bob",
+		asymmetric.NewPrivKey().GetPubKey(),
+	)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	time.Sleep(200 * time.Millisecond)
+
+	if _, err := introductions.Load(id); !errors.Is(err, ErrIntroductionNotFound) {
+		t.Error("success load expired introduction")
+		return
+	}
+	if len(introductions.List()) != 0 {
+		t.Error("expired introduction in the list")
+		return
+	}
+}
@@ -0,0 +1,22 @@
+package introductions
+
+import (
+	"time"
+
+	"github.com/number571/go-peer/pkg/crypto/asymmetric"
+)
+
+type IIntroductions interface {
+	Push(asymmetric.IPubKey, string, asymmetric.IPubKey) (string, error)
+	Load(string) (IIntroduction, error)
+	Delete(string) error
+	List() []IIntroduction
+}
+
+type IIntroduction interface {
+	GetID() string
+	GetIntroducer() asymmetric.IPubKey
+	GetAliasName() string
+	GetPubKey() asymmetric.IPubKey
+	GetReceived() time.Time
+}
@@ -0,0 +1,18 @@
+package limiter
+
+const (
+	errPrefix = "internal/service/internal/limiter = "
+)
+
+type SLimiterError struct {
+	str string
+}
+
+func (err *SLimiterError) Error() string {
+	return errPrefix + err.str
+}
+
+var (
+	ErrRequestsLimit = &SLimiterError{"requests limit"}
+	ErrBytesLimit    = &SLimiterError{"bytes limit"}
+)
@@ -0,0 +1,167 @@
+package limiter
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	_ ILimiter = &sLimiter{}
+	_ ICounter = &sCounter{}
+)
+
+type sLimiter struct {
+	fMutex   sync.Mutex
+	fBuckets map[sBucketKey]*sBucket
+}
+
+type sBucketKey struct {
+	fAliasName string
+	fHostName  string
+}
+
+// sBucket contains two token buckets (requests and bytes) of the friend
+// for the service and the counters of accepted and rejected requests.
+type sBucket struct {
+	fUpdated       time.Time
+	fRequestsRate  uint64
+	fBytesRate     uint64
+	fRequestsToken float64
+	fBytesToken    float64
+	fRequests      uint64
+	fBytes         uint64
+	fRejected      uint64
+}
+
+type sCounter struct {
+	fAliasName         string
+	fHostName          string
+	fRequests          uint64
+	fBytes             uint64
+	fRejected          uint64
+	fAvailableRequests uint64
+	fAvailableBytes    uint64
+}
+
+// NewLimiter creates the token buckets for each friend and service.
+// The capacity of bucket is equal to the rate per minute, so the burst
+// of requests can be equal to the limit of one minute.
+func NewLimiter() ILimiter {
+	return &sLimiter{
+		fBuckets: make(map[sBucketKey]*sBucket, 64),
+	}
+}
+
+func (p *sLimiter) Allow(pAliasName, pHostName string, pRate IRate, pSize uint64) error {
+	requestsRate := pRate.GetRequestsPerMinute()
+	bytesRate := pRate.GetBytesPerMinute()
+	if requestsRate == 0 && bytesRate == 0 {
+		return nil
+	}
+
+	p.fMutex.Lock()
+	defer p.fMutex.Unlock()
+
+	now := time.Now()
+	key := sBucketKey{fAliasName: pAliasName, fHostName: pHostName}
+
+	bucket, ok := p.fBuckets[key]
+	if !ok {
+		bucket = &sBucket{
+			fUpdated:       now,
+			fRequestsToken: float64(requestsRate),
+			fBytesToken:    float64(bytesRate),
+		}
+		p.fBuckets[key] = bucket
+	}
+
+	// rates can be changed by the reloading of config
+	bucket.fRequestsRate = requestsRate
+	bucket.fBytesRate = bytesRate
+	bucket.refill(now)
+
+	if requestsRate != 0 && bucket.fRequestsToken < 1 {
+		bucket.fRejected++
+		return ErrRequestsLimit
+	}
+	if bytesRate != 0 && bucket.fBytesToken < float64(pSize) {
+		bucket.fRejected++
+		return ErrBytesLimit
+	}
+
+	if requestsRate != 0 {
+		bucket.fRequestsToken--
+	}
+	if bytesRate != 0 {
+		bucket.fBytesToken -= float64(pSize)
+	}
+
+	bucket.fRequests++
+	bucket.fBytes += pSize
+	return nil
+}
+
+func (p *sLimiter) GetCounters() []ICounter {
+	p.fMutex.Lock()
+	defer p.fMutex.Unlock()
+
+	now := time.Now()
+	result := make([]ICounter, 0, len(p.fBuckets))
+	for key, bucket := range p.fBuckets {
+		bucket.refill(now)
+		result = append(result, &sCounter{
+			fAliasName:         key.fAliasName,
+			fHostName:          key.fHostName,
+			fRequests:          bucket.fRequests,
+			fBytes:             bucket.fBytes,
+			fRejected:          bucket.fRejected,
+			fAvailableRequests: uint64(bucket.fRequestsToken),
+			fAvailableBytes:    uint64(bucket.fBytesToken),
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].GetAliasName() != result[j].GetAliasName() {
+			return result[i].GetAliasName() < result[j].GetAliasName()
+		}
+		return result[i].GetHostName() < result[j].GetHostName()
+	})
+	return result
+}
+
+func (p *sBucket) refill(pNow time.Time) {
+	elapsed := pNow.Sub(p.fUpdated).Minutes()
+	p.fUpdated = pNow
+
+	p.fRequestsToken = min(float64(p.fRequestsRate), p.fRequestsToken+elapsed*float64(p.fRequestsRate))
+	p.fBytesToken = min(float64(p.fBytesRate), p.fBytesToken+elapsed*float64(p.fBytesRate))
+}
+
+func (p *sCounter) GetAliasName() string {
+	return p.fAliasName
+}
+
+func (p *sCounter) GetHostName() string {
+	return p.fHostName
+}
+
+func (p *sCounter) GetRequests() uint64 {
+	return p.fRequests
+}
+
+func (p *sCounter) GetBytes() uint64 {
+	return p.fBytes
+}
+
+func (p *sCounter) GetRejected() uint64 {
+	return p.fRejected
+}
+
+func (p *sCounter) GetAvailableRequests() uint64 {
+	return p.fAvailableRequests
+}
+
+func (p *sCounter) GetAvailableBytes() uint64 {
+	return p.fAvailableBytes
+}
@@ -0,0 +1,92 @@
+package limiter
+
+import (
+	"errors"
+	"testing"
+)
+
+type tsRate struct {
+	fRequests uint64
+	fBytes    uint64
+}
+
+func (p *tsRate) GetRequestsPerMinute() uint64 { return p.fRequests }
+func (p *tsRate) GetBytesPerMinute() uint64    { return p.fBytes }
+
+func TestError(t *testing.T) {
+	t.Parallel()
+
+	str := "value"
+	err := &SLimiterError{str}
+	if err.Error() != errPrefix+str {
+		t.Error("incorrect err.Error()")
+		return
+	}
+}
+
+func TestLimiter(t *testing.T) {
+	t.Parallel()
+
+	limiter := NewLimiter()
+
+	// not limited
+	for i := 0; i < 10; i++ {
+		if err := limiter.Allow("a", "service", &tsRate{}, 1024); err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	if len(limiter.GetCounters()) != 0 {
+		t.Error("counters of unlimited requests are stored")
+		return
+	}
+
+	requestsRate := &tsRate{fRequests: 2}
+	for i := 0; i < 2; i++ {
+		if err := limiter.Allow("a", "service", requestsRate, 10); err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	if err := limiter.Allow("a", "service", requestsRate, 10); !errors.Is(err, ErrRequestsLimit) {
+		t.Error("success request over the limit of requests")
+		return
+	}
+
+	// buckets are separated by friends and services
+	if err := limiter.Allow("b", "service", requestsRate, 10); err != nil {
+		t.Error(err)
+		return
+	}
+	bytesRate := &tsRate{fBytes: 100}
+	if err := limiter.Allow("a", "other", bytesRate, 60); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := limiter.Allow("a", "other", bytesRate, 60); !errors.Is(err, ErrBytesLimit) {
+		t.Error("success request over the limit of bytes")
+		return
+	}
+
+	counters := limiter.GetCounters()
+	if len(counters) != 3 {
+		t.Error("got invalid count of counters")
+		return
+	}
+
+	other := counters[0]
+	if other.GetAliasName() != "a" || other.GetHostName() != "other" {
+		t.Error("counters are not sorted")
+		return
+	}
+	if other.GetRequests() != 1 || other.GetBytes() != 60 || other.GetRejected() != 1 || other.GetAvailableBytes() != 40 {
+		t.Error("got invalid counter (bytes)")
+		return
+	}
+
+	service := counters[1]
+	if service.GetRequests() != 2 || service.GetBytes() != 20 || service.GetRejected() != 1 || service.GetAvailableRequests() != 0 {
+		t.Error("got invalid counter (requests)")
+		return
+	}
+}
@@ -0,0 +1,23 @@
+package limiter
+
+type ILimiter interface {
+	Allow(string, string, IRate, uint64) error
+	GetCounters() []ICounter
+}
+
+// IRate is a capacity of buckets which are refilled per minute.
+// Zero value of the rate is not limited.
+type IRate interface {
+	GetRequestsPerMinute() uint64
+	GetBytesPerMinute() uint64
+}
+
+type ICounter interface {
+	GetAliasName() string
+	GetHostName() string
+	GetRequests() uint64
+	GetBytes() uint64
+	GetRejected() uint64
+	GetAvailableRequests() uint64
+	GetAvailableBytes() uint64
+}
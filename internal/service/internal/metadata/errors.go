@@ -0,0 +1,22 @@
+package metadata
+
+const (
+	errPrefix = "internal/service/internal/metadata = "
+)
+
+type SMetadataError struct {
+	str string
+}
+
+func (err *SMetadataError) Error() string {
+	return errPrefix + err.str
+}
+
+var (
+	ErrInvalidNotes   = &SMetadataError{"invalid notes"}
+	ErrInvalidTags    = &SMetadataError{"invalid tags"}
+	ErrGetMetadata    = &SMetadataError{"get metadata"}
+	ErrSetMetadata    = &SMetadataError{"set metadata"}
+	ErrDelMetadata    = &SMetadataError{"delete metadata"}
+	ErrDecodeMetadata = &SMetadataError{"decode metadata"}
+)
@@ -0,0 +1,221 @@
+package metadata
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/number571/go-peer/pkg/crypto/asymmetric"
+	"github.com/number571/go-peer/pkg/encoding"
+	"github.com/number571/go-peer/pkg/storage/database"
+)
+
+const (
+	// the prefix separates metadata from hashes of messages in the database
+	cKeyPrefix = "hls/friends/metadata/"
+
+	cMaxNotesSize = (1 << 10)
+	cMaxTagSize   = (1 << 6)
+	cMaxTagsCount = (1 << 4)
+)
+
+var (
+	_ IMetadata   = &sMetadata{}
+	_ IFriendMeta = &sFriendMeta{}
+)
+
+type sMetadata struct {
+	fMutex     sync.Mutex
+	fDatabase  database.IKVDatabase
+	fPrecision time.Duration
+	fLastSeen  map[string]time.Time
+}
+
+type sFriendMeta struct {
+	FNotes    string   `json:"notes,omitempty"`
+	FTags     []string `json:"tags,omitempty"`
+	FAdded    int64    `json:"added,omitempty"`
+	FLastSeen int64    `json:"last_seen,omitempty"`
+}
+
+// NewMetadata creates the storage of friends metadata (notes, tags, time of
+// adding and last-seen time) in the database of the HLS, so the format of
+// config is not changed. The last-seen time is saved to the database not
+// more often than once per precision to not write on each request.
+func NewMetadata(pDatabase database.IKVDatabase, pPrecision time.Duration) IMetadata {
+	return &sMetadata{
+		fDatabase:  pDatabase,
+		fPrecision: pPrecision,
+		fLastSeen:  make(map[string]time.Time, 64),
+	}
+}
+
+// Get returns the metadata of friend. Empty metadata is returned
+// if nothing is stored for the public key.
+func (p *sMetadata) Get(pPubKey asymmetric.IPubKey) (IFriendMeta, error) {
+	p.fMutex.Lock()
+	defer p.fMutex.Unlock()
+
+	return p.get(pPubKey)
+}
+
+func (p *sMetadata) Delete(pPubKey asymmetric.IPubKey) error {
+	p.fMutex.Lock()
+	defer p.fMutex.Unlock()
+
+	key := getKey(pPubKey)
+	delete(p.fLastSeen, key)
+
+	if err := p.fDatabase.Del([]byte(key)); err != nil {
+		return errors.Join(ErrDelMetadata, err)
+	}
+	return nil
+}
+
+// SetInfo replaces notes and tags of friend. Tags are trimmed,
+// empty and repeated tags are removed.
+func (p *sMetadata) SetInfo(pPubKey asymmetric.IPubKey, pNotes string, pTags []string) error {
+	if err := ValidateInfo(pNotes, pTags); err != nil {
+		return err
+	}
+	tags, _ := normalizeTags(pTags)
+
+	p.fMutex.Lock()
+	defer p.fMutex.Unlock()
+
+	meta, err := p.get(pPubKey)
+	if err != nil {
+		return err
+	}
+
+	meta.FNotes = pNotes
+	meta.FTags = tags
+	return p.set(pPubKey, meta)
+}
+
+// SetAdded saves the current time as the time of adding friend.
+// The time is not changed if it is already set.
+func (p *sMetadata) SetAdded(pPubKey asymmetric.IPubKey) error {
+	p.fMutex.Lock()
+	defer p.fMutex.Unlock()
+
+	meta, err := p.get(pPubKey)
+	if err != nil {
+		return err
+	}
+	if meta.FAdded != 0 {
+		return nil
+	}
+
+	meta.FAdded = time.Now().Unix()
+	return p.set(pPubKey, meta)
+}
+
+func (p *sMetadata) SetLastSeen(pPubKey asymmetric.IPubKey) error {
+	p.fMutex.Lock()
+	defer p.fMutex.Unlock()
+
+	now := time.Now()
+	key := getKey(pPubKey)
+	if lastSeen, ok := p.fLastSeen[key]; ok && now.Sub(lastSeen) < p.fPrecision {
+		return nil
+	}
+
+	meta, err := p.get(pPubKey)
+	if err != nil {
+		return err
+	}
+
+	meta.FLastSeen = now.Unix()
+	if err := p.set(pPubKey, meta); err != nil {
+		return err
+	}
+
+	p.fLastSeen[key] = now
+	return nil
+}
+
+func (p *sMetadata) get(pPubKey asymmetric.IPubKey) (*sFriendMeta, error) {
+	data, err := p.fDatabase.Get([]byte(getKey(pPubKey)))
+	if err != nil {
+		if errors.Is(err, database.ErrNotFound) {
+			return &sFriendMeta{}, nil
+		}
+		return nil, errors.Join(ErrGetMetadata, err)
+	}
+
+	meta := new(sFriendMeta)
+	if err := encoding.DeserializeJSON(data, meta); err != nil {
+		return nil, errors.Join(ErrDecodeMetadata, err)
+	}
+	return meta, nil
+}
+
+func (p *sMetadata) set(pPubKey asymmetric.IPubKey, pMeta *sFriendMeta) error {
+	if err := p.fDatabase.Set([]byte(getKey(pPubKey)), encoding.SerializeJSON(pMeta)); err != nil {
+		return errors.Join(ErrSetMetadata, err)
+	}
+	return nil
+}
+
+func (p *sFriendMeta) GetNotes() string {
+	return p.FNotes
+}
+
+func (p *sFriendMeta) GetTags() []string {
+	return p.FTags
+}
+
+func (p *sFriendMeta) GetAdded() time.Time {
+	return unixToTime(p.FAdded)
+}
+
+func (p *sFriendMeta) GetLastSeen() time.Time {
+	return unixToTime(p.FLastSeen)
+}
+
+// ValidateInfo checks notes and tags of friend before they are saved.
+func ValidateInfo(pNotes string, pTags []string) error {
+	if len(pNotes) > cMaxNotesSize {
+		return ErrInvalidNotes
+	}
+	if _, err := normalizeTags(pTags); err != nil {
+		return err
+	}
+	return nil
+}
+
+func normalizeTags(pTags []string) ([]string, error) {
+	result := make([]string, 0, len(pTags))
+	mapping := make(map[string]struct{}, len(pTags))
+	for _, tag := range pTags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+		if len(tag) > cMaxTagSize {
+			return nil, ErrInvalidTags
+		}
+		if _, ok := mapping[tag]; ok {
+			continue
+		}
+		mapping[tag] = struct{}{}
+		result = append(result, tag)
+	}
+	if len(result) > cMaxTagsCount {
+		return nil, ErrInvalidTags
+	}
+	return result, nil
+}
+
+func unixToTime(pUnix int64) time.Time {
+	if pUnix == 0 {
+		return time.Time{}
+	}
+	return time.Unix(pUnix, 0)
+}
+
+func getKey(pPubKey asymmetric.IPubKey) string {
+	return cKeyPrefix + pPubKey.GetHasher().ToString()
+}
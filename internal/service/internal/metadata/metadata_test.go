@@ -0,0 +1,171 @@
+package metadata
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/number571/go-peer/pkg/crypto/asymmetric"
+	"github.com/number571/go-peer/pkg/storage/database"
+)
+
+func TestError(t *testing.T) {
+	t.Parallel()
+
+	str := "value"
+	err := &SMetadataError{str}
+	if err.Error() != errPrefix+str {
+		t.Error("incorrect err.Error()")
+		return
+	}
+}
+
+func TestMetadata(t *testing.T) {
+	t.Parallel()
+
+	db, err := database.NewKVDatabase(filepath.Join(t.TempDir(), "hls.db"))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer db.Close()
+
+	metadata := NewMetadata(db, time.Hour)
+	pubKey := asymmetric.NewPrivKey().GetPubKey()
+
+	meta, err := metadata.Get(pubKey)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !meta.GetAdded().IsZero() || !meta.GetLastSeen().IsZero() || meta.GetNotes() != "" {
+		t.Error("got not empty metadata")
+		return
+	}
+
+	if err := metadata.SetAdded(pubKey); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := metadata.SetInfo(pubKey, "notes", []string{" work ", "", "work", "family"}); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := metadata.SetLastSeen(pubKey); err != nil {
+		t.Error(err)
+		return
+	}
+
+	meta, err = metadata.Get(pubKey)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	added := meta.GetAdded()
+	if added.IsZero() || meta.GetLastSeen().IsZero() {
+		t.Error("time is not saved")
+		return
+	}
+	if meta.GetNotes() != "notes" || strings.Join(meta.GetTags(), ",") != "work,family" {
+		t.Error("got invalid notes or tags")
+		return
+	}
+
+	// the time of adding is not changed
+	if err := metadata.SetAdded(pubKey); err != nil {
+		t.Error(err)
+		return
+	}
+	meta, _ = metadata.Get(pubKey)
+	if !meta.GetAdded().Equal(added) {
+		t.Error("time of adding is changed")
+		return
+	}
+
+	if err := metadata.SetInfo(pubKey, strings.Repeat("a", cMaxNotesSize+1), nil); !errors.Is(err, ErrInvalidNotes) {
+		t.Error("success set invalid notes")
+		return
+	}
+	if err := metadata.SetInfo(pubKey, "", []string{strings.Repeat("a", cMaxTagSize+1)}); !errors.Is(err, ErrInvalidTags) {
+		t.Error("success set invalid tag")
+		return
+	}
+	if err := ValidateInfo("notes", []string{"tag"}); err != nil {
+		t.Error(err)
+		return
+	}
+	tooManyTags := make([]string, 0, cMaxTagsCount+1)
+	for i := 0; i <= cMaxTagsCount; i++ {
+		tooManyTags = append(tooManyTags, strings.Repeat("a", i+1))
+	}
+	if err := metadata.SetInfo(pubKey, "", tooManyTags); !errors.Is(err, ErrInvalidTags) {
+		t.Error("success set too many tags")
+		return
+	}
+
+	if err := metadata.Delete(pubKey); err != nil {
+		t.Error(err)
+		return
+	}
+	meta, _ = metadata.Get(pubKey)
+	if !meta.GetAdded().IsZero() || meta.GetNotes() != "" {
+		t.Error("metadata is not deleted")
+		return
+	}
+
+	if err := db.Set([]byte(getKey(pubKey)), []byte("undefined")); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err := metadata.Get(pubKey); !errors.Is(err, ErrDecodeMetadata) {
+		t.Error("success get invalid metadata")
+		return
+	}
+}
+
+func TestMetadataLastSeen(t *testing.T) {
+	t.Parallel()
+
+	db, err := database.NewKVDatabase(filepath.Join(t.TempDir(), "hls.db"))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer db.Close()
+
+	metadata := NewMetadata(db, time.Hour)
+	pubKey := asymmetric.NewPrivKey().GetPubKey()
+
+	if err := metadata.SetLastSeen(pubKey); err != nil {
+		t.Error(err)
+		return
+	}
+
+	// the next update is skipped by the precision
+	if err := db.Del([]byte(getKey(pubKey))); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := metadata.SetLastSeen(pubKey); err != nil {
+		t.Error(err)
+		return
+	}
+	meta, _ := metadata.Get(pubKey)
+	if !meta.GetLastSeen().IsZero() {
+		t.Error("last-seen time is updated before precision")
+		return
+	}
+
+	metadataNoPrecision := NewMetadata(db, 0)
+	if err := metadataNoPrecision.SetLastSeen(pubKey); err != nil {
+		t.Error(err)
+		return
+	}
+	meta, _ = metadataNoPrecision.Get(pubKey)
+	if meta.GetLastSeen().IsZero() {
+		t.Error("last-seen time is not updated")
+		return
+	}
+}
@@ -0,0 +1,23 @@
+package metadata
+
+import (
+	"time"
+
+	"github.com/number571/go-peer/pkg/crypto/asymmetric"
+)
+
+type IMetadata interface {
+	Get(asymmetric.IPubKey) (IFriendMeta, error)
+	Delete(asymmetric.IPubKey) error
+
+	SetInfo(asymmetric.IPubKey, string, []string) error
+	SetAdded(asymmetric.IPubKey) error
+	SetLastSeen(asymmetric.IPubKey) error
+}
+
+type IFriendMeta interface {
+	GetNotes() string
+	GetTags() []string
+	GetAdded() time.Time
+	GetLastSeen() time.Time
+}
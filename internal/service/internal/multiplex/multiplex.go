@@ -0,0 +1,145 @@
+package multiplex
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	anon_logger "github.com/number571/go-peer/pkg/anonymity/logger"
+	"github.com/number571/go-peer/pkg/logger"
+	"github.com/number571/go-peer/pkg/message/layer1"
+	hls_settings "github.com/number571/hidden-lake/internal/service/pkg/settings"
+	internal_anon_logger "github.com/number571/hidden-lake/internal/utils/logger/anon"
+	"github.com/number571/hidden-lake/pkg/adapters"
+)
+
+var (
+	_ IMultiplexer            = &sMultiplexer{}
+	_ adapters.IRunnerAdapter = &sAdapter{}
+)
+
+type sMultiplexer struct {
+	fLogger   logger.ILogger
+	fAdapter  adapters.IRunnerAdapter
+	fAdapters []*sAdapter
+}
+
+type sAdapter struct {
+	fMultiplexer *sMultiplexer
+	fNetMsgChan  chan layer1.IMessage
+}
+
+// NewMultiplexer shares one adapter (set of connections) between several
+// anonymity nodes. Each consumed message is passed to all nodes, produced
+// messages are also passed to the other nodes, so identities of one
+// service can communicate with each other. The message is dropped for
+// the node which queue is full, so one slow node does not block others.
+func NewMultiplexer(
+	pLogger logger.ILogger,
+	pAdapter adapters.IRunnerAdapter,
+	pCount, pCapacity uint64,
+) IMultiplexer {
+	multiplexer := &sMultiplexer{
+		fLogger:   pLogger,
+		fAdapter:  pAdapter,
+		fAdapters: make([]*sAdapter, 0, pCount),
+	}
+	for i := uint64(0); i < pCount; i++ {
+		multiplexer.fAdapters = append(multiplexer.fAdapters, &sAdapter{
+			fMultiplexer: multiplexer,
+			fNetMsgChan:  make(chan layer1.IMessage, pCapacity),
+		})
+	}
+	return multiplexer
+}
+
+func (p *sMultiplexer) GetAdapter(pIndex uint64) adapters.IRunnerAdapter {
+	return p.fAdapters[pIndex]
+}
+
+func (p *sMultiplexer) GetCount() uint64 {
+	return uint64(len(p.fAdapters))
+}
+
+func (p *sMultiplexer) Run(pCtx context.Context) error {
+	chCtx, cancel := context.WithCancel(pCtx)
+	defer cancel()
+
+	const N = 2
+
+	errs := make([]error, N)
+	wg := &sync.WaitGroup{}
+	wg.Add(N)
+
+	go func() {
+		defer func() { wg.Done(); cancel() }()
+		errs[0] = p.fAdapter.Run(chCtx)
+	}()
+
+	go func() {
+		defer func() { wg.Done(); cancel() }()
+		errs[1] = p.runConsumer(chCtx)
+	}()
+
+	wg.Wait()
+
+	select {
+	case <-pCtx.Done():
+		return pCtx.Err()
+	default:
+		return errors.Join(errs...)
+	}
+}
+
+func (p *sMultiplexer) runConsumer(pCtx context.Context) error {
+	for {
+		netMsg, err := p.fAdapter.Consume(pCtx)
+		if err != nil {
+			select {
+			case <-pCtx.Done():
+				return pCtx.Err()
+			default:
+				// some error of adapter
+				continue
+			}
+		}
+		p.broadcast(netMsg, nil)
+	}
+}
+
+func (p *sMultiplexer) broadcast(pNetMsg layer1.IMessage, pExcept *sAdapter) {
+	for _, a := range p.fAdapters {
+		if a == pExcept {
+			continue
+		}
+		select {
+		case a.fNetMsgChan <- pNetMsg:
+		default:
+			p.fLogger.PushWarn(anon_logger.NewLogBuilder(hls_settings.GServiceName.Short()).
+				WithType(internal_anon_logger.CLogWarnMultiplexDrop).
+				WithHash(pNetMsg.GetHash()).
+				WithProof(pNetMsg.GetProof()).
+				WithSize(len(pNetMsg.ToBytes())))
+		}
+	}
+}
+
+// Run of the shared adapter is called by the multiplexer.
+func (p *sAdapter) Run(pCtx context.Context) error {
+	<-pCtx.Done()
+	return pCtx.Err()
+}
+
+func (p *sAdapter) Produce(pCtx context.Context, pNetMsg layer1.IMessage) error {
+	p.fMultiplexer.broadcast(pNetMsg, p)
+	return p.fMultiplexer.fAdapter.Produce(pCtx, pNetMsg)
+}
+
+func (p *sAdapter) Consume(pCtx context.Context) (layer1.IMessage, error) {
+	select {
+	case <-pCtx.Done():
+		return nil, pCtx.Err()
+	case msg := <-p.fNetMsgChan:
+		return msg, nil
+	}
+}
@@ -0,0 +1,193 @@
+package multiplex
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	gopeer_adapters "github.com/number571/go-peer/pkg/anonymity/adapters"
+	"github.com/number571/go-peer/pkg/logger"
+	"github.com/number571/go-peer/pkg/message/layer1"
+	"github.com/number571/go-peer/pkg/payload"
+	"github.com/number571/hidden-lake/pkg/adapters"
+)
+
+func TestMultiplexer(t *testing.T) {
+	t.Parallel()
+
+	var (
+		chConsume = make(chan layer1.IMessage, 1)
+		chProduce = make(chan layer1.IMessage, 1)
+	)
+
+	adapter := adapters.NewRunnerAdapter(
+		gopeer_adapters.NewAdapterByFuncs(
+			func(_ context.Context, pMsg layer1.IMessage) error {
+				chProduce <- pMsg
+				return nil
+			},
+			func(pCtx context.Context) (layer1.IMessage, error) {
+				select {
+				case <-pCtx.Done():
+					return nil, pCtx.Err()
+				case msg := <-chConsume:
+					return msg, nil
+				}
+			},
+		),
+		func(pCtx context.Context) error {
+			<-pCtx.Done()
+			return pCtx.Err()
+		},
+	)
+
+	multiplexer := NewMultiplexer(testNewLogger(), adapter, 3, 4)
+	if multiplexer.GetCount() != 3 {
+		t.Error("invalid count of adapters")
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() { _ = multiplexer.Run(ctx) }()
+	for i := uint64(0); i < multiplexer.GetCount(); i++ {
+		go func(i uint64) { _ = multiplexer.GetAdapter(i).Run(ctx) }(i)
+	}
+
+	// consumed message is passed to all adapters
+	msg1 := testNewMessage("consumed")
+	chConsume <- msg1
+	for i := uint64(0); i < multiplexer.GetCount(); i++ {
+		if err := testConsume(ctx, multiplexer.GetAdapter(i), msg1); err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	// produced message is passed to the network and other adapters
+	msg2 := testNewMessage("produced")
+	if err := multiplexer.GetAdapter(0).Produce(ctx, msg2); err != nil {
+		t.Error(err)
+		return
+	}
+	select {
+	case msg := <-chProduce:
+		if !bytes.Equal(msg.GetHash(), msg2.GetHash()) {
+			t.Error("invalid produced message")
+			return
+		}
+	case <-time.After(time.Second):
+		t.Error("message is not produced")
+		return
+	}
+	for i := uint64(1); i < multiplexer.GetCount(); i++ {
+		if err := testConsume(ctx, multiplexer.GetAdapter(i), msg2); err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	ctx1, cancel1 := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel1()
+
+	if _, err := multiplexer.GetAdapter(0).Consume(ctx1); err == nil {
+		t.Error("success consume own produced message")
+		return
+	}
+
+	cancel()
+	if err := multiplexer.Run(ctx); !errors.Is(err, context.Canceled) {
+		t.Error("success run with canceled context")
+		return
+	}
+}
+
+func TestMultiplexerDrop(t *testing.T) {
+	t.Parallel()
+
+	adapter := adapters.NewRunnerAdapter(
+		gopeer_adapters.NewAdapterByFuncs(
+			func(_ context.Context, _ layer1.IMessage) error { return nil },
+			func(pCtx context.Context) (layer1.IMessage, error) {
+				<-pCtx.Done()
+				return nil, pCtx.Err()
+			},
+		),
+		func(pCtx context.Context) error {
+			<-pCtx.Done()
+			return pCtx.Err()
+		},
+	)
+
+	multiplexer := NewMultiplexer(testNewLogger(), adapter, 2, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// the full queue of the other node does not block the producer
+	msg1, msg2 := testNewMessage("msg1"), testNewMessage("msg2")
+	done := make(chan error, 1)
+	go func() {
+		errs := make([]error, 0, 2)
+		errs = append(errs, multiplexer.GetAdapter(0).Produce(ctx, msg1))
+		errs = append(errs, multiplexer.GetAdapter(0).Produce(ctx, msg2))
+		done <- errors.Join(errs...)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	case <-time.After(time.Second):
+		t.Error("producer is blocked by the full queue")
+		return
+	}
+
+	if err := testConsume(ctx, multiplexer.GetAdapter(1), msg1); err != nil {
+		t.Error(err)
+		return
+	}
+
+	ctx1, cancel1 := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel1()
+
+	if _, err := multiplexer.GetAdapter(1).Consume(ctx1); err == nil {
+		t.Error("success consume dropped message")
+		return
+	}
+}
+
+func testNewLogger() logger.ILogger {
+	return logger.NewLogger(
+		logger.NewSettings(&logger.SSettings{}),
+		func(_ logger.ILogArg) string { return "" },
+	)
+}
+
+func testConsume(pCtx context.Context, pAdapter adapters.IRunnerAdapter, pMsg layer1.IMessage) error {
+	ctx, cancel := context.WithTimeout(pCtx, time.Second)
+	defer cancel()
+
+	msg, err := pAdapter.Consume(ctx)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(msg.GetHash(), pMsg.GetHash()) {
+		return errors.New("invalid consumed message") // nolint: err113
+	}
+	return nil
+}
+
+func testNewMessage(pBody string) layer1.IMessage {
+	return layer1.NewMessage(
+		layer1.NewConstructSettings(&layer1.SConstructSettings{
+			FSettings: layer1.NewSettings(&layer1.SSettings{}),
+		}),
+		payload.NewPayload32(0x01, []byte(pBody)),
+	)
+}
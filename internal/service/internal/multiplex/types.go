@@ -0,0 +1,12 @@
+package multiplex
+
+import (
+	"github.com/number571/go-peer/pkg/types"
+	"github.com/number571/hidden-lake/pkg/adapters"
+)
+
+type IMultiplexer interface {
+	types.IRunner
+	GetAdapter(uint64) adapters.IRunnerAdapter
+	GetCount() uint64
+}
@@ -0,0 +1,21 @@
+package streams
+
+const (
+	errPrefix = "internal/service/internal/streams = "
+)
+
+type SStreamsError struct {
+	str string
+}
+
+func (err *SStreamsError) Error() string {
+	return errPrefix + err.str
+}
+
+var (
+	ErrStreamExists    = &SStreamsError{"stream already exists"}
+	ErrStreamNotFound  = &SStreamsError{"stream not found"}
+	ErrStreamClosed    = &SStreamsError{"stream closed"}
+	ErrRequestNotFound = &SStreamsError{"request not found"}
+	ErrReplyTimeout    = &SStreamsError{"reply timeout"}
+)
@@ -0,0 +1,185 @@
+package streams
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/number571/go-peer/pkg/crypto/asymmetric"
+	"github.com/number571/go-peer/pkg/crypto/random"
+	"github.com/number571/go-peer/pkg/encoding"
+	"github.com/number571/hidden-lake/pkg/request"
+	"github.com/number571/hidden-lake/pkg/response"
+)
+
+const (
+	cRequestIDSize = 16
+)
+
+var (
+	_ IStreams  = &sStreams{}
+	_ IStream   = &sStream{}
+	_ IIncoming = &sIncoming{}
+)
+
+type sStreams struct {
+	fMutex   sync.Mutex
+	fTimeout time.Duration
+	fStreams map[string]*sStream
+	fPending map[string]chan response.IResponse
+}
+
+type sStream struct {
+	fStreams  *sStreams
+	fHost     string
+	fIncoming chan IIncoming
+	fClosed   chan struct{}
+	fOnce     sync.Once
+}
+
+type sIncoming struct {
+	fRequestID string
+	fSender    asymmetric.IPubKey
+	fRequest   request.IRequest
+}
+
+// NewStreams creates the registry of pull-mode services. Each hostname
+// can have only one subscriber, the timeout restricts the waiting time
+// of the subscriber's reply.
+func NewStreams(pTimeout time.Duration) IStreams {
+	return &sStreams{
+		fTimeout: pTimeout,
+		fStreams: make(map[string]*sStream, 16),
+		fPending: make(map[string]chan response.IResponse, 64),
+	}
+}
+
+func (p *sStreams) HasStream(pHost string) bool {
+	p.fMutex.Lock()
+	defer p.fMutex.Unlock()
+
+	_, ok := p.fStreams[pHost]
+	return ok
+}
+
+func (p *sStreams) Subscribe(pHost string) (IStream, error) {
+	p.fMutex.Lock()
+	defer p.fMutex.Unlock()
+
+	if _, ok := p.fStreams[pHost]; ok {
+		return nil, ErrStreamExists
+	}
+
+	stream := &sStream{
+		fStreams:  p,
+		fHost:     pHost,
+		fIncoming: make(chan IIncoming),
+		fClosed:   make(chan struct{}),
+	}
+	p.fStreams[pHost] = stream
+	return stream, nil
+}
+
+// Request passes the request to the subscriber of the hostname and waits
+// for the reply. The nil response means that the response mode is 'off'.
+func (p *sStreams) Request(
+	pCtx context.Context,
+	pSender asymmetric.IPubKey,
+	pRequest request.IRequest,
+) (response.IResponse, error) {
+	requestID := encoding.HexEncode(random.NewRandom().GetBytes(cRequestIDSize))
+	result := make(chan response.IResponse, 1)
+
+	p.fMutex.Lock()
+	stream, ok := p.fStreams[pRequest.GetHost()]
+	if ok {
+		p.fPending[requestID] = result
+	}
+	p.fMutex.Unlock()
+
+	if !ok {
+		return nil, ErrStreamNotFound
+	}
+
+	defer func() {
+		p.fMutex.Lock()
+		delete(p.fPending, requestID)
+		p.fMutex.Unlock()
+	}()
+
+	timer := time.NewTimer(p.fTimeout)
+	defer timer.Stop()
+
+	incoming := &sIncoming{
+		fRequestID: requestID,
+		fSender:    pSender,
+		fRequest:   pRequest,
+	}
+
+	select {
+	case <-pCtx.Done():
+		return nil, pCtx.Err()
+	case <-stream.fClosed:
+		return nil, ErrStreamClosed
+	case <-timer.C:
+		return nil, ErrReplyTimeout
+	case stream.fIncoming <- incoming:
+	}
+
+	select {
+	case <-pCtx.Done():
+		return nil, pCtx.Err()
+	case <-stream.fClosed:
+		return nil, ErrStreamClosed
+	case <-timer.C:
+		return nil, ErrReplyTimeout
+	case rsp := <-result:
+		return rsp, nil
+	}
+}
+
+func (p *sStreams) Reply(pRequestID string, pResponse response.IResponse) error {
+	p.fMutex.Lock()
+	defer p.fMutex.Unlock()
+
+	result, ok := p.fPending[pRequestID]
+	if !ok {
+		return ErrRequestNotFound
+	}
+
+	delete(p.fPending, pRequestID)
+	result <- pResponse
+	return nil
+}
+
+func (p *sStream) GetHost() string {
+	return p.fHost
+}
+
+func (p *sStream) GetIncoming() <-chan IIncoming {
+	return p.fIncoming
+}
+
+func (p *sStream) Close() {
+	p.fOnce.Do(func() {
+		p.fStreams.fMutex.Lock()
+		defer p.fStreams.fMutex.Unlock()
+
+		if p.fStreams.fStreams[p.fHost] == p {
+			delete(p.fStreams.fStreams, p.fHost)
+		}
+		close(p.fClosed)
+	})
+}
+
+func (p *sIncoming) GetRequestID() string {
+	return p.fRequestID
+}
+
+func (p *sIncoming) GetSender() asymmetric.IPubKey {
+	return p.fSender
+}
+
+func (p *sIncoming) GetRequest() request.IRequest {
+	return p.fRequest
+}
@@ -0,0 +1,149 @@
+package streams
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/number571/go-peer/pkg/crypto/asymmetric"
+	"github.com/number571/hidden-lake/pkg/request"
+	"github.com/number571/hidden-lake/pkg/response"
+)
+
+func TestError(t *testing.T) {
+	t.Parallel()
+
+	str := "value"
+	err := &SStreamsError{str}
+	if err.Error() != errPrefix+str {
+		t.Error("incorrect err.Error()")
+		return
+	}
+}
+
+func TestStreams(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	streams := NewStreams(time.Second)
+	sender := asymmetric.NewPrivKey().GetPubKey()
+	req := request.NewRequestBuilder().WithHost("hidden-some-host").WithPath("/echo").Build()
+
+	if _, err := streams.Request(ctx, sender, req); !errors.Is(err, ErrStreamNotFound) {
+		t.Error("success request without stream")
+		return
+	}
+
+	stream, err := streams.Subscribe("hidden-some-host")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if stream.GetHost() != "hidden-some-host" || !streams.HasStream("hidden-some-host") {
+		t.Error("stream is not registered")
+		return
+	}
+	if _, err := streams.Subscribe("hidden-some-host"); !errors.Is(err, ErrStreamExists) {
+		t.Error("success subscribe to the existing stream")
+		return
+	}
+
+	go func() {
+		for incoming := range stream.GetIncoming() {
+			if incoming.GetRequest().GetPath() == "/off" {
+				_ = streams.Reply(incoming.GetRequestID(), nil)
+				continue
+			}
+			rsp := response.NewResponseBuilder().
+				WithCode(http.StatusOK).
+				WithBody([]byte(incoming.GetSender().ToString())).
+				Build()
+			_ = streams.Reply(incoming.GetRequestID(), rsp)
+		}
+	}()
+
+	rsp, err := streams.Request(ctx, sender, req)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if string(rsp.GetBody()) != sender.ToString() {
+		t.Error("got invalid response")
+		return
+	}
+
+	reqOff := request.NewRequestBuilder().WithHost("hidden-some-host").WithPath("/off").Build()
+	rsp, err = streams.Request(ctx, sender, reqOff)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if rsp != nil {
+		t.Error("got response with mode off")
+		return
+	}
+
+	if err := streams.Reply("undefined", nil); !errors.Is(err, ErrRequestNotFound) {
+		t.Error("success reply to undefined request")
+		return
+	}
+
+	stream.Close()
+	stream.Close()
+
+	if streams.HasStream("hidden-some-host") {
+		t.Error("stream is not deleted after close")
+		return
+	}
+	if _, err := streams.Subscribe("hidden-some-host"); err != nil {
+		t.Error(err)
+		return
+	}
+}
+
+func TestStreamsTimeout(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	streams := NewStreams(100 * time.Millisecond)
+	sender := asymmetric.NewPrivKey().GetPubKey()
+	req := request.NewRequestBuilder().WithHost("hidden-some-host").Build()
+
+	stream, err := streams.Subscribe("hidden-some-host")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// nobody reads the stream
+	if _, err := streams.Request(ctx, sender, req); !errors.Is(err, ErrReplyTimeout) {
+		t.Error("success request without subscriber's reading")
+		return
+	}
+
+	// the request is read, but there is no reply
+	go func() { <-stream.GetIncoming() }()
+	if _, err := streams.Request(ctx, sender, req); !errors.Is(err, ErrReplyTimeout) {
+		t.Error("success request without reply")
+		return
+	}
+
+	go func() {
+		<-stream.GetIncoming()
+		stream.Close()
+	}()
+	if _, err := streams.Request(ctx, sender, req); !errors.Is(err, ErrStreamClosed) {
+		t.Error("success request with closed stream")
+		return
+	}
+
+	ctxCancel, cancel := context.WithCancel(ctx)
+	cancel()
+
+	if _, err := streams.Request(ctxCancel, sender, req); err == nil {
+		t.Error("success request with canceled context")
+		return
+	}
+}
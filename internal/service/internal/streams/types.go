@@ -0,0 +1,29 @@
+package streams
+
+import (
+	"context"
+
+	"github.com/number571/go-peer/pkg/crypto/asymmetric"
+	"github.com/number571/hidden-lake/pkg/request"
+	"github.com/number571/hidden-lake/pkg/response"
+)
+
+type IStreams interface {
+	HasStream(string) bool
+	Subscribe(string) (IStream, error)
+
+	Request(context.Context, asymmetric.IPubKey, request.IRequest) (response.IResponse, error)
+	Reply(string, response.IResponse) error
+}
+
+type IStream interface {
+	GetHost() string
+	GetIncoming() <-chan IIncoming
+	Close()
+}
+
+type IIncoming interface {
+	GetRequestID() string
+	GetSender() asymmetric.IPubKey
+	GetRequest() request.IRequest
+}
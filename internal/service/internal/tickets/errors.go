@@ -0,0 +1,18 @@
+package tickets
+
+const (
+	errPrefix = "internal/service/internal/tickets = "
+)
+
+type STicketsError struct {
+	str string
+}
+
+func (err *STicketsError) Error() string {
+	return errPrefix + err.str
+}
+
+var (
+	ErrTicketsLimit   = &STicketsError{"tickets limit"}
+	ErrTicketNotFound = &STicketsError{"ticket not found"}
+)
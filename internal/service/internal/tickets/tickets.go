@@ -0,0 +1,158 @@
+package tickets
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/number571/go-peer/pkg/crypto/random"
+	"github.com/number571/go-peer/pkg/encoding"
+	"github.com/number571/hidden-lake/pkg/response"
+)
+
+const (
+	cTicketIDSize = 16
+)
+
+var (
+	_ ITickets = &sTickets{}
+	_ ITicket  = &sTicketState{}
+)
+
+type sTickets struct {
+	fMutex   sync.Mutex
+	fTTL     time.Duration
+	fLimit   uint64
+	fTickets map[string]*sTicket
+}
+
+type sTicket struct {
+	fCancel   context.CancelFunc
+	fDone     chan struct{}
+	fDoneTime time.Time
+	fResponse response.IResponse
+	fError    error
+}
+
+type sTicketState struct {
+	fDone     bool
+	fResponse response.IResponse
+	fError    error
+}
+
+// NewTickets creates the storage of asynchronous fetch requests.
+// Completed tickets are removed after TTL, the limit restricts
+// the count of stored (pending and completed) tickets.
+func NewTickets(pTTL time.Duration, pLimit uint64) ITickets {
+	return &sTickets{
+		fTTL:     pTTL,
+		fLimit:   pLimit,
+		fTickets: make(map[string]*sTicket, 64),
+	}
+}
+
+func (p *sTickets) Push(pCtx context.Context, pFetchF IFetchF) (string, error) {
+	p.fMutex.Lock()
+	defer p.fMutex.Unlock()
+
+	p.clearExpired()
+
+	if uint64(len(p.fTickets)) >= p.fLimit {
+		return "", ErrTicketsLimit
+	}
+
+	ctx, cancel := context.WithCancel(pCtx)
+	ticketID := encoding.HexEncode(random.NewRandom().GetBytes(cTicketIDSize))
+	ticket := &sTicket{
+		fCancel: cancel,
+		fDone:   make(chan struct{}),
+	}
+	p.fTickets[ticketID] = ticket
+
+	go func() {
+		defer cancel()
+		rsp, err := pFetchF(ctx)
+
+		p.fMutex.Lock()
+		defer p.fMutex.Unlock()
+
+		ticket.fResponse = rsp
+		ticket.fError = err
+		ticket.fDoneTime = time.Now()
+		close(ticket.fDone)
+	}()
+
+	return ticketID, nil
+}
+
+// Load returns the ticket after its completion or after the wait time.
+func (p *sTickets) Load(pCtx context.Context, pTicketID string, pWait time.Duration) (ITicket, error) {
+	p.fMutex.Lock()
+	p.clearExpired()
+	ticket, ok := p.fTickets[pTicketID]
+	p.fMutex.Unlock()
+
+	if !ok {
+		return nil, ErrTicketNotFound
+	}
+
+	if pWait > 0 {
+		select {
+		case <-pCtx.Done():
+		case <-ticket.fDone:
+		case <-time.After(pWait):
+		}
+	}
+
+	p.fMutex.Lock()
+	defer p.fMutex.Unlock()
+
+	return &sTicketState{
+		fDone:     ticket.isDone(),
+		fResponse: ticket.fResponse,
+		fError:    ticket.fError,
+	}, nil
+}
+
+func (p *sTickets) Delete(pTicketID string) error {
+	p.fMutex.Lock()
+	defer p.fMutex.Unlock()
+
+	ticket, ok := p.fTickets[pTicketID]
+	if !ok {
+		return ErrTicketNotFound
+	}
+
+	ticket.fCancel()
+	delete(p.fTickets, pTicketID)
+	return nil
+}
+
+func (p *sTickets) clearExpired() {
+	for k, v := range p.fTickets {
+		if v.isDone() && time.Since(v.fDoneTime) > p.fTTL {
+			delete(p.fTickets, k)
+		}
+	}
+}
+
+func (p *sTicket) isDone() bool {
+	select {
+	case <-p.fDone:
+		return true
+	default:
+		return false
+	}
+}
+
+func (p *sTicketState) IsDone() bool {
+	return p.fDone
+}
+
+func (p *sTicketState) GetResponse() response.IResponse {
+	return p.fResponse
+}
+
+func (p *sTicketState) GetError() error {
+	return p.fError
+}
@@ -0,0 +1,99 @@
+package tickets
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/number571/hidden-lake/pkg/response"
+)
+
+func TestError(t *testing.T) {
+	t.Parallel()
+
+	str := "value"
+	err := &STicketsError{str}
+	if err.Error() != errPrefix+str {
+		t.Error("incorrect err.Error()")
+		return
+	}
+}
+
+func TestTickets(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	tickets := NewTickets(time.Second, 2)
+
+	ticketID, err := tickets.Push(ctx, func(context.Context) (response.IResponse, error) {
+		time.Sleep(200 * time.Millisecond)
+		return response.NewResponseBuilder().WithCode(http.StatusAccepted).Build(), nil
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	ticket, err := tickets.Load(ctx, ticketID, 0)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if ticket.IsDone() {
+		t.Error("ticket is done before response")
+		return
+	}
+
+	ticket, err = tickets.Load(ctx, ticketID, time.Minute)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !ticket.IsDone() || ticket.GetError() != nil {
+		t.Error("ticket is not done after wait")
+		return
+	}
+	if ticket.GetResponse().GetCode() != http.StatusAccepted {
+		t.Error("got invalid response code")
+		return
+	}
+
+	errTicketID, err := tickets.Push(ctx, func(context.Context) (response.IResponse, error) {
+		return nil, errors.New("some error") // nolint: err113
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err := tickets.Push(ctx, nil); err == nil {
+		t.Error("success push ticket with limit")
+		return
+	}
+
+	ticket, err = tickets.Load(ctx, errTicketID, time.Minute)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !ticket.IsDone() || ticket.GetError() == nil {
+		t.Error("got success ticket with error")
+		return
+	}
+
+	if err := tickets.Delete(errTicketID); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := tickets.Delete(errTicketID); err == nil {
+		t.Error("success delete deleted ticket")
+		return
+	}
+
+	time.Sleep(1500 * time.Millisecond)
+	if _, err := tickets.Load(ctx, ticketID, 0); err == nil {
+		t.Error("success load expired ticket")
+		return
+	}
+}
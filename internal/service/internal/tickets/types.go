@@ -0,0 +1,22 @@
+package tickets
+
+import (
+	"context"
+	"time"
+
+	"github.com/number571/hidden-lake/pkg/response"
+)
+
+type IFetchF func(context.Context) (response.IResponse, error)
+
+type ITickets interface {
+	Push(context.Context, IFetchF) (string, error)
+	Load(context.Context, string, time.Duration) (ITicket, error)
+	Delete(string) error
+}
+
+type ITicket interface {
+	IsDone() bool
+	GetResponse() response.IResponse
+	GetError() error
+}
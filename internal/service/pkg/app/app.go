@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"path/filepath"
 	"sync"
 
 	"github.com/number571/go-peer/pkg/crypto/asymmetric"
@@ -13,8 +14,15 @@ import (
 	"github.com/number571/go-peer/pkg/logger"
 	"github.com/number571/go-peer/pkg/state"
 	"github.com/number571/go-peer/pkg/types"
+	"github.com/number571/hidden-lake/internal/service/internal/introductions"
+	"github.com/number571/hidden-lake/internal/service/internal/limiter"
+	"github.com/number571/hidden-lake/internal/service/internal/metadata"
+	"github.com/number571/hidden-lake/internal/service/internal/multiplex"
+	"github.com/number571/hidden-lake/internal/service/internal/streams"
 	"github.com/number571/hidden-lake/internal/service/pkg/app/config"
 	"github.com/number571/hidden-lake/internal/utils/closer"
+	"github.com/number571/hidden-lake/internal/utils/socket"
+	"github.com/number571/hidden-lake/pkg/adapters/http/client"
 	"github.com/number571/hidden-lake/pkg/network"
 
 	pkg_config "github.com/number571/hidden-lake/internal/service/pkg/config"
@@ -34,9 +42,12 @@ type sApp struct {
 	fPathTo   string
 	fParallel uint64
 
-	fCfgW    config.IWrapper
-	fNode    network.IHiddenLakeNode
-	fPrivKey asymmetric.IPrivKey
+	fCfgW        config.IWrapper
+	fIdentities  []*sIdentity // the first is the default identity
+	fMultiplexer multiplex.IMultiplexer
+
+	fEPMutex   sync.RWMutex
+	fEPClients []client.IClient
 
 	fAnonLogger logger.ILogger
 	fHTTPLogger logger.ILogger
@@ -45,13 +56,54 @@ type sApp struct {
 	fServiceHTTP *http.Server
 }
 
+// sIdentity has its own private key, config (friends, services, access)
+// and anonymity node with the queue. Connections are shared.
+type sIdentity struct {
+	fName    string
+	fPathTo  string
+	fCfgW    config.IWrapper
+	fNode    network.IHiddenLakeNode
+	fPrivKey asymmetric.IPrivKey
+
+	fStreams       streams.IStreams
+	fIntroductions introductions.IIntroductions
+	fMetadata      metadata.IMetadata
+	fLimiter       limiter.ILimiter
+}
+
+// SIdentity is the additional identity of the HLS. The settings, logging,
+// address, endpoints and tokens of the identity's config are not used.
+type SIdentity struct {
+	FName    string
+	FConfig  config.IConfig
+	FPrivKey asymmetric.IPrivKey
+}
+
 func NewApp(
 	pCfg config.IConfig,
 	pPrivKey asymmetric.IPrivKey,
 	pPathTo string,
 	pParallel uint64,
+	pIdentities ...*SIdentity,
 ) types.IRunner {
-	logging := pCfg.GetLogging()
+	cfgWrapper := config.NewWrapper(pCfg)
+	logging := &sLogging{cfgWrapper}
+
+	identities := make([]*sIdentity, 0, len(pIdentities)+1)
+	identities = append(identities, newIdentity(
+		hls_settings.CDefaultIdentity,
+		pPathTo,
+		cfgWrapper,
+		pPrivKey,
+	))
+	for _, v := range pIdentities {
+		identities = append(identities, newIdentity(
+			v.FName,
+			filepath.Join(pPathTo, hls_settings.CPathIdentities, v.FName),
+			config.NewWrapper(v.FConfig),
+			v.FPrivKey,
+		))
+	}
 
 	var (
 		anonLogger = std_logger.NewStdLogger(logging, anon_logger.GetLogFunc())
@@ -63,18 +115,41 @@ func NewApp(
 		fState:      state.NewBoolState(),
 		fPathTo:     pPathTo,
 		fParallel:   pParallel,
-		fCfgW:       config.NewWrapper(pCfg),
-		fPrivKey:    pPrivKey,
+		fCfgW:       cfgWrapper,
+		fIdentities: identities,
 		fAnonLogger: anonLogger,
 		fHTTPLogger: httpLogger,
 		fStdfLogger: stdfLogger,
 	}
 }
 
+func newIdentity(
+	pName string,
+	pPathTo string,
+	pCfgW config.IWrapper,
+	pPrivKey asymmetric.IPrivKey,
+) *sIdentity {
+	return &sIdentity{
+		fName:    pName,
+		fPathTo:  pPathTo,
+		fCfgW:    pCfgW,
+		fPrivKey: pPrivKey,
+		fStreams: streams.NewStreams(hls_settings.CStreamsReplyTimeout),
+		fIntroductions: introductions.NewIntroductions(
+			hls_settings.CIntroductionsTTL,
+			hls_settings.CIntroductionsLimit,
+			hls_settings.CIntroductionsIntroducerLimit,
+		),
+		fLimiter: limiter.NewLimiter(),
+	}
+}
+
 func (p *sApp) Run(pCtx context.Context) error {
 	services := []internal_types.IServiceF{
 		p.runListenerInternal,
 		p.runAnonymityNode,
+		p.runConfigReloader,
+		p.runFriendsExpiry,
 	}
 
 	ctx, cancel := context.WithCancel(pCtx)
@@ -114,7 +189,7 @@ func (p *sApp) enable(pCtx context.Context) state.IStateF {
 			hls_settings.GServiceName.Short(),
 			encoding.SerializeJSON(pkg_config.GetConfigSettings(
 				p.fCfgW.GetConfig(),
-				p.fNode.GetAnonymityNode().GetQBProcessor().GetClient(),
+				p.fIdentities[0].fNode.GetAnonymityNode().GetQBProcessor().GetClient(),
 			)),
 		))
 		return nil
@@ -135,10 +210,12 @@ func (p *sApp) disable(pCancel context.CancelFunc, pWg *sync.WaitGroup) state.IS
 }
 
 func (p *sApp) stop() error {
-	err := closer.CloseAll([]io.Closer{
-		p.fServiceHTTP,
-		p.fNode.GetAnonymityNode().GetKVDatabase(),
-	})
+	closers := make([]io.Closer, 0, len(p.fIdentities)+1)
+	closers = append(closers, p.fServiceHTTP)
+	for _, identity := range p.fIdentities {
+		closers = append(closers, identity.fNode.GetAnonymityNode().GetKVDatabase())
+	}
+	err := closer.CloseAll(closers)
 	if err != nil {
 		return errors.Join(ErrClose, err)
 	}
@@ -148,7 +225,29 @@ func (p *sApp) stop() error {
 func (p *sApp) runAnonymityNode(pCtx context.Context, wg *sync.WaitGroup, pChErr chan<- error) {
 	defer wg.Done()
 
-	if err := p.fNode.Run(pCtx); err != nil {
+	runners := make([]types.IRunner, 0, len(p.fIdentities)+1)
+	runners = append(runners, p.fMultiplexer)
+	for _, identity := range p.fIdentities {
+		runners = append(runners, identity.fNode)
+	}
+
+	chCtx, cancel := context.WithCancel(pCtx)
+	defer cancel()
+
+	errs := make([]error, len(runners))
+	wgRunners := &sync.WaitGroup{}
+	wgRunners.Add(len(runners))
+
+	for i, runner := range runners {
+		go func(i int, runner types.IRunner) {
+			defer func() { wgRunners.Done(); cancel() }()
+			errs[i] = runner.Run(chCtx)
+		}(i, runner)
+	}
+
+	wgRunners.Wait()
+
+	if err := errors.Join(errs...); err != nil {
 		pChErr <- err
 		return
 	}
@@ -163,7 +262,7 @@ func (p *sApp) runListenerInternal(pCtx context.Context, wg *sync.WaitGroup, pCh
 	}
 
 	go func() {
-		err := p.fServiceHTTP.ListenAndServe()
+		err := socket.ListenAndServe(p.fServiceHTTP)
 		if err != nil && !errors.Is(err, http.ErrServerClosed) {
 			pChErr <- err
 			return
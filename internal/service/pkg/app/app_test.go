@@ -5,15 +5,18 @@ import (
 	"errors"
 	"net/http"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/number571/go-peer/pkg/crypto/asymmetric"
 	testutils_gopeer "github.com/number571/go-peer/test/utils"
 	"github.com/number571/hidden-lake/internal/service/pkg/app/config"
-	"github.com/number571/hidden-lake/internal/service/pkg/client"
 	pkg_settings "github.com/number571/hidden-lake/internal/service/pkg/settings"
 	"github.com/number571/hidden-lake/internal/utils/flag"
+	"github.com/number571/hidden-lake/internal/utils/passphrase"
+	"github.com/number571/hidden-lake/internal/utils/privkey"
+	"github.com/number571/hidden-lake/pkg/service/client"
 	testutils "github.com/number571/hidden-lake/test/utils"
 )
 
@@ -32,9 +35,43 @@ var (
 		flag.NewFlagBuilder("-t", "--threads").
 			WithDescription("set num of parallel functions to calculate PoW").
 			WithDefinedValue("1"),
+		flag.NewFlagBuilder("-f", "--passphrase-fd").
+			WithDescription("read passphrase of private key from file descriptor").
+			WithDefinedValue(""),
+		flag.NewFlagBuilder("-k", "--key-passwd").
+			WithDescription("set or change passphrase of private key and exit"),
+		flag.NewFlagBuilder("-r", "--key-required").
+			WithDescription("fail if private key is not found instead of generating new"),
+	).Build()
+)
+
+var (
+	tgKeyFlags = flag.NewFlagsBuilder(
+		flag.NewFlagBuilder("-p", "--path").
+			WithDescription("set path to config, database files").
+			WithDefinedValue("."),
+		flag.NewFlagBuilder("-f", "--passphrase-fd").
+			WithDescription("read passphrase of private key from file descriptor").
+			WithDefinedValue(""),
+		flag.NewFlagBuilder("-o", "--output").
+			WithDescription("set path to backup file").
+			WithDefinedValue(""),
+		flag.NewFlagBuilder("-i", "--input").
+			WithDescription("set path to backup file").
+			WithDefinedValue(""),
+		flag.NewFlagBuilder("-w", "--with-friends").
+			WithDescription("include list of friends"),
+		flag.NewFlagBuilder("--force").
+			WithDescription("overwrite existing file"),
 	).Build()
 )
 
+const (
+	tcTestdataKeyPath    = "./testdata/key/"
+	tcTestdataImportPath = "./testdata/import/"
+	tcBackupPath         = "./testdata/backup.hlk"
+)
+
 const (
 	tcTestdataPath = "./testdata/"
 	tcPathDB       = pkg_settings.CPathDB
@@ -127,6 +164,7 @@ func TestApp(t *testing.T) {
 		client.NewRequester(
 			testutils.TgAddrs[3],
 			&http.Client{Timeout: time.Minute},
+			client.NewSettings(nil),
 		),
 	)
 
@@ -178,3 +216,426 @@ func TestApp(t *testing.T) {
 	}()
 	time.Sleep(100 * time.Millisecond)
 }
+
+func TestKeyCommands(t *testing.T) {
+	// environment variables can not be used with t.Parallel()
+	t.Setenv(passphrase.CEnvPassphrase, "key_passphrase")
+	t.Setenv(passphrase.CEnvBackupPassphrase, "backup_passphrase")
+
+	for _, path := range []string{tcTestdataKeyPath, tcTestdataImportPath} {
+		if err := os.MkdirAll(path, 0o700); err != nil {
+			t.Error(err)
+			return
+		}
+		defer os.RemoveAll(path)
+	}
+	defer os.RemoveAll(tcBackupPath)
+
+	if _, err := GetKeyPubKey([]string{"-p", tcTestdataKeyPath}, tgKeyFlags); err == nil {
+		t.Error("success get not found private key")
+		return
+	}
+	if _, err := InitApp([]string{"-p", tcTestdataKeyPath, "--key-required"}, tgFlags); err == nil {
+		t.Error("success init app without private key")
+		return
+	}
+
+	friendPubKey := asymmetric.NewPrivKey().GetPubKey()
+	_, err := config.BuildConfig(tcTestdataKeyPath+tcPathConfig, &config.SConfig{
+		FSettings: &config.SConfigSettings{
+			FMessageSizeBytes: (8 << 10),
+			FWorkSizeBits:     10,
+			FQueuePeriodMS:    5_000,
+			FFetchTimeoutMS:   30_000,
+			FNetworkKey:       "_",
+		},
+		FFriends: map[string]string{
+			"Alice": friendPubKey.ToString(),
+		},
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	privKey := asymmetric.NewPrivKey()
+	if err := privkey.WritePrivKey(tcTestdataKeyPath+tcPathKey, privKey, []byte("key_passphrase")); err != nil {
+		t.Error(err)
+		return
+	}
+
+	pubKey, err := GetKeyPubKey([]string{"-p", tcTestdataKeyPath}, tgKeyFlags)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if pubKey.ToString() != privKey.GetPubKey().ToString() {
+		t.Error("diff public keys")
+		return
+	}
+
+	if err := ExportKey([]string{"-p", tcTestdataKeyPath}, tgKeyFlags); !errors.Is(err, ErrBackupPathUndefined) {
+		t.Error("success export without output")
+		return
+	}
+	exportArgs := []string{"-p", tcTestdataKeyPath, "-o", tcBackupPath, "--with-friends"}
+	if err := ExportKey(exportArgs, tgKeyFlags); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := ExportKey(exportArgs, tgKeyFlags); !errors.Is(err, ErrBackupExist) {
+		t.Error("success overwrite backup")
+		return
+	}
+
+	importArgs := []string{"-p", tcTestdataImportPath, "-i", tcBackupPath, "--with-friends"}
+	if err := ImportKey(importArgs, tgKeyFlags); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := ImportKey(importArgs, tgKeyFlags); !errors.Is(err, ErrPrivateKeyExist) {
+		t.Error("success overwrite private key")
+		return
+	}
+
+	importedPubKey, err := GetKeyPubKey([]string{"-p", tcTestdataImportPath}, tgKeyFlags)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if importedPubKey.ToString() != privKey.GetPubKey().ToString() {
+		t.Error("diff imported public keys")
+		return
+	}
+
+	cfg, err := config.LoadConfig(tcTestdataImportPath + tcPathConfig)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	alice, ok := cfg.GetFriends()["Alice"]
+	if !ok || alice.ToString() != friendPubKey.ToString() {
+		t.Error("friend is not imported")
+		return
+	}
+
+	t.Setenv(passphrase.CEnvBackupPassphrase, "invalid_passphrase")
+	if err := ImportKey(append(importArgs, "--force"), tgKeyFlags); !errors.Is(err, privkey.ErrInvalidPassphrase) {
+		t.Error("success import with invalid passphrase")
+		return
+	}
+}
+
+func TestOncePassphraseF(t *testing.T) {
+	t.Parallel()
+
+	count := 0
+	passphraseF := getOncePassphraseF(func(pPrompt bool) ([]byte, error) {
+		count++
+		if !pPrompt {
+			return nil, nil
+		}
+		return []byte("passphrase"), nil
+	})
+
+	if v, err := passphraseF(false); err != nil || v != nil {
+		t.Error("got passphrase without prompt")
+		return
+	}
+	for i := 0; i < 3; i++ {
+		if v, err := passphraseF(true); err != nil || string(v) != "passphrase" {
+			t.Error("got invalid passphrase")
+			return
+		}
+	}
+	if count != 2 {
+		t.Error("passphrase is read more than once")
+		return
+	}
+}
+
+func TestMergeFriends(t *testing.T) {
+	t.Parallel()
+
+	pubKey1 := asymmetric.NewPrivKey().GetPubKey()
+	pubKey2 := asymmetric.NewPrivKey().GetPubKey()
+	pubKey3 := asymmetric.NewPrivKey().GetPubKey()
+
+	friends, err := mergeFriends(
+		map[string]asymmetric.IPubKey{"a": pubKey1},
+		map[string]string{
+			"a": pubKey2.ToString(), // alias exists
+			"b": pubKey1.ToString(), // public key exists
+			"c": pubKey3.ToString(),
+		},
+	)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(friends) != 2 || friends["a"].ToString() != pubKey1.ToString() || friends["c"] == nil {
+		t.Error("invalid merged friends")
+		return
+	}
+
+	if _, err := mergeFriends(nil, map[string]string{"a": "abc"}); !errors.Is(err, ErrInvalidFriendPubKey) {
+		t.Error("success merge invalid public key")
+		return
+	}
+}
+
+func TestAppIdentities(t *testing.T) {
+	t.Parallel()
+
+	path := t.TempDir()
+
+	_, err := config.BuildConfig(filepath.Join(path, tcPathConfig), &config.SConfig{
+		FSettings: &config.SConfigSettings{
+			FMessageSizeBytes: (8 << 10),
+			FWorkSizeBits:     10,
+			FQueuePeriodMS:    5_000,
+			FFetchTimeoutMS:   30_000,
+			FNetworkKey:       "_",
+		},
+		FAddress: &config.SAddress{
+			FExternal: testutils.TgAddrs[26],
+			FInternal: testutils.TgAddrs[27],
+		},
+		FFriends: map[string]string{
+			"Alice": asymmetric.NewPrivKey().GetPubKey().ToString(),
+		},
+		FIdentities: []string{"work"},
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	app, err := InitApp([]string{"--path", path}, tgFlags)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		if err := app.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			t.Error(err)
+			return
+		}
+	}()
+
+	newClient := func(pIdentity string) client.IClient {
+		return client.NewClient(
+			client.NewBuilder(),
+			client.NewRequester(
+				testutils.TgAddrs[27],
+				&http.Client{Timeout: time.Minute},
+				client.NewSettings(&client.SSettings{FIdentity: pIdentity}),
+			),
+		)
+	}
+
+	defaultClient := newClient("")
+	workClient := newClient("work")
+
+	err1 := testutils_gopeer.TryN(
+		50,
+		10*time.Millisecond,
+		func() error {
+			_, err := defaultClient.GetIndex(context.Background())
+			return err
+		},
+	)
+	if err1 != nil {
+		t.Error(err1)
+		return
+	}
+
+	identities, err := defaultClient.GetIdentities(context.Background())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(identities) != 2 || identities[0].FName != pkg_settings.CDefaultIdentity || identities[1].FName != "work" {
+		t.Error("got invalid identities")
+		return
+	}
+
+	workPubKey, err := workClient.GetPubKey(context.Background())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if workPubKey.ToString() != identities[1].FPublicKey || workPubKey.ToString() == identities[0].FPublicKey {
+		t.Error("got invalid public key of identity")
+		return
+	}
+
+	// friends are separated
+	if err := workClient.AddFriend(context.Background(), "Bob", asymmetric.NewPrivKey().GetPubKey()); err != nil {
+		t.Error(err)
+		return
+	}
+	defaultFriends, err := defaultClient.GetFriends(context.Background())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if _, ok := defaultFriends["Bob"]; ok || len(defaultFriends) != 1 {
+		t.Error("friend of identity is added to default identity")
+		return
+	}
+	workFriends, err := workClient.GetFriends(context.Background())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if _, ok := workFriends["Bob"]; !ok || len(workFriends) != 1 {
+		t.Error("friend is not added to identity")
+		return
+	}
+
+	// counters are created only for the limited services
+	limits, err := workClient.GetLimits(context.Background())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(limits) != 0 {
+		t.Error("got counters without limits")
+		return
+	}
+
+	if _, err := newClient("undefined").GetPubKey(context.Background()); err == nil {
+		t.Error("success request to undefined identity")
+		return
+	}
+
+	// identity key and config are stored in own directory
+	identityPath := filepath.Join(path, pkg_settings.CPathIdentities, "work")
+	identityCfg, err := config.LoadConfig(filepath.Join(identityPath, tcPathConfig))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if _, ok := identityCfg.GetFriends()["Bob"]; !ok {
+		t.Error("friend is not saved in config of identity")
+		return
+	}
+	if _, err := os.Stat(filepath.Join(identityPath, tcPathKey)); err != nil {
+		t.Error(err)
+		return
+	}
+}
+
+func TestAppFriendsExpiry(t *testing.T) {
+	t.Parallel()
+
+	path := t.TempDir()
+
+	_, err := config.BuildConfig(filepath.Join(path, tcPathConfig), &config.SConfig{
+		FSettings: &config.SConfigSettings{
+			FMessageSizeBytes: (8 << 10),
+			FWorkSizeBits:     10,
+			FQueuePeriodMS:    5_000,
+			FFetchTimeoutMS:   30_000,
+			FNetworkKey:       "_",
+		},
+		FAddress: &config.SAddress{
+			FExternal: testutils.TgAddrs[30],
+			FInternal: testutils.TgAddrs[31],
+		},
+		FFriends: map[string]string{
+			"Alice": asymmetric.NewPrivKey().GetPubKey().ToString(),
+			"Bob":   asymmetric.NewPrivKey().GetPubKey().ToString(),
+		},
+		FExpires: map[string]string{
+			"Alice": time.Now().Add(-time.Hour).Format(time.RFC3339),
+			"Bob":   time.Now().Add(time.Hour).Format(time.RFC3339),
+		},
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	app, err := InitApp([]string{"--path", path}, tgFlags)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		if err := app.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			t.Error(err)
+			return
+		}
+	}()
+
+	hlsClient := client.NewClient(
+		client.NewBuilder(),
+		client.NewRequester(
+			testutils.TgAddrs[31],
+			&http.Client{Timeout: time.Minute},
+			client.NewSettings(&client.SSettings{}),
+		),
+	)
+
+	// expired friend is removed at the start of service
+	err1 := testutils_gopeer.TryN(
+		50,
+		10*time.Millisecond,
+		func() error {
+			friends, err := hlsClient.GetFriends(context.Background())
+			if err != nil {
+				return err
+			}
+			if _, ok := friends["Alice"]; ok {
+				return errors.New("expired friend is not removed") // nolint: err113
+			}
+			return nil
+		},
+	)
+	if err1 != nil {
+		t.Error(err1)
+		return
+	}
+
+	if err := hlsClient.ExtendFriend(context.Background(), "Bob", 2*time.Hour); err != nil {
+		t.Error(err)
+		return
+	}
+	friends, err := hlsClient.GetFriendsInfo(context.Background())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(friends) != 1 || friends[0].FAliasName != "Bob" || friends[0].FTTLMS <= uint64(time.Hour.Milliseconds()) {
+		t.Error("friend is not extended")
+		return
+	}
+
+	if err := hlsClient.AddTempFriend(context.Background(), "Carol", asymmetric.NewPrivKey().GetPubKey(), time.Hour); err != nil {
+		t.Error(err)
+		return
+	}
+	cfg, err := config.LoadConfig(filepath.Join(path, tcPathConfig))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if _, ok := cfg.GetExpires()["Carol"]; !ok {
+		t.Error("expiry of temporary friend is not saved")
+		return
+	}
+	if _, ok := cfg.GetFriends()["Alice"]; ok {
+		t.Error("expired friend is saved in config")
+		return
+	}
+}
@@ -2,19 +2,30 @@ package config
 
 import (
 	"errors"
+	"net/http"
 	"os"
+	"slices"
 	"sync"
 	"time"
 
 	"github.com/number571/go-peer/pkg/crypto/asymmetric"
 	"github.com/number571/go-peer/pkg/encoding"
+	hls_settings "github.com/number571/hidden-lake/internal/service/pkg/settings"
 	logger "github.com/number571/hidden-lake/internal/utils/logger/std"
 )
 
+const (
+	cMaxIdentityNameSize = 64
+)
+
 var (
 	_ IConfigSettings = &SConfigSettings{}
 	_ IConfig         = &SConfig{}
 	_ IAddress        = &SAddress{}
+	_ IAccess         = &SAccess{}
+	_ ILimit          = &SLimit{}
+	_ IRate           = &SRate{}
+	_ IToken          = &SToken{}
 )
 
 type SConfigSettings struct {
@@ -30,13 +41,66 @@ type SConfig struct {
 	fMutex    sync.RWMutex
 	fLogging  logger.ILogging
 	fFriends  map[string]asymmetric.IPubKey
+	fExpires  map[string]time.Time
+
+	FSettings  *SConfigSettings    `yaml:"settings"`
+	FLogging   []string            `yaml:"logging,omitempty"`
+	FAddress   *SAddress           `yaml:"address,omitempty"`
+	FServices  map[string]string   `yaml:"services,omitempty"`
+	FEndpoints []string            `yaml:"endpoints,omitempty"`
+	FFriends   map[string]string   `yaml:"friends,omitempty"`
+	FAccess    map[string]*SAccess `yaml:"access,omitempty"`
+	FTokens    map[string]*SToken  `yaml:"tokens,omitempty"`
+
+	// Named groups of friends (by aliases) for the multicast requests.
+	// Aliases of deleted friends are not removed from the groups.
+	FGroups map[string][]string `yaml:"groups,omitempty"`
+
+	// Expiry time (RFC 3339) of the temporary friends by aliases.
+	// Expired friends are removed from the config by the HLS.
+	FExpires map[string]string `yaml:"expires,omitempty"`
+
+	// Rate limits of requests from each friend to the services by names.
+	FLimits map[string]*SLimit `yaml:"limits,omitempty"`
+
+	// Introductions of friends by the built-in service are received only
+	// if it is enabled. The change of value requires the restart of HLS.
+	FIntroductionsEnabled bool `yaml:"introductions_enabled,omitempty"`
+
+	// Names of the additional identities. Each identity has its own
+	// private key, friends, services and access in the directory
+	// identities/<name>, but shares connections and settings.
+	FIdentities []string `yaml:"identities,omitempty"`
+}
+
+// SToken authorizes requests to the internal HTTP API by the bearer token.
+// If the tokens are not set, then the API is not restricted. The token
+// without scopes has full access.
+type SToken struct {
+	FToken  string   `yaml:"token"`
+	FScopes []string `yaml:"scopes,omitempty"`
+}
+
+// SAccess restricts friends (by aliases) which can send requests to the service.
+// If the allow list is not empty, then only friends from it are permitted.
+// The deny list always takes precedence over the allow list.
+type SAccess struct {
+	FAllow []string `json:"allow,omitempty" yaml:"allow,omitempty"`
+	FDeny  []string `json:"deny,omitempty" yaml:"deny,omitempty"`
+}
+
+// SLimit restricts requests of each friend to the service by the count
+// of requests and bytes per minute. Zero value is not limited. The rates
+// of friends (by aliases) take precedence over the rate of the service.
+type SLimit struct {
+	FRequestsPerMinute uint64            `json:"requests_per_minute,omitempty" yaml:"requests_per_minute,omitempty"`
+	FBytesPerMinute    uint64            `json:"bytes_per_minute,omitempty" yaml:"bytes_per_minute,omitempty"`
+	FFriends           map[string]*SRate `json:"friends,omitempty" yaml:"friends,omitempty"`
+}
 
-	FSettings  *SConfigSettings  `yaml:"settings"`
-	FLogging   []string          `yaml:"logging,omitempty"`
-	FAddress   *SAddress         `yaml:"address,omitempty"`
-	FServices  map[string]string `yaml:"services,omitempty"`
-	FEndpoints []string          `yaml:"endpoints,omitempty"`
-	FFriends   map[string]string `yaml:"friends,omitempty"`
+type SRate struct {
+	FRequestsPerMinute uint64 `json:"requests_per_minute,omitempty" yaml:"requests_per_minute,omitempty"`
+	FBytesPerMinute    uint64 `json:"bytes_per_minute,omitempty" yaml:"bytes_per_minute,omitempty"`
 }
 
 type SAddress struct {
@@ -118,12 +182,126 @@ func (p *SConfig) isValid() bool {
 			return false
 		}
 	}
+	if !p.isValidAccess() {
+		return false
+	}
+	if !p.isValidLimits() {
+		return false
+	}
+	if !p.isValidTokens() {
+		return false
+	}
+	if !p.isValidIdentities() {
+		return false
+	}
+	if !p.isValidGroups() {
+		return false
+	}
 	return true &&
 		p.FSettings.FMessageSizeBytes != 0 &&
 		p.FSettings.FQueuePeriodMS != 0 &&
 		p.FSettings.FFetchTimeoutMS != 0
 }
 
+func (p *SConfig) isValidTokens() bool {
+	mapping := make(map[string]struct{}, len(p.FTokens))
+	for _, v := range p.FTokens {
+		if v == nil || v.FToken == "" {
+			return false
+		}
+		if _, ok := mapping[v.FToken]; ok {
+			return false
+		}
+		mapping[v.FToken] = struct{}{}
+		for _, s := range v.FScopes {
+			switch s {
+			case hls_settings.CTokenScopeRead, hls_settings.CTokenScopeNetwork, hls_settings.CTokenScopeService:
+			default:
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (p *SConfig) isValidLimits() bool {
+	for _, v := range p.FLimits {
+		if v == nil {
+			return false
+		}
+		for _, r := range v.FFriends {
+			if r == nil {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (p *SConfig) isValidIdentities() bool {
+	mapping := make(map[string]struct{}, len(p.FIdentities))
+	for _, v := range p.FIdentities {
+		if v == hls_settings.CDefaultIdentity || !isValidIdentityName(v) {
+			return false
+		}
+		if _, ok := mapping[v]; ok {
+			return false
+		}
+		mapping[v] = struct{}{}
+	}
+	return true
+}
+
+func (p *SConfig) isValidAccess() bool {
+	for k, v := range p.FAccess {
+		if k == "" || v == nil {
+			return false
+		}
+		for _, list := range [][]string{v.FAllow, v.FDeny} {
+			for _, alias := range list {
+				if alias == "" {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+func (p *SConfig) isValidGroups() bool {
+	for k, v := range p.FGroups {
+		if k == "" || len(v) == 0 {
+			return false
+		}
+		mapping := make(map[string]struct{}, len(v))
+		for _, alias := range v {
+			if alias == "" {
+				return false
+			}
+			if _, ok := mapping[alias]; ok {
+				return false
+			}
+			mapping[alias] = struct{}{}
+		}
+	}
+	return true
+}
+
+// the name of identity is used as the name of directory
+func isValidIdentityName(pName string) bool {
+	if pName == "" || len(pName) > cMaxIdentityNameSize {
+		return false
+	}
+	for _, c := range pName {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '-', c == '_':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
 func (p *SConfig) initConfig() error {
 	if p.FSettings == nil {
 		p.FSettings = new(SConfigSettings)
@@ -141,6 +319,10 @@ func (p *SConfig) initConfig() error {
 		return errors.Join(ErrLoadPublicKey, err)
 	}
 
+	if err := p.loadExpires(); err != nil {
+		return errors.Join(ErrLoadExpires, err)
+	}
+
 	if err := p.loadLogging(); err != nil {
 		return errors.Join(ErrLoadLogging, err)
 	}
@@ -177,6 +359,18 @@ func (p *SConfig) loadPubKeys() error {
 	return nil
 }
 
+func (p *SConfig) loadExpires() error {
+	p.fExpires = make(map[string]time.Time, len(p.FExpires))
+	for name, val := range p.FExpires {
+		expires, err := time.Parse(time.RFC3339, val)
+		if err != nil {
+			return err
+		}
+		p.fExpires[name] = expires
+	}
+	return nil
+}
+
 func (p *SConfig) GetFriends() map[string]asymmetric.IPubKey {
 	p.fMutex.RLock()
 	defer p.fMutex.RUnlock()
@@ -188,6 +382,22 @@ func (p *SConfig) GetFriends() map[string]asymmetric.IPubKey {
 	return result
 }
 
+// GetExpires returns the expiry time of the temporary friends.
+// Aliases which are not friends are ignored.
+func (p *SConfig) GetExpires() map[string]time.Time {
+	p.fMutex.RLock()
+	defer p.fMutex.RUnlock()
+
+	result := make(map[string]time.Time, len(p.fExpires))
+	for k, v := range p.fExpires {
+		if _, ok := p.fFriends[k]; !ok {
+			continue
+		}
+		result[k] = v
+	}
+	return result
+}
+
 func (p *SConfig) GetLogging() logger.ILogging {
 	return p.fLogging
 }
@@ -208,6 +418,153 @@ func (p *SConfig) GetService(name string) (string, bool) {
 	return service, ok
 }
 
+func (p *SConfig) GetServices() map[string]string {
+	p.fMutex.RLock()
+	defer p.fMutex.RUnlock()
+
+	result := make(map[string]string, len(p.FServices))
+	for k, v := range p.FServices {
+		result[k] = v
+	}
+	return result
+}
+
+func (p *SConfig) GetAccess() map[string]IAccess {
+	p.fMutex.RLock()
+	defer p.fMutex.RUnlock()
+
+	result := make(map[string]IAccess, len(p.FAccess))
+	for k, v := range p.FAccess {
+		result[k] = v
+	}
+	return result
+}
+
+func (p *SConfig) GetLimits() map[string]ILimit {
+	p.fMutex.RLock()
+	defer p.fMutex.RUnlock()
+
+	result := make(map[string]ILimit, len(p.FLimits))
+	for k, v := range p.FLimits {
+		result[k] = v
+	}
+	return result
+}
+
+func (p *SConfig) GetGroups() map[string][]string {
+	p.fMutex.RLock()
+	defer p.fMutex.RUnlock()
+
+	result := make(map[string][]string, len(p.FGroups))
+	for k, v := range p.FGroups {
+		result[k] = slices.Clone(v)
+	}
+	return result
+}
+
+func (p *SConfig) GetIdentities() []string {
+	return p.FIdentities
+}
+
+func (p *SConfig) GetIntroductionsEnabled() bool {
+	return p.FIntroductionsEnabled
+}
+
+func (p *SConfig) GetTokens() map[string]IToken {
+	p.fMutex.RLock()
+	defer p.fMutex.RUnlock()
+
+	result := make(map[string]IToken, len(p.FTokens))
+	for k, v := range p.FTokens {
+		result[k] = v
+	}
+	return result
+}
+
+func (p *SAccess) GetAllow() []string {
+	return p.FAllow
+}
+
+func (p *SAccess) GetDeny() []string {
+	return p.FDeny
+}
+
+func (p *SAccess) IsAllowed(pAliasName string) bool {
+	for _, v := range p.FDeny {
+		if v == pAliasName {
+			return false
+		}
+	}
+	if len(p.FAllow) == 0 {
+		return true
+	}
+	for _, v := range p.FAllow {
+		if v == pAliasName {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *SToken) GetToken() string {
+	return p.FToken
+}
+
+func (p *SToken) GetScopes() []string {
+	return p.FScopes
+}
+
+func (p *SToken) IsAllowed(pMethod, pPath string) bool {
+	if len(p.FScopes) == 0 || pPath == hls_settings.CHandleIndexPath {
+		return true
+	}
+	for _, s := range p.FScopes {
+		switch s {
+		case hls_settings.CTokenScopeRead:
+			// tickets contain the responses of friends, so they are
+			// allowed only by the network scope as the requests
+			if pMethod == http.MethodGet &&
+				pPath != hls_settings.CHandleServiceStreamPath &&
+				pPath != hls_settings.CHandleNetworkTicketPath {
+				return true
+			}
+		case hls_settings.CTokenScopeNetwork:
+			switch pPath {
+			case hls_settings.CHandleNetworkRequestPath, hls_settings.CHandleNetworkMulticastPath, hls_settings.CHandleNetworkTicketPath:
+				return true
+			case hls_settings.CHandleConfigContactNewPath:
+				// the contact is signed by the private key of node
+				if pMethod == http.MethodPost {
+					return true
+				}
+			}
+		case hls_settings.CTokenScopeService:
+			if pPath == hls_settings.CHandleServiceStreamPath || pPath == hls_settings.CHandleServiceReplyPath {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (p *SLimit) GetRate(pAliasName string) IRate {
+	if rate, ok := p.FFriends[pAliasName]; ok {
+		return rate
+	}
+	return &SRate{
+		FRequestsPerMinute: p.FRequestsPerMinute,
+		FBytesPerMinute:    p.FBytesPerMinute,
+	}
+}
+
+func (p *SRate) GetRequestsPerMinute() uint64 {
+	return p.FRequestsPerMinute
+}
+
+func (p *SRate) GetBytesPerMinute() uint64 {
+	return p.FBytesPerMinute
+}
+
 func (p *SAddress) GetExternal() string {
 	return p.FExternal
 }
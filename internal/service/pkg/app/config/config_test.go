@@ -4,12 +4,14 @@ package config
 import (
 	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/number571/go-peer/pkg/crypto/asymmetric"
+	hls_settings "github.com/number571/hidden-lake/internal/service/pkg/settings"
 )
 
 const (
@@ -337,3 +339,329 @@ func TestWrapper(t *testing.T) {
 		return
 	}
 }
+
+func TestWrapperReload(t *testing.T) {
+	t.Parallel()
+
+	configFile := fmt.Sprintf(tcConfigFileTemplate, 7)
+
+	testConfigDefaultInit(configFile)
+	defer os.Remove(configFile)
+
+	cfg, err := LoadConfig(configFile)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	wrapper := NewWrapper(cfg)
+
+	newConfig := strings.ReplaceAll(
+		testNewConfigString(),
+		fmt.Sprintf("message_size_bytes: %d", tcMessageSize),
+		fmt.Sprintf("message_size_bytes: %d", tcMessageSize+1),
+	)
+	if err := os.WriteFile(configFile, []byte(newConfig), 0o600); err != nil {
+		t.Error(err)
+		return
+	}
+
+	restartRequired, err := wrapper.ReloadConfig()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(restartRequired) != 1 || restartRequired[0] != "settings.message_size_bytes" {
+		t.Error("invalid list of restart required values")
+		return
+	}
+
+	if wrapper.GetConfig().GetSettings().GetMessageSizeBytes() != tcMessageSize {
+		t.Error("message size changed without restart")
+		return
+	}
+
+	if err := os.WriteFile(configFile, []byte("undefined"), 0o600); err != nil {
+		t.Error(err)
+		return
+	}
+
+	if _, err := wrapper.ReloadConfig(); err == nil {
+		t.Error("success reload invalid config")
+		return
+	}
+
+	if wrapper.GetConfig().GetSettings().GetMessageSizeBytes() != tcMessageSize {
+		t.Error("config changed after failed reload")
+		return
+	}
+}
+
+func TestAccess(t *testing.T) {
+	t.Parallel()
+
+	access := &SAccess{
+		FAllow: []string{"a", "b"},
+		FDeny:  []string{"b"},
+	}
+
+	if !access.IsAllowed("a") {
+		t.Error("friend from allow list is denied")
+		return
+	}
+	if access.IsAllowed("b") {
+		t.Error("friend from deny list is allowed")
+		return
+	}
+	if access.IsAllowed("c") {
+		t.Error("friend not from allow list is allowed")
+		return
+	}
+
+	accessDeny := &SAccess{FDeny: []string{"b"}}
+	if !accessDeny.IsAllowed("c") {
+		t.Error("friend not from deny list is denied")
+		return
+	}
+}
+
+func TestTokens(t *testing.T) {
+	t.Parallel()
+
+	token := &SToken{
+		FToken:  "test_token",
+		FScopes: []string{hls_settings.CTokenScopeRead, hls_settings.CTokenScopeService},
+	}
+
+	if token.GetToken() != "test_token" || len(token.GetScopes()) != 2 {
+		t.Error("got invalid token")
+		return
+	}
+	if !token.IsAllowed(http.MethodGet, hls_settings.CHandleConfigFriendsPath) {
+		t.Error("read request is denied")
+		return
+	}
+	if token.IsAllowed(http.MethodPost, hls_settings.CHandleConfigFriendsPath) {
+		t.Error("write request is allowed")
+		return
+	}
+	if !token.IsAllowed(http.MethodPost, hls_settings.CHandleServiceReplyPath) {
+		t.Error("service request is denied")
+		return
+	}
+	if token.IsAllowed(http.MethodPost, hls_settings.CHandleNetworkRequestPath) {
+		t.Error("network request is allowed")
+		return
+	}
+	if token.IsAllowed(http.MethodPost, hls_settings.CHandleConfigContactNewPath) {
+		t.Error("new contact request is allowed")
+		return
+	}
+	if token.IsAllowed(http.MethodGet, hls_settings.CHandleNetworkTicketPath) {
+		t.Error("ticket request is allowed")
+		return
+	}
+
+	tokenNetwork := &SToken{FToken: "test_token_network", FScopes: []string{hls_settings.CTokenScopeNetwork}}
+	if !tokenNetwork.IsAllowed(http.MethodPost, hls_settings.CHandleNetworkMulticastPath) {
+		t.Error("multicast request is denied")
+		return
+	}
+	if !tokenNetwork.IsAllowed(http.MethodPost, hls_settings.CHandleConfigContactNewPath) {
+		t.Error("new contact request is denied")
+		return
+	}
+	if !tokenNetwork.IsAllowed(http.MethodGet, hls_settings.CHandleNetworkTicketPath) {
+		t.Error("ticket request is denied")
+		return
+	}
+
+	tokenFull := &SToken{FToken: "test_token_full"}
+	if !tokenFull.IsAllowed(http.MethodPost, hls_settings.CHandleNetworkRequestPath) {
+		t.Error("request with full access is denied")
+		return
+	}
+
+	invalidTokens := []map[string]*SToken{
+		{"a": nil},
+		{"a": {FToken: ""}},
+		{"a": {FToken: "x"}, "b": {FToken: "x"}},
+		{"a": {FToken: "x", FScopes: []string{"unknown"}}},
+	}
+	for i, tokens := range invalidTokens {
+		cfg := &SConfig{FTokens: tokens}
+		if cfg.isValidTokens() {
+			t.Errorf("invalid tokens are valid (%d)", i)
+			return
+		}
+	}
+
+	cfg := &SConfig{FTokens: map[string]*SToken{"a": token, "b": tokenFull}}
+	if !cfg.isValidTokens() || len(cfg.GetTokens()) != 2 {
+		t.Error("got invalid tokens")
+		return
+	}
+}
+
+func TestIdentities(t *testing.T) {
+	t.Parallel()
+
+	invalidIdentities := [][]string{
+		{""},
+		{hls_settings.CDefaultIdentity},
+		{"work", "work"},
+		{"../work"},
+		{"work/personal"},
+		{strings.Repeat("a", cMaxIdentityNameSize+1)},
+	}
+	for i, identities := range invalidIdentities {
+		cfg := &SConfig{FIdentities: identities}
+		if cfg.isValidIdentities() {
+			t.Errorf("invalid identities are valid (%d)", i)
+			return
+		}
+	}
+
+	cfg := &SConfig{FIdentities: []string{"work", "personal_1", "Home-2"}}
+	if !cfg.isValidIdentities() || len(cfg.GetIdentities()) != 3 {
+		t.Error("got invalid identities")
+		return
+	}
+
+	restartRequired := getRestartRequired(
+		&SConfig{FSettings: &SConfigSettings{}, FAddress: &SAddress{}},
+		&SConfig{FSettings: &SConfigSettings{}, FAddress: &SAddress{}, FIdentities: []string{"work"}},
+	)
+	if len(restartRequired) != 1 || restartRequired[0] != "identities" {
+		t.Error("invalid list of restart required values")
+		return
+	}
+}
+
+func TestIntroductionsEnabled(t *testing.T) {
+	t.Parallel()
+
+	cfg := &SConfig{FIntroductionsEnabled: true}
+	if !cfg.GetIntroductionsEnabled() {
+		t.Error("introductions are disabled")
+		return
+	}
+
+	restartRequired := getRestartRequired(
+		&SConfig{FSettings: &SConfigSettings{}, FAddress: &SAddress{}},
+		&SConfig{FSettings: &SConfigSettings{}, FAddress: &SAddress{}, FIntroductionsEnabled: true},
+	)
+	if len(restartRequired) != 1 || restartRequired[0] != "introductions_enabled" {
+		t.Error("invalid list of restart required values")
+		return
+	}
+}
+
+func TestGroups(t *testing.T) {
+	t.Parallel()
+
+	invalidGroups := []map[string][]string{
+		{"": {"a"}},
+		{"g": {}},
+		{"g": {""}},
+		{"g": {"a", "a"}},
+	}
+	for i, groups := range invalidGroups {
+		cfg := &SConfig{FGroups: groups}
+		if cfg.isValidGroups() {
+			t.Errorf("invalid groups are valid (%d)", i)
+			return
+		}
+	}
+
+	cfg := &SConfig{FGroups: map[string][]string{"g": {"a", "b"}}}
+	if !cfg.isValidGroups() {
+		t.Error("got invalid groups")
+		return
+	}
+
+	groups := cfg.GetGroups()
+	groups["g"][0] = "c"
+	if cfg.GetGroups()["g"][0] != "a" {
+		t.Error("groups are changed by the copy")
+		return
+	}
+}
+
+func TestExpires(t *testing.T) {
+	t.Parallel()
+
+	pubKey := asymmetric.NewPrivKey().GetPubKey()
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	cfg := &SConfig{
+		FFriends: map[string]string{"a": pubKey.ToString()},
+		FExpires: map[string]string{
+			"a":       expiresAt.Format(time.RFC3339),
+			"unknown": expiresAt.Format(time.RFC3339),
+		},
+	}
+	if err := cfg.loadPubKeys(); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := cfg.loadExpires(); err != nil {
+		t.Error(err)
+		return
+	}
+
+	expires := cfg.GetExpires()
+	if len(expires) != 1 || !expires["a"].Equal(expiresAt) {
+		t.Error("got invalid expires")
+		return
+	}
+
+	cfg.FExpires = map[string]string{"a": "abc"}
+	if err := cfg.loadExpires(); err == nil {
+		t.Error("success load invalid expires")
+		return
+	}
+}
+
+func TestLimits(t *testing.T) {
+	t.Parallel()
+
+	invalidLimits := []map[string]*SLimit{
+		{"service": nil},
+		{"service": {FFriends: map[string]*SRate{"a": nil}}},
+	}
+	for i, limits := range invalidLimits {
+		cfg := &SConfig{FLimits: limits}
+		if cfg.isValidLimits() {
+			t.Errorf("invalid limits are valid (%d)", i)
+			return
+		}
+	}
+
+	cfg := &SConfig{FLimits: map[string]*SLimit{
+		"service": {
+			FRequestsPerMinute: 10,
+			FBytesPerMinute:    1024,
+			FFriends:           map[string]*SRate{"a": {FRequestsPerMinute: 100}},
+		},
+	}}
+	if !cfg.isValidLimits() {
+		t.Error("got invalid limits")
+		return
+	}
+
+	limit, ok := cfg.GetLimits()["service"]
+	if !ok {
+		t.Error("limit of service is not found")
+		return
+	}
+	if rate := limit.GetRate("a"); rate.GetRequestsPerMinute() != 100 || rate.GetBytesPerMinute() != 0 {
+		t.Error("got invalid rate of friend")
+		return
+	}
+	if rate := limit.GetRate("b"); rate.GetRequestsPerMinute() != 10 || rate.GetBytesPerMinute() != 1024 {
+		t.Error("got invalid rate of service")
+		return
+	}
+}
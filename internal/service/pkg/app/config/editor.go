@@ -4,6 +4,7 @@ import (
 	"errors"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/number571/go-peer/pkg/crypto/asymmetric"
 	"github.com/number571/go-peer/pkg/encoding"
@@ -18,7 +19,7 @@ type sEditor struct {
 	fConfig *SConfig
 }
 
-func newEditor(pCfg IConfig) IEditor {
+func newEditor(pCfg IConfig) *sEditor {
 	if pCfg == nil {
 		panic("cfg = nil")
 	}
@@ -48,6 +49,16 @@ func (p *sEditor) UpdateFriends(pFriends map[string]asymmetric.IPubKey) error {
 	cfg := icfg.(*SConfig)
 	cfg.fFriends = pFriends
 	cfg.FFriends = pubKeysToStrings(pFriends)
+
+	// expiry of the deleted friends is not needed
+	for name := range cfg.fExpires {
+		if _, ok := pFriends[name]; ok {
+			continue
+		}
+		delete(cfg.fExpires, name)
+		delete(cfg.FExpires, name)
+	}
+
 	if err := os.WriteFile(filepath, encoding.SerializeYAML(cfg), 0o600); err != nil {
 		return errors.Join(ErrWriteConfig, err)
 	}
@@ -57,9 +68,173 @@ func (p *sEditor) UpdateFriends(pFriends map[string]asymmetric.IPubKey) error {
 
 	p.fConfig.fFriends = cfg.fFriends
 	p.fConfig.FFriends = cfg.FFriends
+	p.fConfig.fExpires = cfg.fExpires
+	p.fConfig.FExpires = cfg.FExpires
 	return nil
 }
 
+func (p *sEditor) UpdateExpires(pExpires map[string]time.Time) error {
+	p.fMutex.Lock()
+	defer p.fMutex.Unlock()
+
+	filepath := p.fConfig.fFilepath
+	icfg, err := LoadConfig(filepath)
+	if err != nil {
+		return errors.Join(ErrLoadConfig, err)
+	}
+
+	cfg := icfg.(*SConfig)
+	cfg.fExpires = pExpires
+	cfg.FExpires = expiresToStrings(pExpires)
+	if err := os.WriteFile(filepath, encoding.SerializeYAML(cfg), 0o600); err != nil {
+		return errors.Join(ErrWriteConfig, err)
+	}
+
+	p.fConfig.fMutex.Lock()
+	defer p.fConfig.fMutex.Unlock()
+
+	p.fConfig.fExpires = cfg.fExpires
+	p.fConfig.FExpires = cfg.FExpires
+	return nil
+}
+
+// DeleteExpiredFriends removes the friends which are expired at the time
+// and returns them. The friends are read and written under the lock of
+// editor, so the concurrent updates of friends are not overwritten.
+func (p *sEditor) DeleteExpiredFriends(pNow time.Time) (map[string]asymmetric.IPubKey, error) {
+	p.fMutex.Lock()
+	defer p.fMutex.Unlock()
+
+	filepath := p.fConfig.fFilepath
+	icfg, err := LoadConfig(filepath)
+	if err != nil {
+		return nil, errors.Join(ErrLoadConfig, err)
+	}
+
+	cfg := icfg.(*SConfig)
+	expired := make(map[string]asymmetric.IPubKey, 4)
+	for name, expiresAt := range cfg.fExpires {
+		if pNow.Before(expiresAt) {
+			continue
+		}
+		if pubKey, ok := cfg.fFriends[name]; ok {
+			expired[name] = pubKey
+		}
+		delete(cfg.fFriends, name)
+		delete(cfg.FFriends, name)
+		delete(cfg.fExpires, name)
+		delete(cfg.FExpires, name)
+	}
+	if len(expired) == 0 {
+		return expired, nil
+	}
+
+	if err := os.WriteFile(filepath, encoding.SerializeYAML(cfg), 0o600); err != nil {
+		return nil, errors.Join(ErrWriteConfig, err)
+	}
+
+	p.fConfig.fMutex.Lock()
+	defer p.fConfig.fMutex.Unlock()
+
+	p.fConfig.fFriends = cfg.fFriends
+	p.fConfig.FFriends = cfg.FFriends
+	p.fConfig.fExpires = cfg.fExpires
+	p.fConfig.FExpires = cfg.FExpires
+	return expired, nil
+}
+
+func (p *sEditor) UpdateServices(pServices map[string]string) error {
+	p.fMutex.Lock()
+	defer p.fMutex.Unlock()
+
+	filepath := p.fConfig.fFilepath
+	icfg, err := LoadConfig(filepath)
+	if err != nil {
+		return errors.Join(ErrLoadConfig, err)
+	}
+
+	cfg := icfg.(*SConfig)
+	cfg.FServices = pServices
+	if !cfg.isValid() {
+		return ErrInvalidConfig
+	}
+
+	if err := os.WriteFile(filepath, encoding.SerializeYAML(cfg), 0o600); err != nil {
+		return errors.Join(ErrWriteConfig, err)
+	}
+
+	p.fConfig.fMutex.Lock()
+	defer p.fConfig.fMutex.Unlock()
+
+	p.fConfig.FServices = cfg.FServices
+	return nil
+}
+
+func (p *sEditor) UpdateAccess(pAccess map[string]IAccess) error {
+	p.fMutex.Lock()
+	defer p.fMutex.Unlock()
+
+	filepath := p.fConfig.fFilepath
+	icfg, err := LoadConfig(filepath)
+	if err != nil {
+		return errors.Join(ErrLoadConfig, err)
+	}
+
+	cfg := icfg.(*SConfig)
+	cfg.FAccess = accessToConfig(pAccess)
+	if !cfg.isValid() {
+		return ErrInvalidConfig
+	}
+
+	if err := os.WriteFile(filepath, encoding.SerializeYAML(cfg), 0o600); err != nil {
+		return errors.Join(ErrWriteConfig, err)
+	}
+
+	p.fConfig.fMutex.Lock()
+	defer p.fConfig.fMutex.Unlock()
+
+	p.fConfig.FAccess = cfg.FAccess
+	return nil
+}
+
+func (p *sEditor) UpdateGroups(pGroups map[string][]string) error {
+	p.fMutex.Lock()
+	defer p.fMutex.Unlock()
+
+	filepath := p.fConfig.fFilepath
+	icfg, err := LoadConfig(filepath)
+	if err != nil {
+		return errors.Join(ErrLoadConfig, err)
+	}
+
+	cfg := icfg.(*SConfig)
+	cfg.FGroups = pGroups
+	if !cfg.isValid() {
+		return ErrInvalidConfig
+	}
+
+	if err := os.WriteFile(filepath, encoding.SerializeYAML(cfg), 0o600); err != nil {
+		return errors.Join(ErrWriteConfig, err)
+	}
+
+	p.fConfig.fMutex.Lock()
+	defer p.fConfig.fMutex.Unlock()
+
+	p.fConfig.FGroups = cfg.FGroups
+	return nil
+}
+
+func accessToConfig(pAccess map[string]IAccess) map[string]*SAccess {
+	result := make(map[string]*SAccess, len(pAccess))
+	for name, access := range pAccess {
+		result[name] = &SAccess{
+			FAllow: access.GetAllow(),
+			FDeny:  access.GetDeny(),
+		}
+	}
+	return result
+}
+
 func pubKeysToStrings(pPubKeys map[string]asymmetric.IPubKey) map[string]string {
 	result := make(map[string]string, len(pPubKeys))
 	for name, pubKey := range pPubKeys {
@@ -68,6 +243,14 @@ func pubKeysToStrings(pPubKeys map[string]asymmetric.IPubKey) map[string]string
 	return result
 }
 
+func expiresToStrings(pExpires map[string]time.Time) map[string]string {
+	result := make(map[string]string, len(pExpires))
+	for name, expires := range pExpires {
+		result[name] = expires.Format(time.RFC3339)
+	}
+	return result
+}
+
 func hasDuplicatePubKeys(pPubKeys map[string]asymmetric.IPubKey) bool {
 	mapping := make(map[string]struct{}, len(pPubKeys))
 	for _, pubKey := range pPubKeys {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/number571/go-peer/pkg/crypto/asymmetric"
 	"github.com/number571/go-peer/pkg/crypto/random"
@@ -43,6 +44,14 @@ func (p *tsConfig) GetNetworkKey() string                     { return "" }
 func (p *tsConfig) GetEndpoints() []string                    { return nil }
 func (p *tsConfig) GetFriends() map[string]asymmetric.IPubKey { return nil }
 func (p *tsConfig) GetService(_ string) (string, bool)        { return "", false }
+func (p *tsConfig) GetServices() map[string]string            { return nil }
+func (p *tsConfig) GetAccess() map[string]IAccess             { return nil }
+func (p *tsConfig) GetLimits() map[string]ILimit              { return nil }
+func (p *tsConfig) GetTokens() map[string]IToken              { return nil }
+func (p *tsConfig) GetIdentities() []string                   { return nil }
+func (p *tsConfig) GetIntroductionsEnabled() bool             { return false }
+func (p *tsConfig) GetGroups() map[string][]string            { return nil }
+func (p *tsConfig) GetExpires() map[string]time.Time          { return nil }
 
 func TestPanicEditor(t *testing.T) {
 	t.Parallel()
@@ -113,6 +122,105 @@ func TestEditor(t *testing.T) {
 		t.Error("success update friends with duplicates")
 		return
 	}
+
+	if err := editor.UpdateServices(map[string]string{"a": "localhost:8080"}); err != nil {
+		t.Error(err)
+		return
+	}
+	if addr, ok := config.GetService("a"); !ok || addr != "localhost:8080" {
+		t.Error("afterServices != newServices")
+		return
+	}
+	if len(config.GetServices()) != 1 {
+		t.Error("old services exist after update")
+		return
+	}
+
+	if err := editor.UpdateServices(map[string]string{"a": ""}); err == nil {
+		t.Error("success update services with empty address")
+		return
+	}
+
+	if err := editor.UpdateAccess(map[string]IAccess{"a": &SAccess{FDeny: []string{"b"}}}); err != nil {
+		t.Error(err)
+		return
+	}
+	access, ok := config.GetAccess()["a"]
+	if !ok || access.IsAllowed("b") {
+		t.Error("afterAccess != newAccess")
+		return
+	}
+	if err := editor.UpdateAccess(map[string]IAccess{"a": &SAccess{FAllow: []string{""}}}); err == nil {
+		t.Error("success update access with empty alias")
+		return
+	}
+	if err := editor.UpdateAccess(map[string]IAccess{"": &SAccess{}}); err == nil {
+		t.Error("success update access with empty host name")
+		return
+	}
+
+	if err := editor.UpdateGroups(map[string][]string{"g": {"a", "b"}}); err != nil {
+		t.Error(err)
+		return
+	}
+	if members, ok := config.GetGroups()["g"]; !ok || len(members) != 2 {
+		t.Error("afterGroups != newGroups")
+		return
+	}
+	if err := editor.UpdateGroups(map[string][]string{"g": {}}); err == nil {
+		t.Error("success update groups with empty members")
+		return
+	}
+
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	if err := editor.UpdateExpires(map[string]time.Time{"a": expiresAt, "unknown": expiresAt}); err != nil {
+		t.Error(err)
+		return
+	}
+	if expires := config.GetExpires(); len(expires) != 1 || !expires["a"].Equal(expiresAt) {
+		t.Error("afterExpires != newExpires")
+		return
+	}
+	if err := editor.UpdateFriends(map[string]asymmetric.IPubKey{"b": tgPubKey2}); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, ok := config.GetExpires()["a"]; ok {
+		t.Error("expiry of removed friend is not deleted")
+		return
+	}
+
+	if err := editor.UpdateExpires(map[string]time.Time{"b": expiresAt}); err != nil {
+		t.Error(err)
+		return
+	}
+	if expired, err := editor.DeleteExpiredFriends(time.Now()); err != nil || len(expired) != 0 {
+		t.Error("not expired friend is deleted")
+		return
+	}
+	expired, err := editor.DeleteExpiredFriends(expiresAt.Add(time.Second))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if pubKey, ok := expired["b"]; !ok || len(expired) != 1 || pubKey.ToString() != tgPubKey2.ToString() {
+		t.Error("expired friend is not returned")
+		return
+	}
+	if len(config.GetFriends()) != 0 || len(config.GetExpires()) != 0 {
+		t.Error("expired friend is not deleted")
+		return
+	}
+
+	cfg, err = LoadConfig(configFile)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if _, ok := cfg.GetAccess()["a"]; !ok {
+		t.Error("access is not saved to the config file")
+		return
+	}
 }
 
 func TestIncorrectFilepathEditor(t *testing.T) {
@@ -30,4 +30,6 @@ var (
 	ErrBuildConfig         = &SConfigError{"build config"}
 	ErrRebuildConfig       = &SConfigError{"rebuild config"}
 	ErrNetworkNotFound     = &SConfigError{"network not found"}
+	ErrReloadConfig        = &SConfigError{"reload config"}
+	ErrLoadExpires         = &SConfigError{"load expires"}
 )
@@ -11,10 +11,16 @@ import (
 type IWrapper interface {
 	GetConfig() IConfig
 	GetEditor() IEditor
+	ReloadConfig() ([]string, error)
 }
 
 type IEditor interface {
 	UpdateFriends(map[string]asymmetric.IPubKey) error
+	UpdateExpires(map[string]time.Time) error
+	DeleteExpiredFriends(time.Time) (map[string]asymmetric.IPubKey, error)
+	UpdateServices(map[string]string) error
+	UpdateAccess(map[string]IAccess) error
+	UpdateGroups(map[string][]string) error
 }
 
 type IConfigSettings interface {
@@ -30,8 +36,37 @@ type IConfig interface {
 	GetLogging() logger.ILogging
 	GetAddress() IAddress
 	GetFriends() map[string]asymmetric.IPubKey
+	GetExpires() map[string]time.Time
 	GetEndpoints() []string
 	GetService(string) (string, bool)
+	GetServices() map[string]string
+	GetAccess() map[string]IAccess
+	GetLimits() map[string]ILimit
+	GetTokens() map[string]IToken
+	GetGroups() map[string][]string
+	GetIdentities() []string
+	GetIntroductionsEnabled() bool
+}
+
+type IToken interface {
+	GetToken() string
+	GetScopes() []string
+	IsAllowed(string, string) bool
+}
+
+type IAccess interface {
+	GetAllow() []string
+	GetDeny() []string
+	IsAllowed(string) bool
+}
+
+type ILimit interface {
+	GetRate(string) IRate
+}
+
+type IRate interface {
+	GetRequestsPerMinute() uint64
+	GetBytesPerMinute() uint64
 }
 
 type IAddress interface {
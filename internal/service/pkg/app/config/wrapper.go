@@ -1,12 +1,19 @@
 package config
 
+import (
+	"errors"
+	"slices"
+	"sync"
+)
+
 var (
 	_ IWrapper = &sWrapper{}
 )
 
 type sWrapper struct {
+	fMutex  sync.RWMutex
 	fConfig IConfig
-	fEditor IEditor
+	fEditor *sEditor
 }
 
 func NewWrapper(pCfg IConfig) IWrapper {
@@ -17,9 +24,65 @@ func NewWrapper(pCfg IConfig) IWrapper {
 }
 
 func (p *sWrapper) GetConfig() IConfig {
+	p.fMutex.RLock()
+	defer p.fMutex.RUnlock()
+
 	return p.fConfig
 }
 
 func (p *sWrapper) GetEditor() IEditor {
 	return p.fEditor
 }
+
+// ReloadConfig reads the config file again and swaps the current config.
+// Values that cannot be applied without restart (settings, address) are
+// saved from the current config and returned as a list of changed keys.
+func (p *sWrapper) ReloadConfig() ([]string, error) {
+	p.fEditor.fMutex.Lock()
+	defer p.fEditor.fMutex.Unlock()
+
+	oldCfg := p.fEditor.fConfig
+	icfg, err := LoadConfig(oldCfg.fFilepath)
+	if err != nil {
+		return nil, errors.Join(ErrReloadConfig, err)
+	}
+
+	newCfg := icfg.(*SConfig)
+	restartRequired := getRestartRequired(oldCfg, newCfg)
+
+	newCfg.FSettings = oldCfg.FSettings
+	newCfg.FAddress = oldCfg.FAddress
+	newCfg.FIdentities = oldCfg.FIdentities
+	newCfg.FIntroductionsEnabled = oldCfg.FIntroductionsEnabled
+
+	p.fMutex.Lock()
+	defer p.fMutex.Unlock()
+
+	p.fConfig = newCfg
+	p.fEditor.fConfig = newCfg
+	return restartRequired, nil
+}
+
+func getRestartRequired(pOld, pNew *SConfig) []string {
+	result := make([]string, 0, 8)
+	checks := []struct {
+		fName    string
+		fChanged bool
+	}{
+		{"settings.message_size_bytes", pOld.FSettings.FMessageSizeBytes != pNew.FSettings.FMessageSizeBytes},
+		{"settings.fetch_timeout_ms", pOld.FSettings.FFetchTimeoutMS != pNew.FSettings.FFetchTimeoutMS},
+		{"settings.queue_period_ms", pOld.FSettings.FQueuePeriodMS != pNew.FSettings.FQueuePeriodMS},
+		{"settings.work_size_bits", pOld.FSettings.FWorkSizeBits != pNew.FSettings.FWorkSizeBits},
+		{"settings.network_key", pOld.FSettings.FNetworkKey != pNew.FSettings.FNetworkKey},
+		{"address.external", pOld.FAddress.FExternal != pNew.FAddress.FExternal},
+		{"address.internal", pOld.FAddress.FInternal != pNew.FAddress.FInternal},
+		{"identities", !slices.Equal(pOld.FIdentities, pNew.FIdentities)},
+		{"introductions_enabled", pOld.FIntroductionsEnabled != pNew.FIntroductionsEnabled},
+	}
+	for _, c := range checks {
+		if c.fChanged {
+			result = append(result, c.fName)
+		}
+	}
+	return result
+}
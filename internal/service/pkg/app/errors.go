@@ -13,20 +13,30 @@ func (err *SAppError) Error() string {
 }
 
 var (
-	ErrRunning          = &SAppError{"app running"}
-	ErrService          = &SAppError{"service"}
-	ErrInitDB           = &SAppError{"init database"}
-	ErrClose            = &SAppError{"close"}
-	ErrSizePrivateKey   = &SAppError{"size private key"}
-	ErrGetPrivateKey    = &SAppError{"get private key"}
-	ErrInitConfig       = &SAppError{"init config"}
-	ErrSetParallelNull  = &SAppError{"set parallel = 0"}
-	ErrGetParallel      = &SAppError{"get parallel"}
-	ErrCreateAnonNode   = &SAppError{"create anon node"}
-	ErrOpenKVDatabase   = &SAppError{"open kv database"}
-	ErrReadKVDatabase   = &SAppError{"read kv database"}
-	ErrMessageSizeLimit = &SAppError{"message size limit"}
-	ErrInvalidPsdPubKey = &SAppError{"invalid psd public key"}
-	ErrGetPsdPubKey     = &SAppError{"get psd pub key"}
-	ErrSetPsdPubKey     = &SAppError{"set psd pub key"}
+	ErrRunning             = &SAppError{"app running"}
+	ErrService             = &SAppError{"service"}
+	ErrInitDB              = &SAppError{"init database"}
+	ErrClose               = &SAppError{"close"}
+	ErrSizePrivateKey      = &SAppError{"size private key"}
+	ErrGetPrivateKey       = &SAppError{"get private key"}
+	ErrInitConfig          = &SAppError{"init config"}
+	ErrSetParallelNull     = &SAppError{"set parallel = 0"}
+	ErrGetParallel         = &SAppError{"get parallel"}
+	ErrCreateAnonNode      = &SAppError{"create anon node"}
+	ErrOpenKVDatabase      = &SAppError{"open kv database"}
+	ErrReadKVDatabase      = &SAppError{"read kv database"}
+	ErrMessageSizeLimit    = &SAppError{"message size limit"}
+	ErrInvalidPsdPubKey    = &SAppError{"invalid psd public key"}
+	ErrGetPsdPubKey        = &SAppError{"get psd pub key"}
+	ErrSetPsdPubKey        = &SAppError{"set psd pub key"}
+	ErrReloadConfig        = &SAppError{"reload config"}
+	ErrChangePassphrase    = &SAppError{"change passphrase"}
+	ErrExportKey           = &SAppError{"export key"}
+	ErrImportKey           = &SAppError{"import key"}
+	ErrBackupPathUndefined = &SAppError{"backup path undefined"}
+	ErrBackupExist         = &SAppError{"backup already exist"}
+	ErrPrivateKeyExist     = &SAppError{"private key already exist"}
+	ErrInvalidFriendPubKey = &SAppError{"invalid public key of friend"}
+	ErrInitIdentity        = &SAppError{"init identity"}
+	ErrDuplicateIdentity   = &SAppError{"duplicate private key of identity"}
 )
@@ -2,6 +2,7 @@ package app
 
 import (
 	"errors"
+	"fmt"
 	"path/filepath"
 
 	"github.com/number571/go-peer/pkg/encoding"
@@ -10,10 +11,13 @@ import (
 	"github.com/number571/hidden-lake/build"
 	"github.com/number571/hidden-lake/pkg/adapters"
 	"github.com/number571/hidden-lake/pkg/adapters/http"
+	pkg_handler "github.com/number571/hidden-lake/pkg/handler"
 	"github.com/number571/hidden-lake/pkg/network"
 
 	"github.com/number571/go-peer/pkg/client"
 	"github.com/number571/hidden-lake/internal/service/internal/handler"
+	"github.com/number571/hidden-lake/internal/service/internal/metadata"
+	"github.com/number571/hidden-lake/internal/service/internal/multiplex"
 	hls_settings "github.com/number571/hidden-lake/internal/service/pkg/settings"
 )
 
@@ -23,14 +27,11 @@ func (p *sApp) initAnonNode() error {
 		cfgSettings = cfg.GetSettings()
 	)
 
-	client := client.NewClient(p.fPrivKey, cfgSettings.GetMessageSizeBytes())
-	if client.GetPayloadLimit() <= encoding.CSizeUint64 {
-		return ErrMessageSizeLimit
-	}
-
-	kvDatabase, err := database.NewKVDatabase(filepath.Join(p.fPathTo, hls_settings.CPathDB))
-	if err != nil {
-		return errors.Join(ErrOpenKVDatabase, err)
+	for _, identity := range p.fIdentities {
+		client := client.NewClient(identity.fPrivKey, cfgSettings.GetMessageSizeBytes())
+		if client.GetPayloadLimit() <= encoding.CSizeUint64 {
+			return ErrMessageSizeLimit
+		}
 	}
 
 	adapterSettings := adapters.NewSettings(&adapters.SSettings{
@@ -39,9 +40,60 @@ func (p *sApp) initAnonNode() error {
 		FMessageSizeBytes: cfgSettings.GetMessageSizeBytes(),
 	})
 
+	// connections are shared by all identities
+	p.fMultiplexer = multiplex.NewMultiplexer(
+		p.fAnonLogger,
+		http.NewHTTPAdapter(
+			http.NewSettings(&http.SSettings{
+				FAdapterSettings: adapterSettings,
+				FAddress:         cfg.GetAddress().GetExternal(),
+			}),
+			cache.NewLRUCache(build.GSettings.FNetworkManager.FCacheHashesCap),
+			func() []string { return p.fCfgW.GetConfig().GetEndpoints() },
+		),
+		uint64(len(p.fIdentities)),
+		build.GSettings.FQueueProblem.FConsumersCap,
+	)
+
+	for i, identity := range p.fIdentities {
+		kvDatabase, err := database.NewKVDatabase(filepath.Join(identity.fPathTo, hls_settings.CPathDB))
+		if err != nil {
+			p.closeKVDatabases(i)
+			return errors.Join(ErrOpenKVDatabase, err)
+		}
+		identity.fMetadata = metadata.NewMetadata(kvDatabase, hls_settings.CFriendsLastSeenPrecision)
+		identity.fNode = p.newAnonNode(identity, adapterSettings, kvDatabase, p.fMultiplexer.GetAdapter(uint64(i)))
+	}
+
+	return nil
+}
+
+func (p *sApp) newAnonNode(
+	pIdentity *sIdentity,
+	pAdapterSettings adapters.ISettings,
+	pKVDatabase database.IKVDatabase,
+	pAdapter adapters.IRunnerAdapter,
+) network.IHiddenLakeNode {
+	cfgSettings := p.fCfgW.GetConfig().GetSettings()
+
+	// built-in services of the HLS
+	serviceMux := pkg_handler.NewServiceMux()
+	if pIdentity.fCfgW.GetConfig().GetIntroductionsEnabled() {
+		serviceMux.HandleFunc(
+			hls_settings.CIntroductionHostName,
+			"POST "+hls_settings.CIntroductionPath,
+			handler.HandleIntroductionService(
+				pIdentity.fCfgW,
+				p.fAnonLogger,
+				pIdentity.fPrivKey.GetPubKey(),
+				pIdentity.fIntroductions,
+			),
+		)
+	}
+
 	node := network.NewHiddenLakeNode(
 		network.NewSettings(&network.SSettings{
-			FAdapterSettings: adapterSettings,
+			FAdapterSettings: pAdapterSettings,
 			FQueuePeriod:     cfgSettings.GetQueuePeriod(),
 			FFetchTimeout:    cfgSettings.GetFetchTimeout(),
 			FSubSettings: &network.SSubSettings{
@@ -50,24 +102,39 @@ func (p *sApp) initAnonNode() error {
 				FLogger:      p.fAnonLogger,
 			},
 		}),
-		p.fPrivKey,
-		kvDatabase,
-		http.NewHTTPAdapter(
-			http.NewSettings(&http.SSettings{
-				FAdapterSettings: adapterSettings,
-				FAddress:         cfg.GetAddress().GetExternal(),
-			}),
-			cache.NewLRUCache(build.GSettings.FNetworkManager.FCacheHashesCap),
-			func() []string { return p.fCfgW.GetConfig().GetEndpoints() },
-		),
-		handler.HandleServiceFunc(cfg, p.fAnonLogger),
+		pIdentity.fPrivKey,
+		pKVDatabase,
+		pAdapter,
+		handler.HandleServiceFunc(pIdentity.fCfgW, p.fAnonLogger, serviceMux, pIdentity.fStreams, pIdentity.fMetadata, pIdentity.fLimiter),
 	)
 
 	originNode := node.GetAnonymityNode()
-	for _, f := range cfg.GetFriends() {
+	for _, f := range pIdentity.fCfgW.GetConfig().GetFriends() {
 		originNode.GetMapPubKeys().SetPubKey(f)
 	}
+	p.setFriendsAdded(pIdentity)
 
-	p.fNode = node
-	return nil
+	return node
+}
+
+func (p *sApp) closeKVDatabases(pCount int) {
+	for _, identity := range p.fIdentities[:pCount] {
+		_ = identity.fNode.GetAnonymityNode().GetKVDatabase().Close()
+	}
+}
+
+// setFriendsAdded saves the time of adding for friends which were
+// added by editing of the config file.
+func (p *sApp) setFriendsAdded(pIdentity *sIdentity) {
+	for aliasName, pubKey := range pIdentity.fCfgW.GetConfig().GetFriends() {
+		if err := pIdentity.fMetadata.SetAdded(pubKey); err != nil {
+			p.fStdfLogger.PushWarn(fmt.Sprintf(
+				"%s metadata of friend is not saved (%s/%s); %s",
+				hls_settings.GServiceName.Short(),
+				pIdentity.fName,
+				aliasName,
+				err.Error(),
+			))
+		}
+	}
 }
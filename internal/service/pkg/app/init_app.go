@@ -2,10 +2,11 @@ package app
 
 import (
 	"errors"
+	"os"
 	"path/filepath"
 	"strconv"
-	"strings"
 
+	"github.com/number571/go-peer/pkg/crypto/asymmetric"
 	"github.com/number571/go-peer/pkg/types"
 	"github.com/number571/hidden-lake/internal/utils/flag"
 	"github.com/number571/hidden-lake/internal/utils/privkey"
@@ -22,7 +23,22 @@ func InitApp(pArgs []string, pFlags flag.IFlags) (types.IRunner, error) {
 		return nil, errors.Join(ErrGetParallel, err)
 	}
 
-	inputPath := strings.TrimSuffix(pFlags.Get("-p").GetStringValue(pArgs), "/")
+	inputPath := getInputPath(pArgs, pFlags)
+
+	keyPath := filepath.Join(inputPath, pkg_settings.CPathKey)
+	getPrivKey := privkey.GetPrivKey
+	if pFlags.Get("--key-required").GetBoolValue(pArgs) {
+		getPrivKey = privkey.ReadPrivKey
+	}
+
+	// the passphrase is read once (the file descriptor is closed after
+	// reading) and the same bytes are used for the keys of all identities
+	passphraseF := getOncePassphraseF(getPassphraseF(pArgs, pFlags))
+
+	privKey, err := getPrivKey(keyPath, passphraseF)
+	if err != nil {
+		return nil, errors.Join(ErrGetPrivateKey, err)
+	}
 
 	cfgPath := filepath.Join(inputPath, pkg_settings.CPathYML)
 	cfg, err := config.InitConfig(cfgPath, nil, pFlags.Get("-n").GetStringValue(pArgs))
@@ -30,11 +46,76 @@ func InitApp(pArgs []string, pFlags flag.IFlags) (types.IRunner, error) {
 		return nil, errors.Join(ErrInitConfig, err)
 	}
 
-	keyPath := filepath.Join(inputPath, pkg_settings.CPathKey)
-	privKey, err := privkey.GetPrivKey(keyPath)
+	identities, err := initIdentities(cfg, inputPath, func(pKeyPath string) (asymmetric.IPrivKey, error) {
+		return getPrivKey(pKeyPath, passphraseF)
+	})
 	if err != nil {
-		return nil, errors.Join(ErrGetPrivateKey, err)
+		return nil, errors.Join(ErrInitIdentity, err)
+	}
+
+	for _, identity := range identities {
+		if identity.FPrivKey.GetPubKey().ToString() == privKey.GetPubKey().ToString() {
+			return nil, ErrDuplicateIdentity
+		}
+	}
+
+	return NewApp(cfg, privKey, inputPath, setParallel, identities...), nil
+}
+
+// initIdentities reads (or creates) the private keys and configs of the
+// additional identities from the directories identities/<name>. The config
+// of identity is created with the settings of the main config.
+func initIdentities(
+	pCfg config.IConfig,
+	pInputPath string,
+	pGetPrivKey func(string) (asymmetric.IPrivKey, error),
+) ([]*SIdentity, error) {
+	names := pCfg.GetIdentities()
+	result := make([]*SIdentity, 0, len(names))
+	pubKeys := make(map[string]struct{}, len(names))
+
+	for _, name := range names {
+		identityPath := filepath.Join(pInputPath, pkg_settings.CPathIdentities, name)
+		if err := os.MkdirAll(identityPath, 0o700); err != nil {
+			return nil, err
+		}
+
+		privKey, err := pGetPrivKey(filepath.Join(identityPath, pkg_settings.CPathKey))
+		if err != nil {
+			return nil, errors.Join(ErrGetPrivateKey, err)
+		}
+
+		pubKey := privKey.GetPubKey().ToString()
+		if _, ok := pubKeys[pubKey]; ok {
+			return nil, ErrDuplicateIdentity
+		}
+		pubKeys[pubKey] = struct{}{}
+
+		cfgSettings := pCfg.GetSettings()
+		identityCfg, err := config.InitConfig(
+			filepath.Join(identityPath, pkg_settings.CPathYML),
+			&config.SConfig{
+				FSettings: &config.SConfigSettings{
+					FMessageSizeBytes: cfgSettings.GetMessageSizeBytes(),
+					FWorkSizeBits:     cfgSettings.GetWorkSizeBits(),
+					FFetchTimeoutMS:   uint64(cfgSettings.GetFetchTimeout().Milliseconds()), // nolint: gosec
+					FQueuePeriodMS:    uint64(cfgSettings.GetQueuePeriod().Milliseconds()),  // nolint: gosec
+					FNetworkKey:       cfgSettings.GetNetworkKey(),
+				},
+				FFriends: map[string]string{},
+			},
+			"",
+		)
+		if err != nil {
+			return nil, errors.Join(ErrInitConfig, err)
+		}
+
+		result = append(result, &SIdentity{
+			FName:    name,
+			FConfig:  identityCfg,
+			FPrivKey: privKey,
+		})
 	}
 
-	return NewApp(cfg, privKey, inputPath, setParallel), nil
+	return result, nil
 }
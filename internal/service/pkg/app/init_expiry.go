@@ -0,0 +1,58 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	hls_settings "github.com/number571/hidden-lake/internal/service/pkg/settings"
+)
+
+func (p *sApp) runFriendsExpiry(pCtx context.Context, wg *sync.WaitGroup, _ chan<- error) {
+	defer wg.Done()
+
+	// friends can be expired while the HLS is stopped
+	p.removeExpiredFriends()
+
+	ticker := time.NewTicker(hls_settings.CFriendsExpiryPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-pCtx.Done():
+			return
+		case <-ticker.C:
+			p.removeExpiredFriends()
+		}
+	}
+}
+
+func (p *sApp) removeExpiredFriends() {
+	now := time.Now()
+	for _, identity := range p.fIdentities {
+		expired, err := identity.fCfgW.GetEditor().DeleteExpiredFriends(now)
+		if err != nil {
+			p.fStdfLogger.PushWarn(fmt.Sprintf(
+				"%s expired friends are not removed (%s); %s",
+				hls_settings.GServiceName.Short(),
+				identity.fName,
+				err.Error(),
+			))
+			continue
+		}
+
+		mapPubKeys := identity.fNode.GetAnonymityNode().GetMapPubKeys()
+		for aliasName, pubKey := range expired {
+			mapPubKeys.DelPubKey(pubKey)
+			_ = identity.fMetadata.Delete(pubKey)
+
+			p.fStdfLogger.PushInfo(fmt.Sprintf(
+				"%s friend is expired and removed (%s/%s)",
+				hls_settings.GServiceName.Short(),
+				identity.fName,
+				aliasName,
+			))
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package app
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/number571/go-peer/pkg/crypto/asymmetric"
+	"github.com/number571/hidden-lake/internal/service/pkg/app/config"
+	pkg_settings "github.com/number571/hidden-lake/internal/service/pkg/settings"
+	"github.com/number571/hidden-lake/internal/utils/flag"
+	"github.com/number571/hidden-lake/internal/utils/passphrase"
+	"github.com/number571/hidden-lake/internal/utils/privkey"
+)
+
+// ChangeKeyPassphrase encrypts the private key by the new passphrase.
+// The new passphrase is read from the environment variable or terminal.
+func ChangeKeyPassphrase(pArgs []string, pFlags flag.IFlags) error {
+	keyPath := filepath.Join(getInputPath(pArgs, pFlags), pkg_settings.CPathKey)
+
+	newPassphrase := passphrase.FromEnv(passphrase.CEnvNewPassphrase)
+	if newPassphrase == nil {
+		var err error
+		newPassphrase, err = passphrase.NewFromTerminal(
+			"New passphrase of private key: ",
+			"Repeat new passphrase: ",
+		)
+		if err != nil {
+			return errors.Join(ErrChangePassphrase, err)
+		}
+	}
+
+	err := privkey.ChangePassphrase(keyPath, getPassphraseF(pArgs, pFlags), newPassphrase)
+	if err != nil {
+		return errors.Join(ErrChangePassphrase, err)
+	}
+	return nil
+}
+
+// GetKeyPubKey reads the existing private key and returns its public key.
+func GetKeyPubKey(pArgs []string, pFlags flag.IFlags) (asymmetric.IPubKey, error) {
+	keyPath := filepath.Join(getInputPath(pArgs, pFlags), pkg_settings.CPathKey)
+	privKey, err := privkey.ReadPrivKey(keyPath, getPassphraseF(pArgs, pFlags))
+	if err != nil {
+		return nil, errors.Join(ErrGetPrivateKey, err)
+	}
+	return privKey.GetPubKey(), nil
+}
+
+// ExportKey writes the encrypted backup of the private key and optionally
+// the list of friends from hls.yml.
+func ExportKey(pArgs []string, pFlags flag.IFlags) error {
+	inputPath := getInputPath(pArgs, pFlags)
+
+	outputPath := pFlags.Get("--output").GetStringValue(pArgs)
+	if outputPath == "" {
+		return ErrBackupPathUndefined
+	}
+	if !pFlags.Get("--force").GetBoolValue(pArgs) && isFileExist(outputPath) {
+		return ErrBackupExist
+	}
+
+	keyPath := filepath.Join(inputPath, pkg_settings.CPathKey)
+	privKey, err := privkey.ReadPrivKey(keyPath, getPassphraseF(pArgs, pFlags))
+	if err != nil {
+		return errors.Join(ErrGetPrivateKey, err)
+	}
+
+	backup := &privkey.SBackup{FPrivKey: privKey.ToString()}
+	if pFlags.Get("--with-friends").GetBoolValue(pArgs) {
+		cfg, err := config.LoadConfig(filepath.Join(inputPath, pkg_settings.CPathYML))
+		if err != nil {
+			return errors.Join(ErrInitConfig, err)
+		}
+		friends := cfg.GetFriends()
+		backup.FFriends = make(map[string]string, len(friends))
+		for aliasName, pubKey := range friends {
+			backup.FFriends[aliasName] = pubKey.ToString()
+		}
+	}
+
+	backupPassphrase := passphrase.FromEnv(passphrase.CEnvBackupPassphrase)
+	if backupPassphrase == nil {
+		backupPassphrase, err = passphrase.NewFromTerminal(
+			"Passphrase of backup: ",
+			"Repeat passphrase of backup: ",
+		)
+		if err != nil {
+			return errors.Join(ErrExportKey, err)
+		}
+	}
+
+	if err := privkey.WriteBackup(outputPath, backup, backupPassphrase); err != nil {
+		return errors.Join(ErrExportKey, err)
+	}
+	return nil
+}
+
+// ImportKey restores the private key from the backup. The key is encrypted
+// by the passphrase only if it is set by the file descriptor or environment.
+// Imported friends do not replace existing aliases and public keys.
+func ImportKey(pArgs []string, pFlags flag.IFlags) error {
+	inputPath := getInputPath(pArgs, pFlags)
+
+	backupPath := pFlags.Get("--input").GetStringValue(pArgs)
+	if backupPath == "" {
+		return ErrBackupPathUndefined
+	}
+
+	keyPath := filepath.Join(inputPath, pkg_settings.CPathKey)
+	if !pFlags.Get("--force").GetBoolValue(pArgs) && isFileExist(keyPath) {
+		return ErrPrivateKeyExist
+	}
+
+	backupPassphrase := passphrase.FromEnv(passphrase.CEnvBackupPassphrase)
+	if backupPassphrase == nil {
+		var err error
+		backupPassphrase, err = passphrase.FromTerminal("Passphrase of backup: ")
+		if err != nil {
+			return errors.Join(ErrImportKey, err)
+		}
+	}
+
+	backup, privKey, err := privkey.ReadBackup(backupPath, backupPassphrase)
+	if err != nil {
+		return errors.Join(ErrImportKey, err)
+	}
+
+	keyPassphrase, err := getPassphraseF(pArgs, pFlags)(false)
+	if err != nil {
+		return errors.Join(ErrImportKey, err)
+	}
+	if err := privkey.WritePrivKey(keyPath, privKey, keyPassphrase); err != nil {
+		return errors.Join(ErrImportKey, err)
+	}
+
+	if !pFlags.Get("--with-friends").GetBoolValue(pArgs) || len(backup.FFriends) == 0 {
+		return nil
+	}
+
+	cfg, err := config.InitConfig(filepath.Join(inputPath, pkg_settings.CPathYML), nil, "")
+	if err != nil {
+		return errors.Join(ErrInitConfig, err)
+	}
+
+	friends, err := mergeFriends(cfg.GetFriends(), backup.FFriends)
+	if err != nil {
+		return errors.Join(ErrImportKey, err)
+	}
+	if err := config.NewWrapper(cfg).GetEditor().UpdateFriends(friends); err != nil {
+		return errors.Join(ErrImportKey, err)
+	}
+	return nil
+}
+
+func mergeFriends(
+	pFriends map[string]asymmetric.IPubKey,
+	pBackupFriends map[string]string,
+) (map[string]asymmetric.IPubKey, error) {
+	result := make(map[string]asymmetric.IPubKey, len(pFriends)+len(pBackupFriends))
+	exists := make(map[string]struct{}, len(pFriends)+len(pBackupFriends))
+	for aliasName, pubKey := range pFriends {
+		result[aliasName] = pubKey
+		exists[pubKey.ToString()] = struct{}{}
+	}
+	for aliasName, pubKeyStr := range pBackupFriends {
+		pubKey := asymmetric.LoadPubKey(pubKeyStr)
+		if pubKey == nil {
+			return nil, ErrInvalidFriendPubKey
+		}
+		if _, ok := result[aliasName]; ok {
+			continue
+		}
+		if _, ok := exists[pubKey.ToString()]; ok {
+			continue
+		}
+		result[aliasName] = pubKey
+		exists[pubKey.ToString()] = struct{}{}
+	}
+	return result, nil
+}
+
+func getPassphraseF(pArgs []string, pFlags flag.IFlags) privkey.IPassphraseF {
+	passphraseFD := pFlags.Get("--passphrase-fd").GetStringValue(pArgs)
+	return func(pPrompt bool) ([]byte, error) {
+		if passphraseFD != "" {
+			return passphrase.FromFD(passphraseFD)
+		}
+		if v := passphrase.FromEnv(passphrase.CEnvPassphrase); v != nil {
+			return v, nil
+		}
+		if !pPrompt {
+			return nil, nil
+		}
+		return passphrase.FromTerminal("Passphrase of private key: ")
+	}
+}
+
+// getOncePassphraseF caches the read passphrase. The empty passphrase
+// without prompt is not cached, so it can be requested again by prompt.
+func getOncePassphraseF(pPassphraseF privkey.IPassphraseF) privkey.IPassphraseF {
+	var (
+		isRead     bool
+		passphrase []byte
+		err        error
+	)
+	return func(pPrompt bool) ([]byte, error) {
+		if isRead {
+			return passphrase, err
+		}
+		passphrase, err = pPassphraseF(pPrompt)
+		isRead = pPrompt || err != nil || len(passphrase) != 0
+		return passphrase, err
+	}
+}
+
+func getInputPath(pArgs []string, pFlags flag.IFlags) string {
+	return strings.TrimSuffix(pFlags.Get("-p").GetStringValue(pArgs), "/")
+}
+
+func isFileExist(pPath string) bool {
+	_, err := os.Stat(pPath)
+	return !os.IsNotExist(err)
+}
@@ -0,0 +1,105 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/number571/go-peer/pkg/crypto/asymmetric"
+	"github.com/number571/hidden-lake/internal/service/pkg/app/config"
+	hls_settings "github.com/number571/hidden-lake/internal/service/pkg/settings"
+	std_logger "github.com/number571/hidden-lake/internal/utils/logger/std"
+)
+
+var (
+	_ std_logger.ILogging = &sLogging{}
+)
+
+// sLogging reads log levels from the current config on every call,
+// so reloading of config also changes the loggers.
+type sLogging struct {
+	fWrapper config.IWrapper
+}
+
+func (p *sLogging) HasInfo() bool { return p.fWrapper.GetConfig().GetLogging().HasInfo() }
+func (p *sLogging) HasWarn() bool { return p.fWrapper.GetConfig().GetLogging().HasWarn() }
+func (p *sLogging) HasErro() bool { return p.fWrapper.GetConfig().GetLogging().HasErro() }
+
+func (p *sApp) runConfigReloader(pCtx context.Context, wg *sync.WaitGroup, _ chan<- error) {
+	defer wg.Done()
+
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+	defer signal.Stop(sighup)
+
+	for {
+		select {
+		case <-pCtx.Done():
+			return
+		case <-sighup:
+			_, _ = p.reloadConfig()
+		}
+	}
+}
+
+func (p *sApp) reloadConfig() ([]string, error) {
+	var restartRequired []string
+
+	// the config of default identity is the main config
+	for i, identity := range p.fIdentities {
+		oldFriends := identity.fCfgW.GetConfig().GetFriends()
+
+		result, err := identity.fCfgW.ReloadConfig()
+		if err != nil {
+			p.fStdfLogger.PushWarn(fmt.Sprintf(
+				"%s config is not reloaded (%s); %s",
+				hls_settings.GServiceName.Short(),
+				identity.fName,
+				err.Error(),
+			))
+			return nil, errors.Join(ErrReloadConfig, err)
+		}
+
+		if i == 0 {
+			restartRequired = result
+		}
+
+		syncMapPubKeys(
+			identity.fNode.GetAnonymityNode().GetMapPubKeys(),
+			oldFriends,
+			identity.fCfgW.GetConfig().GetFriends(),
+		)
+		p.setFriendsAdded(identity)
+	}
+
+	p.initEndpointClients(p.fCfgW.GetConfig().GetEndpoints())
+
+	p.fStdfLogger.PushInfo(fmt.Sprintf(
+		"%s config is reloaded; restart required: %v",
+		hls_settings.GServiceName.Short(),
+		restartRequired,
+	))
+	return restartRequired, nil
+}
+
+func syncMapPubKeys(
+	pMapPubKeys asymmetric.IMapPubKeys,
+	pOldFriends map[string]asymmetric.IPubKey,
+	pNewFriends map[string]asymmetric.IPubKey,
+) {
+	newPubKeys := make(map[string]struct{}, len(pNewFriends))
+	for _, pubKey := range pNewFriends {
+		newPubKeys[pubKey.ToString()] = struct{}{}
+		pMapPubKeys.SetPubKey(pubKey)
+	}
+	for _, pubKey := range pOldFriends {
+		if _, ok := newPubKeys[pubKey.ToString()]; ok {
+			continue
+		}
+		pMapPubKeys.DelPubKey(pubKey)
+	}
+}
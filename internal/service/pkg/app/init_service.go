@@ -6,33 +6,86 @@ import (
 	"time"
 
 	"github.com/number571/hidden-lake/internal/service/internal/handler"
+	"github.com/number571/hidden-lake/internal/service/internal/tickets"
 	hls_settings "github.com/number571/hidden-lake/internal/service/pkg/settings"
 	"github.com/number571/hidden-lake/pkg/adapters/http/client"
 )
 
 func (p *sApp) initServiceHTTP(pCtx context.Context) {
-	mux := http.NewServeMux()
 	cfg := p.fCfgW.GetConfig()
-	origNode := p.fNode.GetAnonymityNode()
 
-	endpoints := cfg.GetEndpoints()
-	epClients := make([]client.IClient, 0, len(endpoints))
-	for _, ep := range endpoints {
-		requester := client.NewRequester(ep, &http.Client{Timeout: 5 * time.Second})
-		epClients = append(epClients, client.NewClient(requester))
+	p.initEndpointClients(cfg.GetEndpoints())
+
+	identities := make([]*hls_settings.SIdentity, 0, len(p.fIdentities))
+	for _, identity := range p.fIdentities {
+		identities = append(identities, &hls_settings.SIdentity{
+			FName:      identity.fName,
+			FPublicKey: identity.fPrivKey.GetPubKey().ToString(),
+		})
 	}
 
-	mux.HandleFunc(hls_settings.CHandleIndexPath, handler.HandleIndexAPI(p.fHTTPLogger))
-	mux.HandleFunc(hls_settings.CHandleConfigSettingsPath, handler.HandleConfigSettingsAPI(p.fCfgW, p.fHTTPLogger, origNode))
-	mux.HandleFunc(hls_settings.CHandleConfigConnectsPath, handler.HandleConfigConnectsAPI(pCtx, p.fHTTPLogger, epClients))
-	mux.HandleFunc(hls_settings.CHandleConfigFriendsPath, handler.HandleConfigFriendsAPI(p.fCfgW, p.fHTTPLogger, origNode))
-	mux.HandleFunc(hls_settings.CHandleNetworkOnlinePath, handler.HandleNetworkOnlineAPI(pCtx, p.fHTTPLogger, epClients))
-	mux.HandleFunc(hls_settings.CHandleServicePubKeyPath, handler.HandleServicePubKeyAPI(p.fHTTPLogger, origNode))
-	mux.HandleFunc(hls_settings.CHandleNetworkRequestPath, handler.HandleNetworkRequestAPI(pCtx, cfg, p.fHTTPLogger, p.fNode))
+	handlers := make(map[string]http.Handler, len(p.fIdentities))
+	for _, identity := range p.fIdentities {
+		handlers[identity.fName] = p.newIdentityMux(pCtx, identity, identities)
+	}
 
 	p.fServiceHTTP = &http.Server{
 		Addr:        cfg.GetAddress().GetInternal(),
-		Handler:     mux,
+		Handler:     handler.HandleAuth(p.fCfgW, p.fHTTPLogger, handler.HandleIdentity(p.fHTTPLogger, handlers)),
 		ReadTimeout: (5 * time.Second),
 	}
 }
+
+func (p *sApp) newIdentityMux(
+	pCtx context.Context,
+	pIdentity *sIdentity,
+	pIdentities []*hls_settings.SIdentity,
+) http.Handler {
+	mux := http.NewServeMux()
+	cfgW := pIdentity.fCfgW
+	origNode := pIdentity.fNode.GetAnonymityNode()
+	fetchTickets := tickets.NewTickets(hls_settings.CTicketsTTL, hls_settings.CTicketsLimit)
+
+	mux.HandleFunc(hls_settings.CHandleIndexPath, handler.HandleIndexAPI(p.fHTTPLogger))
+	mux.HandleFunc(hls_settings.CHandleConfigSettingsPath, handler.HandleConfigSettingsAPI(p.fCfgW, p.fHTTPLogger, origNode))
+	mux.HandleFunc(hls_settings.CHandleConfigConnectsPath, handler.HandleConfigConnectsAPI(pCtx, p.fHTTPLogger, p.getEndpointClients))
+	mux.HandleFunc(hls_settings.CHandleConfigFriendsPath, handler.HandleConfigFriendsAPI(cfgW, p.fHTTPLogger, origNode, pIdentity.fMetadata))
+	mux.HandleFunc(hls_settings.CHandleConfigAccessPath, handler.HandleConfigAccessAPI(cfgW, p.fHTTPLogger))
+	mux.HandleFunc(hls_settings.CHandleConfigServicesPath, handler.HandleConfigServicesAPI(cfgW, p.fHTTPLogger))
+	mux.HandleFunc(hls_settings.CHandleConfigContactPath, handler.HandleConfigContactAPI(cfgW, p.fHTTPLogger, origNode, pIdentity.fMetadata))
+	mux.HandleFunc(hls_settings.CHandleConfigContactNewPath, handler.HandleConfigContactNewAPI(cfgW, p.fHTTPLogger, origNode))
+	mux.HandleFunc(hls_settings.CHandleConfigIntroductionsPath, handler.HandleConfigIntroductionsAPI(pCtx, cfgW, p.fHTTPLogger, pIdentity.fNode, pIdentity.fIntroductions, pIdentity.fMetadata))
+	mux.HandleFunc(hls_settings.CHandleConfigGroupsPath, handler.HandleConfigGroupsAPI(cfgW, p.fHTTPLogger))
+	mux.HandleFunc(hls_settings.CHandleConfigIdentitiesPath, handler.HandleConfigIdentitiesAPI(p.fHTTPLogger, pIdentities))
+	mux.HandleFunc(hls_settings.CHandleConfigReloadPath, handler.HandleConfigReloadAPI(p.fHTTPLogger, p.reloadConfig))
+	mux.HandleFunc(hls_settings.CHandleNetworkOnlinePath, handler.HandleNetworkOnlineAPI(pCtx, p.fHTTPLogger, p.getEndpointClients))
+	mux.HandleFunc(hls_settings.CHandleServicePubKeyPath, handler.HandleServicePubKeyAPI(p.fHTTPLogger, origNode))
+	mux.HandleFunc(hls_settings.CHandleServiceStreamPath, handler.HandleServiceStreamAPI(p.fHTTPLogger, pIdentity.fStreams, hls_settings.CStreamsPingPeriod))
+	mux.HandleFunc(hls_settings.CHandleServiceReplyPath, handler.HandleServiceReplyAPI(p.fHTTPLogger, pIdentity.fStreams))
+	mux.HandleFunc(hls_settings.CHandleServiceLimitsPath, handler.HandleServiceLimitsAPI(p.fHTTPLogger, pIdentity.fLimiter))
+	mux.HandleFunc(hls_settings.CHandleNetworkRequestPath, handler.HandleNetworkRequestAPI(pCtx, cfgW, p.fHTTPLogger, pIdentity.fNode, fetchTickets))
+	mux.HandleFunc(hls_settings.CHandleNetworkMulticastPath, handler.HandleNetworkMulticastAPI(pCtx, cfgW, p.fHTTPLogger, pIdentity.fNode))
+	mux.HandleFunc(hls_settings.CHandleNetworkTicketPath, handler.HandleNetworkTicketAPI(p.fHTTPLogger, fetchTickets))
+
+	return mux
+}
+
+func (p *sApp) initEndpointClients(pEndpoints []string) {
+	epClients := make([]client.IClient, 0, len(pEndpoints))
+	for _, ep := range pEndpoints {
+		requester := client.NewRequester(ep, &http.Client{Timeout: 5 * time.Second})
+		epClients = append(epClients, client.NewClient(requester))
+	}
+
+	p.fEPMutex.Lock()
+	defer p.fEPMutex.Unlock()
+
+	p.fEPClients = epClients
+}
+
+func (p *sApp) getEndpointClients() []client.IClient {
+	p.fEPMutex.RLock()
+	defer p.fEPMutex.RUnlock()
+
+	return p.fEPClients
+}
@@ -6,18 +6,17 @@ import (
 	"github.com/number571/go-peer/pkg/client"
 	"github.com/number571/go-peer/pkg/encoding"
 	"github.com/number571/hidden-lake/internal/service/pkg/app/config"
+	hls_settings "github.com/number571/hidden-lake/pkg/service/settings"
 )
 
-func GetConfigSettings(pCfg config.IConfig, pClient client.IClient) SConfigSettings {
+func GetConfigSettings(pCfg config.IConfig, pClient client.IClient) hls_settings.SConfigSettings {
 	sett := pCfg.GetSettings()
-	return SConfigSettings{
-		SConfigSettings: config.SConfigSettings{
-			FNetworkKey:       sett.GetNetworkKey(),
-			FMessageSizeBytes: sett.GetMessageSizeBytes(),
-			FWorkSizeBits:     sett.GetWorkSizeBits(),
-			FFetchTimeoutMS:   uint64(sett.GetFetchTimeout() / time.Millisecond),
-			FQueuePeriodMS:    uint64(sett.GetQueuePeriod() / time.Millisecond),
-		},
+	return hls_settings.SConfigSettings{
+		FNetworkKey:       sett.GetNetworkKey(),
+		FMessageSizeBytes: sett.GetMessageSizeBytes(),
+		FWorkSizeBits:     sett.GetWorkSizeBits(),
+		FFetchTimeoutMS:   uint64(sett.GetFetchTimeout() / time.Millisecond),
+		FQueuePeriodMS:    uint64(sett.GetQueuePeriod() / time.Millisecond),
 		// encoding.CSizeUint64 = payload64.Head()
 		FPayloadSizeBytes: pClient.GetPayloadLimit() - encoding.CSizeUint64,
 	}
@@ -1,14 +1,33 @@
 package config
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/number571/go-peer/pkg/client"
+	"github.com/number571/go-peer/pkg/crypto/asymmetric"
+	"github.com/number571/go-peer/pkg/encoding"
+	"github.com/number571/hidden-lake/internal/service/pkg/app/config"
+)
 
 func TestConfig(t *testing.T) {
-	v := uint64(10)
-	c := &SConfigSettings{
-		FPayloadSizeBytes: v,
+	t.Parallel()
+
+	cfg := &config.SConfig{
+		FSettings: &config.SConfigSettings{
+			FMessageSizeBytes: (8 << 10),
+			FFetchTimeoutMS:   60_000,
+			FQueuePeriodMS:    5_000,
+		},
+	}
+	cl := client.NewClient(asymmetric.NewPrivKey(), cfg.FSettings.FMessageSizeBytes)
+
+	sett := GetConfigSettings(cfg, cl)
+	if sett.GetPayloadSizeBytes() != cl.GetPayloadLimit()-encoding.CSizeUint64 {
+		t.Error("payload size bytes != payload limit")
+		return
 	}
-	if c.GetPayloadSizeBytes() != v {
-		t.Error("payload size bytes != v")
+	if sett.GetFetchTimeout() != cfg.FSettings.GetFetchTimeout() {
+		t.Error("fetch timeout != fetch timeout")
 		return
 	}
 }
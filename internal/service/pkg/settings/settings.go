@@ -1,11 +1,16 @@
 package settings
 
-import "github.com/number571/hidden-lake/internal/utils/name"
+import (
+	"time"
+
+	"github.com/number571/hidden-lake/internal/utils/name"
+	hls_settings "github.com/number571/hidden-lake/pkg/service/settings"
+)
 
 var GServiceName = name.LoadServiceName(CServiceFullName)
 
 const (
-	CServiceFullName    = "hidden-lake-service"
+	CServiceFullName    = hls_settings.CServiceFullName
 	CServiceDescription = "anonymizes traffic using the QB-problem"
 )
 
@@ -13,16 +18,57 @@ const (
 	CPathKey = "hls.key"
 	CPathYML = "hls.yml"
 	CPathDB  = "hls.db"
+
+	// directory of the additional identities
+	CPathIdentities = "identities"
+)
+
+const (
+	CHeaderPublicKey    = hls_settings.CHeaderPublicKey
+	CHeaderResponseMode = hls_settings.CHeaderResponseMode
+)
+
+const (
+	CHeaderIdentity  = hls_settings.CHeaderIdentity
+	CDefaultIdentity = hls_settings.CDefaultIdentity
+)
+
+const (
+	CHeaderResponseModeON  = hls_settings.CHeaderResponseModeON
+	CHeaderResponseModeOFF = hls_settings.CHeaderResponseModeOFF
+)
+
+const (
+	CTokenScopeRead    = hls_settings.CTokenScopeRead
+	CTokenScopeNetwork = hls_settings.CTokenScopeNetwork
+	CTokenScopeService = hls_settings.CTokenScopeService
+)
+
+const (
+	CTicketsTTL     = 5 * time.Minute
+	CTicketsLimit   = (1 << 10)
+	CTicketsMaxWait = time.Minute
+)
+
+const (
+	CIntroductionHostName = hls_settings.CIntroductionHostName
+	CIntroductionPath     = hls_settings.CIntroductionPath
+)
+
+const (
+	CIntroductionsTTL             = 7 * 24 * time.Hour
+	CIntroductionsLimit           = (1 << 6)
+	CIntroductionsIntroducerLimit = (1 << 3)
 )
 
 const (
-	CHeaderPublicKey    = "Hl-Service-Public-Key"
-	CHeaderResponseMode = "Hl-Service-Response-Mode"
+	CFriendsLastSeenPrecision = time.Minute
+	CFriendsExpiryPeriod      = 10 * time.Second
 )
 
 const (
-	CHeaderResponseModeON  = "on" // default
-	CHeaderResponseModeOFF = "off"
+	CStreamsReplyTimeout = time.Minute
+	CStreamsPingPeriod   = 15 * time.Second
 )
 
 const (
@@ -31,11 +77,24 @@ const (
 )
 
 const (
-	CHandleIndexPath          = "/api/index"
-	CHandleConfigSettingsPath = "/api/config/settings"
-	CHandleConfigConnectsPath = "/api/config/connects"
-	CHandleConfigFriendsPath  = "/api/config/friends"
-	CHandleNetworkOnlinePath  = "/api/network/online"
-	CHandleNetworkRequestPath = "/api/network/request"
-	CHandleServicePubKeyPath  = "/api/service/pubkey"
+	CHandleIndexPath               = hls_settings.CHandleIndexPath
+	CHandleConfigSettingsPath      = hls_settings.CHandleConfigSettingsPath
+	CHandleConfigConnectsPath      = hls_settings.CHandleConfigConnectsPath
+	CHandleConfigFriendsPath       = hls_settings.CHandleConfigFriendsPath
+	CHandleConfigAccessPath        = hls_settings.CHandleConfigAccessPath
+	CHandleConfigReloadPath        = hls_settings.CHandleConfigReloadPath
+	CHandleConfigServicesPath      = hls_settings.CHandleConfigServicesPath
+	CHandleConfigContactPath       = hls_settings.CHandleConfigContactPath
+	CHandleConfigContactNewPath    = hls_settings.CHandleConfigContactNewPath
+	CHandleConfigIntroductionsPath = hls_settings.CHandleConfigIntroductionsPath
+	CHandleConfigIdentitiesPath    = hls_settings.CHandleConfigIdentitiesPath
+	CHandleConfigGroupsPath        = hls_settings.CHandleConfigGroupsPath
+	CHandleNetworkOnlinePath       = hls_settings.CHandleNetworkOnlinePath
+	CHandleNetworkRequestPath      = hls_settings.CHandleNetworkRequestPath
+	CHandleNetworkMulticastPath    = hls_settings.CHandleNetworkMulticastPath
+	CHandleNetworkTicketPath       = hls_settings.CHandleNetworkTicketPath
+	CHandleServicePubKeyPath       = hls_settings.CHandleServicePubKeyPath
+	CHandleServiceStreamPath       = hls_settings.CHandleServiceStreamPath
+	CHandleServiceReplyPath        = hls_settings.CHandleServiceReplyPath
+	CHandleServiceLimitsPath       = hls_settings.CHandleServiceLimitsPath
 )
@@ -1,13 +1,31 @@
 package settings
 
-import "github.com/number571/hidden-lake/pkg/request"
+import hls_settings "github.com/number571/hidden-lake/pkg/service/settings"
 
-type SFriend struct {
-	FAliasName string `json:"alias_name"`
-	FPublicKey string `json:"public_key"`
-}
+type (
+	SFriend  = hls_settings.SFriend
+	SService = hls_settings.SService
+	SAccess  = hls_settings.SAccess
+	SRequest = hls_settings.SRequest
+	STicket  = hls_settings.STicket
+	SReload  = hls_settings.SReload
 
-type SRequest struct {
-	FReceiver string            `json:"receiver"` // alias_name
-	FReqData  *request.SRequest `json:"req_data"`
-}
+	SIdentity = hls_settings.SIdentity
+
+	SGroup           = hls_settings.SGroup
+	SMulticast       = hls_settings.SMulticast
+	SMulticastResult = hls_settings.SMulticastResult
+
+	SLimitCounter = hls_settings.SLimitCounter
+
+	SContact       = hls_settings.SContact
+	SContactExport = hls_settings.SContactExport
+	SContactNew    = hls_settings.SContactNew
+	SContactImport = hls_settings.SContactImport
+
+	SIntroduction = hls_settings.SIntroduction
+	SIntroduce    = hls_settings.SIntroduce
+
+	SIncoming = hls_settings.SIncoming
+	SReply    = hls_settings.SReply
+)
@@ -0,0 +1,141 @@
+package contact
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/number571/go-peer/pkg/crypto/asymmetric"
+	"github.com/number571/go-peer/pkg/encoding"
+	"github.com/number571/hidden-lake/build"
+	hls_settings "github.com/number571/hidden-lake/pkg/service/settings"
+)
+
+const (
+	// CURIPrefix is the prefix of contact URI, the rest of URI
+	// is the base64url (without padding) of JSON bundle.
+	CURIPrefix = "hl://contact/"
+
+	cConnectionScheme = "tcp"
+	cMaxAliasNameSize = 256
+)
+
+var (
+	// the signature is not valid for other signed messages
+	gSignaturePrefix = []byte("hidden-lake-contact:")
+)
+
+// NewContact creates the contact signed by the private key. If the list of
+// connections is nil, then the connections of known network are used.
+func NewContact(
+	pPrivKey asymmetric.IPrivKey,
+	pAliasName string,
+	pNetworkKey string,
+	pConnections []string,
+) (*hls_settings.SContact, error) {
+	if pConnections == nil {
+		pConnections = GetNetworkConnections(pNetworkKey)
+	}
+
+	contact := &hls_settings.SContact{
+		FPublicKey:   pPrivKey.GetPubKey().ToString(),
+		FAliasName:   strings.TrimSpace(pAliasName),
+		FNetworkKey:  pNetworkKey,
+		FConnections: pConnections,
+	}
+	if err := validateContact(contact); err != nil {
+		return nil, err
+	}
+
+	sign := pPrivKey.GetDSAPrivKey().SignBytes(getSignedBytes(contact))
+	contact.FSignature = encoding.HexEncode(sign)
+	return contact, nil
+}
+
+// LoadContact reads the contact from URI or JSON bundle and verifies its
+// fields and signature by the public key of the contact.
+func LoadContact(pData string) (*hls_settings.SContact, asymmetric.IPubKey, error) {
+	data := []byte(strings.TrimSpace(pData))
+	if uri, ok := bytes.CutPrefix(data, []byte(CURIPrefix)); ok {
+		decoded, err := base64.RawURLEncoding.DecodeString(string(uri))
+		if err != nil {
+			return nil, nil, errors.Join(ErrDecodeContact, err)
+		}
+		data = decoded
+	}
+
+	contact := new(hls_settings.SContact)
+	if err := encoding.DeserializeJSON(data, contact); err != nil {
+		return nil, nil, errors.Join(ErrDecodeContact, err)
+	}
+	if err := validateContact(contact); err != nil {
+		return nil, nil, err
+	}
+
+	pubKey := asymmetric.LoadPubKey(contact.FPublicKey)
+	sign := encoding.HexDecode(contact.FSignature)
+	if sign == nil || !pubKey.GetDSAPubKey().VerifyBytes(getSignedBytes(contact), sign) {
+		return nil, nil, ErrInvalidSignature
+	}
+	return contact, pubKey, nil
+}
+
+// ToURI converts the contact to the hl:// URI.
+func ToURI(pContact *hls_settings.SContact) string {
+	return CURIPrefix + base64.RawURLEncoding.EncodeToString(encoding.SerializeJSON(pContact))
+}
+
+// GetAliasName returns the alias name of the friend: the defined alias,
+// the suggested alias of contact or the hash of public key.
+func GetAliasName(pContact *hls_settings.SContact, pAliasName string) string {
+	if aliasName := strings.TrimSpace(pAliasName); aliasName != "" {
+		return aliasName
+	}
+	if aliasName := strings.TrimSpace(pContact.FAliasName); aliasName != "" {
+		return aliasName
+	}
+	return asymmetric.LoadPubKey(pContact.FPublicKey).GetHasher().ToString()
+}
+
+// GetNetworkConnections returns the bootstrap connections of the network
+// from build.GNetworks, if the network is known.
+func GetNetworkConnections(pNetworkKey string) []string {
+	network, ok := build.GNetworks[pNetworkKey]
+	if !ok || pNetworkKey == build.CDefaultNetwork {
+		return nil
+	}
+	result := make([]string, 0, len(network.FConnections))
+	for _, c := range network.FConnections {
+		if u, err := url.Parse(c); err == nil && u.Scheme == cConnectionScheme {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+func validateContact(pContact *hls_settings.SContact) error {
+	if asymmetric.LoadPubKey(pContact.FPublicKey) == nil {
+		return ErrInvalidPublicKey
+	}
+	if len(pContact.FAliasName) > cMaxAliasNameSize {
+		return ErrInvalidAliasName
+	}
+	for _, c := range pContact.FConnections {
+		u, err := url.Parse(c)
+		if err != nil || u.Scheme != cConnectionScheme || u.Host == "" {
+			return ErrInvalidConnection
+		}
+	}
+	return nil
+}
+
+func getSignedBytes(pContact *hls_settings.SContact) []byte {
+	contact := *pContact
+	contact.FSignature = ""
+	return bytes.Join(
+		[][]byte{gSignaturePrefix, encoding.SerializeJSON(contact)},
+		[]byte{},
+	)
+}
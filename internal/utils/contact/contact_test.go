@@ -0,0 +1,152 @@
+package contact
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/number571/go-peer/pkg/crypto/asymmetric"
+	"github.com/number571/go-peer/pkg/encoding"
+	"github.com/number571/hidden-lake/build"
+)
+
+func TestError(t *testing.T) {
+	t.Parallel()
+
+	str := "value"
+	err := &SContactError{str}
+	if err.Error() != errPrefix+str {
+		t.Error("incorrect err.Error()")
+		return
+	}
+}
+
+func TestContact(t *testing.T) {
+	t.Parallel()
+
+	privKey := asymmetric.NewPrivKey()
+	contact, err := NewContact(privKey, " Alice ", "network_key", []string{"tcp://127.0.0.1:9581"})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if contact.FAliasName != "Alice" {
+		t.Error("alias name is not trimmed")
+		return
+	}
+
+	uri := ToURI(contact)
+	if !strings.HasPrefix(uri, CURIPrefix) {
+		t.Error("invalid prefix of uri")
+		return
+	}
+
+	for _, data := range []string{uri, string(encoding.SerializeJSON(contact))} {
+		loaded, pubKey, err := LoadContact(data)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if pubKey.ToString() != privKey.GetPubKey().ToString() {
+			t.Error("diff public keys")
+			return
+		}
+		if loaded.FNetworkKey != "network_key" || len(loaded.FConnections) != 1 {
+			t.Error("diff fields of contact")
+			return
+		}
+	}
+
+	modified := *contact
+	modified.FAliasName = "Eve"
+	if _, _, err := LoadContact(ToURI(&modified)); !errors.Is(err, ErrInvalidSignature) {
+		t.Error("success load modified contact")
+		return
+	}
+
+	substituted := *contact
+	substituted.FPublicKey = asymmetric.NewPrivKey().GetPubKey().ToString()
+	if _, _, err := LoadContact(ToURI(&substituted)); !errors.Is(err, ErrInvalidSignature) {
+		t.Error("success load contact with substituted key")
+		return
+	}
+
+	if _, _, err := LoadContact(CURIPrefix + "!!!"); !errors.Is(err, ErrDecodeContact) {
+		t.Error("success load invalid uri")
+		return
+	}
+	if _, _, err := LoadContact("{123"); !errors.Is(err, ErrDecodeContact) {
+		t.Error("success load invalid json")
+		return
+	}
+	if _, _, err := LoadContact(`{"public_key":"abc"}`); !errors.Is(err, ErrInvalidPublicKey) {
+		t.Error("success load invalid public key")
+		return
+	}
+
+	if _, err := NewContact(privKey, "", "", []string{"http://127.0.0.1:9581"}); !errors.Is(err, ErrInvalidConnection) {
+		t.Error("success create contact with invalid scheme")
+		return
+	}
+	if _, err := NewContact(privKey, strings.Repeat("a", cMaxAliasNameSize+1), "", nil); !errors.Is(err, ErrInvalidAliasName) {
+		t.Error("success create contact with long alias name")
+		return
+	}
+}
+
+func TestNetworkConnections(t *testing.T) {
+	t.Parallel()
+
+	if GetNetworkConnections(build.CDefaultNetwork) != nil {
+		t.Error("success get connections of default network")
+		return
+	}
+	if GetNetworkConnections("undefined_network_key") != nil {
+		t.Error("success get connections of undefined network")
+		return
+	}
+
+	for networkKey, network := range build.GNetworks {
+		if networkKey == build.CDefaultNetwork {
+			continue
+		}
+		conns := GetNetworkConnections(networkKey)
+		if len(conns) > len(network.FConnections) {
+			t.Error("invalid length of connections")
+			return
+		}
+		contact, err := NewContact(asymmetric.NewPrivKey(), "", networkKey, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(contact.FConnections) != len(conns) {
+			t.Error("connections of network are not used")
+			return
+		}
+	}
+}
+
+func TestAliasName(t *testing.T) {
+	t.Parallel()
+
+	privKey := asymmetric.NewPrivKey()
+	contact, err := NewContact(privKey, "Alice", "", nil)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if GetAliasName(contact, " Bob ") != "Bob" {
+		t.Error("defined alias name is not used")
+		return
+	}
+	if GetAliasName(contact, "") != "Alice" {
+		t.Error("suggested alias name is not used")
+		return
+	}
+	contact.FAliasName = ""
+	if GetAliasName(contact, "") != privKey.GetPubKey().GetHasher().ToString() {
+		t.Error("hash of public key is not used")
+		return
+	}
+}
@@ -0,0 +1,21 @@
+package contact
+
+const (
+	errPrefix = "internal/utils/contact = "
+)
+
+type SContactError struct {
+	str string
+}
+
+func (err *SContactError) Error() string {
+	return errPrefix + err.str
+}
+
+var (
+	ErrDecodeContact     = &SContactError{"decode contact"}
+	ErrInvalidPublicKey  = &SContactError{"invalid public key"}
+	ErrInvalidConnection = &SContactError{"invalid connection"}
+	ErrInvalidSignature  = &SContactError{"invalid signature"}
+	ErrInvalidAliasName  = &SContactError{"invalid alias name"}
+)
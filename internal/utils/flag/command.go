@@ -0,0 +1,61 @@
+package flag
+
+var (
+	_ ICommandBuilder = &sCommand{}
+	_ ICommand        = &sCommand{}
+)
+
+type sCommand struct {
+	fNames       []string
+	fDescription string
+	fFlags       []IFlagBuilder
+	fBuilt       IFlags
+}
+
+// NewCommandBuilder creates the subcommand with the list of names
+// (ex. "key", "export"), the names are followed by the flags.
+func NewCommandBuilder(pNames ...string) ICommandBuilder {
+	if len(pNames) == 0 {
+		panic("command names are empty")
+	}
+	return &sCommand{fNames: pNames}
+}
+
+func (p *sCommand) GetNames() []string {
+	return p.fNames
+}
+
+func (p *sCommand) GetDescription() string {
+	return p.fDescription
+}
+
+func (p *sCommand) GetFlags() IFlags {
+	return p.fBuilt
+}
+
+func (p *sCommand) WithDescription(pDescription string) ICommandBuilder {
+	p.fDescription = pDescription
+	return p
+}
+
+func (p *sCommand) WithFlags(pFlags ...IFlagBuilder) ICommandBuilder {
+	p.fFlags = pFlags
+	return p
+}
+
+func (p *sCommand) Build() ICommand {
+	p.fBuilt = NewFlagsBuilder(p.fFlags...).Build()
+	return p
+}
+
+func (p *sCommand) match(pArgs []string) ([]string, bool) {
+	if len(pArgs) < len(p.fNames) {
+		return nil, false
+	}
+	for i, n := range p.fNames {
+		if pArgs[i] != n {
+			return nil, false
+		}
+	}
+	return pArgs[len(p.fNames):], true
+}
@@ -0,0 +1,52 @@
+package flag
+
+import "strings"
+
+var (
+	_ ICommandsBuilder = &sCommandsBuilder{}
+	_ ICommands        = &sCommands{}
+)
+
+type sCommands []ICommand
+type sCommandsBuilder []ICommandBuilder
+
+func NewCommandsBuilder(pArgs ...ICommandBuilder) ICommandsBuilder {
+	v := sCommandsBuilder(pArgs)
+	return &v
+}
+
+func (p *sCommandsBuilder) Build() ICommands {
+	commands := make([]ICommand, 0, len(*p))
+	for _, v := range *p {
+		commands = append(commands, v.Build())
+	}
+	return NewCommands(commands...)
+}
+
+func NewCommands(pCommands ...ICommand) ICommands {
+	mapNames := make(map[string]struct{}, len(pCommands))
+	for _, v := range pCommands {
+		name := strings.Join(v.GetNames(), " ")
+		if _, ok := mapNames[name]; ok {
+			panic("command_name duplicated")
+		}
+		mapNames[name] = struct{}{}
+	}
+	v := sCommands(pCommands)
+	return &v
+}
+
+// Match returns the command by the first arguments and the rest of
+// arguments (flags of the command).
+func (p *sCommands) Match(pArgs []string) (ICommand, []string, bool) {
+	for _, v := range *p {
+		if args, ok := v.(*sCommand).match(pArgs); ok {
+			return v, args, true
+		}
+	}
+	return nil, nil, false
+}
+
+func (p *sCommands) List() []ICommand {
+	return *p
+}
@@ -0,0 +1,93 @@
+package flag
+
+import (
+	"testing"
+)
+
+var (
+	tgCommands = NewCommandsBuilder(
+		NewCommandBuilder("key", "show").
+			WithDescription("print public key").
+			WithFlags(
+				NewFlagBuilder("-p", "--path").
+					WithDescription("set path to config, database files").
+					WithDefinedValue("."),
+			),
+		NewCommandBuilder("key", "export").
+			WithDescription("export private key").
+			WithFlags(
+				NewFlagBuilder("-o", "--output").
+					WithDescription("set path to backup file").
+					WithDefinedValue(""),
+			),
+	).Build()
+)
+
+func TestPanicCommandsBuilder(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("nothing panics")
+			return
+		}
+	}()
+
+	_ = NewCommandsBuilder(
+		NewCommandBuilder("key", "show"),
+		NewCommandBuilder("key", "show"),
+	).Build()
+}
+
+func TestPanicCommandBuilder(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("nothing panics")
+			return
+		}
+	}()
+
+	_ = NewCommandBuilder()
+}
+
+func TestCommandsMatch(t *testing.T) {
+	t.Parallel()
+
+	if len(tgCommands.List()) != 2 {
+		t.Error("invalid length of commands")
+		return
+	}
+
+	cmd, args, ok := tgCommands.Match([]string{"key", "export", "-o", "backup.hlk"})
+	if !ok {
+		t.Error("command not found")
+		return
+	}
+	if cmd.GetDescription() != "export private key" {
+		t.Error("invalid command")
+		return
+	}
+	if !cmd.GetFlags().Validate(args) {
+		t.Error("invalid args of command")
+		return
+	}
+	if cmd.GetFlags().Get("-o").GetStringValue(args) != "backup.hlk" {
+		t.Error("invalid value of flag")
+		return
+	}
+
+	if _, _, ok := tgCommands.Match([]string{"key"}); ok {
+		t.Error("success match incomplete command")
+		return
+	}
+	if _, _, ok := tgCommands.Match([]string{"--path", "key", "show"}); ok {
+		t.Error("success match command after flags")
+		return
+	}
+	if cmd, args, ok := tgCommands.Match([]string{"key", "show"}); !ok || len(args) != 0 || cmd.GetFlags().Validate([]string{"-o", "x"}) {
+		t.Error("invalid command without flags")
+		return
+	}
+}
@@ -22,3 +22,24 @@ type IFlag interface {
 	GetBoolValue([]string) bool
 	GetStringValue([]string) string
 }
+
+type ICommandsBuilder interface {
+	Build() ICommands
+}
+
+type ICommands interface {
+	Match([]string) (ICommand, []string, bool)
+	List() []ICommand
+}
+
+type ICommandBuilder interface {
+	Build() ICommand
+	WithDescription(string) ICommandBuilder
+	WithFlags(...IFlagBuilder) ICommandBuilder
+}
+
+type ICommand interface {
+	GetNames() []string
+	GetDescription() string
+	GetFlags() IFlags
+}
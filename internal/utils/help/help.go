@@ -29,3 +29,25 @@ func Println(pServiceName name.IServiceName, pDescription string, pArgs flag.IFl
 		strings.TrimSpace(args.String()),
 	)
 }
+
+func PrintlnCommands(pCommands flag.ICommands) {
+	cmds := strings.Builder{}
+	cmds.Grow(1 << 10)
+
+	for _, cmd := range pCommands.List() {
+		cmds.WriteString(fmt.Sprintf(
+			"[ %s ] = %s\n",
+			strings.Join(cmd.GetNames(), " "),
+			cmd.GetDescription(),
+		))
+		for _, arg := range cmd.GetFlags().List() {
+			cmds.WriteString(fmt.Sprintf(
+				"\t[ %s ] = %s\n",
+				strings.Join(arg.GetAliases(), ", "),
+				arg.GetDescription(),
+			))
+		}
+	}
+
+	fmt.Printf("Commands:\n%s\n", strings.TrimSpace(cmds.String()))
+}
@@ -35,3 +35,24 @@ func ExamplePrintln() {
 	// [ -n, --network ] = set network key for connections
 	// [ -t, --threads ] = set num of parallel functions to calculate PoW
 }
+
+func ExamplePrintlnCommands() {
+	PrintlnCommands(
+		flag.NewCommandsBuilder(
+			flag.NewCommandBuilder("key", "show").
+				WithDescription("print public key").
+				WithFlags(
+					flag.NewFlagBuilder("-p", "--path").
+						WithDescription("set path to config, database files").
+						WithDefinedValue("."),
+				),
+			flag.NewCommandBuilder("key", "fingerprint").
+				WithDescription("print fingerprint of public key"),
+		).Build(),
+	)
+	// Output:
+	// Commands:
+	// [ key show ] = print public key
+	// 	[ -p, --path ] = set path to config, database files
+	// [ key fingerprint ] = print fingerprint of public key
+}
@@ -25,11 +25,21 @@ var gLogMap = map[anon_logger.ILogType]string{
 	CLogBaseSendNetworkMessage:      "SNMSG",
 	CLogInfoResponseFromService:     "RSPSR",
 	CLogInfoRecvNetworkMessage:      "RNMSG",
+	CLogInfoIntroduction:            "INTRD",
 	CLogWarnRequestToService:        "RQTSR",
 	CLogWarnUndefinedService:        "UNDSR",
+	CLogWarnAccessDenied:            "ACSDN",
 	CLogWarnInvalidRequestMethod:    "IRMTH",
 	CLogWarnFailedReadFullBytes:     "RFBTS",
 	CLogWarnNoConnections:           "NOCON",
+	CLogWarnLimitExceeded:           "LMTEX",
+	CLogWarnChaosDrop:               "CHDRP",
+	CLogWarnChaosDelay:              "CHDLY",
+	CLogWarnChaosDuplicate:          "CHDUP",
+	CLogWarnChaosReorder:            "CHRDR",
+	CLogWarnChaosCorrupt:            "CHCRP",
+	CLogWarnDatagramsLost:           "DGLST",
+	CLogWarnMultiplexDrop:           "MXDRP",
 	CLogErroLoadRequestType:         "LDRQT",
 	CLogErroProxyRequestType:        "PXRQT",
 }
@@ -42,13 +52,23 @@ const (
 	// INFO
 	CLogInfoResponseFromService
 	CLogInfoRecvNetworkMessage
+	CLogInfoIntroduction
 
 	// WARN
 	CLogWarnRequestToService
 	CLogWarnUndefinedService
+	CLogWarnAccessDenied
 	CLogWarnInvalidRequestMethod
 	CLogWarnFailedReadFullBytes
 	CLogWarnNoConnections
+	CLogWarnLimitExceeded
+	CLogWarnChaosDrop
+	CLogWarnChaosDelay
+	CLogWarnChaosDuplicate
+	CLogWarnChaosReorder
+	CLogWarnChaosCorrupt
+	CLogWarnDatagramsLost
+	CLogWarnMultiplexDrop
 
 	// ERRO
 	CLogErroLoadRequestType
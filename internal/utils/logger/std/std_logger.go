@@ -6,23 +6,48 @@ import (
 	"github.com/number571/go-peer/pkg/logger"
 )
 
+var (
+	_ logger.ILogger = &sStdLogger{}
+)
+
+type sStdLogger struct {
+	fLogging ILogging
+	fLogger  logger.ILogger
+}
+
+// The logging levels are checked on each push,
+// so the ILogging value can be changed at runtime.
 func NewStdLogger(pLogging ILogging, pLogFunc logger.ILogFunc) logger.ILogger {
-	return logger.NewLogger(
-		stdSettings(pLogging),
-		pLogFunc,
-	)
+	return &sStdLogger{
+		fLogging: pLogging,
+		fLogger: logger.NewLogger(
+			logger.NewSettings(&logger.SSettings{
+				FInfo: os.Stdout,
+				FWarn: os.Stdout,
+				FErro: os.Stderr,
+			}),
+			pLogFunc,
+		),
+	}
 }
 
-func stdSettings(pLogging ILogging) logger.ISettings {
-	sett := &logger.SSettings{}
-	if pLogging.HasInfo() {
-		sett.FInfo = os.Stdout
+func (p *sStdLogger) PushInfo(pMsg logger.ILogArg) {
+	if !p.fLogging.HasInfo() {
+		return
 	}
-	if pLogging.HasWarn() {
-		sett.FWarn = os.Stdout
+	p.fLogger.PushInfo(pMsg)
+}
+
+func (p *sStdLogger) PushWarn(pMsg logger.ILogArg) {
+	if !p.fLogging.HasWarn() {
+		return
 	}
-	if pLogging.HasErro() {
-		sett.FErro = os.Stderr
+	p.fLogger.PushWarn(pMsg)
+}
+
+func (p *sStdLogger) PushErro(pMsg logger.ILogArg) {
+	if !p.fLogging.HasErro() {
+		return
 	}
-	return logger.NewSettings(sett)
+	p.fLogger.PushErro(pMsg)
 }
@@ -0,0 +1,10 @@
+//go:build darwin || dragonfly || freebsd || netbsd || openbsd
+
+package passphrase
+
+import "golang.org/x/sys/unix"
+
+const (
+	cIoctlReadTermios  = unix.TIOCGETA
+	cIoctlWriteTermios = unix.TIOCSETA
+)
@@ -0,0 +1,8 @@
+package passphrase
+
+import "golang.org/x/sys/unix"
+
+const (
+	cIoctlReadTermios  = unix.TCGETS
+	cIoctlWriteTermios = unix.TCSETS
+)
@@ -0,0 +1,7 @@
+//go:build !linux && !darwin && !dragonfly && !freebsd && !netbsd && !openbsd
+
+package passphrase
+
+func disableEcho(_ int) (func(), error) {
+	return nil, ErrTerminalUnsupported
+}
@@ -0,0 +1,26 @@
+//go:build linux || darwin || dragonfly || freebsd || netbsd || openbsd
+
+package passphrase
+
+import (
+	"errors"
+
+	"golang.org/x/sys/unix"
+)
+
+func disableEcho(pFD int) (func(), error) {
+	termios, err := unix.IoctlGetTermios(pFD, cIoctlReadTermios)
+	if err != nil {
+		return nil, errors.Join(ErrTerminalUnsupported, err)
+	}
+
+	oldState := *termios
+	termios.Lflag &^= unix.ECHO
+	termios.Lflag |= unix.ICANON | unix.ISIG
+	termios.Iflag |= unix.ICRNL
+	if err := unix.IoctlSetTermios(pFD, cIoctlWriteTermios, termios); err != nil {
+		return nil, errors.Join(ErrTerminalUnsupported, err)
+	}
+
+	return func() { _ = unix.IoctlSetTermios(pFD, cIoctlWriteTermios, &oldState) }, nil
+}
@@ -0,0 +1,22 @@
+package passphrase
+
+const (
+	errPrefix = "internal/utils/passphrase = "
+)
+
+type SPassphraseError struct {
+	str string
+}
+
+func (err *SPassphraseError) Error() string {
+	return errPrefix + err.str
+}
+
+var (
+	ErrInvalidFD           = &SPassphraseError{"invalid file descriptor"}
+	ErrReadFD              = &SPassphraseError{"read file descriptor"}
+	ErrOpenTerminal        = &SPassphraseError{"open terminal"}
+	ErrReadTerminal        = &SPassphraseError{"read terminal"}
+	ErrTerminalUnsupported = &SPassphraseError{"terminal is not supported"}
+	ErrPassphraseMismatch  = &SPassphraseError{"passphrases do not match"}
+)
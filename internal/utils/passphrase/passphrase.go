@@ -0,0 +1,101 @@
+package passphrase
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+)
+
+const (
+	// CEnvPassphrase is the environment variable with the passphrase of
+	// the private key. CEnvNewPassphrase is used only to change it.
+	CEnvPassphrase       = "HIDDEN_LAKE_PASSPHRASE"
+	CEnvNewPassphrase    = "HIDDEN_LAKE_NEW_PASSPHRASE"
+	CEnvBackupPassphrase = "HIDDEN_LAKE_BACKUP_PASSPHRASE"
+)
+
+const (
+	cTerminalPath = "/dev/tty"
+)
+
+// FromFD reads the first line of the file descriptor.
+func FromFD(pFD string) ([]byte, error) {
+	fd, err := strconv.ParseUint(pFD, 10, 32)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidFD, err)
+	}
+	file := os.NewFile(uintptr(fd), "passphrase")
+	if file == nil {
+		return nil, ErrInvalidFD
+	}
+	defer file.Close()
+
+	passphrase, err := readLine(file)
+	if err != nil {
+		return nil, errors.Join(ErrReadFD, err)
+	}
+	return passphrase, nil
+}
+
+// FromEnv returns nil if the environment variable is not defined.
+func FromEnv(pName string) []byte {
+	passphrase, ok := os.LookupEnv(pName)
+	if !ok {
+		return nil
+	}
+	return []byte(passphrase)
+}
+
+// FromTerminal prints the prompt and reads the passphrase from
+// the controlling terminal with disabled echo.
+func FromTerminal(pPrompt string) ([]byte, error) {
+	tty, err := os.OpenFile(cTerminalPath, os.O_RDWR, 0)
+	if err != nil {
+		return nil, errors.Join(ErrOpenTerminal, err)
+	}
+	defer tty.Close()
+
+	restore, err := disableEcho(int(tty.Fd())) // nolint: gosec
+	if err != nil {
+		return nil, err
+	}
+	defer restore()
+
+	if _, err := fmt.Fprint(tty, pPrompt); err != nil {
+		return nil, errors.Join(ErrReadTerminal, err)
+	}
+	passphrase, err := readLine(tty)
+	_, _ = fmt.Fprintln(tty)
+	if err != nil {
+		return nil, errors.Join(ErrReadTerminal, err)
+	}
+	return passphrase, nil
+}
+
+// NewFromTerminal reads the new passphrase twice to confirm it.
+func NewFromTerminal(pPrompt, pConfirmPrompt string) ([]byte, error) {
+	passphrase, err := FromTerminal(pPrompt)
+	if err != nil {
+		return nil, err
+	}
+	confirm, err := FromTerminal(pConfirmPrompt)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(passphrase, confirm) {
+		return nil, ErrPassphraseMismatch
+	}
+	return passphrase, nil
+}
+
+func readLine(pReader io.Reader) ([]byte, error) {
+	line, err := bufio.NewReader(pReader).ReadBytes('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	return bytes.TrimRight(line, "\r\n"), nil
+}
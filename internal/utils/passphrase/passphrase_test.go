@@ -0,0 +1,40 @@
+package passphrase
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestError(t *testing.T) {
+	t.Parallel()
+
+	str := "value"
+	err := &SPassphraseError{str}
+	if err.Error() != errPrefix+str {
+		t.Error("incorrect err.Error()")
+		return
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Parallel()
+
+	if FromEnv("HIDDEN_LAKE_UNDEFINED_PASSPHRASE_571") != nil {
+		t.Error("success get undefined passphrase")
+		return
+	}
+}
+
+func TestReadLine(t *testing.T) {
+	t.Parallel()
+
+	line, err := readLine(strings.NewReader("passphrase"))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if string(line) != "passphrase" {
+		t.Error("invalid line without newline")
+		return
+	}
+}
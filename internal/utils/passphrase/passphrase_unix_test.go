@@ -0,0 +1,52 @@
+//go:build linux || darwin || dragonfly || freebsd || netbsd || openbsd
+
+package passphrase
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strconv"
+	"testing"
+
+	"golang.org/x/sys/unix"
+)
+
+func TestFromFD(t *testing.T) {
+	t.Parallel()
+
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer reader.Close()
+
+	if _, err := writer.WriteString("passphrase\r\nother line\n"); err != nil {
+		t.Error(err)
+		return
+	}
+	writer.Close()
+
+	// the file descriptor is closed by FromFD
+	dupFD, err := unix.Dup(int(reader.Fd())) // nolint: gosec
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	passphrase, err := FromFD(strconv.Itoa(dupFD))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !bytes.Equal(passphrase, []byte("passphrase")) {
+		t.Error("invalid passphrase")
+		return
+	}
+
+	if _, err := FromFD("abc"); !errors.Is(err, ErrInvalidFD) {
+		t.Error("success read invalid fd")
+		return
+	}
+}
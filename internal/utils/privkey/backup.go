@@ -0,0 +1,86 @@
+package privkey
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"os"
+
+	"github.com/number571/go-peer/pkg/crypto/asymmetric"
+	"github.com/number571/go-peer/pkg/encoding"
+)
+
+const (
+	cBackupVersion = 1
+)
+
+// SBackup is the content of backup bundle. Friends are stored
+// as the map of alias names to the public keys.
+type SBackup struct {
+	FPrivKey string            `json:"priv_key"`
+	FFriends map[string]string `json:"friends,omitempty"`
+}
+
+type sBackupFile struct {
+	FVersion  uint64          `json:"version"`
+	FChecksum string          `json:"checksum"`
+	FData     json.RawMessage `json:"data"`
+}
+
+// WriteBackup encrypts the backup by the passphrase and writes it to the file.
+// The checksum (SHA-256) of encrypted data allows to detect a damaged file
+// before the passphrase is checked.
+func WriteBackup(pPath string, pBackup *SBackup, pPassphrase []byte) error {
+	encData, err := encryptData(encoding.SerializeJSON(pBackup), pPassphrase)
+	if err != nil {
+		return errors.Join(ErrWriteBackup, err)
+	}
+	checksum := sha256.Sum256(encData)
+	backupFile := encoding.SerializeJSON(sBackupFile{
+		FVersion:  cBackupVersion,
+		FChecksum: encoding.HexEncode(checksum[:]),
+		FData:     encData,
+	})
+	if err := writeFile(pPath, backupFile); err != nil {
+		return errors.Join(ErrWriteBackup, err)
+	}
+	return nil
+}
+
+// ReadBackup reads and decrypts the backup written by WriteBackup.
+func ReadBackup(pPath string, pPassphrase []byte) (*SBackup, asymmetric.IPrivKey, error) {
+	backupBytes, err := os.ReadFile(pPath)
+	if err != nil {
+		return nil, nil, errors.Join(ErrReadBackup, err)
+	}
+
+	backupFile := new(sBackupFile)
+	if err := encoding.DeserializeJSON(backupBytes, backupFile); err != nil {
+		return nil, nil, errors.Join(ErrDecodeBackup, err)
+	}
+	if backupFile.FVersion != cBackupVersion {
+		return nil, nil, ErrDecodeBackup
+	}
+
+	checksum := sha256.Sum256(backupFile.FData)
+	if subtle.ConstantTimeCompare(checksum[:], encoding.HexDecode(backupFile.FChecksum)) != 1 {
+		return nil, nil, ErrInvalidChecksum
+	}
+
+	data, err := decryptData(backupFile.FData, pPassphrase)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	backup := new(SBackup)
+	if err := encoding.DeserializeJSON(data, backup); err != nil {
+		return nil, nil, errors.Join(ErrDecodeBackup, err)
+	}
+
+	privKey := asymmetric.LoadPrivKey(backup.FPrivKey)
+	if privKey == nil {
+		return nil, nil, ErrInvalidPrivateKey
+	}
+	return backup, privKey, nil
+}
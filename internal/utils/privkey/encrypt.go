@@ -0,0 +1,153 @@
+package privkey
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"errors"
+
+	"github.com/number571/go-peer/pkg/crypto/asymmetric"
+	"github.com/number571/go-peer/pkg/crypto/random"
+	"github.com/number571/go-peer/pkg/encoding"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+const (
+	cEncryptedVersion = 1
+	cKDFName          = "argon2id"
+
+	// RFC 9106: second recommended option (64 MiB of memory).
+	cKDFTime     = 3
+	cKDFMemory   = 64 * 1024
+	cKDFThreads  = 4
+	cKDFSaltSize = 32
+
+	// limits of the loaded parameters (1 GiB of memory)
+	cKDFMaxTime   = 64
+	cKDFMaxMemory = 1024 * 1024
+)
+
+type sEncrypted struct {
+	sEncryptedHead
+	FNonce      string `json:"nonce"`
+	FCiphertext string `json:"ciphertext"`
+}
+
+type sEncryptedHead struct {
+	FVersion uint64 `json:"version"`
+	FKDF     sKDF   `json:"kdf"`
+}
+
+type sKDF struct {
+	FName    string `json:"name"`
+	FTime    uint32 `json:"time"`
+	FMemory  uint32 `json:"memory"`
+	FThreads uint8  `json:"threads"`
+	FSalt    string `json:"salt"`
+}
+
+// IsEncrypted checks that the content of key file is an encrypted private key.
+func IsEncrypted(pData []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(pData), []byte("{"))
+}
+
+// EncryptPrivKey encrypts the private key by the passphrase. The encryption
+// key is derived by Argon2id, the private key is sealed by XChaCha20-Poly1305.
+func EncryptPrivKey(pPrivKey asymmetric.IPrivKey, pPassphrase []byte) ([]byte, error) {
+	return encryptData([]byte(pPrivKey.ToString()), pPassphrase)
+}
+
+// DecryptPrivKey decrypts the private key encrypted by EncryptPrivKey.
+func DecryptPrivKey(pData []byte, pPassphrase []byte) (asymmetric.IPrivKey, error) {
+	plaintext, err := decryptData(pData, pPassphrase)
+	if err != nil {
+		return nil, err
+	}
+	privKey := asymmetric.LoadPrivKey(string(plaintext))
+	if privKey == nil {
+		return nil, ErrInvalidPrivateKey
+	}
+	return privKey, nil
+}
+
+func encryptData(pData []byte, pPassphrase []byte) ([]byte, error) {
+	if len(pPassphrase) == 0 {
+		return nil, ErrEmptyPassphrase
+	}
+
+	prng := random.NewRandom()
+	salt := prng.GetBytes(cKDFSaltSize)
+	nonce := prng.GetBytes(chacha20poly1305.NonceSizeX)
+
+	head := sEncryptedHead{
+		FVersion: cEncryptedVersion,
+		FKDF: sKDF{
+			FName:    cKDFName,
+			FTime:    cKDFTime,
+			FMemory:  cKDFMemory,
+			FThreads: cKDFThreads,
+			FSalt:    encoding.HexEncode(salt),
+		},
+	}
+
+	aead, err := newAEAD(&head.FKDF, salt, pPassphrase)
+	if err != nil {
+		return nil, errors.Join(ErrEncryptPrivateKey, err)
+	}
+
+	ciphertext := aead.Seal(nil, nonce, pData, encoding.SerializeJSON(head))
+	return encoding.SerializeJSON(sEncrypted{
+		sEncryptedHead: head,
+		FNonce:         encoding.HexEncode(nonce),
+		FCiphertext:    encoding.HexEncode(ciphertext),
+	}), nil
+}
+
+func decryptData(pData []byte, pPassphrase []byte) ([]byte, error) {
+	if len(pPassphrase) == 0 {
+		return nil, ErrEmptyPassphrase
+	}
+
+	encData := new(sEncrypted)
+	if err := encoding.DeserializeJSON(pData, encData); err != nil {
+		return nil, errors.Join(ErrDecodeEncryptedKey, err)
+	}
+
+	kdf := &encData.FKDF
+	if encData.FVersion != cEncryptedVersion || kdf.FName != cKDFName {
+		return nil, ErrDecodeEncryptedKey
+	}
+	if kdf.FTime == 0 || kdf.FTime > cKDFMaxTime || kdf.FMemory == 0 || kdf.FMemory > cKDFMaxMemory || kdf.FThreads == 0 {
+		return nil, ErrDecodeEncryptedKey
+	}
+
+	salt := encoding.HexDecode(kdf.FSalt)
+	nonce := encoding.HexDecode(encData.FNonce)
+	ciphertext := encoding.HexDecode(encData.FCiphertext)
+	if len(salt) == 0 || len(nonce) != chacha20poly1305.NonceSizeX || ciphertext == nil {
+		return nil, ErrDecodeEncryptedKey
+	}
+
+	aead, err := newAEAD(kdf, salt, pPassphrase)
+	if err != nil {
+		return nil, errors.Join(ErrDecodeEncryptedKey, err)
+	}
+
+	plaintext, err := aead.Open(nil, nonce, ciphertext, encoding.SerializeJSON(encData.sEncryptedHead))
+	if err != nil {
+		return nil, ErrInvalidPassphrase
+	}
+	return plaintext, nil
+}
+
+func newAEAD(pKDF *sKDF, pSalt, pPassphrase []byte) (cipher.AEAD, error) {
+	key := argon2.IDKey(
+		pPassphrase,
+		pSalt,
+		pKDF.FTime,
+		pKDF.FMemory,
+		pKDF.FThreads,
+		chacha20poly1305.KeySize,
+	)
+	return chacha20poly1305.NewX(key)
+}
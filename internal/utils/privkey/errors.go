@@ -13,8 +13,19 @@ func (err *SAppError) Error() string {
 }
 
 var (
-	ErrInvalidPrivateKey = &SAppError{"invalid private key"}
-	ErrReadPrivateKey    = &SAppError{"read private key"}
-	ErrWritePrivateKey   = &SAppError{"write private key"}
-	ErrSizePrivateKey    = &SAppError{"size private key"}
+	ErrInvalidPrivateKey  = &SAppError{"invalid private key"}
+	ErrReadPrivateKey     = &SAppError{"read private key"}
+	ErrWritePrivateKey    = &SAppError{"write private key"}
+	ErrSizePrivateKey     = &SAppError{"size private key"}
+	ErrEncryptPrivateKey  = &SAppError{"encrypt private key"}
+	ErrDecodeEncryptedKey = &SAppError{"decode encrypted private key"}
+	ErrPassphraseRequired = &SAppError{"passphrase of private key is required"}
+	ErrInvalidPassphrase  = &SAppError{"invalid passphrase of private key"}
+	ErrEmptyPassphrase    = &SAppError{"empty passphrase"}
+	ErrGetPassphrase      = &SAppError{"get passphrase"}
+	ErrPrivateKeyNotFound = &SAppError{"private key not found"}
+	ErrReadBackup         = &SAppError{"read backup"}
+	ErrWriteBackup        = &SAppError{"write backup"}
+	ErrDecodeBackup       = &SAppError{"decode backup"}
+	ErrInvalidChecksum    = &SAppError{"invalid checksum of backup"}
 )
@@ -3,25 +3,115 @@ package privkey
 import (
 	"errors"
 	"os"
+	"path/filepath"
 
 	"github.com/number571/go-peer/pkg/crypto/asymmetric"
 )
 
-func GetPrivKey(pKeyPath string) (asymmetric.IPrivKey, error) {
+// GetPrivKey reads the private key from the file or generates a new one if
+// the file does not exist. The encrypted key is decrypted by the passphrase,
+// the new key is encrypted only if the passphrase is defined without prompt.
+func GetPrivKey(pKeyPath string, pPassphraseF IPassphraseF) (asymmetric.IPrivKey, error) {
 	if _, err := os.Stat(pKeyPath); os.IsNotExist(err) {
 		privKey := asymmetric.NewPrivKey()
-		if err := os.WriteFile(pKeyPath, []byte(privKey.ToString()), 0o600); err != nil {
-			return nil, errors.Join(ErrWritePrivateKey, err)
+		passphrase, err := getPassphrase(pPassphraseF, false)
+		if err != nil {
+			return nil, err
+		}
+		if err := WritePrivKey(pKeyPath, privKey, passphrase); err != nil {
+			return nil, err
 		}
 		return privKey, nil
 	}
-	privKeyStr, err := os.ReadFile(pKeyPath)
+	return ReadPrivKey(pKeyPath, pPassphraseF)
+}
+
+// ChangePassphrase sets the new passphrase of the private key. The plaintext
+// private key is encrypted, so it is also the way to migrate old key files.
+func ChangePassphrase(pKeyPath string, pPassphraseF IPassphraseF, pNewPassphrase []byte) error {
+	if len(pNewPassphrase) == 0 {
+		return ErrEmptyPassphrase
+	}
+	privKey, err := ReadPrivKey(pKeyPath, pPassphraseF)
 	if err != nil {
+		return err
+	}
+	return WritePrivKey(pKeyPath, privKey, pNewPassphrase)
+}
+
+// ReadPrivKey reads the existing private key without generating a new one.
+func ReadPrivKey(pKeyPath string, pPassphraseF IPassphraseF) (asymmetric.IPrivKey, error) {
+	privKeyBytes, err := os.ReadFile(pKeyPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.Join(ErrPrivateKeyNotFound, err)
+		}
 		return nil, errors.Join(ErrReadPrivateKey, err)
 	}
-	privKey := asymmetric.LoadPrivKey(string(privKeyStr))
-	if privKey == nil {
-		return nil, ErrInvalidPrivateKey
+
+	if !IsEncrypted(privKeyBytes) {
+		privKey := asymmetric.LoadPrivKey(string(privKeyBytes))
+		if privKey == nil {
+			return nil, ErrInvalidPrivateKey
+		}
+		return privKey, nil
+	}
+
+	passphrase, err := getPassphrase(pPassphraseF, true)
+	if err != nil {
+		return nil, err
+	}
+	if len(passphrase) == 0 {
+		return nil, ErrPassphraseRequired
+	}
+
+	return DecryptPrivKey(privKeyBytes, passphrase)
+}
+
+// WritePrivKey replaces the key file. The private key is encrypted
+// if the passphrase is not empty.
+func WritePrivKey(pKeyPath string, pPrivKey asymmetric.IPrivKey, pPassphrase []byte) error {
+	privKeyBytes := []byte(pPrivKey.ToString())
+	if len(pPassphrase) != 0 {
+		encBytes, err := EncryptPrivKey(pPrivKey, pPassphrase)
+		if err != nil {
+			return err
+		}
+		privKeyBytes = encBytes
+	}
+	return writeFile(pKeyPath, privKeyBytes)
+}
+
+func writeFile(pPath string, pData []byte) error {
+	// the file is replaced atomically to not lose the key
+	// if the process is interrupted while writing
+	tmpFile, err := os.CreateTemp(filepath.Dir(pPath), filepath.Base(pPath)+".*.tmp")
+	if err != nil {
+		return errors.Join(ErrWritePrivateKey, err)
+	}
+	tmpPath := tmpFile.Name()
+	defer os.Remove(tmpPath)
+
+	_, errWrite := tmpFile.Write(pData)
+	errSync := tmpFile.Sync()
+	errClose := tmpFile.Close()
+	if err := errors.Join(errWrite, errSync, errClose); err != nil {
+		return errors.Join(ErrWritePrivateKey, err)
+	}
+
+	if err := os.Rename(tmpPath, pPath); err != nil {
+		return errors.Join(ErrWritePrivateKey, err)
+	}
+	return nil
+}
+
+func getPassphrase(pPassphraseF IPassphraseF, pPrompt bool) ([]byte, error) {
+	if pPassphraseF == nil {
+		return nil, nil
+	}
+	passphrase, err := pPassphraseF(pPrompt)
+	if err != nil {
+		return nil, errors.Join(ErrGetPassphrase, err)
 	}
-	return privKey, nil
+	return passphrase, nil
 }
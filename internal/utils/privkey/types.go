@@ -0,0 +1,6 @@
+package privkey
+
+// IPassphraseF returns the passphrase of the private key. If pPrompt is
+// false then the passphrase must be returned without user interaction
+// (nil passphrase means it is not defined).
+type IPassphraseF func(pPrompt bool) ([]byte, error)
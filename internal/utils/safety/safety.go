@@ -0,0 +1,59 @@
+package safety
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/number571/go-peer/pkg/crypto/asymmetric"
+)
+
+const (
+	cVersion    = 0
+	cIterations = 1024
+
+	// each party gives 6 groups of 5 digits = 60 digits in total
+	cGroupsPerKey = 6
+	cGroupSize    = 5
+	cGroupBytes   = 5
+	cGroupModulo  = 100_000
+)
+
+// GetSafetyNumber returns the number of 12 groups with 5 digits derived from
+// both public keys. The result does not depend on the order of keys, so both
+// parties see the same number and can compare it out of band.
+func GetSafetyNumber(pPubKeyA, pPubKeyB asymmetric.IPubKey) string {
+	parts := []string{
+		GetFingerprint(pPubKeyA),
+		GetFingerprint(pPubKeyB),
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, " ")
+}
+
+// GetFingerprint returns 6 groups with 5 digits derived from one public key.
+func GetFingerprint(pPubKey asymmetric.IPubKey) string {
+	hash := iterateHash(pPubKey.ToBytes())
+
+	groups := make([]string, 0, cGroupsPerKey)
+	for i := 0; i < cGroupsPerKey; i++ {
+		chunk := hash[i*cGroupBytes : (i+1)*cGroupBytes]
+		num := uint64(0)
+		for _, b := range chunk {
+			num = (num << 8) | uint64(b)
+		}
+		groups = append(groups, fmt.Sprintf("%0*d", cGroupSize, num%cGroupModulo))
+	}
+	return strings.Join(groups, " ")
+}
+
+func iterateHash(pPubKey []byte) []byte {
+	hash := bytes.Join([][]byte{{cVersion}, pPubKey}, nil)
+	for i := 0; i < cIterations; i++ {
+		sum := sha512.Sum512(bytes.Join([][]byte{hash, pPubKey}, nil))
+		hash = sum[:]
+	}
+	return hash
+}
@@ -0,0 +1,49 @@
+package safety
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/number571/go-peer/pkg/crypto/asymmetric"
+)
+
+func TestSafetyNumber(t *testing.T) {
+	t.Parallel()
+
+	pubKeyA := asymmetric.NewPrivKey().GetPubKey()
+	pubKeyB := asymmetric.NewPrivKey().GetPubKey()
+	pubKeyC := asymmetric.NewPrivKey().GetPubKey()
+
+	safetyNumber := GetSafetyNumber(pubKeyA, pubKeyB)
+	if ok, _ := regexp.MatchString(`^(\d{5} ){11}\d{5}$`, safetyNumber); !ok {
+		t.Errorf("invalid format of safety number: %s", safetyNumber)
+		return
+	}
+	if safetyNumber != GetSafetyNumber(pubKeyB, pubKeyA) {
+		t.Error("safety number depends on the order of keys")
+		return
+	}
+	if safetyNumber != GetSafetyNumber(pubKeyA, pubKeyB) {
+		t.Error("safety number is not deterministic")
+		return
+	}
+	if safetyNumber == GetSafetyNumber(pubKeyA, pubKeyC) {
+		t.Error("safety numbers are equal for different keys")
+		return
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	t.Parallel()
+
+	pubKey := asymmetric.NewPrivKey().GetPubKey()
+	fingerprint := GetFingerprint(pubKey)
+	if ok, _ := regexp.MatchString(`^(\d{5} ){5}\d{5}$`, fingerprint); !ok {
+		t.Errorf("invalid format of fingerprint: %s", fingerprint)
+		return
+	}
+	if fingerprint != GetFingerprint(asymmetric.LoadPubKey(pubKey.ToString())) {
+		t.Error("fingerprint is not deterministic")
+		return
+	}
+}
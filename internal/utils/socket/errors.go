@@ -0,0 +1,19 @@
+package socket
+
+const (
+	errPrefix = "internal/utils/socket = "
+)
+
+type SSocketError struct {
+	str string
+}
+
+func (err *SSocketError) Error() string {
+	return errPrefix + err.str
+}
+
+var (
+	ErrRemoveSocket = &SSocketError{"remove socket"}
+	ErrListen       = &SSocketError{"listen"}
+	ErrChmodSocket  = &SSocketError{"chmod socket"}
+)
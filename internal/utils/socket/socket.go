@@ -0,0 +1,142 @@
+package socket
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+)
+
+const (
+	CUnixScheme = "unix://"
+
+	// Only the owner of the socket can connect to the service.
+	CUnixSocketPerm = 0o600
+)
+
+const (
+	// the host is not used in the URL for the unix socket
+	cUnixHost = "unix"
+)
+
+// GetUnixPath returns the path of the socket if the address
+// has the format unix:///path/to/socket.
+func GetUnixPath(pAddr string) (string, bool) {
+	if !strings.HasPrefix(pAddr, CUnixScheme) {
+		return "", false
+	}
+	path := strings.TrimPrefix(pAddr, CUnixScheme)
+	return path, path != ""
+}
+
+// Listen creates the unix socket listener (with restricted permissions)
+// or the TCP listener by the address.
+func Listen(pAddr string) (net.Listener, error) {
+	path, ok := GetUnixPath(pAddr)
+	if !ok {
+		listener, err := net.Listen("tcp", pAddr)
+		if err != nil {
+			return nil, errors.Join(ErrListen, err)
+		}
+		return listener, nil
+	}
+
+	// the socket file stays after the crash of the application
+	if info, err := os.Stat(path); err == nil && info.Mode()&fs.ModeSocket != 0 {
+		if err := os.Remove(path); err != nil {
+			return nil, errors.Join(ErrRemoveSocket, err)
+		}
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, errors.Join(ErrListen, err)
+	}
+
+	if err := os.Chmod(path, CUnixSocketPerm); err != nil {
+		_ = listener.Close()
+		return nil, errors.Join(ErrChmodSocket, err)
+	}
+
+	return listener, nil
+}
+
+// WrapHTTPClient returns the host for the URL and the client. If the address
+// is the unix socket, then the client dials the socket for each request.
+func WrapHTTPClient(pAddr string, pClient *http.Client) (string, *http.Client) {
+	path, ok := GetUnixPath(pAddr)
+	if !ok {
+		return pAddr, pClient
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if t, ok := pClient.Transport.(*http.Transport); ok {
+		transport = t.Clone()
+	}
+
+	dialer := &net.Dialer{}
+	transport.DialContext = func(pCtx context.Context, _, _ string) (net.Conn, error) {
+		return dialer.DialContext(pCtx, "unix", path)
+	}
+
+	client := *pClient
+	client.Transport = transport
+	return cUnixHost, &client
+}
+
+var (
+	_ IHTTPClients = &sHTTPClients{}
+)
+
+type IHTTPClients interface {
+	Get(string) (string, *http.Client)
+}
+
+type sHTTPClient struct {
+	fHost   string
+	fClient *http.Client
+}
+
+type sHTTPClients struct {
+	fMutex   sync.Mutex
+	fClient  *http.Client
+	fClients map[string]*sHTTPClient
+}
+
+// NewHTTPClients keeps the wrapped clients by the addresses, so the
+// connections to the unix sockets are reused between requests.
+func NewHTTPClients(pClient *http.Client) IHTTPClients {
+	return &sHTTPClients{
+		fClient:  pClient,
+		fClients: make(map[string]*sHTTPClient, 8),
+	}
+}
+
+// Get returns the host and the client created by WrapHTTPClient
+// for the first request to the address.
+func (p *sHTTPClients) Get(pAddr string) (string, *http.Client) {
+	p.fMutex.Lock()
+	defer p.fMutex.Unlock()
+
+	if c, ok := p.fClients[pAddr]; ok {
+		return c.fHost, c.fClient
+	}
+
+	host, client := WrapHTTPClient(pAddr, p.fClient)
+	p.fClients[pAddr] = &sHTTPClient{fHost: host, fClient: client}
+	return host, client
+}
+
+// ListenAndServe is the same as the http.Server.ListenAndServe,
+// but also supports the unix socket addresses.
+func ListenAndServe(pServer *http.Server) error {
+	listener, err := Listen(pServer.Addr)
+	if err != nil {
+		return err
+	}
+	return pServer.Serve(listener)
+}
@@ -0,0 +1,150 @@
+package socket
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestError(t *testing.T) {
+	t.Parallel()
+
+	str := "value"
+	err := &SSocketError{str}
+	if err.Error() != errPrefix+str {
+		t.Error("incorrect err.Error()")
+		return
+	}
+}
+
+func TestGetUnixPath(t *testing.T) {
+	t.Parallel()
+
+	if path, ok := GetUnixPath("unix:///tmp/hls.sock"); !ok || path != "/tmp/hls.sock" {
+		t.Error("got invalid unix path")
+		return
+	}
+	if _, ok := GetUnixPath("unix://"); ok {
+		t.Error("success get empty unix path")
+		return
+	}
+	if _, ok := GetUnixPath("127.0.0.1:9572"); ok {
+		t.Error("success get unix path from tcp address")
+		return
+	}
+}
+
+func TestUnixSocket(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "test.sock")
+	addr := CUnixScheme + path
+
+	for i := 0; i < 2; i++ {
+		listener, err := Listen(addr)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if info.Mode().Perm() != CUnixSocketPerm {
+			t.Error("got invalid permissions of socket")
+			return
+		}
+
+		// the next listen should remove the stale socket
+		listener.(*net.UnixListener).SetUnlinkOnClose(false)
+		_ = listener.Close()
+	}
+
+	if _, err := Listen(CUnixScheme + filepath.Join(path, "undefined", "x.sock")); err == nil {
+		t.Error("success listen invalid path")
+		return
+	}
+}
+
+func TestWrapHTTPClient(t *testing.T) {
+	t.Parallel()
+
+	client := &http.Client{Timeout: time.Second}
+	if host, c := WrapHTTPClient("127.0.0.1:9572", client); host != "127.0.0.1:9572" || c != client {
+		t.Error("tcp client is wrapped")
+		return
+	}
+
+	path := filepath.Join(t.TempDir(), "test.sock")
+	addr := CUnixScheme + path
+
+	listener, err := Listen(addr)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	srv := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = io.WriteString(w, "hello")
+		}),
+		ReadTimeout: time.Second,
+	}
+	defer srv.Close()
+	go func() { _ = srv.Serve(listener) }()
+
+	host, wrapped := WrapHTTPClient(addr, client)
+	if wrapped.Timeout != client.Timeout {
+		t.Error("got invalid timeout")
+		return
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, fmt.Sprintf("http://%s/", host), nil)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	rsp, err := wrapped.Do(req)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer rsp.Body.Close()
+
+	body, err := io.ReadAll(rsp.Body)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if string(body) != "hello" {
+		t.Error("got invalid response")
+		return
+	}
+}
+
+func TestHTTPClients(t *testing.T) {
+	t.Parallel()
+
+	clients := NewHTTPClients(&http.Client{Timeout: time.Second})
+
+	addr := CUnixScheme + filepath.Join(t.TempDir(), "test.sock")
+	host1, client1 := clients.Get(addr)
+	host2, client2 := clients.Get(addr)
+	if host1 != host2 || client1 != client2 {
+		t.Error("client is wrapped for each request")
+		return
+	}
+
+	if _, client3 := clients.Get("127.0.0.1:9572"); client3 == client1 {
+		t.Error("got client of other address")
+		return
+	}
+}
@@ -0,0 +1,225 @@
+package chaos
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/number571/go-peer/pkg/anonymity/logger"
+	"github.com/number571/go-peer/pkg/encoding"
+	"github.com/number571/go-peer/pkg/message/layer1"
+	"github.com/number571/hidden-lake/internal/utils/name"
+	"github.com/number571/hidden-lake/pkg/adapters"
+
+	gopeer_logger "github.com/number571/go-peer/pkg/logger"
+	internal_anon_logger "github.com/number571/hidden-lake/internal/utils/logger/anon"
+)
+
+var (
+	_ IChaosAdapter   = &sChaosAdapter{}
+	_ layer1.IMessage = &sCorruptedMessage{}
+)
+
+type sChaosAdapter struct {
+	fSettings ISettings
+	fAdapter  adapters.IRunnerAdapter
+
+	fMutex  sync.Mutex
+	fRandom *rand.Rand
+	fHeld   layer1.IMessage
+	fTimer  *time.Timer
+
+	fShortName string
+	fLogger    gopeer_logger.ILogger
+}
+
+type sFaults struct {
+	fDrop      bool
+	fDelay     bool
+	fDuplicate bool
+	fReorder   bool
+	fCorrupt   bool
+	fDelayDur  time.Duration
+	fIndex     uint64
+}
+
+type sCorruptedMessage struct {
+	layer1.IMessage
+	fBytes []byte
+}
+
+// NewChaosAdapter wraps the adapter and injects faults into the produced
+// messages: drop, delay, duplicate, reorder and corrupt. The faults are
+// chosen by the random generator with the seed from settings, so the same
+// sequence of produced messages gets the same sequence of faults.
+func NewChaosAdapter(pSettings ISettings, pAdapter adapters.IRunnerAdapter) IChaosAdapter {
+	return &sChaosAdapter{
+		fSettings: pSettings,
+		fAdapter:  pAdapter,
+		fRandom:   rand.New(rand.NewSource(int64(pSettings.GetSeed()))), // nolint: gosec
+		fLogger: gopeer_logger.NewLogger(
+			gopeer_logger.NewSettings(&gopeer_logger.SSettings{}),
+			func(_ gopeer_logger.ILogArg) string { return "" },
+		),
+	}
+}
+
+func (p *sChaosAdapter) WithLogger(pName name.IServiceName, pLogger gopeer_logger.ILogger) IChaosAdapter {
+	p.fShortName = pName.Short()
+	p.fLogger = pLogger
+	return p
+}
+
+func (p *sChaosAdapter) GetSettings() ISettings {
+	return p.fSettings
+}
+
+func (p *sChaosAdapter) Run(pCtx context.Context) error {
+	defer p.stopTimer()
+	return p.fAdapter.Run(pCtx)
+}
+
+func (p *sChaosAdapter) Consume(pCtx context.Context) (layer1.IMessage, error) {
+	return p.fAdapter.Consume(pCtx)
+}
+
+func (p *sChaosAdapter) Produce(pCtx context.Context, pNetMsg layer1.IMessage) error {
+	faults := p.rollFaults()
+
+	if faults.fDrop {
+		p.pushLog(internal_anon_logger.CLogWarnChaosDrop, pNetMsg)
+		return nil
+	}
+
+	msg := pNetMsg
+	if faults.fCorrupt {
+		p.pushLog(internal_anon_logger.CLogWarnChaosCorrupt, pNetMsg)
+		msg = newCorruptedMessage(pNetMsg, faults.fIndex)
+	}
+
+	if faults.fDelay {
+		p.pushLog(internal_anon_logger.CLogWarnChaosDelay, pNetMsg)
+		time.AfterFunc(faults.fDelayDur, func() {
+			if pCtx.Err() != nil {
+				// the adapter is stopped
+				return
+			}
+			_ = p.produce(pCtx, msg, faults.fDuplicate)
+		})
+		return nil
+	}
+
+	if faults.fReorder && p.holdMessage(pCtx, msg) {
+		// the message will be produced after the next message
+		p.pushLog(internal_anon_logger.CLogWarnChaosReorder, pNetMsg)
+		return nil
+	}
+
+	err := p.produce(pCtx, msg, faults.fDuplicate)
+	p.flushMessage(pCtx)
+	return err
+}
+
+func (p *sChaosAdapter) produce(pCtx context.Context, pNetMsg layer1.IMessage, pDuplicate bool) error {
+	if err := p.fAdapter.Produce(pCtx, pNetMsg); err != nil {
+		return errors.Join(ErrProduceMessage, err)
+	}
+	if !pDuplicate {
+		return nil
+	}
+	p.pushLog(internal_anon_logger.CLogWarnChaosDuplicate, pNetMsg)
+	if err := p.fAdapter.Produce(pCtx, pNetMsg); err != nil {
+		return errors.Join(ErrProduceMessage, err)
+	}
+	return nil
+}
+
+func (p *sChaosAdapter) holdMessage(pCtx context.Context, pNetMsg layer1.IMessage) bool {
+	p.fMutex.Lock()
+	defer p.fMutex.Unlock()
+
+	if p.fHeld != nil {
+		return false
+	}
+
+	// the held message is released by timeout if there are no next messages
+	p.fHeld = pNetMsg
+	p.fTimer = time.AfterFunc(p.fSettings.GetDelayMax(), func() {
+		p.flushMessage(pCtx)
+	})
+	return true
+}
+
+func (p *sChaosAdapter) stopTimer() {
+	p.fMutex.Lock()
+	defer p.fMutex.Unlock()
+
+	// the held message is dropped on shutdown
+	if p.fTimer != nil {
+		p.fTimer.Stop()
+	}
+	p.fHeld, p.fTimer = nil, nil
+}
+
+func (p *sChaosAdapter) flushMessage(pCtx context.Context) {
+	p.fMutex.Lock()
+	held := p.fHeld
+	if held != nil {
+		p.fTimer.Stop()
+		p.fHeld, p.fTimer = nil, nil
+	}
+	p.fMutex.Unlock()
+
+	if held == nil || pCtx.Err() != nil {
+		return
+	}
+	_ = p.produce(pCtx, held, false)
+}
+
+func (p *sChaosAdapter) rollFaults() *sFaults {
+	p.fMutex.Lock()
+	defer p.fMutex.Unlock()
+
+	// all values are generated for each message to keep
+	// the sequence of faults independent of the previous faults
+	return &sFaults{
+		fDrop:      p.fRandom.Float64() < p.fSettings.GetDropProb(),
+		fDelay:     p.fRandom.Float64() < p.fSettings.GetDelayProb(),
+		fDuplicate: p.fRandom.Float64() < p.fSettings.GetDuplicateProb(),
+		fReorder:   p.fRandom.Float64() < p.fSettings.GetReorderProb(),
+		fCorrupt:   p.fRandom.Float64() < p.fSettings.GetCorruptProb(),
+		fDelayDur:  time.Duration(p.fRandom.Int63n(int64(p.fSettings.GetDelayMax()) + 1)),
+		fIndex:     p.fRandom.Uint64(),
+	}
+}
+
+func (p *sChaosAdapter) pushLog(pType logger.ILogType, pNetMsg layer1.IMessage) {
+	p.fLogger.PushWarn(logger.NewLogBuilder(p.fShortName).
+		WithType(pType).
+		WithHash(pNetMsg.GetHash()).
+		WithProof(pNetMsg.GetProof()).
+		WithSize(len(pNetMsg.ToBytes())))
+}
+
+func newCorruptedMessage(pNetMsg layer1.IMessage, pIndex uint64) layer1.IMessage {
+	msgBytes := pNetMsg.ToBytes()
+	corrupted := make([]byte, len(msgBytes))
+	copy(corrupted, msgBytes)
+	if len(corrupted) != 0 {
+		corrupted[pIndex%uint64(len(corrupted))] ^= 0xFF
+	}
+	return &sCorruptedMessage{
+		IMessage: pNetMsg,
+		fBytes:   corrupted,
+	}
+}
+
+func (p *sCorruptedMessage) ToBytes() []byte {
+	return p.fBytes
+}
+
+func (p *sCorruptedMessage) ToString() string {
+	return encoding.HexEncode(p.fBytes)
+}
@@ -0,0 +1,344 @@
+package chaos
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	gopeer_adapters "github.com/number571/go-peer/pkg/anonymity/adapters"
+	"github.com/number571/go-peer/pkg/crypto/asymmetric"
+	"github.com/number571/go-peer/pkg/message/layer1"
+	"github.com/number571/go-peer/pkg/payload"
+	"github.com/number571/go-peer/pkg/storage/cache"
+	"github.com/number571/go-peer/pkg/storage/database"
+	"github.com/number571/hidden-lake/pkg/adapters"
+	"github.com/number571/hidden-lake/pkg/adapters/memory"
+	"github.com/number571/hidden-lake/pkg/network"
+	"github.com/number571/hidden-lake/pkg/request"
+	"github.com/number571/hidden-lake/pkg/response"
+)
+
+func TestError(t *testing.T) {
+	t.Parallel()
+
+	str := "value"
+	err := &SAppError{str}
+	if err.Error() != errPrefix+str {
+		t.Error("incorrect err.Error()")
+		return
+	}
+}
+
+func TestSettings(t *testing.T) {
+	t.Parallel()
+
+	sett := NewSettings(nil)
+	if sett.GetSeed() == 0 {
+		t.Error("seed is not generated")
+		return
+	}
+	if sett.GetDelayMax() != cDefaultDelayMax {
+		t.Error("got invalid delay max")
+		return
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("nothing panics")
+			return
+		}
+	}()
+	_ = NewSettings(&SSettings{FDropProb: 1.5})
+}
+
+func TestChaosFaults(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	msg1 := testNewMessage("1")
+
+	dropAdapter, dropChan := testNewChaosAdapter(&SSettings{FSeed: 1, FDropProb: 1})
+	if err := dropAdapter.Produce(ctx, msg1); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(dropChan) != 0 {
+		t.Error("message is not dropped")
+		return
+	}
+
+	dupAdapter, dupChan := testNewChaosAdapter(&SSettings{FSeed: 1, FDuplicateProb: 1})
+	if err := dupAdapter.Produce(ctx, msg1); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(dupChan) != 2 || (<-dupChan).ToString() != (<-dupChan).ToString() {
+		t.Error("message is not duplicated")
+		return
+	}
+
+	corAdapter, corChan := testNewChaosAdapter(&SSettings{FSeed: 1, FCorruptProb: 1})
+	if err := corAdapter.Produce(ctx, msg1); err != nil {
+		t.Error(err)
+		return
+	}
+	corMsg := <-corChan
+	if corMsg.ToString() == msg1.ToString() {
+		t.Error("message is not corrupted")
+		return
+	}
+	if _, err := layer1.LoadMessage(adapters.NewSettings(nil), corMsg.ToBytes()); err == nil {
+		t.Error("success load corrupted message")
+		return
+	}
+
+	delayAdapter, delayChan := testNewChaosAdapter(&SSettings{
+		FSeed:      1,
+		FDelayProb: 1,
+		FDelayMax:  100 * time.Millisecond,
+	})
+	if err := delayAdapter.Produce(ctx, msg1); err != nil {
+		t.Error(err)
+		return
+	}
+	select {
+	case <-delayChan:
+	case <-time.After(time.Second):
+		t.Error("delayed message is not produced")
+		return
+	}
+}
+
+func TestChaosReorder(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	msg1 := testNewMessage("1")
+	msg2 := testNewMessage("2")
+	msg3 := testNewMessage("3")
+
+	adapter, msgChan := testNewChaosAdapter(&SSettings{
+		FSeed:        1,
+		FReorderProb: 1,
+		FDelayMax:    100 * time.Millisecond,
+	})
+
+	for _, msg := range []layer1.IMessage{msg1, msg2, msg3} {
+		if err := adapter.Produce(ctx, msg); err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	if (<-msgChan).ToString() != msg2.ToString() || (<-msgChan).ToString() != msg1.ToString() {
+		t.Error("messages are not reordered")
+		return
+	}
+
+	// the last message is released by timeout
+	select {
+	case msg := <-msgChan:
+		if msg.ToString() != msg3.ToString() {
+			t.Error("got invalid message")
+			return
+		}
+	case <-time.After(time.Second):
+		t.Error("held message is not produced")
+		return
+	}
+}
+
+func TestChaosShutdown(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	delayAdapter, delayChan := testNewChaosAdapter(&SSettings{
+		FSeed:      1,
+		FDelayProb: 1,
+		FDelayMax:  100 * time.Millisecond,
+	})
+	reorderAdapter, reorderChan := testNewChaosAdapter(&SSettings{
+		FSeed:        1,
+		FReorderProb: 1,
+		FDelayMax:    100 * time.Millisecond,
+	})
+
+	chRun := make(chan struct{})
+	go func() {
+		defer close(chRun)
+		_ = reorderAdapter.Run(ctx)
+	}()
+
+	if err := delayAdapter.Produce(ctx, testNewMessage("1")); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := reorderAdapter.Produce(ctx, testNewMessage("2")); err != nil {
+		t.Error(err)
+		return
+	}
+
+	cancel()
+	<-chRun
+
+	// delayed and held messages are not produced after shutdown
+	select {
+	case <-delayChan:
+		t.Error("delayed message is produced after shutdown")
+		return
+	case <-reorderChan:
+		t.Error("held message is produced after shutdown")
+		return
+	case <-time.After(300 * time.Millisecond):
+	}
+}
+
+func TestChaosSeed(t *testing.T) {
+	t.Parallel()
+
+	const n = 64
+
+	sett := &SSettings{FSeed: 571, FDropProb: 0.5}
+	adapter1, msgChan1 := testNewChaosAdapter(sett)
+	adapter2, msgChan2 := testNewChaosAdapter(sett)
+
+	ctx := context.Background()
+	for i := 0; i < n; i++ {
+		msg := testNewMessage(string(rune('a' + i%26)))
+		_ = adapter1.Produce(ctx, msg)
+		_ = adapter2.Produce(ctx, msg)
+	}
+
+	if len(msgChan1) == 0 || len(msgChan1) == n {
+		t.Error("messages are not dropped by probability")
+		return
+	}
+	if len(msgChan1) != len(msgChan2) {
+		t.Error("got different faults with the same seed")
+		return
+	}
+	for len(msgChan1) != 0 {
+		if (<-msgChan1).ToString() != (<-msgChan2).ToString() {
+			t.Error("got different faults with the same seed")
+			return
+		}
+	}
+}
+
+func TestChaosNetwork(t *testing.T) {
+	t.Parallel()
+
+	bus := memory.NewBus()
+
+	node1 := testNewHiddenLakeNode("chaos_node1.db", bus)
+	node1PubKey := node1.GetAnonymityNode().GetQBProcessor().GetClient().GetPrivKey().GetPubKey()
+	defer os.Remove("chaos_node1.db")
+
+	node2 := testNewHiddenLakeNode("chaos_node2.db", bus)
+	node2PubKey := node2.GetAnonymityNode().GetQBProcessor().GetClient().GetPrivKey().GetPubKey()
+	defer os.Remove("chaos_node2.db")
+
+	node1.GetAnonymityNode().GetMapPubKeys().SetPubKey(node2PubKey)
+	node2.GetAnonymityNode().GetMapPubKeys().SetPubKey(node1PubKey)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() { _ = node1.Run(ctx) }()
+	go func() { _ = node2.Run(ctx) }()
+
+	// duplicates, delays and reorders do not break the fetch requests
+	for i := 0; i < 2; i++ {
+		rsp, err := node1.FetchRequest(
+			ctx,
+			node2PubKey,
+			request.NewRequestBuilder().WithMethod(http.MethodGet).WithBody([]byte("hello")).Build(),
+		)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if string(rsp.GetBody()) != "hello" {
+			t.Error("got invalid response body")
+			return
+		}
+	}
+}
+
+func testNewMessage(pData string) layer1.IMessage {
+	return layer1.NewMessage(
+		layer1.NewConstructSettings(&layer1.SConstructSettings{
+			FSettings: adapters.NewSettings(nil),
+		}),
+		payload.NewPayload32(0x01, []byte(pData)),
+	)
+}
+
+func testNewChaosAdapter(pSett *SSettings) (IChaosAdapter, chan layer1.IMessage) {
+	msgChan := make(chan layer1.IMessage, 256)
+	return NewChaosAdapter(
+		NewSettings(pSett),
+		adapters.NewRunnerAdapter(
+			gopeer_adapters.NewAdapterByFuncs(
+				func(_ context.Context, msg layer1.IMessage) error {
+					msgChan <- msg
+					return nil
+				},
+				func(ctx context.Context) (layer1.IMessage, error) {
+					<-ctx.Done()
+					return nil, ctx.Err()
+				},
+			),
+			func(ctx context.Context) error {
+				<-ctx.Done()
+				return nil
+			},
+		),
+	), msgChan
+}
+
+func testNewHiddenLakeNode(pDBPath string, pBus memory.IBus) network.IHiddenLakeNode {
+	adapterSettings := adapters.NewSettings(&adapters.SSettings{
+		FMessageSizeBytes: 8 << 10,
+	})
+	return network.NewHiddenLakeNode(
+		network.NewSettings(&network.SSettings{
+			FQueuePeriod:     time.Second,
+			FFetchTimeout:    time.Minute,
+			FAdapterSettings: adapterSettings,
+		}),
+		asymmetric.NewPrivKey(),
+		func() database.IKVDatabase {
+			db, err := database.NewKVDatabase(pDBPath)
+			if err != nil {
+				panic(err)
+			}
+			return db
+		}(),
+		NewChaosAdapter(
+			NewSettings(&SSettings{
+				FDelayProb:     0.5,
+				FDuplicateProb: 0.5,
+				FReorderProb:   0.5,
+				FDelayMax:      500 * time.Millisecond,
+			}),
+			memory.NewMemoryAdapter(
+				memory.NewSettings(&memory.SSettings{
+					FAdapterSettings: adapterSettings,
+				}),
+				pBus,
+				cache.NewLRUCache(1<<10),
+			),
+		),
+		func(
+			_ context.Context,
+			_ asymmetric.IPubKey,
+			req request.IRequest,
+		) (response.IResponse, error) {
+			return response.NewResponseBuilder().WithBody(req.GetBody()).Build(), nil
+		},
+	)
+}
@@ -0,0 +1,17 @@
+package chaos
+
+const (
+	errPrefix = "pkg/adapters/chaos = "
+)
+
+type SAppError struct {
+	str string
+}
+
+func (err *SAppError) Error() string {
+	return errPrefix + err.str
+}
+
+var (
+	ErrProduceMessage = &SAppError{"produce message"}
+)
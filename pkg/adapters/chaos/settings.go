@@ -0,0 +1,101 @@
+package chaos
+
+import (
+	"time"
+)
+
+const (
+	cDefaultDelayMax = time.Second
+)
+
+var (
+	_ ISettings = &sSettings{}
+)
+
+type SSettings sSettings
+type sSettings struct {
+	FSeed          uint64
+	FDropProb      float64
+	FDelayProb     float64
+	FDuplicateProb float64
+	FReorderProb   float64
+	FCorruptProb   float64
+	FDelayMax      time.Duration
+}
+
+func NewSettings(pSett *SSettings) ISettings {
+	if pSett == nil {
+		pSett = &SSettings{}
+	}
+	return (&sSettings{
+		FSeed:          pSett.FSeed,
+		FDropProb:      pSett.FDropProb,
+		FDelayProb:     pSett.FDelayProb,
+		FDuplicateProb: pSett.FDuplicateProb,
+		FReorderProb:   pSett.FReorderProb,
+		FCorruptProb:   pSett.FCorruptProb,
+		FDelayMax:      pSett.FDelayMax,
+	}).useDefault().mustValid()
+}
+
+func (p *sSettings) useDefault() *sSettings {
+	if p.FSeed == 0 {
+		// the seed is printed by the adapter, so the run can be repeated
+		p.FSeed = uint64(time.Now().UnixNano()) // nolint: gosec
+	}
+	if p.FDelayMax == 0 {
+		p.FDelayMax = cDefaultDelayMax
+	}
+	return p
+}
+
+func (p *sSettings) mustValid() ISettings {
+	probs := []float64{
+		p.FDropProb,
+		p.FDelayProb,
+		p.FDuplicateProb,
+		p.FReorderProb,
+		p.FCorruptProb,
+	}
+	for _, prob := range probs {
+		if !IsValidProb(prob) {
+			panic("probability is not in [0;1]")
+		}
+	}
+	if p.FDelayMax < 0 {
+		panic("p.FDelayMax < 0")
+	}
+	return p
+}
+
+func IsValidProb(pProb float64) bool {
+	return 0 <= pProb && pProb <= 1
+}
+
+func (p *sSettings) GetSeed() uint64 {
+	return p.FSeed
+}
+
+func (p *sSettings) GetDropProb() float64 {
+	return p.FDropProb
+}
+
+func (p *sSettings) GetDelayProb() float64 {
+	return p.FDelayProb
+}
+
+func (p *sSettings) GetDuplicateProb() float64 {
+	return p.FDuplicateProb
+}
+
+func (p *sSettings) GetReorderProb() float64 {
+	return p.FReorderProb
+}
+
+func (p *sSettings) GetCorruptProb() float64 {
+	return p.FCorruptProb
+}
+
+func (p *sSettings) GetDelayMax() time.Duration {
+	return p.FDelayMax
+}
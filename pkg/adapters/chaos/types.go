@@ -0,0 +1,26 @@
+package chaos
+
+import (
+	"time"
+
+	"github.com/number571/go-peer/pkg/logger"
+	"github.com/number571/hidden-lake/internal/utils/name"
+	"github.com/number571/hidden-lake/pkg/adapters"
+)
+
+type IChaosAdapter interface {
+	adapters.IRunnerAdapter
+
+	WithLogger(name.IServiceName, logger.ILogger) IChaosAdapter
+	GetSettings() ISettings
+}
+
+type ISettings interface {
+	GetSeed() uint64
+	GetDropProb() float64
+	GetDelayProb() float64
+	GetDuplicateProb() float64
+	GetReorderProb() float64
+	GetCorruptProb() float64
+	GetDelayMax() time.Duration
+}
@@ -0,0 +1,137 @@
+package memory
+
+import (
+	"context"
+	"errors"
+
+	"github.com/number571/go-peer/pkg/logger"
+	"github.com/number571/go-peer/pkg/message/layer1"
+	"github.com/number571/go-peer/pkg/storage/cache"
+	"github.com/number571/hidden-lake/internal/utils/name"
+
+	anon_logger "github.com/number571/go-peer/pkg/anonymity/logger"
+	internal_anon_logger "github.com/number571/hidden-lake/internal/utils/logger/anon"
+)
+
+const (
+	netMessageChanSize = 32
+)
+
+var (
+	_ IMemoryAdapter = &sMemoryAdapter{}
+)
+
+type sMemoryAdapter struct {
+	fSettings   ISettings
+	fBus        IBus
+	fCache      cache.ICache
+	fNetMsgChan chan layer1.IMessage
+
+	fShortName string
+	fLogger    logger.ILogger
+}
+
+// NewMemoryAdapter creates the adapter which sends and receives messages
+// through the shared bus without sockets. The cache drops already seen
+// messages in the same way as the network node of the TCP adapter.
+func NewMemoryAdapter(
+	pSettings ISettings,
+	pBus IBus,
+	pCache cache.ICache,
+) IMemoryAdapter {
+	return &sMemoryAdapter{
+		fSettings:   pSettings,
+		fBus:        pBus,
+		fCache:      pCache,
+		fNetMsgChan: make(chan layer1.IMessage, netMessageChanSize),
+		fLogger: logger.NewLogger(
+			logger.NewSettings(&logger.SSettings{}),
+			func(_ logger.ILogArg) string { return "" },
+		),
+	}
+}
+
+func (p *sMemoryAdapter) WithLogger(pName name.IServiceName, pLogger logger.ILogger) IMemoryAdapter {
+	p.fShortName = pName.Short()
+	p.fLogger = pLogger
+	return p
+}
+
+func (p *sMemoryAdapter) GetBus() IBus {
+	return p.fBus
+}
+
+func (p *sMemoryAdapter) Run(pCtx context.Context) error {
+	unsubscribe := p.fBus.Subscribe(func(pMsgCtx context.Context, pMsg layer1.IMessage) {
+		p.handleMessage(pCtx, pMsgCtx, pMsg)
+	})
+	defer unsubscribe()
+
+	<-pCtx.Done()
+	return pCtx.Err()
+}
+
+func (p *sMemoryAdapter) Produce(pCtx context.Context, pNetMsg layer1.IMessage) error {
+	logBuilder := anon_logger.NewLogBuilder(p.fShortName)
+	logBuilder.
+		WithType(internal_anon_logger.CLogBaseSendNetworkMessage).
+		WithHash(pNetMsg.GetHash()).
+		WithProof(pNetMsg.GetProof()).
+		WithSize(len(pNetMsg.ToBytes())).
+		WithConn("memory")
+
+	// the message should not be received back by the sender
+	_ = p.fCache.Set(pNetMsg.GetHash(), []byte{})
+
+	if err := p.fBus.Publish(pCtx, pNetMsg); err != nil {
+		if errors.Is(err, ErrNoSubscribers) {
+			p.fLogger.PushWarn(logBuilder.WithType(internal_anon_logger.CLogWarnNoConnections))
+		} else {
+			p.fLogger.PushInfo(logBuilder)
+		}
+		return errors.Join(ErrBroadcast, err)
+	}
+	p.fLogger.PushInfo(logBuilder)
+	return nil
+}
+
+func (p *sMemoryAdapter) Consume(pCtx context.Context) (layer1.IMessage, error) {
+	select {
+	case <-pCtx.Done():
+		return nil, pCtx.Err()
+	case msg := <-p.fNetMsgChan:
+		return msg, nil
+	}
+}
+
+func (p *sMemoryAdapter) handleMessage(pRunCtx, pMsgCtx context.Context, pMsg layer1.IMessage) {
+	logBuilder := anon_logger.NewLogBuilder(p.fShortName)
+
+	// the message is copied so that the nodes do not share the same object
+	msg, err := layer1.LoadMessage(p.fSettings.GetAdapterSettings(), pMsg.ToBytes())
+	if err != nil {
+		p.fLogger.PushWarn(logBuilder.
+			WithType(anon_logger.CLogWarnMessageNull).
+			WithSize(len(pMsg.ToBytes())).
+			WithConn("memory"))
+		return
+	}
+
+	if !p.fCache.Set(msg.GetHash(), []byte{}) {
+		// the message has already been received or sent
+		return
+	}
+
+	p.fLogger.PushInfo(logBuilder.
+		WithType(internal_anon_logger.CLogInfoRecvNetworkMessage).
+		WithHash(msg.GetHash()).
+		WithProof(msg.GetProof()).
+		WithSize(len(msg.ToBytes())).
+		WithConn("memory"))
+
+	select {
+	case <-pRunCtx.Done():
+	case <-pMsgCtx.Done():
+	case p.fNetMsgChan <- msg:
+	}
+}
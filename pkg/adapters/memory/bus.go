@@ -0,0 +1,108 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/number571/go-peer/pkg/message/layer1"
+)
+
+const (
+	subscriberQueueSize = 32
+)
+
+var (
+	_ IBus = &sBus{}
+)
+
+type sBus struct {
+	fMutex       sync.RWMutex
+	fNextID      uint64
+	fSubscribers map[uint64]*sSubscriber
+}
+
+type sSubscriber struct {
+	fQueue chan layer1.IMessage
+	fDone  <-chan struct{}
+}
+
+// NewBus creates the shared in-process medium of messages. Each published
+// message is delivered to all subscribers (including the publisher), so
+// the duplicates must be dropped by the cache of the adapter. Each
+// subscriber has its own queue, the publisher waits for the free place
+// in the queue until the context is done, so the bus does not lose
+// messages. Lossy delivery is injected by the chaos adapter wrapper.
+func NewBus() IBus {
+	return &sBus{
+		fSubscribers: make(map[uint64]*sSubscriber, 16),
+	}
+}
+
+func (p *sBus) Publish(pCtx context.Context, pMsg layer1.IMessage) error {
+	if err := pCtx.Err(); err != nil {
+		return err
+	}
+
+	p.fMutex.RLock()
+	subscribers := make([]*sSubscriber, 0, len(p.fSubscribers))
+	for _, subscriber := range p.fSubscribers {
+		subscribers = append(subscribers, subscriber)
+	}
+	p.fMutex.RUnlock()
+
+	if len(subscribers) == 0 {
+		return ErrNoSubscribers
+	}
+
+	for _, subscriber := range subscribers {
+		select {
+		case <-pCtx.Done():
+			return pCtx.Err()
+		case <-subscriber.fDone:
+			// subscriber is unsubscribed
+		case subscriber.fQueue <- pMsg:
+		}
+	}
+	return nil
+}
+
+// Subscribe runs the handler in its own goroutine until unsubscribe.
+// The context of handler is canceled by unsubscribe.
+func (p *sBus) Subscribe(pHandlerF IHandlerF) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	subscriber := &sSubscriber{
+		fQueue: make(chan layer1.IMessage, subscriberQueueSize),
+		fDone:  ctx.Done(),
+	}
+
+	p.fMutex.Lock()
+	id := p.fNextID
+	p.fNextID++
+	p.fSubscribers[id] = subscriber
+	p.fMutex.Unlock()
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg := <-subscriber.fQueue:
+				pHandlerF(ctx, msg)
+			}
+		}
+	}()
+
+	return func() {
+		p.fMutex.Lock()
+		delete(p.fSubscribers, id)
+		p.fMutex.Unlock()
+		cancel()
+	}
+}
+
+func (p *sBus) GetCount() uint64 {
+	p.fMutex.RLock()
+	defer p.fMutex.RUnlock()
+
+	return uint64(len(p.fSubscribers))
+}
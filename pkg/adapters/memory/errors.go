@@ -0,0 +1,18 @@
+package memory
+
+const (
+	errPrefix = "pkg/adapters/memory = "
+)
+
+type SAppError struct {
+	str string
+}
+
+func (err *SAppError) Error() string {
+	return errPrefix + err.str
+}
+
+var (
+	ErrNoSubscribers = &SAppError{"no subscribers"}
+	ErrBroadcast     = &SAppError{"broadcast message"}
+)
@@ -0,0 +1,261 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/number571/go-peer/pkg/crypto/asymmetric"
+	"github.com/number571/go-peer/pkg/message/layer1"
+	"github.com/number571/go-peer/pkg/payload"
+	"github.com/number571/go-peer/pkg/storage/cache"
+	"github.com/number571/go-peer/pkg/storage/database"
+	"github.com/number571/hidden-lake/pkg/adapters"
+	"github.com/number571/hidden-lake/pkg/network"
+	"github.com/number571/hidden-lake/pkg/request"
+	"github.com/number571/hidden-lake/pkg/response"
+)
+
+func TestError(t *testing.T) {
+	t.Parallel()
+
+	str := "value"
+	err := &SAppError{str}
+	if err.Error() != errPrefix+str {
+		t.Error("incorrect err.Error()")
+		return
+	}
+}
+
+func TestSettings(t *testing.T) {
+	t.Parallel()
+
+	_ = NewSettings(nil)
+}
+
+func TestMemoryAdapter(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bus := NewBus()
+	if err := bus.Publish(ctx, testNewMessage([]byte("hello"))); !errors.Is(err, ErrNoSubscribers) {
+		t.Error("success publish without subscribers")
+		return
+	}
+
+	adapter1 := testNewMemoryAdapter(bus)
+	adapter2 := testNewMemoryAdapter(bus)
+
+	go func() { _ = adapter1.Run(ctx) }()
+	go func() { _ = adapter2.Run(ctx) }()
+
+	for bus.GetCount() != 2 {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	msg := testNewMessage([]byte("hello, world!"))
+	if err := adapter1.Produce(ctx, msg); err != nil {
+		t.Error(err)
+		return
+	}
+
+	// the duplicate is dropped by the cache
+	if err := adapter1.Produce(ctx, msg); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := bus.Publish(ctx, msg); err != nil {
+		t.Error(err)
+		return
+	}
+
+	recvMsg, err := adapter2.Consume(ctx)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if recvMsg.ToString() != msg.ToString() {
+		t.Error("got invalid message")
+		return
+	}
+
+	chCtx, chCancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer chCancel()
+
+	if _, err := adapter2.Consume(chCtx); err == nil {
+		t.Error("success consume duplicate message")
+		return
+	}
+	if _, err := adapter1.Consume(chCtx); err == nil {
+		t.Error("success consume own message")
+		return
+	}
+}
+
+func TestBusSlowSubscriber(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bus := NewBus()
+
+	chBlocked := make(chan struct{})
+	unsubscribe1 := bus.Subscribe(func(pCtx context.Context, _ layer1.IMessage) {
+		select {
+		case <-pCtx.Done():
+		case <-chBlocked:
+		}
+	})
+	defer unsubscribe1()
+
+	chRecv := make(chan struct{}, 2*subscriberQueueSize)
+	unsubscribe2 := bus.Subscribe(func(_ context.Context, _ layer1.IMessage) {
+		chRecv <- struct{}{}
+	})
+	defer unsubscribe2()
+
+	// one message is taken by the blocked handler, others fill the queue
+	for i := 0; i < subscriberQueueSize+1; i++ {
+		if err := bus.Publish(ctx, testNewMessage([]byte("hello"))); err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	// the publisher waits for the slow subscriber until the context is done
+	chCtx, chCancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer chCancel()
+
+	if err := bus.Publish(chCtx, testNewMessage([]byte("hello"))); !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("message is published to the full queue")
+		return
+	}
+
+	close(chBlocked)
+	if err := bus.Publish(ctx, testNewMessage([]byte("hello"))); err != nil {
+		t.Error(err)
+		return
+	}
+
+	// messages are not lost by the bus
+	for i := 0; i < subscriberQueueSize+2; i++ {
+		select {
+		case <-chRecv:
+		case <-time.After(time.Second):
+			t.Error("message is not received")
+			return
+		}
+	}
+}
+
+func TestMemoryNetwork(t *testing.T) {
+	t.Parallel()
+
+	const n = 3
+
+	bus := NewBus()
+	nodes := make([]network.IHiddenLakeNode, 0, n)
+	pubKeys := make([]asymmetric.IPubKey, 0, n)
+
+	for i := 0; i < n; i++ {
+		dbPath := fmt.Sprintf("memory_node%d.db", i+1)
+		defer os.Remove(dbPath)
+
+		node := testNewHiddenLakeNode(dbPath, bus)
+		nodes = append(nodes, node)
+		pubKeys = append(pubKeys, node.GetAnonymityNode().GetQBProcessor().GetClient().GetPrivKey().GetPubKey())
+	}
+
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if i == j {
+				continue
+			}
+			nodes[i].GetAnonymityNode().GetMapPubKeys().SetPubKey(pubKeys[j])
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	for _, node := range nodes {
+		go func(node network.IHiddenLakeNode) { _ = node.Run(ctx) }(node)
+	}
+
+	for i := 1; i < n; i++ {
+		rsp, err := nodes[0].FetchRequest(
+			ctx,
+			pubKeys[i],
+			request.NewRequestBuilder().WithMethod(http.MethodGet).WithBody([]byte("hello")).Build(),
+		)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if string(rsp.GetBody()) != "hello" {
+			t.Error("got invalid response body")
+			return
+		}
+	}
+}
+
+func testNewMessage(pData []byte) layer1.IMessage {
+	return layer1.NewMessage(
+		layer1.NewConstructSettings(&layer1.SConstructSettings{
+			FSettings: adapters.NewSettings(nil),
+		}),
+		payload.NewPayload32(0x01, pData),
+	)
+}
+
+func testNewMemoryAdapter(pBus IBus) IMemoryAdapter {
+	return NewMemoryAdapter(
+		NewSettings(&SSettings{
+			FAdapterSettings: adapters.NewSettings(nil),
+		}),
+		pBus,
+		cache.NewLRUCache(1<<10),
+	)
+}
+
+func testNewHiddenLakeNode(pDBPath string, pBus IBus) network.IHiddenLakeNode {
+	adapterSettings := adapters.NewSettings(&adapters.SSettings{
+		FMessageSizeBytes: 8 << 10,
+	})
+	return network.NewHiddenLakeNode(
+		network.NewSettings(&network.SSettings{
+			FQueuePeriod:     time.Second,
+			FFetchTimeout:    time.Minute,
+			FAdapterSettings: adapterSettings,
+		}),
+		asymmetric.NewPrivKey(),
+		func() database.IKVDatabase {
+			db, err := database.NewKVDatabase(pDBPath)
+			if err != nil {
+				panic(err)
+			}
+			return db
+		}(),
+		NewMemoryAdapter(
+			NewSettings(&SSettings{
+				FAdapterSettings: adapterSettings,
+			}),
+			pBus,
+			cache.NewLRUCache(1<<10),
+		),
+		func(
+			_ context.Context,
+			_ asymmetric.IPubKey,
+			req request.IRequest,
+		) (response.IResponse, error) {
+			return response.NewResponseBuilder().WithBody(req.GetBody()).Build(), nil
+		},
+	)
+}
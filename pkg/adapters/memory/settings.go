@@ -0,0 +1,31 @@
+package memory
+
+import (
+	"github.com/number571/hidden-lake/pkg/adapters"
+)
+
+var (
+	_ ISettings = &sSettings{}
+)
+
+type SSettings sSettings
+type sSettings struct {
+	FAdapterSettings adapters.ISettings
+}
+
+func NewSettings(pSett *SSettings) ISettings {
+	if pSett == nil {
+		pSett = &SSettings{}
+	}
+	return (&sSettings{
+		FAdapterSettings: pSett.FAdapterSettings,
+	}).useDefault()
+}
+
+func (p *sSettings) useDefault() *sSettings {
+	return p
+}
+
+func (p *sSettings) GetAdapterSettings() adapters.ISettings {
+	return p.FAdapterSettings
+}
@@ -0,0 +1,29 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/number571/go-peer/pkg/logger"
+	"github.com/number571/go-peer/pkg/message/layer1"
+	"github.com/number571/hidden-lake/internal/utils/name"
+	"github.com/number571/hidden-lake/pkg/adapters"
+)
+
+type IMemoryAdapter interface {
+	adapters.IRunnerAdapter
+
+	WithLogger(name.IServiceName, logger.ILogger) IMemoryAdapter
+	GetBus() IBus
+}
+
+type IBus interface {
+	Publish(context.Context, layer1.IMessage) error
+	Subscribe(IHandlerF) func()
+	GetCount() uint64
+}
+
+type IHandlerF func(context.Context, layer1.IMessage)
+
+type ISettings interface {
+	GetAdapterSettings() adapters.ISettings
+}
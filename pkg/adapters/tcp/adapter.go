@@ -39,7 +39,6 @@ func NewTCPAdapter(
 	pCache cache.ICache,
 	pConnsGetter func() []string,
 ) ITCPAdapter {
-	adapterSettings := pSettings.GetAdapterSettings()
 	p := &sTCPAdapter{
 		fNetMsgChan: make(chan layer1.IMessage, netMessageChanSize),
 		fConnKeeper: connkeeper.NewConnKeeper(
@@ -47,23 +46,7 @@ func NewTCPAdapter(
 				FDuration:    build.GSettings.GetKeeperPeriod(),
 				FConnections: pConnsGetter,
 			}),
-			network.NewNode(
-				network.NewSettings(&network.SSettings{
-					FAddress:      pSettings.GetAddress(),
-					FMaxConnects:  build.GSettings.FNetworkManager.FConnectsLimiter,
-					FReadTimeout:  build.GSettings.GetReadTimeout(),
-					FWriteTimeout: build.GSettings.GetWriteTimeout(),
-					FConnSettings: conn.NewSettings(&conn.SSettings{
-						FMessageSettings:       adapterSettings,
-						FLimitMessageSizeBytes: adapterSettings.GetMessageSizeBytes(),
-						FWaitReadTimeout:       build.GSettings.GetWaitTimeout(),
-						FDialTimeout:           build.GSettings.GetDialTimeout(),
-						FReadTimeout:           build.GSettings.GetReadTimeout(),
-						FWriteTimeout:          build.GSettings.GetWriteTimeout(),
-					}),
-				}),
-				pCache,
-			),
+			newNetworkNode(pSettings, pCache),
 		),
 		fLogger: logger.NewLogger(
 			logger.NewSettings(&logger.SSettings{}),
@@ -87,6 +70,28 @@ func NewTCPAdapter(
 	return p
 }
 
+func newNetworkNode(pSettings ISettings, pCache cache.ICache) network.INode {
+	adapterSettings := pSettings.GetAdapterSettings()
+	networkSettings := network.NewSettings(&network.SSettings{
+		FAddress:      pSettings.GetAddress(),
+		FMaxConnects:  build.GSettings.FNetworkManager.FConnectsLimiter,
+		FReadTimeout:  build.GSettings.GetReadTimeout(),
+		FWriteTimeout: build.GSettings.GetWriteTimeout(),
+		FConnSettings: conn.NewSettings(&conn.SSettings{
+			FMessageSettings:       adapterSettings,
+			FLimitMessageSizeBytes: adapterSettings.GetMessageSizeBytes(),
+			FWaitReadTimeout:       build.GSettings.GetWaitTimeout(),
+			FDialTimeout:           build.GSettings.GetDialTimeout(),
+			FReadTimeout:           build.GSettings.GetReadTimeout(),
+			FWriteTimeout:          build.GSettings.GetWriteTimeout(),
+		}),
+	})
+	if tlsSettings := pSettings.GetTLSSettings(); tlsSettings != nil {
+		return newTLSNode(networkSettings, tlsSettings, pCache)
+	}
+	return network.NewNode(networkSettings, pCache)
+}
+
 func (p *sTCPAdapter) WithLogger(pName name.IServiceName, pLogger logger.ILogger) ITCPAdapter {
 	p.fShortName = pName.Short()
 	p.fLogger = pLogger
@@ -15,4 +15,13 @@ func (err *SAppError) Error() string {
 var (
 	ErrRunning   = &SAppError{"adapter running"}
 	ErrBroadcast = &SAppError{"broadcast message"}
+
+	ErrLoadCertificate  = &SAppError{"load certificate"}
+	ErrLoadCA           = &SAppError{"load ca"}
+	ErrGenerateCert     = &SAppError{"generate certificate"}
+	ErrDecodeCert       = &SAppError{"decode certificate"}
+	ErrPeerCertificate  = &SAppError{"peer certificate"}
+	ErrPinMismatch      = &SAppError{"pin of certificate mismatch"}
+	ErrTLSNotLoaded     = &SAppError{"tls is not loaded"}
+	ErrCreateConnection = &SAppError{"create connection"}
 )
@@ -0,0 +1,397 @@
+package tcp
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/number571/go-peer/pkg/message/layer1"
+	"github.com/number571/go-peer/pkg/network"
+	"github.com/number571/go-peer/pkg/network/conn"
+	"github.com/number571/go-peer/pkg/storage/cache"
+)
+
+const (
+	// the version of go-peer from which sTLSNode is copied, the test
+	// fails when go-peer is updated until the copy is checked
+	cGoPeerForkVersion = "v1.7.10"
+)
+
+var (
+	_ network.INode = &sTLSNode{}
+)
+
+// sTLSNode is a copy of sNode from go-peer v1.7.10 (pkg/network/network.go).
+// The node of go-peer creates the listener and dials the connections inside
+// and has no hook to wrap them, so the node is copied with the differences:
+//   - Run loads the certificates and wraps the listener by TLS;
+//   - AddConnection dials by tls.Dialer with the pin of the address;
+//   - accepted connections complete the handshake in acceptConn before
+//     they are saved, and the slot of the limit is reserved for them;
+//   - the listener is not saved in the node.
+//
+// The messages are read and written by the connections of go-peer over
+// TLS sockets. The rest of the code must follow go-peer when it is updated
+// (TestTLSNodeGoPeerVersion fails until cGoPeerForkVersion is changed).
+type sTLSNode struct {
+	fMutex        sync.RWMutex
+	fSettings     network.ISettings
+	fTLSSettings  ITLSSettings
+	fTLSConfig    *sTLSConfig
+	fCacheSetter  cache.ICacheSetter
+	fConnections  map[string]conn.IConn
+	fReserved     uint64
+	fHandleRoutes map[uint32]network.IHandlerF
+}
+
+func newTLSNode(
+	pSettings network.ISettings,
+	pTLSSettings ITLSSettings,
+	pCacheSetter cache.ICacheSetter,
+) network.INode {
+	return &sTLSNode{
+		fSettings:     pSettings,
+		fTLSSettings:  pTLSSettings,
+		fCacheSetter:  pCacheSetter,
+		fConnections:  make(map[string]conn.IConn, pSettings.GetMaxConnects()),
+		fHandleRoutes: make(map[uint32]network.IHandlerF, 64),
+	}
+}
+
+func (p *sTLSNode) GetSettings() network.ISettings {
+	return p.fSettings
+}
+
+func (p *sTLSNode) GetCacheSetter() cache.ICacheSetter {
+	return p.fCacheSetter
+}
+
+func (p *sTLSNode) BroadcastMessage(pCtx context.Context, pMsg layer1.IMessage) error {
+	connections := p.GetConnections()
+	lenConnections := len(connections)
+
+	// can't broadcast message to the network if len(connections) = 0
+	if lenConnections == 0 {
+		return network.ErrNoConnections
+	}
+
+	// node can redirect received message
+	_ = p.fCacheSetter.Set(pMsg.GetHash(), []byte{})
+
+	wg := sync.WaitGroup{}
+	wg.Add(lenConnections)
+
+	listErr := make([]error, lenConnections)
+	i := 0
+
+	for a, c := range connections {
+		chErr := make(chan error)
+
+		go func(c conn.IConn) {
+			chErr <- c.WriteMessage(pCtx, pMsg)
+		}(c)
+
+		go func(i int, a string) {
+			defer wg.Done()
+
+			timer := time.NewTimer(p.fSettings.GetWriteTimeout())
+			defer timer.Stop()
+
+			select {
+			case <-pCtx.Done():
+				listErr[i] = pCtx.Err()
+			case <-timer.C:
+				listErr[i] = network.ErrWriteTimeout
+			case err := <-chErr:
+				if err == nil {
+					return
+				}
+				listErr[i] = errors.Join(network.ErrBroadcastMessage, err)
+			}
+
+			// if got error -> delete connection
+			_ = p.DelConnection(a)
+		}(i, a)
+
+		i++
+	}
+
+	wg.Wait()
+	return errors.Join(listErr...)
+}
+
+// Run loads the certificates and accepts the TLS connections. The node
+// without address only dials to the peers.
+func (p *sTLSNode) Run(pCtx context.Context) error {
+	tlsConfig, err := loadTLSConfig(p.fTLSSettings)
+	if err != nil {
+		return err
+	}
+	p.setTLSConfig(tlsConfig)
+
+	if p.fSettings.GetAddress() == "" {
+		<-pCtx.Done()
+		return pCtx.Err()
+	}
+
+	listener, err := net.Listen("tcp", p.fSettings.GetAddress())
+	if err != nil {
+		return errors.Join(network.ErrCreateListener, err)
+	}
+	defer listener.Close()
+
+	go func() {
+		<-pCtx.Done()
+		listener.Close()
+	}()
+
+	tlsListener := tls.NewListener(listener, tlsConfig.getServerConfig())
+	for {
+		select {
+		case <-pCtx.Done():
+			return pCtx.Err()
+		default:
+			tconn, err := tlsListener.Accept()
+			if err != nil {
+				return errors.Join(network.ErrListenerAccept, err)
+			}
+
+			// the slot is reserved before the handshake, so connections
+			// in the handshake can not exceed the limit of connections
+			if !p.reserveConnSlot() {
+				tconn.Close()
+				continue
+			}
+
+			go p.acceptConn(pCtx, tconn.(*tls.Conn)) // nolint: forcetypeassert
+		}
+	}
+}
+
+func (p *sTLSNode) HandleFunc(pHead uint32, pHandle network.IHandlerF) network.INode {
+	p.fMutex.Lock()
+	defer p.fMutex.Unlock()
+
+	p.fHandleRoutes[pHead] = pHandle
+	return p
+}
+
+func (p *sTLSNode) GetConnections() map[string]conn.IConn {
+	p.fMutex.RLock()
+	defer p.fMutex.RUnlock()
+
+	mapping := make(map[string]conn.IConn, len(p.fConnections))
+	for addr, conn := range p.fConnections {
+		mapping[addr] = conn
+	}
+
+	return mapping
+}
+
+func (p *sTLSNode) AddConnection(pCtx context.Context, pAddress string) error {
+	if _, ok := p.getConnection(pAddress); ok {
+		return network.ErrConnectionIsExist
+	}
+
+	tlsConfig := p.getTLSConfig()
+	if tlsConfig == nil {
+		return errors.Join(network.ErrAddConnections, ErrTLSNotLoaded)
+	}
+
+	if !p.reserveConnSlot() {
+		return network.ErrHasLimitConnections
+	}
+
+	sett := p.fSettings.GetConnSettings()
+	dialer := &tls.Dialer{
+		NetDialer: &net.Dialer{Timeout: sett.GetDialTimeout()},
+		Config:    tlsConfig.getClientConfig(pAddress),
+	}
+
+	tconn, err := dialer.DialContext(pCtx, "tcp", pAddress)
+	if err != nil {
+		p.releaseConnSlot()
+		return errors.Join(network.ErrAddConnections, ErrCreateConnection, err)
+	}
+
+	conn := conn.LoadConn(sett, tconn)
+	p.setConnection(pAddress, conn)
+	go p.handleConn(pCtx, pAddress, conn)
+
+	return nil
+}
+
+func (p *sTLSNode) DelConnection(pAddress string) error {
+	p.fMutex.Lock()
+	defer p.fMutex.Unlock()
+
+	conn, ok := p.fConnections[pAddress]
+	if !ok {
+		return network.ErrConnectionIsNotExist
+	}
+
+	delete(p.fConnections, pAddress)
+
+	if err := conn.Close(); err != nil {
+		return errors.Join(network.ErrCloseConnection, err)
+	}
+
+	return nil
+}
+
+// acceptConn completes the handshake before the connection is saved,
+// so peers rejected by the pins or by the CA never become connections.
+func (p *sTLSNode) acceptConn(pCtx context.Context, pConn *tls.Conn) {
+	sett := p.fSettings.GetConnSettings()
+
+	ctx, cancel := context.WithTimeout(pCtx, sett.GetDialTimeout())
+	defer cancel()
+
+	if err := pConn.HandshakeContext(ctx); err != nil {
+		p.releaseConnSlot()
+		pConn.Close()
+		return
+	}
+
+	conn := conn.LoadConn(sett, pConn)
+	address := pConn.RemoteAddr().String()
+
+	p.setConnection(address, conn)
+	p.handleConn(pCtx, address, conn)
+}
+
+func (p *sTLSNode) handleConn(pCtx context.Context, pAddress string, pConn conn.IConn) {
+	defer func() { _ = p.DelConnection(pAddress) }()
+
+	var (
+		readHeadCh = make(chan struct{})
+		readFullCh = make(chan layer1.IMessage)
+	)
+
+	go p.messageReader(
+		pCtx,
+		pConn,
+		readHeadCh,
+		readFullCh,
+	)
+
+	for {
+		select {
+		case <-pCtx.Done():
+			return
+		case <-readHeadCh:
+			select {
+			case <-pCtx.Done():
+				return
+			case <-time.After(p.fSettings.GetReadTimeout()):
+				return
+			case msg := <-readFullCh:
+				if msg == nil {
+					return
+				}
+				if ok := p.handleMessage(pCtx, pConn, msg); !ok {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (p *sTLSNode) messageReader(
+	pCtx context.Context,
+	pConn conn.IConn,
+	pReadHeadCh chan<- struct{},
+	pReadFullCh chan<- layer1.IMessage,
+) {
+	for {
+		select {
+		case <-pCtx.Done():
+			return
+		default:
+			msg, err := pConn.ReadMessage(pCtx, pReadHeadCh)
+			if err != nil {
+				pReadFullCh <- nil
+				return
+			}
+			pReadFullCh <- msg
+		}
+	}
+}
+
+func (p *sTLSNode) handleMessage(pCtx context.Context, pConn conn.IConn, pMsg layer1.IMessage) bool {
+	if !p.fCacheSetter.Set(pMsg.GetHash(), []byte{}) {
+		return true // hash of message already in queue
+	}
+
+	f, ok := p.getFunction(pMsg.GetPayload().GetHead())
+	if !ok || f == nil {
+		return false // function is not found = protocol error
+	}
+
+	err := f(pCtx, p, pConn, pMsg)
+	return err == nil // function error = protocol error
+}
+
+// reserveConnSlot counts the connection before the handshake. The slot
+// becomes the connection by setConnection or is freed by releaseConnSlot.
+func (p *sTLSNode) reserveConnSlot() bool {
+	p.fMutex.Lock()
+	defer p.fMutex.Unlock()
+
+	maxConns := p.fSettings.GetMaxConnects()
+	if uint64(len(p.fConnections))+p.fReserved >= maxConns {
+		return false
+	}
+
+	p.fReserved++
+	return true
+}
+
+func (p *sTLSNode) releaseConnSlot() {
+	p.fMutex.Lock()
+	defer p.fMutex.Unlock()
+
+	p.fReserved--
+}
+
+func (p *sTLSNode) getConnection(pAddress string) (conn.IConn, bool) {
+	p.fMutex.RLock()
+	defer p.fMutex.RUnlock()
+
+	conn, ok := p.fConnections[pAddress]
+	return conn, ok
+}
+
+// setConnection saves the connection instead of the reserved slot.
+func (p *sTLSNode) setConnection(pAddress string, pConn conn.IConn) {
+	p.fMutex.Lock()
+	defer p.fMutex.Unlock()
+
+	p.fReserved--
+	p.fConnections[pAddress] = pConn
+}
+
+func (p *sTLSNode) getFunction(pHead uint32) (network.IHandlerF, bool) {
+	p.fMutex.RLock()
+	defer p.fMutex.RUnlock()
+
+	f, ok := p.fHandleRoutes[pHead]
+	return f, ok
+}
+
+func (p *sTLSNode) setTLSConfig(pTLSConfig *sTLSConfig) {
+	p.fMutex.Lock()
+	defer p.fMutex.Unlock()
+
+	p.fTLSConfig = pTLSConfig
+}
+
+func (p *sTLSNode) getTLSConfig() *sTLSConfig {
+	p.fMutex.RLock()
+	defer p.fMutex.RUnlock()
+
+	return p.fTLSConfig
+}
@@ -12,6 +12,7 @@ type SSettings sSettings
 type sSettings struct {
 	FAddress         string
 	FAdapterSettings adapters.ISettings
+	FTLSSettings     ITLSSettings
 }
 
 func NewSettings(pSett *SSettings) ISettings {
@@ -21,6 +22,7 @@ func NewSettings(pSett *SSettings) ISettings {
 	return (&sSettings{
 		FAddress:         pSett.FAddress,
 		FAdapterSettings: pSett.FAdapterSettings,
+		FTLSSettings:     pSett.FTLSSettings,
 	}).useDefault()
 }
 
@@ -35,3 +37,8 @@ func (p *sSettings) GetAddress() string {
 func (p *sSettings) GetAdapterSettings() adapters.ISettings {
 	return p.FAdapterSettings
 }
+
+// GetTLSSettings returns nil if the connections are not wrapped by TLS.
+func (p *sSettings) GetTLSSettings() ITLSSettings {
+	return p.FTLSSettings
+}
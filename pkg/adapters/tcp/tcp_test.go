@@ -1,6 +1,22 @@
 package tcp
 
-import "testing"
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"testing"
+	"time"
+
+	"github.com/number571/go-peer/pkg/message/layer1"
+	"github.com/number571/go-peer/pkg/network"
+	"github.com/number571/go-peer/pkg/network/conn"
+	"github.com/number571/go-peer/pkg/payload"
+	"github.com/number571/go-peer/pkg/storage/cache"
+	"github.com/number571/hidden-lake/build"
+	"github.com/number571/hidden-lake/pkg/adapters"
+	testutils "github.com/number571/hidden-lake/test/utils"
+)
 
 func TestError(t *testing.T) {
 	t.Parallel()
@@ -17,9 +33,280 @@ func TestSettings(t *testing.T) {
 	t.Parallel()
 
 	_ = NewSettings(nil)
+
+	sett := NewTLSSettings(&STLSSettings{
+		FCertFile: "cert.pem",
+		FKeyFile:  "key.pem",
+		FPins:     map[string]string{"127.0.0.1:9999": "ABCD"},
+	})
+	if sett.GetPins()["127.0.0.1:9999"] != "abcd" {
+		t.Error("pin is not normalized")
+		return
+	}
+
+	testPanicTLSSettings(t, &STLSSettings{FCertFile: "cert.pem"})
+	testPanicTLSSettings(t, &STLSSettings{
+		FCertFile:   "cert.pem",
+		FKeyFile:    "key.pem",
+		FClientAuth: true,
+	})
+}
+
+func testPanicTLSSettings(t *testing.T, pSett *STLSSettings) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("nothing panics")
+			return
+		}
+	}()
+	_ = NewTLSSettings(pSett)
+}
+
+func TestCertificate(t *testing.T) {
+	t.Parallel()
+
+	certPEM, keyPEM, err := NewSelfSignedCertificate()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(keyPEM) == 0 {
+		t.Error("empty private key")
+		return
+	}
+
+	fingerprint, err := GetCertFingerprint(certPEM)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(fingerprint) != 64 {
+		t.Error("invalid length of fingerprint")
+		return
+	}
+
+	if _, err := GetCertFingerprint(keyPEM); err == nil {
+		t.Error("success get fingerprint of private key")
+		return
+	}
+
+	if _, err := loadTLSConfig(NewTLSSettings(&STLSSettings{
+		FCertFile: "not_exist.pem",
+		FKeyFile:  "not_exist.pem",
+	})); err == nil {
+		t.Error("success load not exist certificate")
+		return
+	}
 }
 
 func TestTCPAdapter(t *testing.T) {
 	t.Parallel()
 
 }
+
+func TestTLSAdapter(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	certFile1, keyFile1, pin1 := testNewCertificate(t, tempDir, "node1")
+	certFile2, keyFile2, pin2 := testNewCertificate(t, tempDir, "node2")
+	certFile3, keyFile3, _ := testNewCertificate(t, tempDir, "node3")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// mutual TLS: the server accepts only the client with the pinned certificate
+	adapter1 := testNewTLSAdapter(testutils.TgAddrs[45], nil, NewTLSSettings(&STLSSettings{
+		FCertFile:   certFile1,
+		FKeyFile:    keyFile1,
+		FClientAuth: true,
+		FPins:       map[string]string{"client": pin2},
+	}))
+	adapter2 := testNewTLSAdapter("", []string{testutils.TgAddrs[45]}, NewTLSSettings(&STLSSettings{
+		FCertFile: certFile2,
+		FKeyFile:  keyFile2,
+		FPins:     map[string]string{testutils.TgAddrs[45]: pin1},
+	}))
+
+	go func() { _ = adapter1.Run(ctx) }()
+	time.Sleep(200 * time.Millisecond)
+	go func() { _ = adapter2.Run(ctx) }()
+
+	for len(adapter1.GetConnKeeper().GetNetworkNode().GetConnections()) == 0 {
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	msg := testNewMessage([]byte("hello, world!"))
+	if err := adapter2.Produce(ctx, msg); err != nil {
+		t.Error(err)
+		return
+	}
+	recvMsg, err := adapter1.Consume(ctx)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if recvMsg.ToString() != msg.ToString() {
+		t.Error("got invalid message")
+		return
+	}
+
+	// the client without the pinned certificate is rejected by the server
+	node3 := newTLSNode(
+		adapter2.GetConnKeeper().GetNetworkNode().GetSettings(),
+		NewTLSSettings(&STLSSettings{FCertFile: certFile3, FKeyFile: keyFile3}),
+		cache.NewLRUCache(1<<10),
+	).(*sTLSNode)
+	tlsConfig3, err := loadTLSConfig(node3.fTLSSettings)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	node3.setTLSConfig(tlsConfig3)
+	if err := node3.AddConnection(ctx, testutils.TgAddrs[45]); err == nil {
+		time.Sleep(200 * time.Millisecond)
+		if _, ok := node3.getConnection(testutils.TgAddrs[45]); ok {
+			t.Error("success connect without pinned certificate")
+			return
+		}
+	}
+	if len(adapter1.GetConnKeeper().GetNetworkNode().GetConnections()) != 1 {
+		t.Error("server accepted connection without pinned certificate")
+		return
+	}
+
+	// the server with other certificate is rejected by the pin of client
+	node4 := newTLSNode(
+		adapter2.GetConnKeeper().GetNetworkNode().GetSettings(),
+		NewTLSSettings(&STLSSettings{
+			FCertFile: certFile2,
+			FKeyFile:  keyFile2,
+			FPins:     map[string]string{testutils.TgAddrs[45]: pin2},
+		}),
+		cache.NewLRUCache(1<<10),
+	).(*sTLSNode)
+	if err := node4.AddConnection(ctx, testutils.TgAddrs[45]); err == nil {
+		t.Error("success connect without loaded tls")
+		return
+	}
+	tlsConfig4, err := loadTLSConfig(node4.fTLSSettings)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	node4.setTLSConfig(tlsConfig4)
+	if err := node4.AddConnection(ctx, testutils.TgAddrs[45]); err == nil {
+		t.Error("success connect to server with other certificate")
+		return
+	}
+}
+
+func TestTLSNodeGoPeerVersion(t *testing.T) {
+	t.Parallel()
+
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		t.Error("build info is not found")
+		return
+	}
+	for _, dep := range info.Deps {
+		if dep.Path != "github.com/number571/go-peer" {
+			continue
+		}
+		// sTLSNode is the copy of the node from go-peer
+		if dep.Version != cGoPeerForkVersion {
+			t.Errorf("go-peer is updated to %s, check the copy of node in node.go", dep.Version)
+		}
+		return
+	}
+	t.Error("go-peer is not found in build info")
+}
+
+func TestTLSNodeSlots(t *testing.T) {
+	t.Parallel()
+
+	node := newTLSNode(
+		network.NewSettings(&network.SSettings{
+			FMaxConnects: 1,
+			FConnSettings: conn.NewSettings(&conn.SSettings{
+				FMessageSettings:       adapters.NewSettings(nil),
+				FLimitMessageSizeBytes: 1,
+				FWaitReadTimeout:       time.Second,
+				FDialTimeout:           time.Second,
+				FReadTimeout:           time.Second,
+				FWriteTimeout:          time.Second,
+			}),
+			FReadTimeout:  time.Second,
+			FWriteTimeout: time.Second,
+		}),
+		nil,
+		cache.NewLRUCache(1<<10),
+	).(*sTLSNode)
+
+	// connections in the handshake are counted by the limit
+	if !node.reserveConnSlot() {
+		t.Error("failed reserve slot")
+		return
+	}
+	if node.reserveConnSlot() {
+		t.Error("success reserve slot over the limit")
+		return
+	}
+
+	node.releaseConnSlot()
+	if !node.reserveConnSlot() {
+		t.Error("failed reserve released slot")
+		return
+	}
+
+	node.setConnection("127.0.0.1:9999", nil)
+	if node.fReserved != 0 || len(node.GetConnections()) != 1 {
+		t.Error("reserved slot is not replaced by connection")
+		return
+	}
+	if node.reserveConnSlot() {
+		t.Error("success reserve slot over the limit of connections")
+		return
+	}
+}
+
+func testNewCertificate(t *testing.T, pDir, pName string) (string, string, string) {
+	certPEM, keyPEM, err := NewSelfSignedCertificate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fingerprint, err := GetCertFingerprint(certPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certFile := filepath.Join(pDir, pName+".crt")
+	keyFile := filepath.Join(pDir, pName+".key")
+	if err := os.WriteFile(certFile, certPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, keyPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile, fingerprint
+}
+
+func testNewMessage(pData []byte) layer1.IMessage {
+	return layer1.NewMessage(
+		layer1.NewConstructSettings(&layer1.SConstructSettings{
+			FSettings: adapters.NewSettings(nil),
+		}),
+		payload.NewPayload32(build.GSettings.FProtoMask.FNetwork, pData),
+	)
+}
+
+func testNewTLSAdapter(pAddress string, pConnections []string, pTLSSettings ITLSSettings) ITCPAdapter {
+	return NewTCPAdapter(
+		NewSettings(&SSettings{
+			FAddress:         pAddress,
+			FAdapterSettings: adapters.NewSettings(nil),
+			FTLSSettings:     pTLSSettings,
+		}),
+		cache.NewLRUCache(1<<10),
+		func() []string { return pConnections },
+	)
+}
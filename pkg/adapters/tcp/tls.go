@@ -0,0 +1,199 @@
+package tcp
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/number571/go-peer/pkg/encoding"
+)
+
+const (
+	cCertValidity = 10 * 365 * 24 * time.Hour
+)
+
+type sTLSConfig struct {
+	fSettings ITLSSettings
+	fCert     tls.Certificate
+	fRoots    *x509.CertPool
+}
+
+// NewSelfSignedCertificate generates the certificate and the private key
+// in the PEM format. The certificate has no names, so it can be verified
+// only by the pin (fingerprint) on the side of peer.
+func NewSelfSignedCertificate() ([]byte, []byte, error) {
+	privKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, errors.Join(ErrGenerateCert, err)
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, errors.Join(ErrGenerateCert, err)
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(cCertValidity),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+	}
+
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &privKey.PublicKey, privKey)
+	if err != nil {
+		return nil, nil, errors.Join(ErrGenerateCert, err)
+	}
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(privKey)
+	if err != nil {
+		return nil, nil, errors.Join(ErrGenerateCert, err)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+	return certPEM, keyPEM, nil
+}
+
+// GetCertFingerprint returns the pin of certificate in the PEM format
+// which can be used in the settings of peers.
+func GetCertFingerprint(pCertPEM []byte) (string, error) {
+	block, _ := pem.Decode(pCertPEM)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return "", ErrDecodeCert
+	}
+	return getFingerprint(block.Bytes), nil
+}
+
+func loadTLSConfig(pSettings ITLSSettings) (*sTLSConfig, error) {
+	cert, err := tls.LoadX509KeyPair(pSettings.GetCertFile(), pSettings.GetKeyFile())
+	if err != nil {
+		return nil, errors.Join(ErrLoadCertificate, err)
+	}
+
+	var roots *x509.CertPool
+	if caFile := pSettings.GetCAFile(); caFile != "" {
+		caPEM, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, errors.Join(ErrLoadCA, err)
+		}
+		roots = x509.NewCertPool()
+		if ok := roots.AppendCertsFromPEM(caPEM); !ok {
+			return nil, ErrLoadCA
+		}
+	}
+
+	return &sTLSConfig{
+		fSettings: pSettings,
+		fCert:     cert,
+		fRoots:    roots,
+	}, nil
+}
+
+func (p *sTLSConfig) getServerConfig() *tls.Config {
+	clientAuth := tls.NoClientCert
+	if p.fSettings.GetClientAuth() {
+		clientAuth = tls.RequireAnyClientCert
+	}
+	return &tls.Config{
+		MinVersion:   tls.VersionTLS13,
+		Certificates: []tls.Certificate{p.fCert},
+		ClientAuth:   clientAuth,
+		VerifyPeerCertificate: func(pRawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if !p.fSettings.GetClientAuth() {
+				return nil
+			}
+			return p.verifyClient(pRawCerts)
+		},
+	}
+}
+
+func (p *sTLSConfig) getClientConfig(pAddress string) *tls.Config {
+	return &tls.Config{
+		MinVersion:   tls.VersionTLS13,
+		Certificates: []tls.Certificate{p.fCert},
+		// peers are set by the addresses without names, so the certificate
+		// of peer is verified by the pin or by the CA in VerifyPeerCertificate
+		InsecureSkipVerify: true, // nolint: gosec
+		VerifyPeerCertificate: func(pRawCerts [][]byte, _ [][]*x509.Certificate) error {
+			return p.verifyServer(pAddress, pRawCerts)
+		},
+	}
+}
+
+// verifyServer checks the certificate of peer by the pin of address,
+// or by the CA if the address has no pin.
+func (p *sTLSConfig) verifyServer(pAddress string, pRawCerts [][]byte) error {
+	if len(pRawCerts) == 0 {
+		return ErrPeerCertificate
+	}
+	if pin, ok := p.fSettings.GetPins()[pAddress]; ok {
+		if getFingerprint(pRawCerts[0]) != pin {
+			return ErrPinMismatch
+		}
+		return nil
+	}
+	if p.fRoots == nil {
+		return nil
+	}
+	return p.verifyChain(pRawCerts)
+}
+
+// verifyClient accepts the client if its certificate is one of the pins
+// or if it is signed by the CA.
+func (p *sTLSConfig) verifyClient(pRawCerts [][]byte) error {
+	if len(pRawCerts) == 0 {
+		return ErrPeerCertificate
+	}
+	fingerprint := getFingerprint(pRawCerts[0])
+	for _, pin := range p.fSettings.GetPins() {
+		if fingerprint == pin {
+			return nil
+		}
+	}
+	if p.fRoots == nil {
+		return ErrPinMismatch
+	}
+	return p.verifyChain(pRawCerts)
+}
+
+func (p *sTLSConfig) verifyChain(pRawCerts [][]byte) error {
+	certs := make([]*x509.Certificate, 0, len(pRawCerts))
+	for _, raw := range pRawCerts {
+		cert, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return errors.Join(ErrPeerCertificate, err)
+		}
+		certs = append(certs, cert)
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	_, err := certs[0].Verify(x509.VerifyOptions{
+		Roots:         p.fRoots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return errors.Join(ErrPeerCertificate, err)
+	}
+	return nil
+}
+
+func getFingerprint(pCertDER []byte) string {
+	hash := sha256.Sum256(pCertDER)
+	return encoding.HexEncode(hash[:])
+}
@@ -0,0 +1,69 @@
+package tcp
+
+import (
+	"strings"
+)
+
+var (
+	_ ITLSSettings = &sTLSSettings{}
+)
+
+type STLSSettings sTLSSettings
+type sTLSSettings struct {
+	FCertFile   string
+	FKeyFile    string
+	FCAFile     string
+	FClientAuth bool
+	FPins       map[string]string
+}
+
+// NewTLSSettings creates the settings of TLS for the listener and the dialer.
+// Certificates of peers are checked by the pins (address -> SHA-256 of
+// the certificate in hex) and by the CA. If neither is set, any certificate
+// of the peer is accepted, so TLS only hides the traffic from observers.
+func NewTLSSettings(pSett *STLSSettings) ITLSSettings {
+	if pSett == nil {
+		pSett = &STLSSettings{}
+	}
+	pins := make(map[string]string, len(pSett.FPins))
+	for addr, pin := range pSett.FPins {
+		pins[addr] = strings.ToLower(pin)
+	}
+	return (&sTLSSettings{
+		FCertFile:   pSett.FCertFile,
+		FKeyFile:    pSett.FKeyFile,
+		FCAFile:     pSett.FCAFile,
+		FClientAuth: pSett.FClientAuth,
+		FPins:       pins,
+	}).mustValid()
+}
+
+func (p *sTLSSettings) mustValid() *sTLSSettings {
+	if p.FCertFile == "" || p.FKeyFile == "" {
+		panic(`p.FCertFile == "" || p.FKeyFile == ""`)
+	}
+	if p.FClientAuth && p.FCAFile == "" && len(p.FPins) == 0 {
+		panic(`p.FClientAuth && p.FCAFile == "" && len(p.FPins) == 0`)
+	}
+	return p
+}
+
+func (p *sTLSSettings) GetCertFile() string {
+	return p.FCertFile
+}
+
+func (p *sTLSSettings) GetKeyFile() string {
+	return p.FKeyFile
+}
+
+func (p *sTLSSettings) GetCAFile() string {
+	return p.FCAFile
+}
+
+func (p *sTLSSettings) GetClientAuth() bool {
+	return p.FClientAuth
+}
+
+func (p *sTLSSettings) GetPins() map[string]string {
+	return p.FPins
+}
@@ -17,4 +17,13 @@ type ITCPAdapter interface {
 type ISettings interface {
 	GetAdapterSettings() adapters.ISettings
 	GetAddress() string
+	GetTLSSettings() ITLSSettings
+}
+
+type ITLSSettings interface {
+	GetCertFile() string
+	GetKeyFile() string
+	GetCAFile() string
+	GetClientAuth() bool
+	GetPins() map[string]string
 }
@@ -0,0 +1,529 @@
+package udp
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/number571/go-peer/pkg/logger"
+	"github.com/number571/go-peer/pkg/message/layer1"
+	"github.com/number571/go-peer/pkg/storage/cache"
+	"github.com/number571/hidden-lake/build"
+	"github.com/number571/hidden-lake/internal/utils/name"
+
+	anon_logger "github.com/number571/go-peer/pkg/anonymity/logger"
+	internal_anon_logger "github.com/number571/hidden-lake/internal/utils/logger/anon"
+)
+
+const (
+	netMessageChanSize = 32
+	doneCacheSize      = 1024
+
+	// the limit of messages which are reassembled at the same time from
+	// one address, so one source can not displace messages of others
+	maxSourceAssembliesSize = 64
+)
+
+const (
+	CServiceScheme = "udp"
+)
+
+var (
+	_ IUDPAdapter = &sUDPAdapter{}
+	_ IStats      = &sStats{}
+)
+
+type sUDPAdapter struct {
+	fSettings    ISettings
+	fCache       cache.ICache
+	fDone        cache.ICache
+	fConnsGetter func() []string
+	fNetMsgChan  chan layer1.IMessage
+
+	fMutex      sync.Mutex
+	fConn       *net.UDPConn
+	fPeers      map[string]struct{}
+	fAssemblies map[sAssemblyKey]*sAssembly
+	fSources    map[string]uint64
+
+	fSentDatagrams atomic.Uint64
+	fRecvDatagrams atomic.Uint64
+	fSentMessages  atomic.Uint64
+	fRecvMessages  atomic.Uint64
+	fLostMessages  atomic.Uint64
+
+	fShortName string
+	fLogger    logger.ILogger
+}
+
+// sAssemblyKey separates the frames of the same ID from different
+// addresses, so forged frames do not break the message of others.
+type sAssemblyKey struct {
+	fAddress string
+	fID      [cFrameIDSize]byte
+}
+
+type sAssembly struct {
+	fCreated time.Time
+	fAddress string
+	fTotal   uint32
+	fRecv    uint16
+	fParts   [][]byte
+}
+
+type sStats struct {
+	fSentDatagrams uint64
+	fRecvDatagrams uint64
+	fSentMessages  uint64
+	fRecvMessages  uint64
+	fLostMessages  uint64
+}
+
+// NewUDPAdapter creates the adapter which sends each message to all peers
+// as the sequence of datagrams of fixed size. Datagrams do not wait for
+// each other (no head-of-line blocking), but the loss of one datagram is
+// the loss of the whole message. Lost messages are detected by the timeout
+// of reassembly and are logged.
+func NewUDPAdapter(
+	pSettings ISettings,
+	pCache cache.ICache,
+	pConnsGetter func() []string,
+) IUDPAdapter {
+	return &sUDPAdapter{
+		fSettings:    pSettings,
+		fCache:       pCache,
+		fDone:        cache.NewLRUCache(doneCacheSize),
+		fConnsGetter: pConnsGetter,
+		fNetMsgChan:  make(chan layer1.IMessage, netMessageChanSize),
+		fPeers:       make(map[string]struct{}, 64),
+		fAssemblies:  make(map[sAssemblyKey]*sAssembly, 64),
+		fSources:     make(map[string]uint64, 64),
+		fLogger: logger.NewLogger(
+			logger.NewSettings(&logger.SSettings{}),
+			func(_ logger.ILogArg) string { return "" },
+		),
+	}
+}
+
+func (p *sUDPAdapter) WithLogger(pName name.IServiceName, pLogger logger.ILogger) IUDPAdapter {
+	p.fShortName = pName.Short()
+	p.fLogger = pLogger
+	return p
+}
+
+func (p *sUDPAdapter) GetSettings() ISettings {
+	return p.fSettings
+}
+
+func (p *sUDPAdapter) GetStats() IStats {
+	return &sStats{
+		fSentDatagrams: p.fSentDatagrams.Load(),
+		fRecvDatagrams: p.fRecvDatagrams.Load(),
+		fSentMessages:  p.fSentMessages.Load(),
+		fRecvMessages:  p.fRecvMessages.Load(),
+		fLostMessages:  p.fLostMessages.Load(),
+	}
+}
+
+func (p *sUDPAdapter) Run(pCtx context.Context) error {
+	conn, err := p.listen()
+	if err != nil {
+		return errors.Join(ErrRunning, err)
+	}
+	defer p.close()
+
+	chCtx, cancel := context.WithCancel(pCtx)
+	defer cancel()
+
+	go func() {
+		<-chCtx.Done()
+		_ = conn.Close()
+	}()
+
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+
+	go func() {
+		defer func() { wg.Done(); cancel() }()
+		p.runLossDetector(chCtx)
+	}()
+
+	errRead := p.runReader(chCtx, conn)
+	cancel()
+	wg.Wait()
+
+	select {
+	case <-pCtx.Done():
+		return pCtx.Err()
+	default:
+		return errors.Join(ErrRunning, errRead)
+	}
+}
+
+func (p *sUDPAdapter) Produce(pCtx context.Context, pNetMsg layer1.IMessage) error {
+	logBuilder := anon_logger.NewLogBuilder(p.fShortName)
+	logBuilder.
+		WithType(internal_anon_logger.CLogBaseSendNetworkMessage).
+		WithHash(pNetMsg.GetHash()).
+		WithProof(pNetMsg.GetProof()).
+		WithSize(len(pNetMsg.ToBytes())).
+		WithConn(CServiceScheme)
+
+	if err := p.broadcastMessage(pCtx, pNetMsg); err != nil {
+		if errors.Is(err, ErrNoConnections) {
+			p.fLogger.PushWarn(logBuilder.WithType(internal_anon_logger.CLogWarnNoConnections))
+		} else {
+			p.fLogger.PushInfo(logBuilder)
+		}
+		return errors.Join(ErrBroadcast, err)
+	}
+	p.fLogger.PushInfo(logBuilder)
+	return nil
+}
+
+func (p *sUDPAdapter) Consume(pCtx context.Context) (layer1.IMessage, error) {
+	select {
+	case <-pCtx.Done():
+		return nil, pCtx.Err()
+	case msg := <-p.fNetMsgChan:
+		return msg, nil
+	}
+}
+
+// GetConnections returns the addresses from the config and the addresses
+// added by AddConnection. Senders of messages do not become peers, because
+// the source address of datagram can be forged.
+func (p *sUDPAdapter) GetConnections() []string {
+	mapConns := make(map[string]struct{}, 64)
+	for _, addr := range p.fConnsGetter() {
+		mapConns[addr] = struct{}{}
+	}
+
+	p.fMutex.Lock()
+	for addr := range p.fPeers {
+		mapConns[addr] = struct{}{}
+	}
+	p.fMutex.Unlock()
+
+	result := make([]string, 0, len(mapConns))
+	for addr := range mapConns {
+		result = append(result, addr)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// AddConnection checks the address and adds it to the list of peers.
+// There is no handshake because UDP has no connections.
+func (p *sUDPAdapter) AddConnection(_ context.Context, pAddress string) error {
+	if _, err := net.ResolveUDPAddr(CServiceScheme, pAddress); err != nil {
+		return errors.Join(ErrAddConnection, err)
+	}
+
+	p.fMutex.Lock()
+	defer p.fMutex.Unlock()
+
+	p.fPeers[pAddress] = struct{}{}
+	return nil
+}
+
+func (p *sUDPAdapter) DelConnection(pAddress string) error {
+	p.fMutex.Lock()
+	defer p.fMutex.Unlock()
+
+	if _, ok := p.fPeers[pAddress]; !ok {
+		return ErrConnectionIsNotExist
+	}
+	delete(p.fPeers, pAddress)
+	return nil
+}
+
+func (p *sUDPAdapter) listen() (*net.UDPConn, error) {
+	p.fMutex.Lock()
+	defer p.fMutex.Unlock()
+
+	if p.fConn != nil {
+		return nil, ErrRunning
+	}
+
+	// without address the adapter listens the random port, so it
+	// only sends messages to the peers
+	udpAddr := &net.UDPAddr{}
+	if address := p.fSettings.GetAddress(); address != "" {
+		var err error
+		udpAddr, err = net.ResolveUDPAddr(CServiceScheme, address)
+		if err != nil {
+			return nil, errors.Join(ErrCreateListener, err)
+		}
+	}
+
+	conn, err := net.ListenUDP(CServiceScheme, udpAddr)
+	if err != nil {
+		return nil, errors.Join(ErrCreateListener, err)
+	}
+
+	p.fConn = conn
+	return conn, nil
+}
+
+func (p *sUDPAdapter) close() {
+	p.fMutex.Lock()
+	defer p.fMutex.Unlock()
+
+	_ = p.fConn.Close()
+	p.fConn = nil
+	p.fAssemblies = make(map[sAssemblyKey]*sAssembly, 64)
+	p.fSources = make(map[string]uint64, 64)
+}
+
+func (p *sUDPAdapter) getConn() *net.UDPConn {
+	p.fMutex.Lock()
+	defer p.fMutex.Unlock()
+
+	return p.fConn
+}
+
+func (p *sUDPAdapter) broadcastMessage(pCtx context.Context, pNetMsg layer1.IMessage) error {
+	conn := p.getConn()
+	if conn == nil {
+		return ErrNotRunning
+	}
+
+	peers := p.GetConnections()
+	if len(peers) == 0 {
+		return ErrNoConnections
+	}
+
+	// node can redirect received message
+	_ = p.fCache.Set(pNetMsg.GetHash(), []byte{})
+
+	id := getFrameID(pNetMsg.GetHash())
+	_ = p.fDone.Set(id[:], []byte{})
+
+	datagrams, err := splitMessage(id, pNetMsg.ToBytes(), p.fSettings.GetDatagramSizeBytes())
+	if err != nil {
+		return err
+	}
+
+	listErr := make([]error, 0, len(peers))
+	for _, addr := range peers {
+		if err := pCtx.Err(); err != nil {
+			return err
+		}
+		udpAddr, err := net.ResolveUDPAddr(CServiceScheme, addr)
+		if err != nil {
+			listErr = append(listErr, errors.Join(ErrSendDatagram, err))
+			continue
+		}
+		for _, datagram := range datagrams {
+			if _, err := conn.WriteToUDP(datagram, udpAddr); err != nil {
+				listErr = append(listErr, errors.Join(ErrSendDatagram, err))
+				break
+			}
+			p.fSentDatagrams.Add(1)
+		}
+	}
+
+	p.fSentMessages.Add(1)
+	return errors.Join(listErr...)
+}
+
+func (p *sUDPAdapter) runReader(pCtx context.Context, pConn *net.UDPConn) error {
+	buffer := make([]byte, p.fSettings.GetDatagramSizeBytes()+1)
+	for {
+		n, udpAddr, err := pConn.ReadFromUDP(buffer)
+		if err != nil {
+			if pCtx.Err() != nil {
+				return pCtx.Err()
+			}
+			return err
+		}
+		p.fRecvDatagrams.Add(1)
+
+		// datagrams with other size are not the frames of this adapter
+		if uint64(n) != p.fSettings.GetDatagramSizeBytes() {
+			continue
+		}
+
+		datagram := make([]byte, n)
+		copy(datagram, buffer[:n])
+
+		msg, ok := p.handleDatagram(udpAddr.String(), datagram)
+		if !ok {
+			continue
+		}
+
+		select {
+		case <-pCtx.Done():
+			return pCtx.Err()
+		case p.fNetMsgChan <- msg:
+		}
+	}
+}
+
+func (p *sUDPAdapter) handleDatagram(pAddress string, pDatagram []byte) (layer1.IMessage, bool) {
+	adapterSettings := p.fSettings.GetAdapterSettings()
+	fullMsgSize := adapterSettings.GetMessageSizeBytes() + layer1.CMessageHeadSize
+
+	frame, err := parseFrame(pDatagram, fullMsgSize)
+	if err != nil {
+		return nil, false
+	}
+
+	if _, ok := p.fDone.Get(frame.fID[:]); ok {
+		return nil, false // message already received or sent
+	}
+
+	msgBytes, ok := p.assembleFrame(pAddress, frame)
+	if !ok {
+		return nil, false
+	}
+
+	msg, err := layer1.LoadMessage(adapterSettings, msgBytes)
+	if err != nil {
+		return nil, false
+	}
+	if msg.GetPayload().GetHead() != build.GSettings.FProtoMask.FNetwork {
+		return nil, false
+	}
+
+	// the ID is done only by the valid message, otherwise the forged
+	// frames with the ID would drop the message from other addresses
+	_ = p.fDone.Set(frame.fID[:], []byte{})
+
+	if !p.fCache.Set(msg.GetHash(), []byte{}) {
+		return nil, false // hash of message already in queue
+	}
+
+	p.fRecvMessages.Add(1)
+	p.fLogger.PushInfo(anon_logger.NewLogBuilder(p.fShortName).
+		WithType(internal_anon_logger.CLogInfoRecvNetworkMessage).
+		WithHash(msg.GetHash()).
+		WithProof(msg.GetProof()).
+		WithSize(len(msg.ToBytes())).
+		WithConn(pAddress))
+
+	return msg, true
+}
+
+// assembleFrame saves the frame and returns the message bytes
+// if all frames of the message have been received.
+func (p *sUDPAdapter) assembleFrame(pAddress string, pFrame *sFrame) ([]byte, bool) {
+	p.fMutex.Lock()
+	defer p.fMutex.Unlock()
+
+	key := sAssemblyKey{fAddress: pAddress, fID: pFrame.fID}
+	assembly, ok := p.fAssemblies[key]
+	if !ok {
+		if p.fSources[pAddress] >= maxSourceAssembliesSize {
+			return nil, false
+		}
+		assembly = &sAssembly{
+			fCreated: time.Now(),
+			fAddress: pAddress,
+			fTotal:   pFrame.fTotal,
+			fParts:   make([][]byte, pFrame.fCount),
+		}
+		p.fAssemblies[key] = assembly
+		p.fSources[pAddress]++
+	}
+
+	if assembly.fTotal != pFrame.fTotal || len(assembly.fParts) != int(pFrame.fCount) {
+		return nil, false
+	}
+	if assembly.fParts[pFrame.fIndex] != nil {
+		return nil, false // duplicate of frame
+	}
+
+	assembly.fParts[pFrame.fIndex] = pFrame.fData
+	assembly.fRecv++
+	if int(assembly.fRecv) != len(assembly.fParts) {
+		return nil, false
+	}
+
+	p.deleteAssembly(key)
+
+	msgBytes := make([]byte, 0, len(assembly.fParts)*len(pFrame.fData))
+	for _, part := range assembly.fParts {
+		msgBytes = append(msgBytes, part...)
+	}
+	return msgBytes[:assembly.fTotal], true
+}
+
+func (p *sUDPAdapter) runLossDetector(pCtx context.Context) {
+	ticker := time.NewTicker(p.fSettings.GetReassemblyTimeout() / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-pCtx.Done():
+			return
+		case <-ticker.C:
+			p.removeLostMessages()
+		}
+	}
+}
+
+func (p *sUDPAdapter) removeLostMessages() {
+	p.fMutex.Lock()
+	lost := make(map[sAssemblyKey]*sAssembly, 8)
+	for key, assembly := range p.fAssemblies {
+		if time.Since(assembly.fCreated) < p.fSettings.GetReassemblyTimeout() {
+			continue
+		}
+		lost[key] = assembly
+		p.deleteAssembly(key)
+	}
+	p.fMutex.Unlock()
+
+	for key, assembly := range lost {
+		if _, ok := p.fDone.Get(key.fID[:]); ok {
+			continue // message was received from other address
+		}
+		p.fLostMessages.Add(1)
+		p.fLogger.PushWarn(anon_logger.NewLogBuilder(p.fShortName).
+			WithType(internal_anon_logger.CLogWarnDatagramsLost).
+			WithHash(key.fID[:]).
+			WithSize(int(assembly.fTotal)).
+			WithConn(assembly.fAddress))
+	}
+}
+
+// deleteAssembly must be called under the mutex.
+func (p *sUDPAdapter) deleteAssembly(pKey sAssemblyKey) {
+	delete(p.fAssemblies, pKey)
+	p.fSources[pKey.fAddress]--
+	if p.fSources[pKey.fAddress] == 0 {
+		delete(p.fSources, pKey.fAddress)
+	}
+}
+
+func getFrameID(pHash []byte) [cFrameIDSize]byte {
+	id := [cFrameIDSize]byte{}
+	copy(id[:], pHash)
+	return id
+}
+
+func (p *sStats) GetSentDatagrams() uint64 {
+	return p.fSentDatagrams
+}
+
+func (p *sStats) GetRecvDatagrams() uint64 {
+	return p.fRecvDatagrams
+}
+
+func (p *sStats) GetSentMessages() uint64 {
+	return p.fSentMessages
+}
+
+func (p *sStats) GetRecvMessages() uint64 {
+	return p.fRecvMessages
+}
+
+func (p *sStats) GetLostMessages() uint64 {
+	return p.fLostMessages
+}
@@ -0,0 +1,26 @@
+package udp
+
+const (
+	errPrefix = "pkg/adapters/udp = "
+)
+
+type SAppError struct {
+	str string
+}
+
+func (err *SAppError) Error() string {
+	return errPrefix + err.str
+}
+
+var (
+	ErrRunning              = &SAppError{"adapter running"}
+	ErrNotRunning           = &SAppError{"adapter not running"}
+	ErrBroadcast            = &SAppError{"broadcast message"}
+	ErrNoConnections        = &SAppError{"no connections"}
+	ErrConnectionIsNotExist = &SAppError{"connection is not exist"}
+	ErrAddConnection        = &SAppError{"add connection"}
+	ErrSendDatagram         = &SAppError{"send datagram"}
+	ErrCreateListener       = &SAppError{"create listener"}
+	ErrInvalidFrame         = &SAppError{"invalid frame"}
+	ErrLargeMessage         = &SAppError{"large message"}
+)
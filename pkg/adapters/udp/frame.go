@@ -0,0 +1,79 @@
+package udp
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/number571/go-peer/pkg/message/layer1"
+)
+
+const (
+	// ID(8) + Total(4) + Index(2) + Count(2)
+	cFrameHeadSize = 16
+	cFrameIDSize   = 8
+)
+
+type sFrame struct {
+	fID    [cFrameIDSize]byte
+	fTotal uint32
+	fIndex uint16
+	fCount uint16
+	fData  []byte
+}
+
+// GetDatagramsPerMessage returns the count of datagrams which are sent
+// to each peer for one message of the full size.
+func GetDatagramsPerMessage(pSettings ISettings) uint64 {
+	msgSize := pSettings.GetAdapterSettings().GetMessageSizeBytes() + layer1.CMessageHeadSize
+	dataSize := pSettings.GetDatagramSizeBytes() - cFrameHeadSize
+	return (msgSize + dataSize - 1) / dataSize
+}
+
+// splitMessage splits the message bytes into the datagrams of fixed size.
+// The last datagram is padded by zeros, so all datagrams are the same.
+func splitMessage(pID [cFrameIDSize]byte, pMsgBytes []byte, pDatagramSize uint64) ([][]byte, error) {
+	dataSize := pDatagramSize - cFrameHeadSize
+	count := (uint64(len(pMsgBytes)) + dataSize - 1) / dataSize
+	if count > math.MaxUint16 || uint64(len(pMsgBytes)) > math.MaxUint32 {
+		return nil, ErrLargeMessage
+	}
+
+	result := make([][]byte, 0, count)
+	for i := uint64(0); i < count; i++ {
+		datagram := make([]byte, pDatagramSize)
+		copy(datagram[:cFrameIDSize], pID[:])
+		binary.BigEndian.PutUint32(datagram[8:12], uint32(len(pMsgBytes))) // nolint: gosec
+		binary.BigEndian.PutUint16(datagram[12:14], uint16(i))             // nolint: gosec
+		binary.BigEndian.PutUint16(datagram[14:16], uint16(count))         // nolint: gosec
+
+		end := min((i+1)*dataSize, uint64(len(pMsgBytes)))
+		copy(datagram[cFrameHeadSize:], pMsgBytes[i*dataSize:end])
+		result = append(result, datagram)
+	}
+	return result, nil
+}
+
+func parseFrame(pDatagram []byte, pMaxTotal uint64) (*sFrame, error) {
+	if len(pDatagram) <= cFrameHeadSize {
+		return nil, ErrInvalidFrame
+	}
+
+	frame := &sFrame{
+		fTotal: binary.BigEndian.Uint32(pDatagram[8:12]),
+		fIndex: binary.BigEndian.Uint16(pDatagram[12:14]),
+		fCount: binary.BigEndian.Uint16(pDatagram[14:16]),
+		fData:  pDatagram[cFrameHeadSize:],
+	}
+	copy(frame.fID[:], pDatagram[:cFrameIDSize])
+
+	dataSize := uint64(len(frame.fData))
+	expCount := (uint64(frame.fTotal) + dataSize - 1) / dataSize
+
+	switch {
+	case frame.fTotal == 0, uint64(frame.fTotal) > pMaxTotal:
+		return nil, ErrInvalidFrame
+	case uint64(frame.fCount) != expCount, frame.fIndex >= frame.fCount:
+		return nil, ErrInvalidFrame
+	}
+	return frame, nil
+}
@@ -0,0 +1,70 @@
+package udp
+
+import (
+	"time"
+
+	"github.com/number571/hidden-lake/pkg/adapters"
+)
+
+const (
+	// fits into the minimal MTU of IPv6 (1280 bytes) with IP and UDP headers
+	CDefaultDatagramSizeBytes = 1200
+	CDefaultReassemblyTimeout = 5 * time.Second
+)
+
+var (
+	_ ISettings = &sSettings{}
+)
+
+type SSettings sSettings
+type sSettings struct {
+	FAddress           string
+	FAdapterSettings   adapters.ISettings
+	FDatagramSizeBytes uint64
+	FReassemblyTimeout time.Duration
+}
+
+func NewSettings(pSett *SSettings) ISettings {
+	if pSett == nil {
+		pSett = &SSettings{}
+	}
+	return (&sSettings{
+		FAddress:           pSett.FAddress,
+		FAdapterSettings:   pSett.FAdapterSettings,
+		FDatagramSizeBytes: pSett.FDatagramSizeBytes,
+		FReassemblyTimeout: pSett.FReassemblyTimeout,
+	}).useDefault().mustValid()
+}
+
+func (p *sSettings) useDefault() *sSettings {
+	if p.FDatagramSizeBytes == 0 {
+		p.FDatagramSizeBytes = CDefaultDatagramSizeBytes
+	}
+	if p.FReassemblyTimeout == 0 {
+		p.FReassemblyTimeout = CDefaultReassemblyTimeout
+	}
+	return p
+}
+
+func (p *sSettings) mustValid() *sSettings {
+	if p.FDatagramSizeBytes <= cFrameHeadSize {
+		panic("p.FDatagramSizeBytes <= cFrameHeadSize")
+	}
+	return p
+}
+
+func (p *sSettings) GetAddress() string {
+	return p.FAddress
+}
+
+func (p *sSettings) GetAdapterSettings() adapters.ISettings {
+	return p.FAdapterSettings
+}
+
+func (p *sSettings) GetDatagramSizeBytes() uint64 {
+	return p.FDatagramSizeBytes
+}
+
+func (p *sSettings) GetReassemblyTimeout() time.Duration {
+	return p.FReassemblyTimeout
+}
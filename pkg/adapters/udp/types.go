@@ -0,0 +1,37 @@
+package udp
+
+import (
+	"context"
+	"time"
+
+	"github.com/number571/go-peer/pkg/logger"
+	"github.com/number571/hidden-lake/internal/utils/name"
+	"github.com/number571/hidden-lake/pkg/adapters"
+)
+
+type IUDPAdapter interface {
+	adapters.IRunnerAdapter
+
+	WithLogger(name.IServiceName, logger.ILogger) IUDPAdapter
+	GetSettings() ISettings
+	GetStats() IStats
+
+	GetConnections() []string
+	AddConnection(context.Context, string) error
+	DelConnection(string) error
+}
+
+type IStats interface {
+	GetSentDatagrams() uint64
+	GetRecvDatagrams() uint64
+	GetSentMessages() uint64
+	GetRecvMessages() uint64
+	GetLostMessages() uint64
+}
+
+type ISettings interface {
+	GetAdapterSettings() adapters.ISettings
+	GetAddress() string
+	GetDatagramSizeBytes() uint64
+	GetReassemblyTimeout() time.Duration
+}
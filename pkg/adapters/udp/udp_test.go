@@ -0,0 +1,369 @@
+package udp
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/number571/go-peer/pkg/crypto/random"
+	"github.com/number571/go-peer/pkg/message/layer1"
+	"github.com/number571/go-peer/pkg/payload"
+	"github.com/number571/go-peer/pkg/storage/cache"
+	"github.com/number571/hidden-lake/build"
+	"github.com/number571/hidden-lake/pkg/adapters"
+	testutils "github.com/number571/hidden-lake/test/utils"
+)
+
+func TestError(t *testing.T) {
+	t.Parallel()
+
+	str := "value"
+	err := &SAppError{str}
+	if err.Error() != errPrefix+str {
+		t.Error("incorrect err.Error()")
+		return
+	}
+}
+
+func TestSettings(t *testing.T) {
+	t.Parallel()
+
+	sett := NewSettings(nil)
+	if sett.GetDatagramSizeBytes() != CDefaultDatagramSizeBytes {
+		t.Error("invalid default datagram size")
+		return
+	}
+
+	sett = NewSettings(&SSettings{
+		FAdapterSettings: adapters.NewSettings(&adapters.SSettings{
+			FMessageSizeBytes: 8192,
+		}),
+	})
+	if GetDatagramsPerMessage(sett) != 7 {
+		t.Error("invalid count of datagrams per message")
+		return
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("nothing panics")
+			return
+		}
+	}()
+	_ = NewSettings(&SSettings{FDatagramSizeBytes: cFrameHeadSize})
+}
+
+func TestFrame(t *testing.T) {
+	t.Parallel()
+
+	id := [cFrameIDSize]byte{1, 2, 3}
+	msgBytes := random.NewRandom().GetBytes(1000)
+
+	datagrams, err := splitMessage(id, msgBytes, 116)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(datagrams) != 10 {
+		t.Error("invalid count of datagrams")
+		return
+	}
+
+	result := make([]byte, 0, len(msgBytes))
+	for i, datagram := range datagrams {
+		if len(datagram) != 116 {
+			t.Error("datagram size is not fixed")
+			return
+		}
+		frame, err := parseFrame(datagram, 1000)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if frame.fID != id || int(frame.fIndex) != i || frame.fCount != 10 {
+			t.Error("invalid frame head")
+			return
+		}
+		result = append(result, frame.fData...)
+	}
+	if !bytes.Equal(result[:1000], msgBytes) {
+		t.Error("invalid reassembled message")
+		return
+	}
+
+	if _, err := parseFrame(datagrams[0], 999); err == nil {
+		t.Error("success parse frame with large total size")
+		return
+	}
+	if _, err := parseFrame(datagrams[0][:cFrameHeadSize], 1000); err == nil {
+		t.Error("success parse frame without data")
+		return
+	}
+	if _, err := parseFrame(datagrams[0][:100], 1000); err == nil {
+		t.Error("success parse frame with invalid count")
+		return
+	}
+	if _, err := splitMessage(id, make([]byte, 1<<20), cFrameHeadSize+1); err == nil {
+		t.Error("success split large message")
+		return
+	}
+}
+
+func TestUDPAdapter(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	adapter1 := testNewUDPAdapter(testutils.TgAddrs[40], nil)
+	adapter2 := testNewUDPAdapter(testutils.TgAddrs[48], []string{testutils.TgAddrs[40]})
+
+	msg1 := testNewMessage([]byte("hello, world!"))
+	if err := adapter2.Produce(ctx, msg1); err == nil {
+		t.Error("success produce without running")
+		return
+	}
+
+	go func() { _ = adapter1.Run(ctx) }()
+	go func() { _ = adapter2.Run(ctx) }()
+	time.Sleep(200 * time.Millisecond)
+
+	if err := adapter1.Run(ctx); err == nil {
+		t.Error("success run already running adapter")
+		return
+	}
+	if err := adapter1.Produce(ctx, msg1); err == nil {
+		t.Error("success produce without connections")
+		return
+	}
+	if err := adapter1.AddConnection(ctx, "localhost:abc"); err == nil {
+		t.Error("success add invalid connection")
+		return
+	}
+
+	msgBytes := []byte("hello, world!")
+	msgBytes = append(msgBytes, random.NewRandom().GetBytes(uint64(8192-len(msgBytes)))...)
+	msg2 := testNewMessage(msgBytes)
+	if err := adapter2.Produce(ctx, msg2); err != nil {
+		t.Error(err)
+		return
+	}
+	recvMsg2, err := adapter1.Consume(ctx)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if recvMsg2.ToString() != msg2.ToString() {
+		t.Error("got invalid message (client -> server)")
+		return
+	}
+
+	// the source address of datagram can be forged
+	if len(adapter1.GetConnections()) != 0 {
+		t.Error("sender of message is saved as peer")
+		return
+	}
+
+	// the duplicate is dropped by the cache
+	if err := adapter2.Produce(ctx, msg2); err != nil {
+		t.Error(err)
+		return
+	}
+
+	if err := adapter1.AddConnection(ctx, testutils.TgAddrs[48]); err != nil {
+		t.Error(err)
+		return
+	}
+
+	msg3 := testNewMessage([]byte("hello, client!"))
+	if err := adapter1.Produce(ctx, msg3); err != nil {
+		t.Error(err)
+		return
+	}
+	recvMsg3, err := adapter2.Consume(ctx)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if recvMsg3.ToString() != msg3.ToString() {
+		t.Error("got invalid message (server -> client)")
+		return
+	}
+
+	chCtx, chCancel := context.WithTimeout(ctx, 200*time.Millisecond)
+	defer chCancel()
+
+	if _, err := adapter1.Consume(chCtx); err == nil {
+		t.Error("success consume duplicate message")
+		return
+	}
+
+	stats := adapter2.GetStats()
+	if stats.GetSentMessages() != 2 || stats.GetRecvMessages() != 1 {
+		t.Error("invalid stats of messages")
+		return
+	}
+	if stats.GetSentDatagrams() <= stats.GetSentMessages() {
+		t.Error("message is not splitted into datagrams")
+		return
+	}
+
+	if err := adapter1.DelConnection(testutils.TgAddrs[48]); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := adapter1.DelConnection(testutils.TgAddrs[48]); err == nil {
+		t.Error("success delete not exist connection")
+		return
+	}
+}
+
+func TestUDPAdapterLoss(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	adapter := NewUDPAdapter(
+		NewSettings(&SSettings{
+			FAddress: testutils.TgAddrs[41],
+			FAdapterSettings: adapters.NewSettings(&adapters.SSettings{
+				FMessageSizeBytes: 8192,
+			}),
+			FReassemblyTimeout: 200 * time.Millisecond,
+		}),
+		cache.NewLRUCache(1<<10),
+		func() []string { return nil },
+	)
+	go func() { _ = adapter.Run(ctx) }()
+	time.Sleep(200 * time.Millisecond)
+
+	conn, err := net.Dial("udp", testutils.TgAddrs[41])
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer conn.Close()
+
+	msg := testNewMessage(random.NewRandom().GetBytes(8192))
+	datagrams, err := splitMessage(
+		getFrameID(msg.GetHash()),
+		msg.ToBytes(),
+		CDefaultDatagramSizeBytes,
+	)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// the last datagram is lost
+	for _, datagram := range datagrams[:len(datagrams)-1] {
+		if _, err := conn.Write(datagram); err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	chCtx, chCancel := context.WithTimeout(ctx, time.Second)
+	defer chCancel()
+
+	if _, err := adapter.Consume(chCtx); err == nil {
+		t.Error("success consume message with lost datagram")
+		return
+	}
+	if adapter.GetStats().GetLostMessages() != 1 {
+		t.Error("lost message is not detected")
+		return
+	}
+}
+
+func TestUDPAdapterAssemblies(t *testing.T) {
+	t.Parallel()
+
+	adapter := testNewUDPAdapter("", nil).(*sUDPAdapter) // nolint: forcetypeassert
+
+	msg := testNewMessage(random.NewRandom().GetBytes(8192))
+	id := getFrameID(msg.GetHash())
+
+	validDatagrams, err := splitMessage(id, msg.ToBytes(), CDefaultDatagramSizeBytes)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	forgedDatagrams, err := splitMessage(id, random.NewRandom().GetBytes(uint64(len(msg.ToBytes()))), CDefaultDatagramSizeBytes)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// forged frames with the same ID are reassembled separately
+	for i := range forgedDatagrams {
+		if _, ok := adapter.handleDatagram("127.0.0.1:1", forgedDatagrams[i]); ok {
+			t.Error("success handle forged message")
+			return
+		}
+		if i == len(forgedDatagrams)-1 {
+			break
+		}
+		if _, ok := adapter.handleDatagram("127.0.0.1:2", validDatagrams[i]); ok {
+			t.Error("success handle incomplete message")
+			return
+		}
+	}
+	if _, ok := adapter.fDone.Get(id[:]); ok {
+		t.Error("forged message is done")
+		return
+	}
+	recvMsg, ok := adapter.handleDatagram("127.0.0.1:2", validDatagrams[len(validDatagrams)-1])
+	if !ok || recvMsg.ToString() != msg.ToString() {
+		t.Error("valid message is not reassembled")
+		return
+	}
+
+	// one source can not displace messages of others
+	for i := 0; i <= maxSourceAssembliesSize+1; i++ {
+		msg := testNewMessage(random.NewRandom().GetBytes(8192))
+		datagrams, err := splitMessage(getFrameID(msg.GetHash()), msg.ToBytes(), CDefaultDatagramSizeBytes)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		addr := "127.0.0.1:3"
+		if i == maxSourceAssembliesSize+1 {
+			addr = "127.0.0.1:4"
+		}
+		_, _ = adapter.handleDatagram(addr, datagrams[0])
+	}
+	if adapter.fSources["127.0.0.1:3"] != maxSourceAssembliesSize {
+		t.Error("limit of assemblies for source is not applied")
+		return
+	}
+	if adapter.fSources["127.0.0.1:4"] != 1 {
+		t.Error("message of other source is displaced")
+		return
+	}
+}
+
+func testNewMessage(pData []byte) layer1.IMessage {
+	return layer1.NewMessage(
+		layer1.NewConstructSettings(&layer1.SConstructSettings{
+			FSettings: adapters.NewSettings(nil),
+		}),
+		payload.NewPayload32(build.GSettings.FProtoMask.FNetwork, pData),
+	)
+}
+
+func testNewUDPAdapter(pAddress string, pConnections []string) IUDPAdapter {
+	return NewUDPAdapter(
+		NewSettings(&SSettings{
+			FAddress: pAddress,
+			FAdapterSettings: adapters.NewSettings(&adapters.SSettings{
+				FMessageSizeBytes: 8192,
+			}),
+		}),
+		cache.NewLRUCache(1<<10),
+		func() []string { return pConnections },
+	)
+}
@@ -0,0 +1,441 @@
+package ws
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/number571/go-peer/pkg/logger"
+	"github.com/number571/go-peer/pkg/message/layer1"
+	"github.com/number571/go-peer/pkg/storage/cache"
+	"github.com/number571/hidden-lake/build"
+	"github.com/number571/hidden-lake/internal/utils/name"
+	"golang.org/x/net/websocket"
+
+	anon_logger "github.com/number571/go-peer/pkg/anonymity/logger"
+	internal_anon_logger "github.com/number571/hidden-lake/internal/utils/logger/anon"
+)
+
+const (
+	netMessageChanSize = 32
+)
+
+const (
+	CServiceScheme = "ws"
+	CHandlePath    = "/"
+)
+
+var (
+	_ IWSAdapter = &sWSAdapter{}
+)
+
+type sWSAdapter struct {
+	fSettings    ISettings
+	fCache       cache.ICache
+	fConnsGetter func() []string
+	fNetMsgChan  chan layer1.IMessage
+
+	fMutex       sync.RWMutex
+	fConnections map[string]*websocket.Conn
+
+	fShortName string
+	fLogger    logger.ILogger
+}
+
+// NewWSAdapter creates the adapter which carries the messages over
+// WebSocket connections. The connections from the getter are kept
+// in the same way as in the TCP adapter, duplicates of messages are
+// dropped by the cache.
+func NewWSAdapter(
+	pSettings ISettings,
+	pCache cache.ICache,
+	pConnsGetter func() []string,
+) IWSAdapter {
+	return &sWSAdapter{
+		fSettings:    pSettings,
+		fCache:       pCache,
+		fConnsGetter: pConnsGetter,
+		fNetMsgChan:  make(chan layer1.IMessage, netMessageChanSize),
+		fConnections: make(map[string]*websocket.Conn, 64),
+		fLogger: logger.NewLogger(
+			logger.NewSettings(&logger.SSettings{}),
+			func(_ logger.ILogArg) string { return "" },
+		),
+	}
+}
+
+func (p *sWSAdapter) WithLogger(pName name.IServiceName, pLogger logger.ILogger) IWSAdapter {
+	p.fShortName = pName.Short()
+	p.fLogger = pLogger
+	return p
+}
+
+func (p *sWSAdapter) Run(pCtx context.Context) error {
+	chCtx, cancel := context.WithCancel(pCtx)
+	defer cancel()
+
+	// hijacked connections are not closed by the http server
+	defer p.closeConnections()
+
+	const N = 2
+
+	errs := make([]error, N)
+	wg := &sync.WaitGroup{}
+	wg.Add(N)
+
+	go func() {
+		defer func() { wg.Done(); cancel() }()
+		errs[0] = p.runListener(chCtx)
+	}()
+
+	go func() {
+		defer func() { wg.Done(); cancel() }()
+		errs[1] = p.runKeeper(chCtx)
+	}()
+
+	wg.Wait()
+
+	select {
+	case <-pCtx.Done():
+		return pCtx.Err()
+	default:
+		errs := append([]error{ErrRunning}, errs...)
+		return errors.Join(errs...)
+	}
+}
+
+func (p *sWSAdapter) Produce(pCtx context.Context, pNetMsg layer1.IMessage) error {
+	logBuilder := anon_logger.NewLogBuilder(p.fShortName)
+	logBuilder.
+		WithType(internal_anon_logger.CLogBaseSendNetworkMessage).
+		WithHash(pNetMsg.GetHash()).
+		WithProof(pNetMsg.GetProof()).
+		WithSize(len(pNetMsg.ToBytes())).
+		WithConn(CServiceScheme)
+
+	if err := p.broadcastMessage(pCtx, pNetMsg); err != nil {
+		if errors.Is(err, ErrNoConnections) {
+			p.fLogger.PushWarn(logBuilder.WithType(internal_anon_logger.CLogWarnNoConnections))
+		} else {
+			p.fLogger.PushInfo(logBuilder)
+		}
+		return errors.Join(ErrBroadcast, err)
+	}
+	p.fLogger.PushInfo(logBuilder)
+	return nil
+}
+
+func (p *sWSAdapter) Consume(pCtx context.Context) (layer1.IMessage, error) {
+	select {
+	case <-pCtx.Done():
+		return nil, pCtx.Err()
+	case msg := <-p.fNetMsgChan:
+		return msg, nil
+	}
+}
+
+func (p *sWSAdapter) GetConnections() []string {
+	p.fMutex.RLock()
+	defer p.fMutex.RUnlock()
+
+	result := make([]string, 0, len(p.fConnections))
+	for addr := range p.fConnections {
+		result = append(result, addr)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func (p *sWSAdapter) AddConnection(pCtx context.Context, pAddress string) error {
+	if p.hasMaxConnSize() {
+		return ErrHasLimitConnections
+	}
+	if _, ok := p.getConnection(pAddress); ok {
+		return ErrConnectionIsExist
+	}
+
+	cfg, err := websocket.NewConfig(
+		CServiceScheme+"://"+pAddress+CHandlePath,
+		"http://"+pAddress+CHandlePath,
+	)
+	if err != nil {
+		return errors.Join(ErrAddConnection, err)
+	}
+	cfg.Dialer = &net.Dialer{Timeout: build.GSettings.GetDialTimeout()}
+
+	conn, err := cfg.DialContext(pCtx)
+	if err != nil {
+		return errors.Join(ErrAddConnection, err)
+	}
+
+	// the state of connections can be changed while dialing
+	if err := p.setConnection(pAddress, conn); err != nil {
+		_ = conn.Close()
+		return err
+	}
+	go p.handleConn(pCtx, pAddress, conn)
+
+	return nil
+}
+
+func (p *sWSAdapter) DelConnection(pAddress string) error {
+	p.fMutex.Lock()
+	defer p.fMutex.Unlock()
+
+	conn, ok := p.fConnections[pAddress]
+	if !ok {
+		return ErrConnectionIsNotExist
+	}
+
+	delete(p.fConnections, pAddress)
+
+	if err := conn.Close(); err != nil {
+		return errors.Join(ErrCloseConnection, err)
+	}
+	return nil
+}
+
+func (p *sWSAdapter) runListener(pCtx context.Context) error {
+	address := p.fSettings.GetAddress()
+	if address == "" {
+		<-pCtx.Done()
+		return pCtx.Err()
+	}
+
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return errors.Join(ErrCreateListener, err)
+	}
+	defer listener.Close()
+
+	mux := http.NewServeMux()
+	mux.Handle(CHandlePath, websocket.Server{
+		Handshake: checkOrigin,
+		Handler: func(pConn *websocket.Conn) {
+			address := pConn.Request().RemoteAddr
+			if err := p.setConnection(address, pConn); err != nil {
+				return
+			}
+			p.handleConn(pCtx, address, pConn)
+		},
+	})
+
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: build.GSettings.GetReadTimeout(),
+	}
+
+	go func() {
+		<-pCtx.Done()
+		server.Close()
+	}()
+
+	err = server.Serve(listener)
+	if errors.Is(err, http.ErrServerClosed) {
+		return pCtx.Err()
+	}
+	return err
+}
+
+// runKeeper periodically connects to the addresses which are not
+// connected yet (the same logic as in the connkeeper of go-peer).
+func (p *sWSAdapter) runKeeper(pCtx context.Context) error {
+	for {
+		p.tryConnectToAll(pCtx)
+		select {
+		case <-pCtx.Done():
+			return pCtx.Err()
+		case <-time.After(build.GSettings.GetKeeperPeriod()):
+			// next iter
+		}
+	}
+}
+
+func (p *sWSAdapter) tryConnectToAll(pCtx context.Context) {
+	connList := p.fConnsGetter()
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(connList))
+
+	for _, addr := range connList {
+		go func(addr string) {
+			defer wg.Done()
+			if _, ok := p.getConnection(addr); ok {
+				return
+			}
+			_ = p.AddConnection(pCtx, addr)
+		}(addr)
+	}
+
+	wg.Wait()
+}
+
+func (p *sWSAdapter) broadcastMessage(pCtx context.Context, pNetMsg layer1.IMessage) error {
+	p.fMutex.RLock()
+	connections := make(map[string]*websocket.Conn, len(p.fConnections))
+	for addr, conn := range p.fConnections {
+		connections[addr] = conn
+	}
+	p.fMutex.RUnlock()
+
+	if len(connections) == 0 {
+		return ErrNoConnections
+	}
+
+	// node can redirect received message
+	_ = p.fCache.Set(pNetMsg.GetHash(), []byte{})
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(connections))
+
+	listErr := make([]error, len(connections))
+	i := 0
+
+	for addr, conn := range connections {
+		go func(i int, addr string, conn *websocket.Conn) {
+			defer wg.Done()
+
+			deadline := time.Now().Add(build.GSettings.GetWriteTimeout())
+			if d, ok := pCtx.Deadline(); ok && d.Before(deadline) {
+				deadline = d
+			}
+			_ = conn.SetWriteDeadline(deadline)
+
+			if err := websocket.Message.Send(conn, pNetMsg.ToBytes()); err != nil {
+				listErr[i] = errors.Join(ErrSendMessage, err)
+				// if got error -> delete connection
+				_ = p.DelConnection(addr)
+			}
+		}(i, addr, conn)
+		i++
+	}
+
+	wg.Wait()
+	return errors.Join(listErr...)
+}
+
+func (p *sWSAdapter) handleConn(pCtx context.Context, pAddress string, pConn *websocket.Conn) {
+	defer func() { _ = p.delConnection(pAddress, pConn) }()
+
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		select {
+		case <-pCtx.Done():
+			_ = pConn.Close()
+		case <-done:
+		}
+	}()
+
+	adapterSettings := p.fSettings.GetAdapterSettings()
+	fullMsgSize := adapterSettings.GetMessageSizeBytes() + layer1.CMessageHeadSize
+	pConn.MaxPayloadBytes = int(fullMsgSize) // nolint: gosec
+
+	for {
+		// large wait read deadline => the connection has not sent anything yet
+		_ = pConn.SetReadDeadline(time.Now().Add(build.GSettings.GetWaitTimeout()))
+
+		var msgBytes []byte
+		if err := websocket.Message.Receive(pConn, &msgBytes); err != nil {
+			return
+		}
+
+		msg, err := layer1.LoadMessage(adapterSettings, msgBytes)
+		if err != nil {
+			return // invalid message = protocol error
+		}
+		if msg.GetPayload().GetHead() != build.GSettings.FProtoMask.FNetwork {
+			return // unknown head of payload = protocol error
+		}
+		if !p.fCache.Set(msg.GetHash(), []byte{}) {
+			continue // hash of message already in queue
+		}
+
+		p.fLogger.PushInfo(anon_logger.NewLogBuilder(p.fShortName).
+			WithType(internal_anon_logger.CLogInfoRecvNetworkMessage).
+			WithHash(msg.GetHash()).
+			WithProof(msg.GetProof()).
+			WithSize(len(msg.ToBytes())).
+			WithConn(pAddress))
+
+		select {
+		case <-pCtx.Done():
+			return
+		case p.fNetMsgChan <- msg:
+		}
+	}
+}
+
+func (p *sWSAdapter) closeConnections() {
+	p.fMutex.Lock()
+	defer p.fMutex.Unlock()
+
+	for addr, conn := range p.fConnections {
+		_ = conn.Close()
+		delete(p.fConnections, addr)
+	}
+}
+
+// delConnection deletes the connection only if it was not replaced.
+func (p *sWSAdapter) delConnection(pAddress string, pConn *websocket.Conn) error {
+	p.fMutex.Lock()
+	defer p.fMutex.Unlock()
+
+	if conn, ok := p.fConnections[pAddress]; ok && conn == pConn {
+		delete(p.fConnections, pAddress)
+	}
+	return pConn.Close()
+}
+
+func (p *sWSAdapter) hasMaxConnSize() bool {
+	p.fMutex.RLock()
+	defer p.fMutex.RUnlock()
+
+	maxConns := build.GSettings.FNetworkManager.FConnectsLimiter
+	return uint64(len(p.fConnections)) >= maxConns
+}
+
+func (p *sWSAdapter) getConnection(pAddress string) (*websocket.Conn, bool) {
+	p.fMutex.RLock()
+	defer p.fMutex.RUnlock()
+
+	conn, ok := p.fConnections[pAddress]
+	return conn, ok
+}
+
+// setConnection checks the limit of connections and saves
+// the connection under one lock.
+func (p *sWSAdapter) setConnection(pAddress string, pConn *websocket.Conn) error {
+	p.fMutex.Lock()
+	defer p.fMutex.Unlock()
+
+	maxConns := build.GSettings.FNetworkManager.FConnectsLimiter
+	if uint64(len(p.fConnections)) >= maxConns {
+		return ErrHasLimitConnections
+	}
+	if _, ok := p.fConnections[pAddress]; ok {
+		return ErrConnectionIsExist
+	}
+
+	p.fConnections[pAddress] = pConn
+	return nil
+}
+
+// checkOrigin rejects the requests of browsers from other sites. The
+// clients of adapter set the origin to the address of server, other
+// clients of network may not send the origin at all.
+func checkOrigin(pCfg *websocket.Config, pReq *http.Request) error {
+	origin, err := websocket.Origin(pCfg, pReq)
+	if err != nil {
+		return errors.Join(ErrInvalidOrigin, err)
+	}
+	if origin != nil && origin.Host != pReq.Host {
+		return ErrInvalidOrigin
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package ws
+
+const (
+	errPrefix = "pkg/adapters/ws = "
+)
+
+type SAppError struct {
+	str string
+}
+
+func (err *SAppError) Error() string {
+	return errPrefix + err.str
+}
+
+var (
+	ErrRunning              = &SAppError{"adapter running"}
+	ErrBroadcast            = &SAppError{"broadcast message"}
+	ErrNoConnections        = &SAppError{"no connections"}
+	ErrHasLimitConnections  = &SAppError{"has limit connections"}
+	ErrConnectionIsExist    = &SAppError{"connection is exist"}
+	ErrConnectionIsNotExist = &SAppError{"connection is not exist"}
+	ErrAddConnection        = &SAppError{"add connection"}
+	ErrCloseConnection      = &SAppError{"close connection"}
+	ErrSendMessage          = &SAppError{"send message"}
+	ErrCreateListener       = &SAppError{"create listener"}
+	ErrInvalidOrigin        = &SAppError{"invalid origin"}
+)
@@ -0,0 +1,24 @@
+package ws
+
+import (
+	"context"
+
+	"github.com/number571/go-peer/pkg/logger"
+	"github.com/number571/hidden-lake/internal/utils/name"
+	"github.com/number571/hidden-lake/pkg/adapters"
+)
+
+type IWSAdapter interface {
+	adapters.IRunnerAdapter
+
+	WithLogger(name.IServiceName, logger.ILogger) IWSAdapter
+
+	GetConnections() []string
+	AddConnection(context.Context, string) error
+	DelConnection(string) error
+}
+
+type ISettings interface {
+	GetAdapterSettings() adapters.ISettings
+	GetAddress() string
+}
@@ -0,0 +1,163 @@
+package ws
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/number571/go-peer/pkg/crypto/random"
+	"github.com/number571/go-peer/pkg/message/layer1"
+	"github.com/number571/go-peer/pkg/payload"
+	"github.com/number571/go-peer/pkg/storage/cache"
+	"github.com/number571/hidden-lake/build"
+	"github.com/number571/hidden-lake/pkg/adapters"
+	testutils "github.com/number571/hidden-lake/test/utils"
+	"golang.org/x/net/websocket"
+)
+
+func TestError(t *testing.T) {
+	t.Parallel()
+
+	str := "value"
+	err := &SAppError{str}
+	if err.Error() != errPrefix+str {
+		t.Error("incorrect err.Error()")
+		return
+	}
+}
+
+func TestSettings(t *testing.T) {
+	t.Parallel()
+
+	_ = NewSettings(nil)
+}
+
+func TestCheckOrigin(t *testing.T) {
+	t.Parallel()
+
+	cfg := &websocket.Config{Version: websocket.ProtocolVersionHybi13}
+
+	req := httptest.NewRequest(http.MethodGet, "http://127.0.0.1:9581/", nil)
+	if err := checkOrigin(cfg, req); err != nil {
+		t.Error("request without origin is rejected")
+		return
+	}
+
+	req.Header.Set("Origin", "http://127.0.0.1:9581/")
+	if err := checkOrigin(cfg, req); err != nil {
+		t.Error("request with origin of server is rejected")
+		return
+	}
+
+	req.Header.Set("Origin", "https://example.com")
+	if err := checkOrigin(cfg, req); err == nil {
+		t.Error("request with origin of other site is accepted")
+		return
+	}
+}
+
+func TestWSAdapter(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	adapter1 := testNewWSAdapter(testutils.TgAddrs[32], nil)
+	adapter2 := testNewWSAdapter("", []string{testutils.TgAddrs[32]})
+
+	go func() { _ = adapter1.Run(ctx) }()
+	time.Sleep(200 * time.Millisecond)
+	go func() { _ = adapter2.Run(ctx) }()
+
+	for len(adapter1.GetConnections()) == 0 || len(adapter2.GetConnections()) == 0 {
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	if err := adapter2.AddConnection(ctx, testutils.TgAddrs[32]); err == nil {
+		t.Error("success add already exist connection")
+		return
+	}
+
+	msgBytes := []byte("hello, world!")
+	msgBytes = append(msgBytes, random.NewRandom().GetBytes(uint64(8192-len(msgBytes)))...)
+	msg1 := testNewMessage(msgBytes)
+	if err := adapter2.Produce(ctx, msg1); err != nil {
+		t.Error(err)
+		return
+	}
+	recvMsg1, err := adapter1.Consume(ctx)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if recvMsg1.ToString() != msg1.ToString() {
+		t.Error("got invalid message (client -> server)")
+		return
+	}
+
+	// the duplicate is dropped by the cache
+	if err := adapter2.Produce(ctx, msg1); err != nil {
+		t.Error(err)
+		return
+	}
+
+	msg2 := testNewMessage([]byte("hello, client!"))
+	if err := adapter1.Produce(ctx, msg2); err != nil {
+		t.Error(err)
+		return
+	}
+	recvMsg2, err := adapter2.Consume(ctx)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if recvMsg2.ToString() != msg2.ToString() {
+		t.Error("got invalid message (server -> client)")
+		return
+	}
+
+	chCtx, chCancel := context.WithTimeout(ctx, 200*time.Millisecond)
+	defer chCancel()
+
+	if _, err := adapter1.Consume(chCtx); err == nil {
+		t.Error("success consume duplicate message")
+		return
+	}
+
+	if err := adapter2.DelConnection(testutils.TgAddrs[32]); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := adapter2.DelConnection(testutils.TgAddrs[32]); err == nil {
+		t.Error("success delete not exist connection")
+		return
+	}
+	if err := adapter2.Produce(ctx, msg2); err == nil {
+		t.Error("success produce without connections")
+		return
+	}
+}
+
+func testNewMessage(pData []byte) layer1.IMessage {
+	return layer1.NewMessage(
+		layer1.NewConstructSettings(&layer1.SConstructSettings{
+			FSettings: adapters.NewSettings(nil),
+		}),
+		payload.NewPayload32(build.GSettings.FProtoMask.FNetwork, pData),
+	)
+}
+
+func testNewWSAdapter(pAddress string, pConnections []string) IWSAdapter {
+	return NewWSAdapter(
+		NewSettings(&SSettings{
+			FAddress: pAddress,
+			FAdapterSettings: adapters.NewSettings(&adapters.SSettings{
+				FMessageSizeBytes: 8192,
+			}),
+		}),
+		cache.NewLRUCache(1<<10),
+		func() []string { return pConnections },
+	)
+}
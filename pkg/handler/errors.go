@@ -18,4 +18,5 @@ var (
 	ErrUndefinedService    = &SHandlerError{"undefined service"}
 	ErrLoadRequest         = &SHandlerError{"load request"}
 	ErrInvalidResponseMode = &SHandlerError{"invalid response mode"}
+	ErrInvalidPattern      = &SHandlerError{"invalid pattern"}
 )
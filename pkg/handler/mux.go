@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/number571/go-peer/pkg/crypto/asymmetric"
+	"github.com/number571/hidden-lake/pkg/request"
+	"github.com/number571/hidden-lake/pkg/response"
+)
+
+var (
+	_ IServiceMux = &sServiceMux{}
+)
+
+type sServiceMux struct {
+	fMutex    sync.RWMutex
+	fServices map[string][]*sRoute
+}
+
+type sRoute struct {
+	fMethod   string
+	fPath     string
+	fHandlerF IHandlerF
+}
+
+func NewServiceMux() IServiceMux {
+	return &sServiceMux{
+		fServices: make(map[string][]*sRoute, 8),
+	}
+}
+
+// HandleFunc registers the handler for the hostname and the pattern.
+// Panics if the pattern is invalid or already registered.
+func (p *sServiceMux) HandleFunc(pHost, pPattern string, pHandlerF IHandlerF) IServiceMux {
+	route, err := newRoute(pPattern, pHandlerF)
+	if err != nil {
+		panic(err)
+	}
+
+	p.fMutex.Lock()
+	defer p.fMutex.Unlock()
+
+	for _, r := range p.fServices[pHost] {
+		if r.fMethod == route.fMethod && r.fPath == route.fPath {
+			panic(ErrInvalidPattern)
+		}
+	}
+
+	p.fServices[pHost] = append(p.fServices[pHost], route)
+	return p
+}
+
+func (p *sServiceMux) HasService(pHost string) bool {
+	p.fMutex.RLock()
+	defer p.fMutex.RUnlock()
+
+	_, ok := p.fServices[pHost]
+	return ok
+}
+
+func (p *sServiceMux) ServeRequest(
+	pCtx context.Context,
+	pSender asymmetric.IPubKey,
+	pRequest request.IRequest,
+) (response.IResponse, error) {
+	p.fMutex.RLock()
+	routes, ok := p.fServices[pRequest.GetHost()]
+	p.fMutex.RUnlock()
+
+	if !ok {
+		return nil, ErrUndefinedService
+	}
+
+	path, _, _ := strings.Cut(pRequest.GetPath(), "?")
+
+	var (
+		found   *sRoute
+		matched bool
+	)
+	for _, r := range routes {
+		if !r.matchPath(path) {
+			continue
+		}
+		matched = true
+		if r.fMethod != "" && r.fMethod != pRequest.GetMethod() {
+			continue
+		}
+		// the most specific route wins
+		if found == nil || len(r.fPath) > len(found.fPath) ||
+			(len(r.fPath) == len(found.fPath) && r.fMethod != "") {
+			found = r
+		}
+	}
+
+	switch {
+	case found != nil:
+		return found.fHandlerF(pCtx, pSender, pRequest)
+	case matched:
+		return newStatusResponse(http.StatusMethodNotAllowed), nil
+	default:
+		return newStatusResponse(http.StatusNotFound), nil
+	}
+}
+
+func newRoute(pPattern string, pHandlerF IHandlerF) (*sRoute, error) {
+	method, path, ok := strings.Cut(pPattern, " ")
+	if !ok {
+		method, path = "", pPattern
+	}
+	if !strings.HasPrefix(path, "/") || pHandlerF == nil {
+		return nil, ErrInvalidPattern
+	}
+	return &sRoute{
+		fMethod:   method,
+		fPath:     path,
+		fHandlerF: pHandlerF,
+	}, nil
+}
+
+func (p *sRoute) matchPath(pPath string) bool {
+	if strings.HasSuffix(p.fPath, "/") {
+		return strings.HasPrefix(pPath, p.fPath)
+	}
+	return p.fPath == pPath
+}
+
+func newStatusResponse(pCode int) response.IResponse {
+	return response.NewResponseBuilder().
+		WithCode(pCode).
+		WithBody([]byte(http.StatusText(pCode))).
+		Build()
+}
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/number571/go-peer/pkg/crypto/asymmetric"
+	"github.com/number571/hidden-lake/pkg/request"
+	"github.com/number571/hidden-lake/pkg/response"
+)
+
+func TestServiceMux(t *testing.T) {
+	t.Parallel()
+
+	mux := NewServiceMux().
+		HandleFunc("hidden-some-host", "GET /echo", testBodyHandler("get")).
+		HandleFunc("hidden-some-host", "/echo", testBodyHandler("any")).
+		HandleFunc("hidden-some-host", "POST /files/", testBodyHandler("files")).
+		HandleFunc("hidden-some-host", "/push", func(context.Context, asymmetric.IPubKey, request.IRequest) (response.IResponse, error) {
+			return nil, nil // response mode 'off'
+		})
+
+	if !mux.HasService("hidden-some-host") || mux.HasService("hidden-unknown-host") {
+		t.Error("got invalid services")
+		return
+	}
+
+	testCases := []struct {
+		fMethod string
+		fPath   string
+		fCode   int
+		fBody   string
+	}{
+		{http.MethodGet, "/echo", http.StatusOK, "get"},
+		{http.MethodGet, "/echo?a=b", http.StatusOK, "get"},
+		{http.MethodPost, "/echo", http.StatusOK, "any"},
+		{http.MethodPost, "/files/a/b", http.StatusOK, "files"},
+		{http.MethodGet, "/files/a/b", http.StatusMethodNotAllowed, ""},
+		{http.MethodGet, "/unknown", http.StatusNotFound, ""},
+	}
+
+	ctx := context.Background()
+	for _, tc := range testCases {
+		req := request.NewRequestBuilder().
+			WithMethod(tc.fMethod).
+			WithHost("hidden-some-host").
+			WithPath(tc.fPath).
+			Build()
+		rsp, err := mux.ServeRequest(ctx, tgPubKey, req)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if rsp.GetCode() != tc.fCode {
+			t.Errorf("got invalid code for %s %s", tc.fMethod, tc.fPath)
+			return
+		}
+		if tc.fBody != "" && string(rsp.GetBody()) != tc.fBody {
+			t.Errorf("got invalid body for %s %s", tc.fMethod, tc.fPath)
+			return
+		}
+	}
+
+	pushReq := request.NewRequestBuilder().WithHost("hidden-some-host").WithPath("/push").Build()
+	if rsp, err := mux.ServeRequest(ctx, tgPubKey, pushReq); err != nil || rsp != nil {
+		t.Error("got response with response mode 'off'")
+		return
+	}
+
+	undefReq := request.NewRequestBuilder().WithHost("hidden-unknown-host").WithPath("/echo").Build()
+	if _, err := mux.ServeRequest(ctx, tgPubKey, undefReq); err == nil {
+		t.Error("success serve request with undefined service")
+		return
+	}
+}
+
+func TestServiceMuxPanic(t *testing.T) {
+	t.Parallel()
+
+	testCases := []func(){
+		func() { _ = NewServiceMux().HandleFunc("host", "GET echo", testBodyHandler("")) },
+		func() { _ = NewServiceMux().HandleFunc("host", "/echo", nil) },
+		func() {
+			_ = NewServiceMux().
+				HandleFunc("host", "/echo", testBodyHandler("")).
+				HandleFunc("host", "/echo", testBodyHandler(""))
+		},
+	}
+
+	for i, f := range testCases {
+		if !testPanics(f) {
+			t.Errorf("nothing panics (%d)", i)
+			return
+		}
+	}
+}
+
+func testPanics(f func()) (panics bool) {
+	defer func() { panics = (recover() != nil) }()
+	f()
+	return false
+}
+
+func testBodyHandler(pBody string) IHandlerF {
+	return func(context.Context, asymmetric.IPubKey, request.IRequest) (response.IResponse, error) {
+		return response.NewResponseBuilder().
+			WithCode(http.StatusOK).
+			WithBody([]byte(pBody)).
+			Build(), nil
+	}
+}
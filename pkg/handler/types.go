@@ -13,3 +13,13 @@ type IHandlerF func(
 	asymmetric.IPubKey,
 	request.IRequest,
 ) (response.IResponse, error)
+
+// IServiceMux routes requests to the handlers registered by the
+// hostname and the pattern "[METHOD ]/path". The pattern ending
+// with a slash matches all paths with this prefix.
+// The handler returns nil response if the response mode is 'off'.
+type IServiceMux interface {
+	HandleFunc(string, string, IHandlerF) IServiceMux
+	HasService(string) bool
+	ServeRequest(context.Context, asymmetric.IPubKey, request.IRequest) (response.IResponse, error)
+}
@@ -13,9 +13,17 @@ func (err *SAppError) Error() string {
 }
 
 var (
-	ErrSendRequest      = &SAppError{"send request"}
-	ErrFetchRequest     = &SAppError{"fetch request"}
-	ErrLoadResponse     = &SAppError{"load response"}
-	ErrAdapterNotRunner = &SAppError{"adapter not runner"}
-	ErrRunning          = &SAppError{"node running"}
+	ErrSendRequest        = &SAppError{"send request"}
+	ErrFetchRequest       = &SAppError{"fetch request"}
+	ErrLoadResponse       = &SAppError{"load response"}
+	ErrAdapterNotRunner   = &SAppError{"adapter not runner"}
+	ErrRunning            = &SAppError{"node running"}
+	ErrSendFragment       = &SAppError{"send fragment"}
+	ErrLoadFragment       = &SAppError{"load fragment"}
+	ErrInvalidFragment    = &SAppError{"invalid fragment"}
+	ErrUndefinedFragments = &SAppError{"undefined fragments"}
+	ErrFragmentsSizeLimit = &SAppError{"fragments size limit"}
+	ErrFragmentsTimeout   = &SAppError{"fragments timeout"}
+
+	ErrFragmentsGroupsLimit = &SAppError{"fragments groups limit"}
 )
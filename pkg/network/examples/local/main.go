@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/number571/go-peer/pkg/storage/database"
 	"github.com/number571/hidden-lake/pkg/adapters"
 	"github.com/number571/hidden-lake/pkg/adapters/tcp"
+	"github.com/number571/hidden-lake/pkg/handler"
 	"github.com/number571/hidden-lake/pkg/network"
 	"github.com/number571/hidden-lake/pkg/request"
 	"github.com/number571/hidden-lake/pkg/response"
@@ -20,6 +22,7 @@ import (
 )
 
 const (
+	serviceName    = "hidden-echo-service"
 	relayerAddress = "localhost:9999"
 	msgSizeBytes   = uint64(8 << 10)
 )
@@ -46,7 +49,12 @@ func main() {
 		rsp, err := node1.FetchRequest(
 			ctx,
 			pubKey,
-			request.NewRequestBuilder().WithBody([]byte("hello, world!")).Build(),
+			request.NewRequestBuilder().
+				WithMethod(http.MethodPost).
+				WithHost(serviceName).
+				WithPath("/echo").
+				WithBody([]byte("hello, world!")).
+				Build(),
 		)
 		if err != nil {
 			fmt.Printf("error:(%s)\n", err.Error())
@@ -75,10 +83,14 @@ func newNode(ctx context.Context, name string) network.IHiddenLakeNode {
 			return kv
 		}(),
 		newTCPAdapter("", []string{relayerAddress}),
-		func(_ context.Context, _ asymmetric.IPubKey, r request.IRequest) (response.IResponse, error) {
-			rsp := []byte(fmt.Sprintf("echo: %s", string(r.GetBody())))
-			return response.NewResponseBuilder().WithBody(rsp).Build(), nil
-		},
+		handler.NewServiceMux().HandleFunc(
+			serviceName,
+			"POST /echo",
+			func(_ context.Context, _ asymmetric.IPubKey, r request.IRequest) (response.IResponse, error) {
+				rsp := []byte(fmt.Sprintf("echo: %s", string(r.GetBody())))
+				return response.NewResponseBuilder().WithBody(rsp).Build(), nil
+			},
+		).ServeRequest,
 	)
 }
 
@@ -0,0 +1,407 @@
+package network
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/number571/go-peer/pkg/crypto/asymmetric"
+	"github.com/number571/go-peer/pkg/encoding"
+)
+
+const (
+	// fragment = [kind:1][id:8][index:8][count:8][data:...]
+	cFragmentHeadSize = 1 + 3*encoding.CSizeUint64
+
+	// marker = [prefix:4][id:8][size:8]
+	cMarkerSize = 4 + 2*encoding.CSizeUint64
+
+	// limit of groups which are assembled for one sender at the same time
+	cMaxGroupsPerSender = 32
+
+	// limit of responses which are stored for one sender until pulls
+	cMaxOutgoingPerSender = 4
+)
+
+const (
+	cFragmentRequest  byte = iota + 1 // request without response
+	cFragmentFetch                    // request with response
+	cFragmentResponse                 // response to the fetch or pull
+	cFragmentPull                     // request to send stored response
+)
+
+var (
+	// the response can not start with this prefix because
+	// it is the size of response's head in the bytes joiner
+	gMarkerPrefix = []byte{0xFF, 0xFF, 0xFF, 0xFF}
+)
+
+type sFragment struct {
+	fKind  byte
+	fID    uint64
+	fIndex uint64
+	fCount uint64
+	fData  []byte
+}
+
+type sFragments struct {
+	fMutex    sync.Mutex
+	fTimeout  time.Duration
+	fMaxSize  uint64
+	fMaxCount uint64
+	fGroups   map[string]*sFragmentGroup
+	fOutgoing map[string]*sOutgoing
+}
+
+type sFragmentGroup struct {
+	fSender  string
+	fUpdated time.Time
+	fWaiting bool
+	fCount   uint64
+	fSize    uint64
+	fParts   map[uint64][]byte
+	fResult  []byte
+	fDone    chan struct{}
+}
+
+type sOutgoing struct {
+	fSender  string
+	fCreated time.Time
+	fData    []byte
+}
+
+func newFragments(pTimeout time.Duration, pMaxSize, pChunkSize uint64) *sFragments {
+	maxCount := uint64(0)
+	if pChunkSize != 0 {
+		// empty data is sent as one fragment
+		maxCount = max(1, (pMaxSize+pChunkSize-1)/pChunkSize)
+	}
+	return &sFragments{
+		fTimeout:  pTimeout,
+		fMaxSize:  pMaxSize,
+		fMaxCount: maxCount,
+		fGroups:   make(map[string]*sFragmentGroup, 16),
+		fOutgoing: make(map[string]*sOutgoing, 16),
+	}
+}
+
+func splitFragments(pKind byte, pID uint64, pData []byte, pChunkSize uint64) [][]byte {
+	dataSize := uint64(len(pData))
+	count := dataSize / pChunkSize
+	if dataSize%pChunkSize != 0 || count == 0 {
+		count++
+	}
+
+	result := make([][]byte, 0, count)
+	for i := uint64(0); i < count; i++ {
+		end := (i + 1) * pChunkSize
+		if end > dataSize {
+			end = dataSize
+		}
+		result = append(result, (&sFragment{
+			fKind:  pKind,
+			fID:    pID,
+			fIndex: i,
+			fCount: count,
+			fData:  pData[i*pChunkSize : end],
+		}).toBytes())
+	}
+	return result
+}
+
+func loadFragment(pBytes []byte) (*sFragment, error) {
+	if len(pBytes) < cFragmentHeadSize {
+		return nil, ErrInvalidFragment
+	}
+
+	var (
+		id    [encoding.CSizeUint64]byte
+		index [encoding.CSizeUint64]byte
+		count [encoding.CSizeUint64]byte
+	)
+
+	copy(id[:], pBytes[1:])
+	copy(index[:], pBytes[1+encoding.CSizeUint64:])
+	copy(count[:], pBytes[1+2*encoding.CSizeUint64:])
+
+	fragment := &sFragment{
+		fKind:  pBytes[0],
+		fID:    encoding.BytesToUint64(id),
+		fIndex: encoding.BytesToUint64(index),
+		fCount: encoding.BytesToUint64(count),
+		fData:  pBytes[cFragmentHeadSize:],
+	}
+
+	if fragment.fKind < cFragmentRequest || fragment.fKind > cFragmentPull {
+		return nil, ErrInvalidFragment
+	}
+	if fragment.fIndex >= fragment.fCount {
+		return nil, ErrInvalidFragment
+	}
+
+	return fragment, nil
+}
+
+func (p *sFragment) toBytes() []byte {
+	id := encoding.Uint64ToBytes(p.fID)
+	index := encoding.Uint64ToBytes(p.fIndex)
+	count := encoding.Uint64ToBytes(p.fCount)
+	return bytes.Join(
+		[][]byte{
+			{p.fKind},
+			id[:],
+			index[:],
+			count[:],
+			p.fData,
+		},
+		[]byte{},
+	)
+}
+
+func newMarker(pID uint64, pSize uint64) []byte {
+	id := encoding.Uint64ToBytes(pID)
+	size := encoding.Uint64ToBytes(pSize)
+	return bytes.Join([][]byte{gMarkerPrefix, id[:], size[:]}, []byte{})
+}
+
+func loadMarker(pBytes []byte) (uint64, uint64, bool) {
+	if len(pBytes) != cMarkerSize || !bytes.HasPrefix(pBytes, gMarkerPrefix) {
+		return 0, 0, false
+	}
+
+	var (
+		id   [encoding.CSizeUint64]byte
+		size [encoding.CSizeUint64]byte
+	)
+
+	copy(id[:], pBytes[len(gMarkerPrefix):])
+	copy(size[:], pBytes[len(gMarkerPrefix)+encoding.CSizeUint64:])
+
+	return encoding.BytesToUint64(id), encoding.BytesToUint64(size), true
+}
+
+// Push saves the fragment and returns the assembled data when the
+// last fragment of request is received. Assembled responses are not
+// returned, they are passed to the waiting side.
+func (p *sFragments) push(pSender asymmetric.IPubKey, pFragment *sFragment) ([]byte, bool, error) {
+	p.fMutex.Lock()
+	defer p.fMutex.Unlock()
+
+	p.clearExpired()
+
+	if pFragment.fCount > p.fMaxCount {
+		return nil, false, ErrFragmentsSizeLimit
+	}
+
+	isResponse := (pFragment.fKind == cFragmentResponse)
+	key := newFragmentsKey(pSender, isResponse, pFragment.fID)
+
+	group, ok := p.fGroups[key]
+	if !ok {
+		sender := pSender.GetHasher().ToString()
+		if p.countGroups(sender) >= cMaxGroupsPerSender {
+			return nil, false, ErrFragmentsGroupsLimit
+		}
+		group = newFragmentGroup(sender, time.Now())
+		p.fGroups[key] = group
+	}
+
+	switch {
+	case group.fResult != nil:
+		return nil, false, ErrInvalidFragment
+	case group.fCount == 0:
+		group.fCount = pFragment.fCount
+	case group.fCount != pFragment.fCount:
+		delete(p.fGroups, key)
+		return nil, false, ErrInvalidFragment
+	}
+
+	if _, ok := group.fParts[pFragment.fIndex]; ok {
+		return nil, false, nil
+	}
+
+	group.fSize += uint64(len(pFragment.fData))
+	if group.fSize > p.fMaxSize {
+		delete(p.fGroups, key)
+		return nil, false, ErrFragmentsSizeLimit
+	}
+
+	group.fUpdated = time.Now()
+	group.fParts[pFragment.fIndex] = pFragment.fData
+
+	if uint64(len(group.fParts)) != group.fCount {
+		return nil, false, nil
+	}
+
+	result := make([]byte, 0, group.fSize)
+	for i := uint64(0); i < group.fCount; i++ {
+		result = append(result, group.fParts[i]...)
+	}
+	group.fParts = nil
+
+	if isResponse {
+		group.fResult = result
+		close(group.fDone)
+		return nil, false, nil
+	}
+
+	delete(p.fGroups, key)
+	return result, true, nil
+}
+
+// Wait returns the assembled response. The timeout is counted from the
+// last received fragment, but not earlier than pStart.
+func (p *sFragments) wait(
+	pCtx context.Context,
+	pSender asymmetric.IPubKey,
+	pID uint64,
+	pStart time.Time,
+	pTimeout time.Duration,
+) ([]byte, error) {
+	key := newFragmentsKey(pSender, true, pID)
+
+	p.fMutex.Lock()
+	group, ok := p.fGroups[key]
+	if !ok {
+		group = newFragmentGroup(pSender.GetHasher().ToString(), pStart)
+		p.fGroups[key] = group
+	}
+	group.fWaiting = true
+	p.fMutex.Unlock()
+
+	defer func() {
+		p.fMutex.Lock()
+		delete(p.fGroups, key)
+		p.fMutex.Unlock()
+	}()
+
+	for {
+		p.fMutex.Lock()
+		updated := group.fUpdated
+		p.fMutex.Unlock()
+
+		if updated.Before(pStart) {
+			updated = pStart
+		}
+
+		waitTime := pTimeout - time.Since(updated)
+		if waitTime <= 0 {
+			return nil, ErrFragmentsTimeout
+		}
+
+		select {
+		case <-pCtx.Done():
+			return nil, pCtx.Err()
+		case <-group.fDone:
+			return group.fResult, nil
+		case <-time.After(waitTime):
+		}
+	}
+}
+
+func (p *sFragments) store(pRecv asymmetric.IPubKey, pID uint64, pData []byte) {
+	p.fMutex.Lock()
+	defer p.fMutex.Unlock()
+
+	p.clearExpired()
+
+	// the oldest response is dropped, so responses which are not
+	// pulled by the sender do not block its new fetch requests
+	sender := pRecv.GetHasher().ToString()
+	if p.countOutgoing(sender) >= cMaxOutgoingPerSender {
+		p.deleteOldestOutgoing(sender)
+	}
+
+	p.fOutgoing[newFragmentsKey(pRecv, true, pID)] = &sOutgoing{
+		fSender:  sender,
+		fCreated: time.Now(),
+		fData:    pData,
+	}
+}
+
+func (p *sFragments) load(pRecv asymmetric.IPubKey, pID uint64) ([]byte, bool) {
+	p.fMutex.Lock()
+	defer p.fMutex.Unlock()
+
+	key := newFragmentsKey(pRecv, true, pID)
+	outgoing, ok := p.fOutgoing[key]
+	if !ok {
+		return nil, false
+	}
+
+	delete(p.fOutgoing, key)
+	return outgoing.fData, true
+}
+
+func (p *sFragments) clearExpired() {
+	for k, v := range p.fGroups {
+		if v.fWaiting {
+			continue
+		}
+		if time.Since(v.fUpdated) > p.fTimeout {
+			delete(p.fGroups, k)
+		}
+	}
+	for k, v := range p.fOutgoing {
+		if time.Since(v.fCreated) > p.fTimeout {
+			delete(p.fOutgoing, k)
+		}
+	}
+}
+
+// countGroups returns the count of groups which were opened by the sender.
+// Groups of responses waited by this node are not counted.
+func (p *sFragments) countGroups(pSender string) uint64 {
+	count := uint64(0)
+	for _, v := range p.fGroups {
+		if v.fWaiting || v.fSender != pSender {
+			continue
+		}
+		count++
+	}
+	return count
+}
+
+func (p *sFragments) countOutgoing(pSender string) uint64 {
+	count := uint64(0)
+	for _, v := range p.fOutgoing {
+		if v.fSender != pSender {
+			continue
+		}
+		count++
+	}
+	return count
+}
+
+func (p *sFragments) deleteOldestOutgoing(pSender string) {
+	oldestKey := ""
+	oldestTime := time.Time{}
+	for k, v := range p.fOutgoing {
+		if v.fSender != pSender {
+			continue
+		}
+		if oldestKey == "" || v.fCreated.Before(oldestTime) {
+			oldestKey, oldestTime = k, v.fCreated
+		}
+	}
+	delete(p.fOutgoing, oldestKey)
+}
+
+func newFragmentGroup(pSender string, pUpdated time.Time) *sFragmentGroup {
+	return &sFragmentGroup{
+		fSender:  pSender,
+		fUpdated: pUpdated,
+		fParts:   make(map[uint64][]byte, 8),
+		fDone:    make(chan struct{}),
+	}
+}
+
+func newFragmentsKey(pPubKey asymmetric.IPubKey, pIsResponse bool, pID uint64) string {
+	id := encoding.Uint64ToBytes(pID)
+	kind := "request"
+	if pIsResponse {
+		kind = "response"
+	}
+	return pPubKey.GetHasher().ToString() + kind + encoding.HexEncode(id[:])
+}
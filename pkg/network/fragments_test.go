@@ -0,0 +1,199 @@
+package network
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/number571/go-peer/pkg/crypto/asymmetric"
+)
+
+func TestFragments(t *testing.T) {
+	t.Parallel()
+
+	data := bytes.Repeat([]byte("0123456789"), 10)
+	fragments := splitFragments(cFragmentFetch, 1, data, 32)
+	if len(fragments) != 4 {
+		t.Error("got invalid count of fragments")
+		return
+	}
+
+	pubKey := asymmetric.NewPrivKey().GetPubKey()
+	frags := newFragments(time.Minute, 1024, 32)
+
+	for i := len(fragments) - 1; i >= 0; i-- {
+		fragment, err := loadFragment(fragments[i])
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		result, ok, err := frags.push(pubKey, fragment)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if ok != (i == 0) {
+			t.Error("got invalid state of assembling")
+			return
+		}
+		if ok && !bytes.Equal(result, data) {
+			t.Error("got invalid assembled data")
+			return
+		}
+	}
+
+	if _, err := loadFragment([]byte{cFragmentFetch}); err == nil {
+		t.Error("success load fragment with invalid size")
+		return
+	}
+	if _, err := loadFragment((&sFragment{fKind: 0xFF, fCount: 1}).toBytes()); err == nil {
+		t.Error("success load fragment with invalid kind")
+		return
+	}
+	if _, err := loadFragment((&sFragment{fKind: cFragmentFetch, fIndex: 1, fCount: 1}).toBytes()); err == nil {
+		t.Error("success load fragment with invalid index")
+		return
+	}
+
+	largeFragments := newFragments(time.Minute, 16, 32)
+	fragment, err := loadFragment(splitFragments(cFragmentRequest, 2, data, 64)[0])
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if _, _, err := largeFragments.push(pubKey, fragment); err == nil {
+		t.Error("success push fragment with large size")
+		return
+	}
+
+	// 1024 bytes by 32 bytes = 32 fragments
+	countFragment, err := loadFragment((&sFragment{fKind: cFragmentRequest, fID: 3, fCount: 33}).toBytes())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if _, _, err := frags.push(pubKey, countFragment); err == nil {
+		t.Error("success push fragment with large count")
+		return
+	}
+}
+
+func TestFragmentsGroupsLimit(t *testing.T) {
+	t.Parallel()
+
+	pubKey := asymmetric.NewPrivKey().GetPubKey()
+	frags := newFragments(time.Minute, 1024, 32)
+
+	for i := uint64(0); i < cMaxGroupsPerSender; i++ {
+		fragment := &sFragment{fKind: cFragmentRequest, fID: i, fCount: 2, fData: []byte{1}}
+		if _, _, err := frags.push(pubKey, fragment); err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	fragment := &sFragment{fKind: cFragmentRequest, fID: cMaxGroupsPerSender, fCount: 2, fData: []byte{1}}
+	if _, _, err := frags.push(pubKey, fragment); !errors.Is(err, ErrFragmentsGroupsLimit) {
+		t.Error("success open group over the limit")
+		return
+	}
+
+	// other senders are not blocked by the limit
+	otherKey := asymmetric.NewPrivKey().GetPubKey()
+	if _, _, err := frags.push(otherKey, fragment); err != nil {
+		t.Error(err)
+		return
+	}
+}
+
+func TestFragmentsOutgoingLimit(t *testing.T) {
+	t.Parallel()
+
+	pubKey := asymmetric.NewPrivKey().GetPubKey()
+	frags := newFragments(time.Minute, 1024, 32)
+
+	for i := uint64(0); i <= cMaxOutgoingPerSender; i++ {
+		frags.store(pubKey, i, []byte{byte(i)})
+	}
+
+	// the oldest response is dropped by the limit
+	if _, ok := frags.load(pubKey, 0); ok {
+		t.Error("success load response over the limit")
+		return
+	}
+	for i := uint64(1); i <= cMaxOutgoingPerSender; i++ {
+		if _, ok := frags.load(pubKey, i); !ok {
+			t.Error("failed load stored response")
+			return
+		}
+	}
+
+	// other senders are not limited by the responses of others
+	otherKey := asymmetric.NewPrivKey().GetPubKey()
+	for i := uint64(0); i < cMaxOutgoingPerSender; i++ {
+		frags.store(pubKey, i, []byte{byte(i)})
+	}
+	frags.store(otherKey, 0, []byte{0})
+	if _, ok := frags.load(otherKey, 0); !ok {
+		t.Error("failed load response of other sender")
+		return
+	}
+	if _, ok := frags.load(pubKey, 0); !ok {
+		t.Error("response is dropped by other sender")
+		return
+	}
+}
+
+func TestFragmentsWait(t *testing.T) {
+	t.Parallel()
+
+	pubKey := asymmetric.NewPrivKey().GetPubKey()
+	frags := newFragments(time.Minute, 1024, 32)
+
+	data := []byte("hello, world!")
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		for _, f := range splitFragments(cFragmentResponse, 1, data, 4) {
+			fragment, _ := loadFragment(f)
+			_, _, _ = frags.push(pubKey, fragment)
+		}
+	}()
+
+	result, err := frags.wait(context.Background(), pubKey, 1, time.Now(), time.Second)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !bytes.Equal(result, data) {
+		t.Error("got invalid response data")
+		return
+	}
+
+	if _, err := frags.wait(context.Background(), pubKey, 2, time.Now(), 100*time.Millisecond); err == nil {
+		t.Error("success wait undefined fragments")
+		return
+	}
+
+	frags.store(pubKey, 3, data)
+	stored, ok := frags.load(pubKey, 3)
+	if !ok || !bytes.Equal(stored, data) {
+		t.Error("got invalid stored data")
+		return
+	}
+	if _, ok := frags.load(pubKey, 3); ok {
+		t.Error("success load stored data twice")
+		return
+	}
+
+	id, size, ok := loadMarker(newMarker(4, 5))
+	if !ok || id != 4 || size != 5 {
+		t.Error("got invalid marker")
+		return
+	}
+	if _, _, ok := loadMarker([]byte("marker")); ok {
+		t.Error("success load invalid marker")
+		return
+	}
+}
@@ -4,11 +4,13 @@ import (
 	"context"
 	"errors"
 	"sync"
+	"time"
 
 	"github.com/number571/go-peer/pkg/anonymity"
 	"github.com/number571/go-peer/pkg/anonymity/queue"
 	"github.com/number571/go-peer/pkg/client"
 	"github.com/number571/go-peer/pkg/crypto/asymmetric"
+	"github.com/number571/go-peer/pkg/crypto/random"
 	"github.com/number571/go-peer/pkg/encoding"
 	"github.com/number571/go-peer/pkg/message/layer1"
 	"github.com/number571/go-peer/pkg/payload"
@@ -25,7 +27,9 @@ var (
 )
 
 type sHiddenLakeNode struct {
+	fSettings      ISettings
 	fAnonymityNode anonymity.INode
+	fFragments     *sFragments
 }
 
 func NewHiddenLakeNode(
@@ -36,42 +40,54 @@ func NewHiddenLakeNode(
 	pHandlerF handler.IHandlerF,
 ) IHiddenLakeNode {
 	adaptersSettings := pSettings.GetAdapterSettings()
-	return &sHiddenLakeNode{
-		anonymity.NewNode(
-			anonymity.NewSettings(&anonymity.SSettings{
-				FServiceName:  pSettings.GetServiceName(),
-				FFetchTimeout: pSettings.GetFetchTimeout(),
-			}),
-			pSettings.GetLogger(),
-			pRunnerAdapter,
-			pKVDatabase,
-			queue.NewQBProblemProcessor(
-				queue.NewSettings(&queue.SSettings{
-					FMessageConstructSettings: layer1.NewConstructSettings(&layer1.SConstructSettings{
-						FSettings: adaptersSettings,
-						FParallel: pSettings.GetParallel(),
-					}),
-					FQueuePeriod:  pSettings.GetQueuePeriod(),
-					FNetworkMask:  build.GSettings.FProtoMask.FNetwork,
-					FConsumersCap: build.GSettings.FQueueProblem.FConsumersCap,
-					FQueuePoolCap: [2]uint64{
-						build.GSettings.FQueueProblem.FMainPoolCap,
-						build.GSettings.FQueueProblem.FRandPoolCap,
-					},
+	hlNode := &sHiddenLakeNode{
+		fSettings: pSettings,
+	}
+	requestHandler := handler.RequestHandler(pHandlerF)
+	hlNode.fAnonymityNode = anonymity.NewNode(
+		anonymity.NewSettings(&anonymity.SSettings{
+			FServiceName:  pSettings.GetServiceName(),
+			FFetchTimeout: pSettings.GetFetchTimeout(),
+		}),
+		pSettings.GetLogger(),
+		pRunnerAdapter,
+		pKVDatabase,
+		queue.NewQBProblemProcessor(
+			queue.NewSettings(&queue.SSettings{
+				FMessageConstructSettings: layer1.NewConstructSettings(&layer1.SConstructSettings{
+					FSettings: adaptersSettings,
+					FParallel: pSettings.GetParallel(),
 				}),
-				func() client.IClient {
-					client := client.NewClient(pPrivKey, adaptersSettings.GetMessageSizeBytes())
-					if client.GetPayloadLimit() <= encoding.CSizeUint64 {
-						panic(`client.GetPayloadLimit() <= encoding.CSizeUint64`)
-					}
-					return client
-				}(),
-			),
-		).HandleFunc(
-			build.GSettings.FProtoMask.FService,
-			handler.RequestHandler(pHandlerF),
+				FQueuePeriod:  pSettings.GetQueuePeriod(),
+				FNetworkMask:  build.GSettings.FProtoMask.FNetwork,
+				FConsumersCap: build.GSettings.FQueueProblem.FConsumersCap,
+				FQueuePoolCap: [2]uint64{
+					build.GSettings.FQueueProblem.FMainPoolCap,
+					build.GSettings.FQueueProblem.FRandPoolCap,
+				},
+			}),
+			func() client.IClient {
+				client := client.NewClient(pPrivKey, adaptersSettings.GetMessageSizeBytes())
+				if client.GetPayloadLimit() <= encoding.CSizeUint64 {
+					panic(`client.GetPayloadLimit() <= encoding.CSizeUint64`)
+				}
+				return client
+			}(),
 		),
-	}
+	).HandleFunc(
+		build.GSettings.FProtoMask.FService,
+		hlNode.handleRequest(requestHandler),
+	).HandleFunc(
+		build.GSettings.FProtoMask.FFragment,
+		hlNode.handleFragment(requestHandler),
+	)
+	// the size of fragment depends on the payload limit of the queue
+	hlNode.fFragments = newFragments(
+		pSettings.GetFragmentsTimeout(),
+		pSettings.GetFragmentsSizeBytes(),
+		hlNode.getChunkSize(),
+	)
+	return hlNode
 }
 
 func (p *sHiddenLakeNode) GetAnonymityNode() anonymity.INode {
@@ -119,15 +135,11 @@ func (p *sHiddenLakeNode) SendRequest(
 	pPubKey asymmetric.IPubKey,
 	pRequest request.IRequest,
 ) error {
-	err := p.fAnonymityNode.SendPayload(
-		pCtx,
-		pPubKey,
-		payload.NewPayload64(
-			uint64(build.GSettings.FProtoMask.FService),
-			pRequest.ToBytes(),
-		),
-	)
-	if err != nil {
+	// requests without responses are always sent by the fragments, so
+	// the handler of service route stores the large responses only for
+	// the fetch requests
+	id := random.NewRandom().GetUint64()
+	if err := p.sendFragments(pCtx, pPubKey, cFragmentRequest, id, pRequest.ToBytes()); err != nil {
 		return errors.Join(ErrSendRequest, err)
 	}
 	return nil
@@ -138,20 +150,168 @@ func (p *sHiddenLakeNode) FetchRequest(
 	pPubKey asymmetric.IPubKey,
 	pRequest request.IRequest,
 ) (response.IResponse, error) {
+	reqBytes := pRequest.ToBytes()
+	if uint64(len(reqBytes)) > p.getPayloadLimit() {
+		rspBytes, err := p.fetchFragments(pCtx, pPubKey, reqBytes)
+		if err != nil {
+			return nil, errors.Join(ErrFetchRequest, err)
+		}
+		return loadResponse(rspBytes)
+	}
 	rspBytes, err := p.fAnonymityNode.FetchPayload(
 		pCtx,
 		pPubKey,
 		payload.NewPayload32(
 			build.GSettings.FProtoMask.FService,
-			pRequest.ToBytes(),
+			reqBytes,
 		),
 	)
 	if err != nil {
 		return nil, errors.Join(ErrFetchRequest, err)
 	}
-	rsp, err := response.LoadResponse(rspBytes)
+	if id, size, ok := loadMarker(rspBytes); ok {
+		rspBytes, err = p.pullFragments(pCtx, pPubKey, id, size)
+		if err != nil {
+			return nil, errors.Join(ErrFetchRequest, err)
+		}
+	}
+	return loadResponse(rspBytes)
+}
+
+func loadResponse(pRspBytes []byte) (response.IResponse, error) {
+	rsp, err := response.LoadResponse(pRspBytes)
 	if err != nil {
 		return nil, errors.Join(ErrLoadResponse, err)
 	}
 	return rsp, nil
 }
+
+func (p *sHiddenLakeNode) handleRequest(pRequestHandler anonymity.IHandlerF) anonymity.IHandlerF {
+	return func(
+		pCtx context.Context,
+		pNode anonymity.INode,
+		pSender asymmetric.IPubKey,
+		pReqBytes []byte,
+	) ([]byte, error) {
+		rspBytes, err := pRequestHandler(pCtx, pNode, pSender, pReqBytes)
+		if err != nil || uint64(len(rspBytes)) <= p.getPayloadLimit() {
+			return rspBytes, err
+		}
+		if uint64(len(rspBytes)) > p.fSettings.GetFragmentsSizeBytes() {
+			return nil, ErrFragmentsSizeLimit
+		}
+		// the response is too large for one message, so it is stored
+		// until the sender pulls it by the fragments
+		id := random.NewRandom().GetUint64()
+		p.fFragments.store(pSender, id, rspBytes)
+		return newMarker(id, uint64(len(rspBytes))), nil
+	}
+}
+
+func (p *sHiddenLakeNode) handleFragment(pRequestHandler anonymity.IHandlerF) anonymity.IHandlerF {
+	return func(
+		pCtx context.Context,
+		pNode anonymity.INode,
+		pSender asymmetric.IPubKey,
+		pBody []byte,
+	) ([]byte, error) {
+		fragment, err := loadFragment(pBody)
+		if err != nil {
+			return nil, errors.Join(ErrLoadFragment, err)
+		}
+
+		if fragment.fKind == cFragmentPull {
+			rspBytes, ok := p.fFragments.load(pSender, fragment.fID)
+			if !ok {
+				return nil, ErrUndefinedFragments
+			}
+			return nil, p.sendFragments(pCtx, pSender, cFragmentResponse, fragment.fID, rspBytes)
+		}
+
+		reqBytes, ok, err := p.fFragments.push(pSender, fragment)
+		if err != nil || !ok {
+			return nil, err
+		}
+
+		rspBytes, err := pRequestHandler(pCtx, pNode, pSender, reqBytes)
+		if err != nil {
+			return nil, err
+		}
+		if fragment.fKind != cFragmentFetch || rspBytes == nil {
+			return nil, nil
+		}
+		return nil, p.sendFragments(pCtx, pSender, cFragmentResponse, fragment.fID, rspBytes)
+	}
+}
+
+func (p *sHiddenLakeNode) fetchFragments(
+	pCtx context.Context,
+	pPubKey asymmetric.IPubKey,
+	pReqBytes []byte,
+) ([]byte, error) {
+	id := random.NewRandom().GetUint64()
+	if err := p.sendFragments(pCtx, pPubKey, cFragmentFetch, id, pReqBytes); err != nil {
+		return nil, err
+	}
+	// fragments are sent one per queue period
+	count := uint64(len(pReqBytes))/p.getChunkSize() + 1
+	start := time.Now().Add(time.Duration(count) * p.fSettings.GetQueuePeriod())
+	return p.fFragments.wait(pCtx, pPubKey, id, start, p.fSettings.GetFetchTimeout())
+}
+
+func (p *sHiddenLakeNode) pullFragments(
+	pCtx context.Context,
+	pPubKey asymmetric.IPubKey,
+	pID uint64,
+	pSize uint64,
+) ([]byte, error) {
+	if pSize > p.fSettings.GetFragmentsSizeBytes() {
+		return nil, ErrFragmentsSizeLimit
+	}
+	if err := p.sendFragments(pCtx, pPubKey, cFragmentPull, pID, []byte{}); err != nil {
+		return nil, err
+	}
+	return p.fFragments.wait(pCtx, pPubKey, pID, time.Now(), p.fSettings.GetFetchTimeout())
+}
+
+func (p *sHiddenLakeNode) sendFragments(
+	pCtx context.Context,
+	pPubKey asymmetric.IPubKey,
+	pKind byte,
+	pID uint64,
+	pData []byte,
+) error {
+	if uint64(len(pData)) > p.fSettings.GetFragmentsSizeBytes() {
+		return ErrFragmentsSizeLimit
+	}
+	if p.getChunkSize() == 0 {
+		return ErrFragmentsSizeLimit
+	}
+	for _, fragment := range splitFragments(pKind, pID, pData, p.getChunkSize()) {
+		err := p.fAnonymityNode.SendPayload(
+			pCtx,
+			pPubKey,
+			payload.NewPayload64(
+				uint64(build.GSettings.FProtoMask.FFragment),
+				fragment,
+			),
+		)
+		if err != nil {
+			return errors.Join(ErrSendFragment, err)
+		}
+	}
+	return nil
+}
+
+func (p *sHiddenLakeNode) getPayloadLimit() uint64 {
+	client := p.fAnonymityNode.GetQBProcessor().GetClient()
+	return client.GetPayloadLimit() - encoding.CSizeUint64
+}
+
+func (p *sHiddenLakeNode) getChunkSize() uint64 {
+	limit := p.getPayloadLimit()
+	if limit <= cFragmentHeadSize {
+		return 0
+	}
+	return limit - cFragmentHeadSize
+}
@@ -1,6 +1,7 @@
 package network
 
 import (
+	"bytes"
 	"context"
 	"net/http"
 	"os"
@@ -97,6 +98,16 @@ func TestSettings(t *testing.T) {
 		return
 	}
 
+	if sett.GetFragmentsSizeBytes() != cDefaultFragmentsSizeBytes {
+		t.Error("got invalid fragments size by default settings")
+		return
+	}
+
+	if sett.GetFragmentsTimeout() != sett.GetFetchTimeout() {
+		t.Error("got invalid fragments timeout by default settings")
+		return
+	}
+
 	sett.GetLogger().PushInfo("___")
 }
 
@@ -155,6 +166,102 @@ func TestHiddenLakeNode(t *testing.T) {
 	}
 }
 
+func TestHiddenLakeNodeFragments(t *testing.T) {
+	t.Parallel()
+
+	msgChan1 := make(chan layer1.IMessage)
+	msgChan2 := make(chan layer1.IMessage)
+
+	node1 := testNewHiddenLakeNode("node3.db", msgChan2, msgChan1)
+	node1PubKey := node1.GetAnonymityNode().GetQBProcessor().GetClient().GetPrivKey().GetPubKey()
+	defer os.Remove("node3.db")
+
+	node2 := testNewHiddenLakeNode("node4.db", msgChan1, msgChan2)
+	node2PubKey := node2.GetAnonymityNode().GetQBProcessor().GetClient().GetPrivKey().GetPubKey()
+	defer os.Remove("node4.db")
+
+	node1.GetAnonymityNode().GetMapPubKeys().SetPubKey(node2PubKey)
+	node2.GetAnonymityNode().GetMapPubKeys().SetPubKey(node1PubKey)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() { _ = node1.Run(ctx) }()
+	go func() { _ = node2.Run(ctx) }()
+
+	largeBody := bytes.Repeat([]byte{'_'}, 20<<10)
+
+	err := node1.SendRequest(
+		ctx,
+		node2PubKey,
+		request.NewRequestBuilder().WithMethod(http.MethodPost).WithBody(largeBody).Build(),
+	)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// the large response of request without waiting is not stored
+	err = node1.SendRequest(
+		ctx,
+		node2PubKey,
+		request.NewRequestBuilder().WithMethod(http.MethodGet).Build(),
+	)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	rsp1, err := node1.FetchRequest(
+		ctx,
+		node2PubKey,
+		request.NewRequestBuilder().WithMethod(http.MethodPatch).WithBody(largeBody).Build(),
+	)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !bytes.Equal(rsp1.GetBody(), largeBody) {
+		t.Error("got invalid response body (large request)")
+		return
+	}
+
+	fragments := node2.(*sHiddenLakeNode).fFragments
+	fragments.fMutex.Lock()
+	countOutgoing := len(fragments.fOutgoing)
+	fragments.fMutex.Unlock()
+	if countOutgoing != 0 {
+		t.Error("response of request without waiting is stored")
+		return
+	}
+
+	rsp2, err := node1.FetchRequest(
+		ctx,
+		node2PubKey,
+		request.NewRequestBuilder().WithMethod(http.MethodGet).Build(),
+	)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !bytes.Equal(rsp2.GetBody(), largeBody) {
+		t.Error("got invalid response body (small request)")
+		return
+	}
+
+	_, err = node1.FetchRequest(
+		ctx,
+		node2PubKey,
+		request.NewRequestBuilder().WithMethod(http.MethodPatch).WithBody(
+			bytes.Repeat([]byte{'_'}, cDefaultFragmentsSizeBytes+1),
+		).Build(),
+	)
+	if err == nil {
+		t.Error("success fetch request with large body")
+		return
+	}
+}
+
 func testNewHiddenLakeNode(dbPath string, outMsgChan, inMsgChan chan layer1.IMessage) IHiddenLakeNode {
 	return NewHiddenLakeNode(
 		NewSettings(&SSettings{
@@ -198,6 +305,12 @@ func testNewHiddenLakeNode(dbPath string, outMsgChan, inMsgChan chan layer1.IMes
 			if req.GetMethod() == http.MethodPut {
 				return response.NewResponseBuilder().WithCode(http.StatusAccepted).Build(), nil
 			}
+			if req.GetMethod() == http.MethodPatch {
+				return response.NewResponseBuilder().WithBody(req.GetBody()).Build(), nil
+			}
+			if req.GetMethod() == http.MethodGet {
+				return response.NewResponseBuilder().WithBody(bytes.Repeat([]byte{'_'}, 20<<10)).Build(), nil
+			}
 			panic("unknown method")
 		},
 	)
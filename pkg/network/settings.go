@@ -12,12 +12,22 @@ var (
 	_ ISettings = &sSettings{}
 )
 
+const (
+	cDefaultFragmentsSizeBytes = (1 << 20) // 1MiB
+)
+
 type SSettings sSettings
 type sSettings struct {
 	FSubSettings     *SSubSettings
 	FQueuePeriod     time.Duration
 	FFetchTimeout    time.Duration
 	FAdapterSettings adapters.ISettings
+
+	// Requests and responses larger than one message are
+	// sent by fragments. The size limits the total size of
+	// fragmented data, the timeout limits waiting of fragments.
+	FFragmentsSizeBytes uint64
+	FFragmentsTimeout   time.Duration
 }
 
 type SSubSettings struct {
@@ -35,6 +45,9 @@ func NewSettings(pSett *SSettings) ISettings {
 		FQueuePeriod:     pSett.FQueuePeriod,
 		FFetchTimeout:    pSett.FFetchTimeout,
 		FSubSettings:     pSett.FSubSettings,
+
+		FFragmentsSizeBytes: pSett.FFragmentsSizeBytes,
+		FFragmentsTimeout:   pSett.FFragmentsTimeout,
 	}).useDefault()
 }
 
@@ -70,6 +83,14 @@ func (p *sSettings) useDefault() *sSettings {
 		p.FFetchTimeout = defaultNetwork.GetFetchTimeout()
 	}
 
+	if p.FFragmentsSizeBytes == 0 {
+		p.FFragmentsSizeBytes = cDefaultFragmentsSizeBytes
+	}
+
+	if p.FFragmentsTimeout == 0 {
+		p.FFragmentsTimeout = p.FFetchTimeout
+	}
+
 	if p.FSubSettings == nil {
 		p.FSubSettings = &SSubSettings{}
 	}
@@ -111,3 +132,11 @@ func (p *sSettings) GetServiceName() string {
 func (p *sSettings) GetLogger() gopeer_logger.ILogger {
 	return p.FSubSettings.FLogger
 }
+
+func (p *sSettings) GetFragmentsSizeBytes() uint64 {
+	return p.FFragmentsSizeBytes
+}
+
+func (p *sSettings) GetFragmentsTimeout() time.Duration {
+	return p.FFragmentsTimeout
+}
@@ -26,6 +26,8 @@ type ISettings interface {
 	GetAdapterSettings() adapters.ISettings
 	GetQueuePeriod() time.Duration
 	GetFetchTimeout() time.Duration
+	GetFragmentsSizeBytes() uint64
+	GetFragmentsTimeout() time.Duration
 }
 
 type ISubSettings interface {
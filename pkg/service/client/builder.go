@@ -0,0 +1,135 @@
+package client
+
+import (
+	"time"
+
+	"github.com/number571/go-peer/pkg/crypto/asymmetric"
+	"github.com/number571/hidden-lake/pkg/request"
+	"github.com/number571/hidden-lake/pkg/response"
+	hls_settings "github.com/number571/hidden-lake/pkg/service/settings"
+)
+
+var (
+	_ IBuilder = &sBuilder{}
+)
+
+type sBuilder struct {
+}
+
+func NewBuilder() IBuilder {
+	return &sBuilder{}
+}
+
+func (p *sBuilder) Friend(pAliasName string, pPubKey asymmetric.IPubKey) *hls_settings.SFriend {
+	if pPubKey == nil {
+		// del friend
+		return &hls_settings.SFriend{
+			FAliasName: pAliasName,
+		}
+	}
+	// add friend
+	return &hls_settings.SFriend{
+		FAliasName: pAliasName,
+		FPublicKey: pPubKey.ToString(),
+	}
+}
+
+func (p *sBuilder) FriendInfo(pAliasName string, pNotes string, pTags []string) *hls_settings.SFriend {
+	return &hls_settings.SFriend{
+		FAliasName: pAliasName,
+		FNotes:     pNotes,
+		FTags:      pTags,
+	}
+}
+
+func (p *sBuilder) TempFriend(pAliasName string, pPubKey asymmetric.IPubKey, pTTL time.Duration) *hls_settings.SFriend {
+	friend := &hls_settings.SFriend{
+		FAliasName: pAliasName,
+		FTTLMS:     uint64(pTTL.Milliseconds()), // nolint: gosec
+	}
+	if pPubKey == nil {
+		// extend friend
+		return friend
+	}
+	// add temp friend
+	friend.FPublicKey = pPubKey.ToString()
+	return friend
+}
+
+func (p *sBuilder) Contact(pContact string, pAliasName string) *hls_settings.SContactImport {
+	return &hls_settings.SContactImport{
+		FContact:   pContact,
+		FAliasName: pAliasName,
+	}
+}
+
+func (p *sBuilder) Introduce(pAliasName1, pAliasName2 string) *hls_settings.SIntroduce {
+	return &hls_settings.SIntroduce{
+		FAliasNames: []string{pAliasName1, pAliasName2},
+	}
+}
+
+func (p *sBuilder) Introduction(pID string, pAliasName string) *hls_settings.SIntroduction {
+	return &hls_settings.SIntroduction{
+		FID:        pID,
+		FAliasName: pAliasName,
+	}
+}
+
+func (p *sBuilder) Service(pHostName string, pAddress string) *hls_settings.SService {
+	return &hls_settings.SService{
+		FHostName: pHostName,
+		FAddress:  pAddress,
+	}
+}
+
+func (p *sBuilder) Access(pHostName string, pAllow, pDeny []string) *hls_settings.SAccess {
+	return &hls_settings.SAccess{
+		FHostName: pHostName,
+		FAllow:    pAllow,
+		FDeny:     pDeny,
+	}
+}
+
+func (p *sBuilder) Request(pReceiver string, pReq request.IRequest) *hls_settings.SRequest {
+	return &hls_settings.SRequest{
+		FReceiver: pReceiver,
+		FReqData:  pReq.(*request.SRequest),
+	}
+}
+
+func (p *sBuilder) AsyncRequest(pReceiver string, pReq request.IRequest) *hls_settings.SRequest {
+	return &hls_settings.SRequest{
+		FReceiver: pReceiver,
+		FReqData:  pReq.(*request.SRequest),
+		FAsync:    true,
+	}
+}
+
+func (p *sBuilder) Group(pName string, pMembers []string) *hls_settings.SGroup {
+	return &hls_settings.SGroup{
+		FName:    pName,
+		FMembers: pMembers,
+	}
+}
+
+func (p *sBuilder) Multicast(pGroup string, pReceivers []string, pReq request.IRequest) *hls_settings.SMulticast {
+	return &hls_settings.SMulticast{
+		FGroup:     pGroup,
+		FReceivers: pReceivers,
+		FReqData:   pReq.(*request.SRequest),
+	}
+}
+
+func (p *sBuilder) Reply(pRequestID string, pRsp response.IResponse) *hls_settings.SReply {
+	if pRsp == nil {
+		// response mode 'off'
+		return &hls_settings.SReply{
+			FRequestID: pRequestID,
+		}
+	}
+	return &hls_settings.SReply{
+		FRequestID: pRequestID,
+		FResponse:  pRsp.(*response.SResponse),
+	}
+}
@@ -0,0 +1,379 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/number571/go-peer/pkg/crypto/asymmetric"
+	"github.com/number571/hidden-lake/pkg/handler"
+	"github.com/number571/hidden-lake/pkg/request"
+	"github.com/number571/hidden-lake/pkg/response"
+	hls_settings "github.com/number571/hidden-lake/pkg/service/settings"
+)
+
+var (
+	_ IClient = &sClient{}
+)
+
+type sClient struct {
+	fBuilder   IBuilder
+	fRequester IRequester
+}
+
+func NewClient(pBuilder IBuilder, pRequester IRequester) IClient {
+	return &sClient{
+		fBuilder:   pBuilder,
+		fRequester: pRequester,
+	}
+}
+
+func (p *sClient) GetIndex(pCtx context.Context) (string, error) {
+	res, err := p.fRequester.GetIndex(pCtx)
+	if err != nil {
+		return "", fmt.Errorf("get index (client): %w", err)
+	}
+	return res, nil
+}
+
+func (p *sClient) GetSettings(pCtx context.Context) (hls_settings.IConfigSettings, error) {
+	res, err := p.fRequester.GetSettings(pCtx)
+	if err != nil {
+		return nil, fmt.Errorf("get settings (client): %w", err)
+	}
+	return res, nil
+}
+
+func (p *sClient) ReloadConfig(pCtx context.Context) ([]string, error) {
+	res, err := p.fRequester.ReloadConfig(pCtx)
+	if err != nil {
+		return nil, fmt.Errorf("reload config (client): %w", err)
+	}
+	return res, nil
+}
+
+func (p *sClient) SendRequest(pCtx context.Context, pRecv string, pData request.IRequest) error {
+	if err := p.fRequester.SendRequest(pCtx, p.fBuilder.Request(pRecv, pData)); err != nil {
+		return fmt.Errorf("send request (client): %w", err)
+	}
+	return nil
+}
+
+func (p *sClient) SendMulticast(pCtx context.Context, pGroup string, pRecvs []string, pData request.IRequest) ([]*hls_settings.SMulticastResult, error) {
+	res, err := p.fRequester.SendMulticast(pCtx, p.fBuilder.Multicast(pGroup, pRecvs, pData))
+	if err != nil {
+		return nil, fmt.Errorf("send multicast (client): %w", err)
+	}
+	return res, nil
+}
+
+func (p *sClient) FetchRequest(pCtx context.Context, pRecv string, pData request.IRequest) (response.IResponse, error) {
+	res, err := p.fRequester.FetchRequest(pCtx, p.fBuilder.Request(pRecv, pData))
+	if err != nil {
+		return nil, fmt.Errorf("fetch request (client): %w", err)
+	}
+	return res, nil
+}
+
+func (p *sClient) FetchRequestAsync(pCtx context.Context, pRecv string, pData request.IRequest) (string, error) {
+	res, err := p.fRequester.FetchRequestAsync(pCtx, p.fBuilder.AsyncRequest(pRecv, pData))
+	if err != nil {
+		return "", fmt.Errorf("fetch request async (client): %w", err)
+	}
+	return res, nil
+}
+
+func (p *sClient) LoadTicket(pCtx context.Context, pTicketID string, pWait time.Duration) (response.IResponse, error) {
+	ticket, err := p.fRequester.GetTicket(pCtx, pTicketID, pWait)
+	if err != nil {
+		return nil, fmt.Errorf("load ticket (client): %w", err)
+	}
+	if !ticket.FDone {
+		return nil, ErrTicketPending
+	}
+	if ticket.FError != "" {
+		return nil, ErrTicketFailed
+	}
+	rsp, err := response.LoadResponse(ticket.FResponse)
+	if err != nil {
+		return nil, fmt.Errorf("load ticket (client): %w", errors.Join(ErrDecodeResponse, err))
+	}
+	return rsp, nil
+}
+
+func (p *sClient) DelTicket(pCtx context.Context, pTicketID string) error {
+	if err := p.fRequester.DelTicket(pCtx, pTicketID); err != nil {
+		return fmt.Errorf("del ticket (client): %w", err)
+	}
+	return nil
+}
+
+// ServeStream receives requests of the pull-mode service from HLS and
+// replies to them with the handler's responses. The nil response or
+// the handler's error mean that the response mode is 'off'.
+func (p *sClient) ServeStream(pCtx context.Context, pHostName string, pHandlerF handler.IHandlerF) error {
+	err := p.fRequester.ServiceStream(pCtx, pHostName, func(pIncoming *hls_settings.SIncoming) {
+		go p.serveIncoming(pCtx, pIncoming, pHandlerF)
+	})
+	if err != nil {
+		return fmt.Errorf("serve stream (client): %w", err)
+	}
+	return nil
+}
+
+func (p *sClient) ReplyRequest(pCtx context.Context, pRequestID string, pRsp response.IResponse) error {
+	if err := p.fRequester.ServiceReply(pCtx, p.fBuilder.Reply(pRequestID, pRsp)); err != nil {
+		return fmt.Errorf("reply request (client): %w", err)
+	}
+	return nil
+}
+
+func (p *sClient) serveIncoming(pCtx context.Context, pIncoming *hls_settings.SIncoming, pHandlerF handler.IHandlerF) {
+	var rsp response.IResponse
+
+	pubKey := asymmetric.LoadPubKey(pIncoming.FSender)
+	if pubKey != nil && pIncoming.FReqData != nil {
+		var err error
+		rsp, err = pHandlerF(pCtx, pubKey, pIncoming.FReqData)
+		if err != nil {
+			rsp = nil
+		}
+	}
+
+	_ = p.ReplyRequest(pCtx, pIncoming.FRequestID, rsp)
+}
+
+func (p *sClient) GetFriends(pCtx context.Context) (map[string]asymmetric.IPubKey, error) {
+	friends, err := p.fRequester.GetFriends(pCtx)
+	if err != nil {
+		return nil, fmt.Errorf("get friends (client): %w", err)
+	}
+
+	result := make(map[string]asymmetric.IPubKey, len(friends))
+	for _, friend := range friends {
+		pubKey := asymmetric.LoadPubKey(friend.FPublicKey)
+		if pubKey == nil {
+			return nil, fmt.Errorf("get friends (client): %w", ErrInvalidPublicKey)
+		}
+		result[friend.FAliasName] = pubKey
+	}
+
+	return result, nil
+}
+
+func (p *sClient) GetFriendsInfo(pCtx context.Context) ([]*hls_settings.SFriend, error) {
+	friends, err := p.fRequester.GetFriends(pCtx)
+	if err != nil {
+		return nil, fmt.Errorf("get friends info (client): %w", err)
+	}
+	return friends, nil
+}
+
+func (p *sClient) AddFriend(pCtx context.Context, pAliasName string, pPubKey asymmetric.IPubKey) error {
+	if err := p.fRequester.AddFriend(pCtx, p.fBuilder.Friend(pAliasName, pPubKey)); err != nil {
+		return fmt.Errorf("add friend (client): %w", err)
+	}
+	return nil
+}
+
+func (p *sClient) UpdateFriend(pCtx context.Context, pAliasName string, pNotes string, pTags []string) error {
+	if err := p.fRequester.UpdateFriend(pCtx, p.fBuilder.FriendInfo(pAliasName, pNotes, pTags)); err != nil {
+		return fmt.Errorf("update friend (client): %w", err)
+	}
+	return nil
+}
+
+func (p *sClient) AddTempFriend(pCtx context.Context, pAliasName string, pPubKey asymmetric.IPubKey, pTTL time.Duration) error {
+	if err := p.fRequester.AddFriend(pCtx, p.fBuilder.TempFriend(pAliasName, pPubKey, pTTL)); err != nil {
+		return fmt.Errorf("add temp friend (client): %w", err)
+	}
+	return nil
+}
+
+func (p *sClient) ExtendFriend(pCtx context.Context, pAliasName string, pTTL time.Duration) error {
+	if err := p.fRequester.UpdateFriend(pCtx, p.fBuilder.TempFriend(pAliasName, nil, pTTL)); err != nil {
+		return fmt.Errorf("extend friend (client): %w", err)
+	}
+	return nil
+}
+
+func (p *sClient) DelFriend(pCtx context.Context, pAliasName string) error {
+	if err := p.fRequester.DelFriend(pCtx, p.fBuilder.Friend(pAliasName, nil)); err != nil {
+		return fmt.Errorf("del friend (client): %w", err)
+	}
+	return nil
+}
+
+func (p *sClient) ExportContact(pCtx context.Context, pAliasName string, pConnections []string) (*hls_settings.SContactExport, error) {
+	res, err := p.fRequester.ExportContact(pCtx, pAliasName, pConnections)
+	if err != nil {
+		return nil, fmt.Errorf("export contact (client): %w", err)
+	}
+	return res, nil
+}
+
+func (p *sClient) ImportContact(pCtx context.Context, pContact string, pAliasName string) (*hls_settings.SFriend, error) {
+	res, err := p.fRequester.ImportContact(pCtx, p.fBuilder.Contact(pContact, pAliasName))
+	if err != nil {
+		return nil, fmt.Errorf("import contact (client): %w", err)
+	}
+	return res, nil
+}
+
+func (p *sClient) GetIdentities(pCtx context.Context) ([]*hls_settings.SIdentity, error) {
+	res, err := p.fRequester.GetIdentities(pCtx)
+	if err != nil {
+		return nil, fmt.Errorf("get identities (client): %w", err)
+	}
+	return res, nil
+}
+
+func (p *sClient) GetIntroductions(pCtx context.Context) ([]*hls_settings.SIntroduction, error) {
+	res, err := p.fRequester.GetIntroductions(pCtx)
+	if err != nil {
+		return nil, fmt.Errorf("get introductions (client): %w", err)
+	}
+	return res, nil
+}
+
+func (p *sClient) Introduce(pCtx context.Context, pAliasName1, pAliasName2 string) error {
+	if err := p.fRequester.Introduce(pCtx, p.fBuilder.Introduce(pAliasName1, pAliasName2)); err != nil {
+		return fmt.Errorf("introduce (client): %w", err)
+	}
+	return nil
+}
+
+func (p *sClient) AcceptIntroduction(pCtx context.Context, pID string, pAliasName string) (*hls_settings.SFriend, error) {
+	res, err := p.fRequester.AcceptIntroduction(pCtx, p.fBuilder.Introduction(pID, pAliasName))
+	if err != nil {
+		return nil, fmt.Errorf("accept introduction (client): %w", err)
+	}
+	return res, nil
+}
+
+func (p *sClient) RejectIntroduction(pCtx context.Context, pID string) error {
+	if err := p.fRequester.RejectIntroduction(pCtx, p.fBuilder.Introduction(pID, "")); err != nil {
+		return fmt.Errorf("reject introduction (client): %w", err)
+	}
+	return nil
+}
+
+func (p *sClient) GetServices(pCtx context.Context) (map[string]string, error) {
+	res, err := p.fRequester.GetServices(pCtx)
+	if err != nil {
+		return nil, fmt.Errorf("get services (client): %w", err)
+	}
+	return res, nil
+}
+
+func (p *sClient) AddService(pCtx context.Context, pHostName string, pAddress string) error {
+	if err := p.fRequester.AddService(pCtx, p.fBuilder.Service(pHostName, pAddress)); err != nil {
+		return fmt.Errorf("add service (client): %w", err)
+	}
+	return nil
+}
+
+func (p *sClient) DelService(pCtx context.Context, pHostName string) error {
+	if err := p.fRequester.DelService(pCtx, p.fBuilder.Service(pHostName, "")); err != nil {
+		return fmt.Errorf("del service (client): %w", err)
+	}
+	return nil
+}
+
+func (p *sClient) GetGroups(pCtx context.Context) (map[string][]string, error) {
+	res, err := p.fRequester.GetGroups(pCtx)
+	if err != nil {
+		return nil, fmt.Errorf("get groups (client): %w", err)
+	}
+	return res, nil
+}
+
+func (p *sClient) SetGroup(pCtx context.Context, pName string, pMembers []string) error {
+	if err := p.fRequester.SetGroup(pCtx, p.fBuilder.Group(pName, pMembers)); err != nil {
+		return fmt.Errorf("set group (client): %w", err)
+	}
+	return nil
+}
+
+func (p *sClient) DelGroup(pCtx context.Context, pName string) error {
+	if err := p.fRequester.DelGroup(pCtx, p.fBuilder.Group(pName, nil)); err != nil {
+		return fmt.Errorf("del group (client): %w", err)
+	}
+	return nil
+}
+
+func (p *sClient) GetAccess(pCtx context.Context) (map[string]*hls_settings.SAccess, error) {
+	res, err := p.fRequester.GetAccess(pCtx)
+	if err != nil {
+		return nil, fmt.Errorf("get access (client): %w", err)
+	}
+	return res, nil
+}
+
+func (p *sClient) SetAccess(pCtx context.Context, pHostName string, pAllow, pDeny []string) error {
+	if err := p.fRequester.SetAccess(pCtx, p.fBuilder.Access(pHostName, pAllow, pDeny)); err != nil {
+		return fmt.Errorf("set access (client): %w", err)
+	}
+	return nil
+}
+
+func (p *sClient) DelAccess(pCtx context.Context, pHostName string) error {
+	if err := p.fRequester.DelAccess(pCtx, p.fBuilder.Access(pHostName, nil, nil)); err != nil {
+		return fmt.Errorf("del access (client): %w", err)
+	}
+	return nil
+}
+
+func (p *sClient) GetOnlines(pCtx context.Context) ([]string, error) {
+	res, err := p.fRequester.GetOnlines(pCtx)
+	if err != nil {
+		return nil, fmt.Errorf("get onlines (client): %w", err)
+	}
+	return res, nil
+}
+
+func (p *sClient) DelOnline(pCtx context.Context, pConnect string) error {
+	if err := p.fRequester.DelOnline(pCtx, pConnect); err != nil {
+		return fmt.Errorf("del online (client): %w", err)
+	}
+	return nil
+}
+
+func (p *sClient) GetConnections(pCtx context.Context) ([]string, error) {
+	res, err := p.fRequester.GetConnections(pCtx)
+	if err != nil {
+		return nil, fmt.Errorf("get connections (client): %w", err)
+	}
+	return res, nil
+}
+
+func (p *sClient) AddConnection(pCtx context.Context, pConnect string) error {
+	if err := p.fRequester.AddConnection(pCtx, pConnect); err != nil {
+		return fmt.Errorf("add connection (client): %w", err)
+	}
+	return nil
+}
+
+func (p *sClient) DelConnection(pCtx context.Context, pConnect string) error {
+	if err := p.fRequester.DelConnection(pCtx, pConnect); err != nil {
+		return fmt.Errorf("del connection (client): %w", err)
+	}
+	return nil
+}
+
+func (p *sClient) GetPubKey(pCtx context.Context) (asymmetric.IPubKey, error) {
+	pubKey, err := p.fRequester.GetPubKey(pCtx)
+	if err != nil {
+		return nil, fmt.Errorf("get public key (client): %w", err)
+	}
+	return pubKey, nil
+}
+
+func (p *sClient) GetLimits(pCtx context.Context) ([]*hls_settings.SLimitCounter, error) {
+	res, err := p.fRequester.ServiceLimits(pCtx)
+	if err != nil {
+		return nil, fmt.Errorf("get limits (client): %w", err)
+	}
+	return res, nil
+}
@@ -0,0 +1,210 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/number571/go-peer/pkg/crypto/asymmetric"
+	"github.com/number571/go-peer/pkg/encoding"
+	"github.com/number571/hidden-lake/internal/utils/socket"
+	hls_settings "github.com/number571/hidden-lake/pkg/service/settings"
+	testutils "github.com/number571/hidden-lake/test/utils"
+)
+
+const (
+	tcToken = "secret-token"
+)
+
+var (
+	tgPubKey = asymmetric.NewPrivKey().GetPubKey()
+)
+
+func TestError(t *testing.T) {
+	t.Parallel()
+
+	str := "value"
+	err := &SClientError{str}
+	if err.Error() != errPrefix+str {
+		t.Error("incorrect err.Error()")
+		return
+	}
+
+	statusErr := &SStatusError{FCode: http.StatusNotFound, FMessage: str}
+	if statusErr.Error() != errPrefix+"status code 404: "+str {
+		t.Error("incorrect statusErr.Error()")
+		return
+	}
+}
+
+func TestSettings(t *testing.T) {
+	t.Parallel()
+
+	sett := NewSettings(nil)
+	if sett.GetRetryNum() != 0 || sett.GetRetryDelay() != cDefaultRetryDelay || sett.GetToken() != "" || sett.GetIdentity() != "" {
+		t.Error("got invalid default settings")
+		return
+	}
+}
+
+func TestRequester(t *testing.T) {
+	t.Parallel()
+
+	addr := testutils.TgAddrs[13]
+
+	var counter atomic.Int64
+	mux := http.NewServeMux()
+	mux.HandleFunc(hls_settings.CHandleIndexPath, func(w http.ResponseWriter, r *http.Request) {
+		// the first request of each pair is failed
+		if counter.Add(1)%2 == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(hls_settings.CServiceFullName))
+	})
+	mux.HandleFunc(hls_settings.CHandleConfigConnectsPath, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	mux.HandleFunc(hls_settings.CHandleServicePubKeyPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+tcToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(tgPubKey.ToString()))
+	})
+	mux.HandleFunc(hls_settings.CHandleConfigIdentitiesPath, func(w http.ResponseWriter, r *http.Request) {
+		// returns the selected identity
+		_, _ = w.Write(encoding.SerializeJSON([]*hls_settings.SIdentity{{
+			FName:      r.Header.Get(hls_settings.CHeaderIdentity),
+			FPublicKey: tgPubKey.ToString(),
+		}}))
+	})
+
+	srv := &http.Server{
+		Addr:        addr,
+		Handler:     mux,
+		ReadTimeout: time.Second,
+	}
+	defer srv.Close()
+	go func() { _ = srv.ListenAndServe() }()
+
+	time.Sleep(200 * time.Millisecond)
+
+	ctx := context.Background()
+	httpClient := &http.Client{Timeout: time.Minute}
+
+	client := NewClient(
+		NewBuilder(),
+		NewRequester(addr, httpClient, NewSettings(&SSettings{
+			FRetryNum:   1,
+			FRetryDelay: 10 * time.Millisecond,
+		})),
+	)
+	if _, err := client.GetIndex(ctx); err != nil {
+		t.Error(err)
+		return
+	}
+
+	// POST is not idempotent
+	err := client.AddConnection(ctx, "tcp://localhost:9999")
+	var statusErr *SStatusError
+	if !errors.As(err, &statusErr) || statusErr.FCode != http.StatusServiceUnavailable {
+		t.Error("got invalid status error")
+		return
+	}
+	if !errors.Is(err, ErrBadRequest) {
+		t.Error("got invalid error type")
+		return
+	}
+
+	clientNoRetry := NewClient(
+		NewBuilder(),
+		NewRequester(addr, httpClient, NewSettings(nil)),
+	)
+	counter.Store(0)
+	if _, err := clientNoRetry.GetIndex(ctx); err == nil {
+		t.Error("success request without retry")
+		return
+	}
+
+	if _, err := clientNoRetry.GetPubKey(ctx); err == nil {
+		t.Error("success request without token")
+		return
+	}
+
+	clientToken := NewClient(
+		NewBuilder(),
+		NewRequester(addr, httpClient, NewSettings(&SSettings{FToken: tcToken})),
+	)
+	pubKey, err := clientToken.GetPubKey(ctx)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if pubKey.ToString() != tgPubKey.ToString() {
+		t.Error("got invalid public key")
+		return
+	}
+
+	clientIdentity := NewClient(
+		NewBuilder(),
+		NewRequester(addr, httpClient, NewSettings(&SSettings{FIdentity: "work"})),
+	)
+	identities, err := clientIdentity.GetIdentities(ctx)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(identities) != 1 || identities[0].FName != "work" {
+		t.Error("identity is not passed")
+		return
+	}
+
+	clientUnknown := NewClient(
+		NewBuilder(),
+		NewRequester(testutils.TcUnknownHost, httpClient, NewSettings(&SSettings{
+			FRetryNum:   1 << 10,
+			FRetryDelay: time.Minute,
+		})),
+	)
+	ctxTimeout, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	if _, err := clientUnknown.GetIndex(ctxTimeout); !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("retries are not stopped by context")
+		return
+	}
+}
+
+func TestRequesterUnix(t *testing.T) {
+	t.Parallel()
+
+	addr := socket.CUnixScheme + filepath.Join(t.TempDir(), "hls.sock")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(hls_settings.CHandleIndexPath, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(hls_settings.CServiceFullName))
+	})
+
+	srv := &http.Server{
+		Addr:        addr,
+		Handler:     mux,
+		ReadTimeout: time.Second,
+	}
+	defer srv.Close()
+	go func() { _ = socket.ListenAndServe(srv) }()
+
+	time.Sleep(200 * time.Millisecond)
+
+	client := NewClient(
+		NewBuilder(),
+		NewRequester(addr, &http.Client{Timeout: time.Minute}, NewSettings(nil)),
+	)
+	if _, err := client.GetIndex(context.Background()); err != nil {
+		t.Error(err)
+		return
+	}
+}
@@ -0,0 +1,37 @@
+package client
+
+import "fmt"
+
+const (
+	errPrefix = "pkg/service/client = "
+)
+
+type SClientError struct {
+	str string
+}
+
+func (err *SClientError) Error() string {
+	return errPrefix + err.str
+}
+
+// SStatusError is returned (joined with ErrBadRequest) when
+// the service responds with an unsuccessful status code.
+type SStatusError struct {
+	FCode    int
+	FMessage string
+}
+
+func (err *SStatusError) Error() string {
+	return fmt.Sprintf("%sstatus code %d: %s", errPrefix, err.FCode, err.FMessage)
+}
+
+var (
+	ErrBuildRequest     = &SClientError{"build request"}
+	ErrBadRequest       = &SClientError{"bad request"}
+	ErrDecodeResponse   = &SClientError{"decode response"}
+	ErrInvalidPublicKey = &SClientError{"invalid public key"}
+	ErrInvalidTitle     = &SClientError{"invalid title"}
+	ErrTicketPending    = &SClientError{"ticket pending"}
+	ErrTicketFailed     = &SClientError{"ticket failed"}
+	ErrStreamClosed     = &SClientError{"stream closed"}
+)
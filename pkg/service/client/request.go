@@ -0,0 +1,108 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/number571/go-peer/pkg/encoding"
+	"github.com/number571/hidden-lake/internal/utils/api"
+	hls_settings "github.com/number571/hidden-lake/pkg/service/settings"
+)
+
+func (p *sRequester) request(
+	pCtx context.Context,
+	pMethod, pURL string,
+	pData interface{},
+) ([]byte, error) {
+	retryNum := p.fSettings.GetRetryNum()
+	for i := uint64(0); ; i++ {
+		rsp, retry, err := p.tryRequest(pCtx, pMethod, pURL, pData)
+		if err == nil {
+			return rsp, nil
+		}
+		if !retry || i >= retryNum {
+			return nil, err
+		}
+		select {
+		case <-pCtx.Done():
+			return nil, errors.Join(err, pCtx.Err())
+		case <-time.After(p.fSettings.GetRetryDelay()):
+		}
+	}
+}
+
+func (p *sRequester) tryRequest(
+	pCtx context.Context,
+	pMethod, pURL string,
+	pData interface{},
+) ([]byte, bool, error) {
+	var (
+		contentType string
+		reqBytes    []byte
+	)
+
+	switch x := pData.(type) {
+	case nil:
+		contentType = api.CTextPlain
+	case []byte:
+		contentType = api.CTextPlain
+		reqBytes = x
+	case string:
+		contentType = api.CTextPlain
+		reqBytes = []byte(x)
+	default:
+		contentType = api.CApplicationJSON
+		reqBytes = encoding.SerializeJSON(x)
+	}
+
+	req, err := http.NewRequestWithContext(pCtx, pMethod, pURL, bytes.NewReader(reqBytes))
+	if err != nil {
+		return nil, false, errors.Join(ErrBuildRequest, err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	p.setHeaders(req)
+
+	resp, err := p.fClient.Do(req)
+	if err != nil {
+		retry := isIdempotent(pMethod) || isDialError(err)
+		return nil, retry && pCtx.Err() == nil, err
+	}
+	defer resp.Body.Close()
+
+	result, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, isIdempotent(pMethod), err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		statusErr := &SStatusError{FCode: resp.StatusCode, FMessage: string(result)}
+		retry := isIdempotent(pMethod) && resp.StatusCode >= http.StatusInternalServerError
+		return nil, retry, statusErr
+	}
+
+	return result, false, nil
+}
+
+func isIdempotent(pMethod string) bool {
+	return pMethod == http.MethodGet || pMethod == http.MethodDelete
+}
+
+// the request is not sent if the connection is not established
+func isDialError(pErr error) bool {
+	var opErr *net.OpError
+	return errors.As(pErr, &opErr) && opErr.Op == "dial"
+}
+
+func (p *sRequester) setHeaders(pReq *http.Request) {
+	if token := p.fSettings.GetToken(); token != "" {
+		pReq.Header.Set("Authorization", "Bearer "+token)
+	}
+	if identity := p.fSettings.GetIdentity(); identity != "" {
+		pReq.Header.Set(hls_settings.CHeaderIdentity, identity)
+	}
+}
@@ -0,0 +1,711 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/number571/go-peer/pkg/crypto/asymmetric"
+	"github.com/number571/go-peer/pkg/encoding"
+	"github.com/number571/hidden-lake/internal/utils/socket"
+	"github.com/number571/hidden-lake/pkg/response"
+	hls_settings "github.com/number571/hidden-lake/pkg/service/settings"
+)
+
+var (
+	_ IRequester = &sRequester{}
+)
+
+const (
+	cHandleIndexTemplate               = "http://" + "%s" + hls_settings.CHandleIndexPath
+	cHandleConfigSettingsTemplate      = "http://" + "%s" + hls_settings.CHandleConfigSettingsPath
+	cHandleConfigConnectsTemplate      = "http://" + "%s" + hls_settings.CHandleConfigConnectsPath
+	cHandleConfigFriendsTemplate       = "http://" + "%s" + hls_settings.CHandleConfigFriendsPath
+	cHandleConfigReloadTemplate        = "http://" + "%s" + hls_settings.CHandleConfigReloadPath
+	cHandleConfigServicesTemplate      = "http://" + "%s" + hls_settings.CHandleConfigServicesPath
+	cHandleConfigContactTemplate       = "http://" + "%s" + hls_settings.CHandleConfigContactPath
+	cHandleConfigContactNewTemplate    = "http://" + "%s" + hls_settings.CHandleConfigContactNewPath
+	cHandleConfigIntroductionsTemplate = "http://" + "%s" + hls_settings.CHandleConfigIntroductionsPath
+	cHandleConfigAccessTemplate        = "http://" + "%s" + hls_settings.CHandleConfigAccessPath
+	cHandleConfigIdentitiesTemplate    = "http://" + "%s" + hls_settings.CHandleConfigIdentitiesPath
+	cHandleConfigGroupsTemplate        = "http://" + "%s" + hls_settings.CHandleConfigGroupsPath
+	cHandleNetworkOnlineTemplate       = "http://" + "%s" + hls_settings.CHandleNetworkOnlinePath
+	cHandleNetworkRequestTemplate      = "http://" + "%s" + hls_settings.CHandleNetworkRequestPath
+	cHandleNetworkMulticastTemplate    = "http://" + "%s" + hls_settings.CHandleNetworkMulticastPath
+	cHandleNetworkTicketTemplate       = "http://" + "%s" + hls_settings.CHandleNetworkTicketPath
+	cHandleServicePubKeyTemplate       = "http://" + "%s" + hls_settings.CHandleServicePubKeyPath
+	cHandleServiceStreamTemplate       = "http://" + "%s" + hls_settings.CHandleServiceStreamPath
+	cHandleServiceReplyTemplate        = "http://" + "%s" + hls_settings.CHandleServiceReplyPath
+	cHandleServiceLimitsTemplate       = "http://" + "%s" + hls_settings.CHandleServiceLimitsPath
+)
+
+type sRequester struct {
+	fHost     string
+	fClient   *http.Client
+	fSettings ISettings
+}
+
+// NewRequester creates the requester to the HLS API. The host can be
+// the unix socket address (unix:///path/to/socket).
+func NewRequester(pHost string, pClient *http.Client, pSettings ISettings) IRequester {
+	host, client := socket.WrapHTTPClient(pHost, pClient)
+	return &sRequester{
+		fHost:     host,
+		fClient:   client,
+		fSettings: pSettings,
+	}
+}
+
+func (p *sRequester) GetIndex(pCtx context.Context) (string, error) {
+	res, err := p.request(
+		pCtx,
+		http.MethodGet,
+		fmt.Sprintf(cHandleIndexTemplate, p.fHost),
+		nil,
+	)
+	if err != nil {
+		return "", errors.Join(ErrBadRequest, err)
+	}
+
+	result := string(res)
+	if result != hls_settings.CServiceFullName {
+		return "", ErrInvalidTitle
+	}
+
+	return result, nil
+}
+
+func (p *sRequester) GetSettings(pCtx context.Context) (hls_settings.IConfigSettings, error) {
+	res, err := p.request(
+		pCtx,
+		http.MethodGet,
+		fmt.Sprintf(cHandleConfigSettingsTemplate, p.fHost),
+		nil,
+	)
+	if err != nil {
+		return nil, errors.Join(ErrBadRequest, err)
+	}
+
+	cfgSettings := new(hls_settings.SConfigSettings)
+	if err := encoding.DeserializeJSON(res, cfgSettings); err != nil {
+		return nil, errors.Join(ErrDecodeResponse, err)
+	}
+
+	return cfgSettings, nil
+}
+
+func (p *sRequester) ReloadConfig(pCtx context.Context) ([]string, error) {
+	res, err := p.request(
+		pCtx,
+		http.MethodPost,
+		fmt.Sprintf(cHandleConfigReloadTemplate, p.fHost),
+		nil,
+	)
+	if err != nil {
+		return nil, errors.Join(ErrBadRequest, err)
+	}
+
+	var vReload hls_settings.SReload
+	if err := encoding.DeserializeJSON(res, &vReload); err != nil {
+		return nil, errors.Join(ErrDecodeResponse, err)
+	}
+
+	return vReload.FRestartRequired, nil
+}
+
+func (p *sRequester) FetchRequest(pCtx context.Context, pRequest *hls_settings.SRequest) (response.IResponse, error) {
+	res, err := p.request(
+		pCtx,
+		http.MethodPost,
+		fmt.Sprintf(cHandleNetworkRequestTemplate, p.fHost),
+		pRequest,
+	)
+	if err != nil {
+		return nil, errors.Join(ErrBadRequest, err)
+	}
+
+	resp, err := response.LoadResponse(string(res))
+	if err != nil {
+		return nil, errors.Join(ErrDecodeResponse, err)
+	}
+	return resp, nil
+}
+
+func (p *sRequester) SendRequest(pCtx context.Context, pRequest *hls_settings.SRequest) error {
+	_, err := p.request(
+		pCtx,
+		http.MethodPut,
+		fmt.Sprintf(cHandleNetworkRequestTemplate, p.fHost),
+		pRequest,
+	)
+	if err != nil {
+		return errors.Join(ErrBadRequest, err)
+	}
+	return nil
+}
+
+func (p *sRequester) SendMulticast(pCtx context.Context, pMulticast *hls_settings.SMulticast) ([]*hls_settings.SMulticastResult, error) {
+	res, err := p.request(
+		pCtx,
+		http.MethodPost,
+		fmt.Sprintf(cHandleNetworkMulticastTemplate, p.fHost),
+		pMulticast,
+	)
+	if err != nil {
+		return nil, errors.Join(ErrBadRequest, err)
+	}
+
+	var vResults []*hls_settings.SMulticastResult
+	if err := encoding.DeserializeJSON(res, &vResults); err != nil {
+		return nil, errors.Join(ErrDecodeResponse, err)
+	}
+
+	return vResults, nil
+}
+
+func (p *sRequester) FetchRequestAsync(pCtx context.Context, pRequest *hls_settings.SRequest) (string, error) {
+	res, err := p.request(
+		pCtx,
+		http.MethodPost,
+		fmt.Sprintf(cHandleNetworkRequestTemplate, p.fHost),
+		pRequest,
+	)
+	if err != nil {
+		return "", errors.Join(ErrBadRequest, err)
+	}
+	return string(res), nil
+}
+
+func (p *sRequester) GetTicket(pCtx context.Context, pTicketID string, pWait time.Duration) (*hls_settings.STicket, error) {
+	query := url.Values{}
+	query.Set("id", pTicketID)
+	query.Set("wait_ms", strconv.FormatInt(pWait.Milliseconds(), 10))
+
+	res, err := p.request(
+		pCtx,
+		http.MethodGet,
+		fmt.Sprintf(cHandleNetworkTicketTemplate, p.fHost)+"?"+query.Encode(),
+		nil,
+	)
+	if err != nil {
+		return nil, errors.Join(ErrBadRequest, err)
+	}
+
+	ticket := new(hls_settings.STicket)
+	if err := encoding.DeserializeJSON(res, ticket); err != nil {
+		return nil, errors.Join(ErrDecodeResponse, err)
+	}
+	return ticket, nil
+}
+
+func (p *sRequester) DelTicket(pCtx context.Context, pTicketID string) error {
+	_, err := p.request(
+		pCtx,
+		http.MethodDelete,
+		fmt.Sprintf(cHandleNetworkTicketTemplate, p.fHost),
+		pTicketID,
+	)
+	if err != nil {
+		return errors.Join(ErrBadRequest, err)
+	}
+	return nil
+}
+
+func (p *sRequester) GetFriends(pCtx context.Context) ([]*hls_settings.SFriend, error) {
+	res, err := p.request(
+		pCtx,
+		http.MethodGet,
+		fmt.Sprintf(cHandleConfigFriendsTemplate, p.fHost),
+		nil,
+	)
+	if err != nil {
+		return nil, errors.Join(ErrBadRequest, err)
+	}
+
+	var vFriends []*hls_settings.SFriend
+	if err := encoding.DeserializeJSON(res, &vFriends); err != nil {
+		return nil, errors.Join(ErrDecodeResponse, err)
+	}
+
+	return vFriends, nil
+}
+
+func (p *sRequester) AddFriend(pCtx context.Context, pFriend *hls_settings.SFriend) error {
+	_, err := p.request(
+		pCtx,
+		http.MethodPost,
+		fmt.Sprintf(cHandleConfigFriendsTemplate, p.fHost),
+		pFriend,
+	)
+	if err != nil {
+		return errors.Join(ErrBadRequest, err)
+	}
+	return nil
+}
+
+func (p *sRequester) UpdateFriend(pCtx context.Context, pFriend *hls_settings.SFriend) error {
+	_, err := p.request(
+		pCtx,
+		http.MethodPut,
+		fmt.Sprintf(cHandleConfigFriendsTemplate, p.fHost),
+		pFriend,
+	)
+	if err != nil {
+		return errors.Join(ErrBadRequest, err)
+	}
+	return nil
+}
+
+func (p *sRequester) DelFriend(pCtx context.Context, pFriend *hls_settings.SFriend) error {
+	_, err := p.request(
+		pCtx,
+		http.MethodDelete,
+		fmt.Sprintf(cHandleConfigFriendsTemplate, p.fHost),
+		pFriend,
+	)
+	if err != nil {
+		return errors.Join(ErrBadRequest, err)
+	}
+	return nil
+}
+
+// ExportContact returns the default contact of node (GET) or creates the new
+// contact (POST) if the alias name or the connections are set.
+func (p *sRequester) ExportContact(pCtx context.Context, pAliasName string, pConnections []string) (*hls_settings.SContactExport, error) {
+	var (
+		res []byte
+		err error
+	)
+	if pAliasName == "" && len(pConnections) == 0 {
+		res, err = p.request(
+			pCtx,
+			http.MethodGet,
+			fmt.Sprintf(cHandleConfigContactTemplate, p.fHost),
+			nil,
+		)
+	} else {
+		res, err = p.request(
+			pCtx,
+			http.MethodPost,
+			fmt.Sprintf(cHandleConfigContactNewTemplate, p.fHost),
+			hls_settings.SContactNew{
+				FAliasName:   pAliasName,
+				FConnections: pConnections,
+			},
+		)
+	}
+	if err != nil {
+		return nil, errors.Join(ErrBadRequest, err)
+	}
+
+	export := new(hls_settings.SContactExport)
+	if err := encoding.DeserializeJSON(res, export); err != nil {
+		return nil, errors.Join(ErrDecodeResponse, err)
+	}
+	return export, nil
+}
+
+func (p *sRequester) ImportContact(pCtx context.Context, pContact *hls_settings.SContactImport) (*hls_settings.SFriend, error) {
+	res, err := p.request(
+		pCtx,
+		http.MethodPost,
+		fmt.Sprintf(cHandleConfigContactTemplate, p.fHost),
+		pContact,
+	)
+	if err != nil {
+		return nil, errors.Join(ErrBadRequest, err)
+	}
+
+	friend := new(hls_settings.SFriend)
+	if err := encoding.DeserializeJSON(res, friend); err != nil {
+		return nil, errors.Join(ErrDecodeResponse, err)
+	}
+	return friend, nil
+}
+
+func (p *sRequester) GetIdentities(pCtx context.Context) ([]*hls_settings.SIdentity, error) {
+	res, err := p.request(
+		pCtx,
+		http.MethodGet,
+		fmt.Sprintf(cHandleConfigIdentitiesTemplate, p.fHost),
+		nil,
+	)
+	if err != nil {
+		return nil, errors.Join(ErrBadRequest, err)
+	}
+
+	var vIdentities []*hls_settings.SIdentity
+	if err := encoding.DeserializeJSON(res, &vIdentities); err != nil {
+		return nil, errors.Join(ErrDecodeResponse, err)
+	}
+	return vIdentities, nil
+}
+
+func (p *sRequester) GetIntroductions(pCtx context.Context) ([]*hls_settings.SIntroduction, error) {
+	res, err := p.request(
+		pCtx,
+		http.MethodGet,
+		fmt.Sprintf(cHandleConfigIntroductionsTemplate, p.fHost),
+		nil,
+	)
+	if err != nil {
+		return nil, errors.Join(ErrBadRequest, err)
+	}
+
+	var vIntroductions []*hls_settings.SIntroduction
+	if err := encoding.DeserializeJSON(res, &vIntroductions); err != nil {
+		return nil, errors.Join(ErrDecodeResponse, err)
+	}
+	return vIntroductions, nil
+}
+
+func (p *sRequester) Introduce(pCtx context.Context, pIntroduce *hls_settings.SIntroduce) error {
+	_, err := p.request(
+		pCtx,
+		http.MethodPost,
+		fmt.Sprintf(cHandleConfigIntroductionsTemplate, p.fHost),
+		pIntroduce,
+	)
+	if err != nil {
+		return errors.Join(ErrBadRequest, err)
+	}
+	return nil
+}
+
+func (p *sRequester) AcceptIntroduction(pCtx context.Context, pIntroduction *hls_settings.SIntroduction) (*hls_settings.SFriend, error) {
+	res, err := p.request(
+		pCtx,
+		http.MethodPut,
+		fmt.Sprintf(cHandleConfigIntroductionsTemplate, p.fHost),
+		pIntroduction,
+	)
+	if err != nil {
+		return nil, errors.Join(ErrBadRequest, err)
+	}
+
+	friend := new(hls_settings.SFriend)
+	if err := encoding.DeserializeJSON(res, friend); err != nil {
+		return nil, errors.Join(ErrDecodeResponse, err)
+	}
+	return friend, nil
+}
+
+func (p *sRequester) RejectIntroduction(pCtx context.Context, pIntroduction *hls_settings.SIntroduction) error {
+	_, err := p.request(
+		pCtx,
+		http.MethodDelete,
+		fmt.Sprintf(cHandleConfigIntroductionsTemplate, p.fHost),
+		pIntroduction,
+	)
+	if err != nil {
+		return errors.Join(ErrBadRequest, err)
+	}
+	return nil
+}
+
+func (p *sRequester) GetServices(pCtx context.Context) (map[string]string, error) {
+	res, err := p.request(
+		pCtx,
+		http.MethodGet,
+		fmt.Sprintf(cHandleConfigServicesTemplate, p.fHost),
+		nil,
+	)
+	if err != nil {
+		return nil, errors.Join(ErrBadRequest, err)
+	}
+
+	var vServices []hls_settings.SService
+	if err := encoding.DeserializeJSON(res, &vServices); err != nil {
+		return nil, errors.Join(ErrDecodeResponse, err)
+	}
+
+	result := make(map[string]string, len(vServices))
+	for _, service := range vServices {
+		result[service.FHostName] = service.FAddress
+	}
+
+	return result, nil
+}
+
+func (p *sRequester) AddService(pCtx context.Context, pService *hls_settings.SService) error {
+	_, err := p.request(
+		pCtx,
+		http.MethodPost,
+		fmt.Sprintf(cHandleConfigServicesTemplate, p.fHost),
+		pService,
+	)
+	if err != nil {
+		return errors.Join(ErrBadRequest, err)
+	}
+	return nil
+}
+
+func (p *sRequester) DelService(pCtx context.Context, pService *hls_settings.SService) error {
+	_, err := p.request(
+		pCtx,
+		http.MethodDelete,
+		fmt.Sprintf(cHandleConfigServicesTemplate, p.fHost),
+		pService,
+	)
+	if err != nil {
+		return errors.Join(ErrBadRequest, err)
+	}
+	return nil
+}
+
+func (p *sRequester) GetGroups(pCtx context.Context) (map[string][]string, error) {
+	res, err := p.request(
+		pCtx,
+		http.MethodGet,
+		fmt.Sprintf(cHandleConfigGroupsTemplate, p.fHost),
+		nil,
+	)
+	if err != nil {
+		return nil, errors.Join(ErrBadRequest, err)
+	}
+
+	var vGroups []*hls_settings.SGroup
+	if err := encoding.DeserializeJSON(res, &vGroups); err != nil {
+		return nil, errors.Join(ErrDecodeResponse, err)
+	}
+
+	result := make(map[string][]string, len(vGroups))
+	for _, group := range vGroups {
+		result[group.FName] = group.FMembers
+	}
+
+	return result, nil
+}
+
+func (p *sRequester) SetGroup(pCtx context.Context, pGroup *hls_settings.SGroup) error {
+	_, err := p.request(
+		pCtx,
+		http.MethodPost,
+		fmt.Sprintf(cHandleConfigGroupsTemplate, p.fHost),
+		pGroup,
+	)
+	if err != nil {
+		return errors.Join(ErrBadRequest, err)
+	}
+	return nil
+}
+
+func (p *sRequester) DelGroup(pCtx context.Context, pGroup *hls_settings.SGroup) error {
+	_, err := p.request(
+		pCtx,
+		http.MethodDelete,
+		fmt.Sprintf(cHandleConfigGroupsTemplate, p.fHost),
+		pGroup,
+	)
+	if err != nil {
+		return errors.Join(ErrBadRequest, err)
+	}
+	return nil
+}
+
+func (p *sRequester) GetAccess(pCtx context.Context) (map[string]*hls_settings.SAccess, error) {
+	res, err := p.request(
+		pCtx,
+		http.MethodGet,
+		fmt.Sprintf(cHandleConfigAccessTemplate, p.fHost),
+		nil,
+	)
+	if err != nil {
+		return nil, errors.Join(ErrBadRequest, err)
+	}
+
+	var vAccess []*hls_settings.SAccess
+	if err := encoding.DeserializeJSON(res, &vAccess); err != nil {
+		return nil, errors.Join(ErrDecodeResponse, err)
+	}
+
+	result := make(map[string]*hls_settings.SAccess, len(vAccess))
+	for _, access := range vAccess {
+		result[access.FHostName] = access
+	}
+
+	return result, nil
+}
+
+func (p *sRequester) SetAccess(pCtx context.Context, pAccess *hls_settings.SAccess) error {
+	_, err := p.request(
+		pCtx,
+		http.MethodPost,
+		fmt.Sprintf(cHandleConfigAccessTemplate, p.fHost),
+		pAccess,
+	)
+	if err != nil {
+		return errors.Join(ErrBadRequest, err)
+	}
+	return nil
+}
+
+func (p *sRequester) DelAccess(pCtx context.Context, pAccess *hls_settings.SAccess) error {
+	_, err := p.request(
+		pCtx,
+		http.MethodDelete,
+		fmt.Sprintf(cHandleConfigAccessTemplate, p.fHost),
+		pAccess,
+	)
+	if err != nil {
+		return errors.Join(ErrBadRequest, err)
+	}
+	return nil
+}
+
+func (p *sRequester) GetOnlines(pCtx context.Context) ([]string, error) {
+	res, err := p.request(
+		pCtx,
+		http.MethodGet,
+		fmt.Sprintf(cHandleNetworkOnlineTemplate, p.fHost),
+		nil,
+	)
+	if err != nil {
+		return nil, errors.Join(ErrBadRequest, err)
+	}
+
+	var onlines []string
+	if err := encoding.DeserializeJSON(res, &onlines); err != nil {
+		return nil, errors.Join(ErrDecodeResponse, err)
+	}
+
+	return onlines, nil
+}
+
+func (p *sRequester) DelOnline(pCtx context.Context, pConnect string) error {
+	_, err := p.request(
+		pCtx,
+		http.MethodDelete,
+		fmt.Sprintf(cHandleNetworkOnlineTemplate, p.fHost),
+		pConnect,
+	)
+	if err != nil {
+		return errors.Join(ErrBadRequest, err)
+	}
+	return nil
+}
+
+func (p *sRequester) GetConnections(pCtx context.Context) ([]string, error) {
+	res, err := p.request(
+		pCtx,
+		http.MethodGet,
+		fmt.Sprintf(cHandleConfigConnectsTemplate, p.fHost),
+		nil,
+	)
+	if err != nil {
+		return nil, errors.Join(ErrBadRequest, err)
+	}
+
+	var connects []string
+	if err := encoding.DeserializeJSON(res, &connects); err != nil {
+		return nil, errors.Join(ErrDecodeResponse, err)
+	}
+
+	return connects, nil
+}
+
+func (p *sRequester) AddConnection(pCtx context.Context, pConnect string) error {
+	_, err := p.request(
+		pCtx,
+		http.MethodPost,
+		fmt.Sprintf(cHandleConfigConnectsTemplate, p.fHost),
+		pConnect,
+	)
+	if err != nil {
+		return errors.Join(ErrBadRequest, err)
+	}
+	return nil
+}
+
+func (p *sRequester) DelConnection(pCtx context.Context, pConnect string) error {
+	_, err := p.request(
+		pCtx,
+		http.MethodDelete,
+		fmt.Sprintf(cHandleConfigConnectsTemplate, p.fHost),
+		pConnect,
+	)
+	if err != nil {
+		return errors.Join(ErrBadRequest, err)
+	}
+	return nil
+}
+
+func (p *sRequester) GetPubKey(pCtx context.Context) (asymmetric.IPubKey, error) {
+	res, err := p.request(
+		pCtx,
+		http.MethodGet,
+		fmt.Sprintf(cHandleServicePubKeyTemplate, p.fHost),
+		nil,
+	)
+	if err != nil {
+		return nil, errors.Join(ErrBadRequest, err)
+	}
+
+	pubKey := asymmetric.LoadPubKey(string(res))
+	if pubKey == nil {
+		return nil, ErrInvalidPublicKey
+	}
+
+	return pubKey, nil
+}
+
+func (p *sRequester) ServiceStream(
+	pCtx context.Context,
+	pHostName string,
+	pHandleF func(*hls_settings.SIncoming),
+) error {
+	query := url.Values{}
+	query.Set("host", pHostName)
+
+	err := p.stream(
+		pCtx,
+		fmt.Sprintf(cHandleServiceStreamTemplate, p.fHost)+"?"+query.Encode(),
+		func(pData []byte) error {
+			incoming := new(hls_settings.SIncoming)
+			if err := encoding.DeserializeJSON(pData, incoming); err != nil {
+				return errors.Join(ErrDecodeResponse, err)
+			}
+			pHandleF(incoming)
+			return nil
+		},
+	)
+	if err != nil {
+		return errors.Join(ErrBadRequest, err)
+	}
+	return nil
+}
+
+func (p *sRequester) ServiceReply(pCtx context.Context, pReply *hls_settings.SReply) error {
+	_, err := p.request(
+		pCtx,
+		http.MethodPost,
+		fmt.Sprintf(cHandleServiceReplyTemplate, p.fHost),
+		pReply,
+	)
+	if err != nil {
+		return errors.Join(ErrBadRequest, err)
+	}
+	return nil
+}
+
+func (p *sRequester) ServiceLimits(pCtx context.Context) ([]*hls_settings.SLimitCounter, error) {
+	res, err := p.request(
+		pCtx,
+		http.MethodGet,
+		fmt.Sprintf(cHandleServiceLimitsTemplate, p.fHost),
+		nil,
+	)
+	if err != nil {
+		return nil, errors.Join(ErrBadRequest, err)
+	}
+
+	var vCounters []*hls_settings.SLimitCounter
+	if err := encoding.DeserializeJSON(res, &vCounters); err != nil {
+		return nil, errors.Join(ErrDecodeResponse, err)
+	}
+	return vCounters, nil
+}
@@ -0,0 +1,61 @@
+package client
+
+import "time"
+
+var (
+	_ ISettings = &sSettings{}
+)
+
+const (
+	cDefaultRetryDelay = time.Second
+)
+
+type SSettings sSettings
+type sSettings struct {
+	// Requests are retried if the connection to the service is
+	// failed, and also on any failure of the idempotent methods.
+	FRetryNum   uint64
+	FRetryDelay time.Duration
+
+	// Bearer token of the API (tokens section in hls.yml).
+	FToken string
+
+	// Identity of the HLS (identities section in hls.yml).
+	// The default identity is used if the value is empty.
+	FIdentity string
+}
+
+func NewSettings(pSett *SSettings) ISettings {
+	if pSett == nil {
+		pSett = &SSettings{}
+	}
+	return (&sSettings{
+		FRetryNum:   pSett.FRetryNum,
+		FRetryDelay: pSett.FRetryDelay,
+		FToken:      pSett.FToken,
+		FIdentity:   pSett.FIdentity,
+	}).useDefault()
+}
+
+func (p *sSettings) useDefault() *sSettings {
+	if p.FRetryDelay == 0 {
+		p.FRetryDelay = cDefaultRetryDelay
+	}
+	return p
+}
+
+func (p *sSettings) GetRetryNum() uint64 {
+	return p.FRetryNum
+}
+
+func (p *sSettings) GetRetryDelay() time.Duration {
+	return p.FRetryDelay
+}
+
+func (p *sSettings) GetToken() string {
+	return p.FToken
+}
+
+func (p *sSettings) GetIdentity() string {
+	return p.FIdentity
+}
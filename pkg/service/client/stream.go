@@ -0,0 +1,73 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// stream reads the server-sent events and passes the data of
+// each event to the handler.
+func (p *sRequester) stream(
+	pCtx context.Context,
+	pURL string,
+	pHandleF func([]byte) error,
+) error {
+	req, err := http.NewRequestWithContext(pCtx, http.MethodGet, pURL, nil)
+	if err != nil {
+		return errors.Join(ErrBuildRequest, err)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	p.setHeaders(req)
+
+	// the stream is not restricted by the timeout of client
+	httpClient := *p.fClient
+	httpClient.Timeout = 0
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		result, _ := io.ReadAll(resp.Body)
+		return &SStatusError{FCode: resp.StatusCode, FMessage: string(result)}
+	}
+
+	reader := bufio.NewReader(resp.Body)
+	data := make([]byte, 0, 1024)
+
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			if ctxErr := pCtx.Err(); ctxErr != nil {
+				return ctxErr
+			}
+			return errors.Join(ErrStreamClosed, err)
+		}
+
+		line = bytes.TrimRight(line, "\r\n")
+		switch {
+		case len(line) == 0:
+			// end of the event
+			if len(data) == 0 {
+				continue
+			}
+			if err := pHandleF(data); err != nil {
+				return err
+			}
+			data = data[:0]
+		case bytes.HasPrefix(line, []byte("data:")):
+			if len(data) != 0 {
+				data = append(data, '\n')
+			}
+			data = append(data, bytes.TrimPrefix(bytes.TrimPrefix(line, []byte("data:")), []byte(" "))...)
+		default:
+			// comments, ids and event types are not used
+		}
+	}
+}
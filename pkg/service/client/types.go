@@ -0,0 +1,146 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/number571/go-peer/pkg/crypto/asymmetric"
+
+	"github.com/number571/hidden-lake/pkg/handler"
+	"github.com/number571/hidden-lake/pkg/request"
+	"github.com/number571/hidden-lake/pkg/response"
+	hls_settings "github.com/number571/hidden-lake/pkg/service/settings"
+)
+
+type ISettings interface {
+	GetRetryNum() uint64
+	GetRetryDelay() time.Duration
+	GetToken() string
+	GetIdentity() string
+}
+
+type IClient interface {
+	GetIndex(context.Context) (string, error)
+	GetSettings(context.Context) (hls_settings.IConfigSettings, error)
+	ReloadConfig(context.Context) ([]string, error)
+
+	GetPubKey(context.Context) (asymmetric.IPubKey, error)
+	GetIdentities(context.Context) ([]*hls_settings.SIdentity, error)
+
+	GetOnlines(context.Context) ([]string, error)
+	DelOnline(context.Context, string) error
+
+	GetFriends(context.Context) (map[string]asymmetric.IPubKey, error)
+	GetFriendsInfo(context.Context) ([]*hls_settings.SFriend, error)
+	AddFriend(context.Context, string, asymmetric.IPubKey) error
+	UpdateFriend(context.Context, string, string, []string) error
+	AddTempFriend(context.Context, string, asymmetric.IPubKey, time.Duration) error
+	ExtendFriend(context.Context, string, time.Duration) error
+	DelFriend(context.Context, string) error
+
+	ExportContact(context.Context, string, []string) (*hls_settings.SContactExport, error)
+	ImportContact(context.Context, string, string) (*hls_settings.SFriend, error)
+
+	GetIntroductions(context.Context) ([]*hls_settings.SIntroduction, error)
+	Introduce(context.Context, string, string) error
+	AcceptIntroduction(context.Context, string, string) (*hls_settings.SFriend, error)
+	RejectIntroduction(context.Context, string) error
+
+	GetServices(context.Context) (map[string]string, error)
+	AddService(context.Context, string, string) error
+	DelService(context.Context, string) error
+
+	GetAccess(context.Context) (map[string]*hls_settings.SAccess, error)
+	SetAccess(context.Context, string, []string, []string) error
+	DelAccess(context.Context, string) error
+
+	GetGroups(context.Context) (map[string][]string, error)
+	SetGroup(context.Context, string, []string) error
+	DelGroup(context.Context, string) error
+
+	GetConnections(context.Context) ([]string, error)
+	AddConnection(context.Context, string) error
+	DelConnection(context.Context, string) error
+
+	SendRequest(context.Context, string, request.IRequest) error
+	SendMulticast(context.Context, string, []string, request.IRequest) ([]*hls_settings.SMulticastResult, error)
+	FetchRequest(context.Context, string, request.IRequest) (response.IResponse, error)
+
+	FetchRequestAsync(context.Context, string, request.IRequest) (string, error)
+	LoadTicket(context.Context, string, time.Duration) (response.IResponse, error)
+	DelTicket(context.Context, string) error
+
+	ServeStream(context.Context, string, handler.IHandlerF) error
+	ReplyRequest(context.Context, string, response.IResponse) error
+
+	GetLimits(context.Context) ([]*hls_settings.SLimitCounter, error)
+}
+
+type IRequester interface {
+	GetIndex(context.Context) (string, error)
+	GetSettings(context.Context) (hls_settings.IConfigSettings, error)
+	ReloadConfig(context.Context) ([]string, error)
+
+	GetPubKey(context.Context) (asymmetric.IPubKey, error)
+	GetIdentities(context.Context) ([]*hls_settings.SIdentity, error)
+
+	GetOnlines(context.Context) ([]string, error)
+	DelOnline(context.Context, string) error
+
+	GetFriends(context.Context) ([]*hls_settings.SFriend, error)
+	AddFriend(context.Context, *hls_settings.SFriend) error
+	UpdateFriend(context.Context, *hls_settings.SFriend) error
+	DelFriend(context.Context, *hls_settings.SFriend) error
+
+	ExportContact(context.Context, string, []string) (*hls_settings.SContactExport, error)
+	ImportContact(context.Context, *hls_settings.SContactImport) (*hls_settings.SFriend, error)
+
+	GetIntroductions(context.Context) ([]*hls_settings.SIntroduction, error)
+	Introduce(context.Context, *hls_settings.SIntroduce) error
+	AcceptIntroduction(context.Context, *hls_settings.SIntroduction) (*hls_settings.SFriend, error)
+	RejectIntroduction(context.Context, *hls_settings.SIntroduction) error
+
+	GetServices(context.Context) (map[string]string, error)
+	AddService(context.Context, *hls_settings.SService) error
+	DelService(context.Context, *hls_settings.SService) error
+
+	GetAccess(context.Context) (map[string]*hls_settings.SAccess, error)
+	SetAccess(context.Context, *hls_settings.SAccess) error
+	DelAccess(context.Context, *hls_settings.SAccess) error
+
+	GetGroups(context.Context) (map[string][]string, error)
+	SetGroup(context.Context, *hls_settings.SGroup) error
+	DelGroup(context.Context, *hls_settings.SGroup) error
+
+	GetConnections(context.Context) ([]string, error)
+	AddConnection(context.Context, string) error
+	DelConnection(context.Context, string) error
+
+	SendRequest(context.Context, *hls_settings.SRequest) error
+	SendMulticast(context.Context, *hls_settings.SMulticast) ([]*hls_settings.SMulticastResult, error)
+	FetchRequest(context.Context, *hls_settings.SRequest) (response.IResponse, error)
+
+	FetchRequestAsync(context.Context, *hls_settings.SRequest) (string, error)
+	GetTicket(context.Context, string, time.Duration) (*hls_settings.STicket, error)
+	DelTicket(context.Context, string) error
+
+	ServiceStream(context.Context, string, func(*hls_settings.SIncoming)) error
+	ServiceReply(context.Context, *hls_settings.SReply) error
+	ServiceLimits(context.Context) ([]*hls_settings.SLimitCounter, error)
+}
+
+type IBuilder interface {
+	Request(string, request.IRequest) *hls_settings.SRequest
+	AsyncRequest(string, request.IRequest) *hls_settings.SRequest
+	Friend(string, asymmetric.IPubKey) *hls_settings.SFriend
+	FriendInfo(string, string, []string) *hls_settings.SFriend
+	TempFriend(string, asymmetric.IPubKey, time.Duration) *hls_settings.SFriend
+	Contact(string, string) *hls_settings.SContactImport
+	Introduce(string, string) *hls_settings.SIntroduce
+	Introduction(string, string) *hls_settings.SIntroduction
+	Service(string, string) *hls_settings.SService
+	Access(string, []string, []string) *hls_settings.SAccess
+	Group(string, []string) *hls_settings.SGroup
+	Multicast(string, []string, request.IRequest) *hls_settings.SMulticast
+	Reply(string, response.IResponse) *hls_settings.SReply
+}
@@ -0,0 +1,31 @@
+package settings
+
+import "time"
+
+var (
+	_ IConfigSettings = &SConfigSettings{}
+)
+
+func (p *SConfigSettings) GetMessageSizeBytes() uint64 {
+	return p.FMessageSizeBytes
+}
+
+func (p *SConfigSettings) GetPayloadSizeBytes() uint64 {
+	return p.FPayloadSizeBytes
+}
+
+func (p *SConfigSettings) GetWorkSizeBits() uint64 {
+	return p.FWorkSizeBits
+}
+
+func (p *SConfigSettings) GetFetchTimeout() time.Duration {
+	return time.Duration(p.FFetchTimeoutMS) * time.Millisecond
+}
+
+func (p *SConfigSettings) GetQueuePeriod() time.Duration {
+	return time.Duration(p.FQueuePeriodMS) * time.Millisecond
+}
+
+func (p *SConfigSettings) GetNetworkKey() string {
+	return p.FNetworkKey
+}
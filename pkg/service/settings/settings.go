@@ -0,0 +1,59 @@
+package settings
+
+const (
+	CServiceFullName = "hidden-lake-service"
+)
+
+// Built-in service of the HLS for introductions of friends.
+const (
+	CIntroductionHostName = "hidden-lake-introduction"
+	CIntroductionPath     = "/introduce"
+)
+
+const (
+	CHeaderPublicKey    = "Hl-Service-Public-Key"
+	CHeaderResponseMode = "Hl-Service-Response-Mode"
+)
+
+// Identity of the HLS selected by the header of the API request.
+// If the header is not set, then the default identity is used.
+const (
+	CHeaderIdentity  = "Hl-Identity"
+	CDefaultIdentity = "default"
+)
+
+const (
+	CHeaderResponseModeON  = "on" // default
+	CHeaderResponseModeOFF = "off"
+)
+
+// Scopes of the tokens for the HLS API.
+// The token without scopes has full access.
+const (
+	CTokenScopeRead    = "read"    // GET requests (except the stream)
+	CTokenScopeNetwork = "network" // requests to the network and tickets
+	CTokenScopeService = "service" // stream and reply of the pull-mode services
+)
+
+const (
+	CHandleIndexPath               = "/api/index"
+	CHandleConfigSettingsPath      = "/api/config/settings"
+	CHandleConfigConnectsPath      = "/api/config/connects"
+	CHandleConfigFriendsPath       = "/api/config/friends"
+	CHandleConfigAccessPath        = "/api/config/access"
+	CHandleConfigReloadPath        = "/api/config/reload"
+	CHandleConfigServicesPath      = "/api/config/services"
+	CHandleConfigContactPath       = "/api/config/contact"
+	CHandleConfigContactNewPath    = "/api/config/contact/new"
+	CHandleConfigIntroductionsPath = "/api/config/introductions"
+	CHandleConfigIdentitiesPath    = "/api/config/identities"
+	CHandleConfigGroupsPath        = "/api/config/groups"
+	CHandleNetworkOnlinePath       = "/api/network/online"
+	CHandleNetworkRequestPath      = "/api/network/request"
+	CHandleNetworkMulticastPath    = "/api/network/multicast"
+	CHandleNetworkTicketPath       = "/api/network/ticket"
+	CHandleServicePubKeyPath       = "/api/service/pubkey"
+	CHandleServiceStreamPath       = "/api/service/stream"
+	CHandleServiceReplyPath        = "/api/service/reply"
+	CHandleServiceLimitsPath       = "/api/service/limits"
+)
@@ -0,0 +1,32 @@
+package settings
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConfigSettings(t *testing.T) {
+	t.Parallel()
+
+	sett := &SConfigSettings{
+		FMessageSizeBytes: (8 << 10),
+		FFetchTimeoutMS:   60_000,
+		FQueuePeriodMS:    5_000,
+		FWorkSizeBits:     22,
+		FNetworkKey:       "_",
+		FPayloadSizeBytes: 1024,
+	}
+
+	if sett.GetMessageSizeBytes() != (8<<10) || sett.GetPayloadSizeBytes() != 1024 {
+		t.Error("got invalid size settings")
+		return
+	}
+	if sett.GetFetchTimeout() != time.Minute || sett.GetQueuePeriod() != 5*time.Second {
+		t.Error("got invalid time settings")
+		return
+	}
+	if sett.GetWorkSizeBits() != 22 || sett.GetNetworkKey() != "_" {
+		t.Error("got invalid network settings")
+		return
+	}
+}
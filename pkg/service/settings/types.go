@@ -0,0 +1,159 @@
+package settings
+
+import (
+	"time"
+
+	"github.com/number571/hidden-lake/pkg/request"
+	"github.com/number571/hidden-lake/pkg/response"
+)
+
+type IConfigSettings interface {
+	GetMessageSizeBytes() uint64
+	GetPayloadSizeBytes() uint64
+	GetWorkSizeBits() uint64
+	GetFetchTimeout() time.Duration
+	GetQueuePeriod() time.Duration
+	GetNetworkKey() string
+}
+
+type SConfigSettings struct {
+	FMessageSizeBytes uint64 `json:"message_size_bytes"`
+	FFetchTimeoutMS   uint64 `json:"fetch_timeout_ms"`
+	FQueuePeriodMS    uint64 `json:"queue_period_ms"`
+	FWorkSizeBits     uint64 `json:"work_size_bits,omitempty"`
+	FNetworkKey       string `json:"network_key,omitempty"`
+	FPayloadSizeBytes uint64 `json:"payload_size_bytes"`
+}
+
+type SFriend struct {
+	FAliasName    string `json:"alias_name"`
+	FPublicKey    string `json:"public_key"`
+	FSafetyNumber string `json:"safety_number,omitempty"`
+
+	// Metadata is not stored in the config. The time is in RFC 3339,
+	// last-seen is the time of the last received request from friend.
+	FNotes    string   `json:"notes,omitempty"`
+	FTags     []string `json:"tags,omitempty"`
+	FAdded    string   `json:"added,omitempty"`
+	FLastSeen string   `json:"last_seen,omitempty"`
+
+	// Expiry of the temporary friend is set by the absolute time (RFC 3339)
+	// or by the time to live. In the list of friends the TTL is remaining.
+	FExpires string `json:"expires,omitempty"`
+	FTTLMS   uint64 `json:"ttl_ms,omitempty"`
+}
+
+// SContact is the bundle to add the owner of public key as a friend.
+// The signature is made by the owner's private key over other fields.
+type SContact struct {
+	FPublicKey   string   `json:"public_key"`
+	FAliasName   string   `json:"alias_name,omitempty"`  // suggested alias
+	FNetworkKey  string   `json:"network_key,omitempty"` // key from networks
+	FConnections []string `json:"connections,omitempty"` // tcp://host:port
+	FSignature   string   `json:"signature,omitempty"`
+}
+
+type SContactExport struct {
+	FURI     string    `json:"uri"` // hl://contact/...
+	FContact *SContact `json:"contact"`
+}
+
+// SContactNew sets the fields of the contact which is signed by the
+// private key of node. The request needs the network scope of token.
+type SContactNew struct {
+	FAliasName   string   `json:"alias_name,omitempty"`
+	FConnections []string `json:"connections,omitempty"`
+}
+
+type SContactImport struct {
+	FContact   string `json:"contact"`              // uri or json bundle
+	FAliasName string `json:"alias_name,omitempty"` // replaces suggested alias
+}
+
+// SIntroduction is the friend of the introducer. The ID, introducer and
+// safety number are set only in the list of pending introductions.
+type SIntroduction struct {
+	FID           string `json:"id,omitempty"`
+	FIntroducer   string `json:"introducer,omitempty"` // alias of the introducer
+	FAliasName    string `json:"alias_name,omitempty"`
+	FPublicKey    string `json:"public_key,omitempty"`
+	FSafetyNumber string `json:"safety_number,omitempty"`
+}
+
+type SIntroduce struct {
+	FAliasNames []string `json:"alias_names"` // two friends
+}
+
+type SIdentity struct {
+	FName      string `json:"name"`
+	FPublicKey string `json:"public_key"`
+}
+
+type SService struct {
+	FHostName string `json:"host_name"`
+	FAddress  string `json:"address"`
+}
+
+type SAccess struct {
+	FHostName string   `json:"host_name"`
+	FAllow    []string `json:"allow,omitempty"`
+	FDeny     []string `json:"deny,omitempty"`
+}
+
+type SRequest struct {
+	FReceiver string            `json:"receiver"` // alias_name
+	FReqData  *request.SRequest `json:"req_data"`
+	FAsync    bool              `json:"async,omitempty"` // POST returns ticket
+}
+
+// SLimitCounter is the state of rate limit of the friend for the service.
+// Available values are omitted if the requests or bytes are not limited.
+type SLimitCounter struct {
+	FAliasName         string `json:"alias_name"`
+	FHostName          string `json:"host_name"`
+	FRequests          uint64 `json:"requests"`
+	FBytes             uint64 `json:"bytes"`
+	FRejected          uint64 `json:"rejected"`
+	FAvailableRequests uint64 `json:"available_requests,omitempty"`
+	FAvailableBytes    uint64 `json:"available_bytes,omitempty"`
+}
+
+type SGroup struct {
+	FName    string   `json:"name"`
+	FMembers []string `json:"members,omitempty"` // alias_names
+}
+
+// SMulticast is the request sent to the members of group and to
+// the receivers. Each of them gets its own encrypted message.
+type SMulticast struct {
+	FGroup     string            `json:"group,omitempty"`
+	FReceivers []string          `json:"receivers,omitempty"` // alias_names
+	FReqData   *request.SRequest `json:"req_data"`
+}
+
+type SMulticastResult struct {
+	FReceiver string `json:"receiver"`
+	FError    string `json:"error,omitempty"` // empty = enqueued
+}
+
+type STicket struct {
+	FTicketID string `json:"ticket_id"`
+	FDone     bool   `json:"done"`
+	FResponse string `json:"response,omitempty"`
+	FError    string `json:"error,omitempty"`
+}
+
+type SIncoming struct {
+	FRequestID string            `json:"request_id"`
+	FSender    string            `json:"sender"` // public_key
+	FReqData   *request.SRequest `json:"req_data"`
+}
+
+type SReply struct {
+	FRequestID string              `json:"request_id"`
+	FResponse  *response.SResponse `json:"response,omitempty"` // nil = response mode 'off'
+}
+
+type SReload struct {
+	FRestartRequired []string `json:"restart_required"`
+}
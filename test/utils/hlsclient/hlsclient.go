@@ -0,0 +1,108 @@
+package hlsclient
+
+import (
+	"context"
+	"time"
+
+	"github.com/number571/go-peer/pkg/crypto/asymmetric"
+	"github.com/number571/hidden-lake/pkg/handler"
+	"github.com/number571/hidden-lake/pkg/request"
+	"github.com/number571/hidden-lake/pkg/response"
+	hls_client "github.com/number571/hidden-lake/pkg/service/client"
+	hls_settings "github.com/number571/hidden-lake/pkg/service/settings"
+)
+
+var (
+	_ hls_client.IClient = &SNopClient{}
+)
+
+// SNopClient implements the client of HLS by methods which return zero
+// values. Fakes of tests embed it and override only the used methods.
+type SNopClient struct{}
+
+func (p *SNopClient) GetIndex(context.Context) (string, error) { return "", nil }
+func (p *SNopClient) GetSettings(context.Context) (hls_settings.IConfigSettings, error) {
+	return nil, nil
+}
+func (p *SNopClient) ReloadConfig(context.Context) ([]string, error) { return nil, nil }
+
+func (p *SNopClient) GetPubKey(context.Context) (asymmetric.IPubKey, error) { return nil, nil }
+func (p *SNopClient) GetIdentities(context.Context) ([]*hls_settings.SIdentity, error) {
+	return nil, nil
+}
+
+func (p *SNopClient) GetOnlines(context.Context) ([]string, error) { return nil, nil }
+func (p *SNopClient) DelOnline(context.Context, string) error      { return nil }
+
+func (p *SNopClient) GetFriends(context.Context) (map[string]asymmetric.IPubKey, error) {
+	return nil, nil
+}
+func (p *SNopClient) GetFriendsInfo(context.Context) ([]*hls_settings.SFriend, error) {
+	return nil, nil
+}
+func (p *SNopClient) AddFriend(context.Context, string, asymmetric.IPubKey) error { return nil }
+func (p *SNopClient) UpdateFriend(context.Context, string, string, []string) error {
+	return nil
+}
+func (p *SNopClient) AddTempFriend(context.Context, string, asymmetric.IPubKey, time.Duration) error {
+	return nil
+}
+func (p *SNopClient) ExtendFriend(context.Context, string, time.Duration) error { return nil }
+func (p *SNopClient) DelFriend(context.Context, string) error                   { return nil }
+
+func (p *SNopClient) ExportContact(context.Context, string, []string) (*hls_settings.SContactExport, error) {
+	return nil, nil
+}
+func (p *SNopClient) ImportContact(context.Context, string, string) (*hls_settings.SFriend, error) {
+	return nil, nil
+}
+
+func (p *SNopClient) GetIntroductions(context.Context) ([]*hls_settings.SIntroduction, error) {
+	return nil, nil
+}
+func (p *SNopClient) Introduce(context.Context, string, string) error { return nil }
+func (p *SNopClient) AcceptIntroduction(context.Context, string, string) (*hls_settings.SFriend, error) {
+	return nil, nil
+}
+func (p *SNopClient) RejectIntroduction(context.Context, string) error { return nil }
+
+func (p *SNopClient) GetServices(context.Context) (map[string]string, error) { return nil, nil }
+func (p *SNopClient) AddService(context.Context, string, string) error       { return nil }
+func (p *SNopClient) DelService(context.Context, string) error               { return nil }
+
+func (p *SNopClient) GetAccess(context.Context) (map[string]*hls_settings.SAccess, error) {
+	return nil, nil
+}
+func (p *SNopClient) SetAccess(context.Context, string, []string, []string) error { return nil }
+func (p *SNopClient) DelAccess(context.Context, string) error                     { return nil }
+
+func (p *SNopClient) GetGroups(context.Context) (map[string][]string, error) { return nil, nil }
+func (p *SNopClient) SetGroup(context.Context, string, []string) error       { return nil }
+func (p *SNopClient) DelGroup(context.Context, string) error                 { return nil }
+
+func (p *SNopClient) GetConnections(context.Context) ([]string, error) { return nil, nil }
+func (p *SNopClient) AddConnection(context.Context, string) error      { return nil }
+func (p *SNopClient) DelConnection(context.Context, string) error      { return nil }
+
+func (p *SNopClient) SendRequest(context.Context, string, request.IRequest) error { return nil }
+func (p *SNopClient) SendMulticast(context.Context, string, []string, request.IRequest) ([]*hls_settings.SMulticastResult, error) {
+	return nil, nil
+}
+func (p *SNopClient) FetchRequest(context.Context, string, request.IRequest) (response.IResponse, error) {
+	return nil, nil
+}
+
+func (p *SNopClient) FetchRequestAsync(context.Context, string, request.IRequest) (string, error) {
+	return "", nil
+}
+func (p *SNopClient) LoadTicket(context.Context, string, time.Duration) (response.IResponse, error) {
+	return nil, nil
+}
+func (p *SNopClient) DelTicket(context.Context, string) error { return nil }
+
+func (p *SNopClient) ServeStream(context.Context, string, handler.IHandlerF) error   { return nil }
+func (p *SNopClient) ReplyRequest(context.Context, string, response.IResponse) error { return nil }
+
+func (p *SNopClient) GetLimits(context.Context) ([]*hls_settings.SLimitCounter, error) {
+	return nil, nil
+}